@@ -16,6 +16,7 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate/providers/certmanager"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/vault"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/debugger"
 )
@@ -40,7 +41,61 @@ const (
 
 var validCertificateManagerOptions = []string{tresorKind, vaultKind, certmanagerKind}
 
-func getTresorOSMCertificateManager(kubeClient kubernetes.Interface, enableDebug bool) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
+// applyCertificateProviderConfig overrides the certificate manager CLI options with the certificate provider set in the
+// OSM config and the config of that provider, when they are set and valid. The Vault token is read from the Secret key
+// the Vault config refers to. It is applied once, when osm-controller starts: the certificate manager is shared by every
+// component issuing certificates, and its CA is the one the proxies trust, so it is not replaced while running and a
+// change of the certificate provider config takes effect on the next start.
+func applyCertificateProviderConfig(kubeClient kubernetes.Interface, cfg configurator.Configurator) error {
+	provider := cfg.GetCertificateProvider()
+	if provider == "" {
+		return nil
+	}
+	log.Info().Msgf("Using certificate manager %s set in the OSM config instead of --certificate-manager=%s", provider, *osmCertificateManagerKind)
+	*osmCertificateManagerKind = provider
+
+	switch provider {
+	case vaultKind:
+		if vaultConfig := cfg.GetVaultConfig(); vaultConfig != nil {
+			token, err := getVaultTokenFromKubernetes(kubeClient, vaultConfig.GetTokenSecretRef())
+			if err != nil {
+				return err
+			}
+			*vaultProtocol = vaultConfig.Protocol
+			*vaultHost = vaultConfig.Host
+			*vaultPort = int(vaultConfig.Port)
+			*vaultToken = token
+			*vaultRole = vaultConfig.Role
+		}
+	case certmanagerKind:
+		if certManagerConfig := cfg.GetCertManagerConfig(); certManagerConfig != nil {
+			*certmanagerIssuerName = certManagerConfig.IssuerName
+			*certmanagerIssuerKind = certManagerConfig.IssuerKind
+			*certmanagerIssuerGroup = certManagerConfig.IssuerGroup
+		}
+	}
+	return nil
+}
+
+// getVaultTokenFromKubernetes returns the Vault token held by the Secret key the given reference refers to
+func getVaultTokenFromKubernetes(kubeClient kubernetes.Interface, tokenSecretRef *configurator.SecretKeyRef) (string, error) {
+	if tokenSecretRef == nil {
+		return "", errors.New("Missing reference to the Secret key holding the Vault token")
+	}
+
+	secret, err := kubeClient.CoreV1().Secrets(tokenSecretRef.Namespace).Get(context.Background(), tokenSecretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Errorf("Error getting Vault token secret %s/%s: %+v", tokenSecretRef.Namespace, tokenSecretRef.Name, err)
+	}
+
+	token, ok := secret.Data[tokenSecretRef.Key]
+	if !ok || len(token) == 0 {
+		return "", errors.Errorf("Opaque k8s secret %s/%s does not have the Vault token field %q", tokenSecretRef.Namespace, tokenSecretRef.Name, tokenSecretRef.Key)
+	}
+	return string(token), nil
+}
+
+func getTresorOSMCertificateManager(kubeClient kubernetes.Interface, cfg configurator.Configurator, enableDebug bool) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
 	var err error
 	var rootCert certificate.Certificater
 
@@ -67,7 +122,7 @@ func getTresorOSMCertificateManager(kubeClient kubernetes.Interface, enableDebug
 		}
 	}
 
-	certManager, err := tresor.NewCertManager(rootCert, getServiceCertValidityPeriod(), rootCertOrganization)
+	certManager, err := tresor.NewCertManager(rootCert, cfg.GetServiceCertValidityDuration(), rootCertOrganization)
 	if err != nil {
 		return nil, nil, errors.Errorf("Failed to instantiate Azure Key Vault as a Certificate Manager")
 	}
@@ -128,14 +183,14 @@ func getCertFromKubernetes(kubeClient kubernetes.Interface, namespace, secretNam
 	return rootCert
 }
 
-func getHashiVaultOSMCertificateManager(enableDebug bool) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
+func getHashiVaultOSMCertificateManager(cfg configurator.Configurator, enableDebug bool) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
 	if _, ok := map[string]interface{}{"http": nil, "https": nil}[*vaultProtocol]; !ok {
 		return nil, nil, errors.Errorf("Value %s is not a valid Hashi Vault protocol", *vaultProtocol)
 	}
 
 	// A Vault address would have the following shape: "http://vault.default.svc.cluster.local:8200"
 	vaultAddr := fmt.Sprintf("%s://%s:%d", *vaultProtocol, *vaultHost, *vaultPort)
-	vaultCertManager, err := vault.NewCertManager(vaultAddr, *vaultToken, getServiceCertValidityPeriod(), *vaultRole)
+	vaultCertManager, err := vault.NewCertManager(vaultAddr, *vaultToken, cfg.GetServiceCertValidityDuration(), *vaultRole)
 	if err != nil {
 		return nil, nil, errors.Errorf("Error instantiating Hashicorp Vault as a Certificate Manager: %+v", err)
 	}
@@ -143,7 +198,7 @@ func getHashiVaultOSMCertificateManager(enableDebug bool) (certificate.Manager,
 	return vaultCertManager, vaultCertManager, nil
 }
 
-func getCertManagerOSMCertificateManager(kubeClient kubernetes.Interface, kubeConfig *rest.Config, enableDebug bool) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
+func getCertManagerOSMCertificateManager(kubeClient kubernetes.Interface, kubeConfig *rest.Config, cfg configurator.Configurator, enableDebug bool) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
 	rootCertSecret, err := kubeClient.CoreV1().Secrets(osmNamespace).Get(context.TODO(), caBundleSecretName, metav1.GetOptions{})
 	if err != nil {
 		return nil, nil, fmt.Errorf("Failed to get cert-manager CA secret %s/%s: %s", osmNamespace, caBundleSecretName, err)
@@ -164,7 +219,7 @@ func getCertManagerOSMCertificateManager(kubeClient kubernetes.Interface, kubeCo
 		return nil, nil, fmt.Errorf("Failed to build cert-manager client set: %s", err)
 	}
 
-	certmanagerCertManager, err := certmanager.NewCertManager(rootCert, client, osmNamespace, getServiceCertValidityPeriod(), cmmeta.ObjectReference{
+	certmanagerCertManager, err := certmanager.NewCertManager(rootCert, client, osmNamespace, cfg.GetServiceCertValidityDuration(), cmmeta.ObjectReference{
 		Name:  *certmanagerIssuerName,
 		Kind:  *certmanagerIssuerKind,
 		Group: *certmanagerIssuerGroup,
@@ -175,7 +230,3 @@ func getCertManagerOSMCertificateManager(kubeClient kubernetes.Interface, kubeCo
 
 	return certmanagerCertManager, certmanagerCertManager, nil
 }
-
-func getServiceCertValidityPeriod() time.Duration {
-	return time.Duration(serviceCertValidityMinutes) * time.Minute
-}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/certificate/pem"
 	"github.com/openservicemesh/osm/pkg/certificate/providers/tresor"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 )
 
@@ -147,4 +149,86 @@ var _ = Describe("Test CMD tools", func() {
 			Expect(*actual).To(Equal(*expected))
 		})
 	})
+
+	Context("Testing applyCertificateProviderConfig", func() {
+		var (
+			mockCtrl         *gomock.Controller
+			mockConfigurator *configurator.MockConfigurator
+			kubeClient       *testclient.Clientset
+		)
+
+		BeforeEach(func() {
+			mockCtrl = gomock.NewController(GinkgoT())
+			mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+			kubeClient = testclient.NewSimpleClientset(&corev1.Secret{
+				ObjectMeta: v1.ObjectMeta{
+					Namespace: "osm-system",
+					Name:      "osm-vault-token",
+				},
+				Data: map[string][]byte{
+					"token": []byte("token"),
+				},
+			})
+			*osmCertificateManagerKind = tresorKind
+		})
+
+		AfterEach(func() {
+			mockCtrl.Finish()
+		})
+
+		It("keeps the CLI options when the certificate provider is unset", func() {
+			mockConfigurator.EXPECT().GetCertificateProvider().Return("")
+
+			Expect(applyCertificateProviderConfig(kubeClient, mockConfigurator)).To(Succeed())
+			Expect(*osmCertificateManagerKind).To(Equal(tresorKind))
+		})
+
+		It("uses the Vault certificate provider and its config", func() {
+			mockConfigurator.EXPECT().GetCertificateProvider().Return(configurator.CertificateProviderVault)
+			mockConfigurator.EXPECT().GetVaultConfig().Return(&configurator.VaultConfig{
+				Protocol:       "https",
+				Host:           "vault.osm-system.svc.cluster.local",
+				Port:           8443,
+				TokenSecretRef: "osm-system/osm-vault-token/token",
+				Role:           "mesh",
+			})
+
+			Expect(applyCertificateProviderConfig(kubeClient, mockConfigurator)).To(Succeed())
+			Expect(*osmCertificateManagerKind).To(Equal(vaultKind))
+			Expect(*vaultProtocol).To(Equal("https"))
+			Expect(*vaultHost).To(Equal("vault.osm-system.svc.cluster.local"))
+			Expect(*vaultPort).To(Equal(8443))
+			Expect(*vaultToken).To(Equal("token"))
+			Expect(*vaultRole).To(Equal("mesh"))
+			Expect(validateCertificateManagerOptions()).To(Succeed())
+		})
+
+		It("fails when the Secret holding the Vault token does not exist", func() {
+			mockConfigurator.EXPECT().GetCertificateProvider().Return(configurator.CertificateProviderVault)
+			mockConfigurator.EXPECT().GetVaultConfig().Return(&configurator.VaultConfig{
+				Protocol:       "https",
+				Host:           "vault.osm-system.svc.cluster.local",
+				Port:           8443,
+				TokenSecretRef: "osm-system/missing-vault-token/token",
+				Role:           "mesh",
+			})
+
+			Expect(applyCertificateProviderConfig(kubeClient, mockConfigurator)).ToNot(Succeed())
+		})
+
+		It("uses the cert-manager certificate provider and its config", func() {
+			mockConfigurator.EXPECT().GetCertificateProvider().Return(configurator.CertificateProviderCertManager)
+			mockConfigurator.EXPECT().GetCertManagerConfig().Return(&configurator.CertManagerConfig{
+				IssuerName:  "osm-ca",
+				IssuerKind:  "ClusterIssuer",
+				IssuerGroup: "cert-manager.io",
+			})
+
+			Expect(applyCertificateProviderConfig(kubeClient, mockConfigurator)).To(Succeed())
+			Expect(*osmCertificateManagerKind).To(Equal(certmanagerKind))
+			Expect(*certmanagerIssuerName).To(Equal("osm-ca"))
+			Expect(*certmanagerIssuerKind).To(Equal("ClusterIssuer"))
+			Expect(*certmanagerIssuerGroup).To(Equal("cert-manager.io"))
+		})
+	})
 })
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
@@ -33,6 +34,7 @@ import (
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/logger"
 	"github.com/openservicemesh/osm/pkg/metricsstore"
+	osmClient "github.com/openservicemesh/osm/pkg/osm_client/clientset/versioned"
 	"github.com/openservicemesh/osm/pkg/signals"
 	"github.com/openservicemesh/osm/pkg/smi"
 	"github.com/openservicemesh/osm/pkg/version"
@@ -54,6 +56,8 @@ var (
 	caBundleSecretName         string
 	enableDebugServer          bool
 	osmConfigMapName           string
+	osmMeshConfigName          string
+	enableStagingConfig        bool
 
 	injectorConfig injector.Config
 
@@ -87,10 +91,12 @@ func init() {
 	flags.StringVar(&kubeConfigFile, "kubeconfig", "", "Path to Kubernetes config file.")
 	flags.StringVar(&osmNamespace, "osm-namespace", "", "Namespace to which OSM belongs to.")
 	flags.StringVar(&webhookName, "webhook-name", "", "Name of the MutatingWebhookConfiguration to be configured by osm-controller")
-	flags.IntVar(&serviceCertValidityMinutes, "service-cert-validity-minutes", defaultServiceCertValidityMinutes, "Certificate validityPeriod duration in minutes")
+	flags.IntVar(&serviceCertValidityMinutes, "service-cert-validity-minutes", defaultServiceCertValidityMinutes, "Default certificate validityPeriod duration in minutes, used unless the OSM ConfigMap sets service_cert_validity_duration")
 	flags.StringVar(&caBundleSecretName, caBundleSecretNameCLIParam, "", "Name of the Kubernetes Secret for the OSM CA bundle")
-	flags.BoolVar(&enableDebugServer, "enable-debug-server", false, "Enable OSM debug HTTP server")
+	flags.BoolVar(&enableDebugServer, "enable-debug-server", false, "Enable OSM debug HTTP server, regardless of the enable_debug_server key of the OSM ConfigMap")
 	flags.StringVar(&osmConfigMapName, "osm-configmap-name", "osm-config", "Name of the OSM ConfigMap")
+	flags.StringVar(&osmMeshConfigName, "osm-meshconfig-name", "", "Name of the OSM MeshConfig custom resource, which takes precedence over the OSM ConfigMap (disabled when empty)")
+	flags.BoolVar(&enableStagingConfig, "enable-staging-config", false, "Watch the staging ConfigMap, named after the OSM ConfigMap with the -staging suffix, configuring the pods labeled osm.io/config-channel=staging")
 
 	// sidecar injector options
 	flags.BoolVar(&injectorConfig.DefaultInjection, "default-injection", true, "Enable sidecar injection by default")
@@ -133,12 +139,34 @@ func main() {
 
 	// This component will be watching the OSM ConfigMap and will make it
 	// to the rest of the components.
-	cfg := configurator.NewConfigurator(kubernetes.NewForConfigOrDie(kubeConfig), stop, osmNamespace, osmConfigMapName)
-	configMap, err := cfg.GetConfigMap()
+	configuratorOptions := []configurator.Option{
+		configurator.WithDefaultServiceCertValidityDuration(time.Duration(serviceCertValidityMinutes) * time.Minute),
+		configurator.WithDefaultEnvoyImage(injectorConfig.SidecarImage),
+		configurator.WithDefaultInitContainerImage(injectorConfig.InitContainerImage),
+	}
+	if enableStagingConfig {
+		configuratorOptions = append(configuratorOptions, configurator.WithStagingConfigMap())
+	}
+	if osmMeshConfigName != "" {
+		configuratorOptions = append(configuratorOptions, configurator.WithMeshConfig(osmClient.NewForConfigOrDie(kubeConfig), osmMeshConfigName))
+	}
+	cfg := configurator.NewConfigurator(kubernetes.NewForConfigOrDie(kubeConfig), stop, osmNamespace, osmConfigMapName, configuratorOptions...)
+	configMap, err := cfg.GetRedactedConfigMap()
 	if err != nil {
 		log.Error().Err(err).Msgf("Error parsing ConfigMap %s", osmConfigMapName)
 	}
 	log.Info().Msgf("Initial ConfigMap %s: %s", osmConfigMapName, string(configMap))
+	if !cfg.IsConfigReady() {
+		log.Warn().Msgf("ConfigMap %s/%s is not available; Using the default config, which disables egress and permissive traffic policy mode, until it is created", osmNamespace, osmConfigMapName)
+	}
+
+	// The certificate provider set in the OSM config takes precedence over the CLI options
+	if err := applyCertificateProviderConfig(kubeClient, cfg); err != nil {
+		log.Fatal().Err(err).Msg("Error applying the certificate provider config")
+	}
+	if err := validateCertificateManagerOptions(); err != nil {
+		log.Fatal().Err(err).Msg("Error validating certificate manager options")
+	}
 
 	kubernetesClient := k8s.NewKubernetesClient(kubeClient, meshName, stop)
 	meshSpec, err := smi.NewMeshSpecClient(*smiKubeConfig, kubeClient, osmNamespace, kubernetesClient, stop)
@@ -146,12 +174,12 @@ func main() {
 		log.Fatal().Err(err).Msg("Failed to create new mesh spec client")
 	}
 
-	certManager, certDebugger, err := getCertificateManager(kubeClient, kubeConfig)
+	certManager, certDebugger, err := getCertificateManager(kubeClient, kubeConfig, cfg)
 	if err != nil {
-		log.Fatal().Err(err).Msgf("Failed to get certificate manager based on CLI argument: %s", *osmCertificateManagerKind)
+		log.Fatal().Err(err).Msgf("Failed to get certificate manager %s", *osmCertificateManagerKind)
 	}
 
-	log.Info().Msgf("Service certificates will be valid for %+v", getServiceCertValidityPeriod())
+	log.Info().Msgf("Service certificates will be valid for %+v", cfg.GetServiceCertValidityDuration())
 
 	if caBundleSecretName == "" {
 		log.Info().Msgf("CA bundle will not be exported to a k8s secret (no --%s provided)", caBundleSecretNameCLIParam)
@@ -201,17 +229,19 @@ func main() {
 
 	// initialize the http server and start it
 	// TODO(draychev): figure out the NS and POD
-	metricsStore := metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName")
+	metricsStore := metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName", cfg.GetMetricsCollector())
+	metricsStore.Start()
 
-	// Expose /debug endpoints and data only if the enableDebugServer flag is enabled
-	var debugServer debugger.DebugServer
-	if enableDebugServer {
-		debugServer = debugger.NewDebugServer(certDebugger, xdsServer, meshCatalog, kubeConfig, kubeClient, cfg)
-	}
+	// Expose /debug endpoints and data when the enableDebugServer flag is enabled, and otherwise only while the OSM
+	// config enables the debug server
+	debugServer := debugger.NewDebugServer(certDebugger, xdsServer, meshCatalog, kubeConfig, kubeClient, cfg)
 
 	funcProbes := []health.Probes{xdsServer}
 	httpProbes := getHTTPHealthProbes()
 	httpServer := httpserver.NewHTTPServer(funcProbes, httpProbes, metricsStore, constants.MetricsServerPort, debugServer)
+	if !enableDebugServer {
+		httpServer.WatchDebugServerConfig(cfg, stop)
+	}
 	httpServer.Start()
 
 	// Wait for exit handler signal
@@ -307,14 +337,14 @@ func saveOrUpdateSecretToKubernetes(kubeClient clientset.Interface, ca certifica
 	return nil
 }
 
-func getCertificateManager(kubeClient kubernetes.Interface, kubeConfig *rest.Config) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
+func getCertificateManager(kubeClient kubernetes.Interface, kubeConfig *rest.Config, cfg configurator.Configurator) (certificate.Manager, debugger.CertificateManagerDebugger, error) {
 	switch *osmCertificateManagerKind {
 	case tresorKind:
-		return getTresorOSMCertificateManager(kubeClient, enableDebugServer)
+		return getTresorOSMCertificateManager(kubeClient, cfg, enableDebugServer)
 	case vaultKind:
-		return getHashiVaultOSMCertificateManager(enableDebugServer)
+		return getHashiVaultOSMCertificateManager(cfg, enableDebugServer)
 	case certmanagerKind:
-		return getCertManagerOSMCertificateManager(kubeClient, kubeConfig, enableDebugServer)
+		return getCertManagerOSMCertificateManager(kubeClient, kubeConfig, cfg, enableDebugServer)
 	default:
 		return nil, nil, fmt.Errorf("Unsupported Certificate Manager %s", *osmCertificateManagerKind)
 	}
@@ -1,6 +1,6 @@
 module github.com/openservicemesh/osm
 
-go 1.15
+go 1.16
 
 require (
 	github.com/AlekSi/gocov-xml v0.0.0-20190121064608-3a14fb1c4737
@@ -33,6 +33,7 @@ require (
 	github.com/servicemeshinterface/smi-sdk-go v0.4.1
 	github.com/spf13/cobra v1.0.0
 	github.com/spf13/pflag v1.0.5
+	github.com/xeipuuv/gojsonschema v1.1.0
 	google.golang.org/grpc v1.27.0
 	gopkg.in/yaml.v2 v2.3.0
 	helm.sh/helm/v3 v3.2.0
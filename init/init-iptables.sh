@@ -8,6 +8,8 @@ PROXY_PORT=${PROXY_PORT:-15001}
 PROXY_INBOUND_PORT=${PROXY_INBOUND_PORT:-15003}
 PROXY_UID=${PROXY_UID:-1337}
 SSH_PORT=${SSH_PORT:-22}
+OUTBOUND_PORT_EXCLUSION_LIST=${OSM_OUTBOUND_PORT_EXCLUSION_LIST:-}
+INBOUND_PORT_EXCLUSION_LIST=${OSM_INBOUND_PORT_EXCLUSION_LIST:-}
 
 # Create a new chain for redirecting outbound traffic to PROXY_PORT
 iptables -t nat -N PROXY_REDIRECT
@@ -29,6 +31,10 @@ iptables -t nat -A PREROUTING -p tcp -j PROXY_INBOUND
 iptables -t nat -A PROXY_INBOUND -p tcp --dport "${SSH_PORT}" -j RETURN
 # Skip inbound stats query redirection
 iptables -t nat -A PROXY_INBOUND -p tcp --dport "${PROXY_STATS_PORT}" -j RETURN
+# Skip inbound redirection for the excluded ports
+for port in ${INBOUND_PORT_EXCLUSION_LIST//,/ }; do
+    iptables -t nat -A PROXY_INBOUND -p tcp --dport "${port}" -j RETURN
+done
 # Redirect remaining inbound traffic to PROXY_INBOUND_PORT
 iptables -t nat -A PROXY_INBOUND -p tcp -j PROXY_IN_REDIRECT
 
@@ -47,5 +53,10 @@ iptables -t nat -A PROXY_OUTPUT -m owner --uid-owner "${PROXY_UID}" -j RETURN
 # Skip localhost traffic
 iptables -t nat -A PROXY_OUTPUT -d 127.0.0.1/32 -j RETURN
 
+# Skip outbound redirection for the excluded ports
+for port in ${OUTBOUND_PORT_EXCLUSION_LIST//,/ }; do
+    iptables -t nat -A PROXY_OUTPUT -p tcp --dport "${port}" -j RETURN
+done
+
 # Redirect remaining outbound traffic to Envoy
 iptables -t nat -A PROXY_OUTPUT -j PROXY_REDIRECT
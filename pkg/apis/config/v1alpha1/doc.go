@@ -0,0 +1,5 @@
+// +k8s:deepcopy-gen=package,register
+// +groupName=config.openservicemesh.io
+
+// Package v1alpha1 is the v1alpha1 version of the API.
+package v1alpha1
@@ -0,0 +1,599 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MeshConfig is the type used to represent the mesh wide configuration of OSM.
+// It is a typed alternative to the OSM ConfigMap.
+type MeshConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MeshConfigSpec `json:"spec"`
+}
+
+// MeshConfigSpec is the spec of the mesh wide configuration of OSM; each field corresponds to a key of the OSM ConfigMap.
+type MeshConfigSpec struct {
+	// PermissiveTrafficPolicyMode toggles whether SMI policies are ignored and all traffic between services is allowed.
+	// +optional
+	PermissiveTrafficPolicyMode bool `json:"permissiveTrafficPolicyMode,omitempty"`
+
+	// PermissiveModeAuditLogging toggles whether the proxies log the requests the SMI policies would deny while in
+	// permissive traffic policy mode.
+	// +optional
+	PermissiveModeAuditLogging bool `json:"permissiveModeAuditLogging,omitempty"`
+
+	// SMISpecVersion is the version of the SMI APIs the SMI policies are honored in: v1alpha3 or v1alpha4.
+	// +optional
+	SMISpecVersion string `json:"smiSpecVersion,omitempty"`
+
+	// Egress toggles whether traffic to destinations outside of the mesh is allowed.
+	// +optional
+	Egress bool `json:"egress,omitempty"`
+
+	// EgressMode is the egress mode of the mesh: disabled, global or policy; when unset, the mode follows Egress.
+	// +optional
+	EgressMode string `json:"egressMode,omitempty"`
+
+	// PrometheusScraping toggles whether the proxies can be scraped by Prometheus.
+	// +optional
+	PrometheusScraping bool `json:"prometheusScraping,omitempty"`
+
+	// PrometheusScrapePort is the port on which Prometheus scrapes the metrics of the proxies.
+	// +optional
+	PrometheusScrapePort int `json:"prometheusScrapePort,omitempty"`
+
+	// PrometheusScrapePath is the path on which Prometheus scrapes the metrics of the proxies.
+	// +optional
+	PrometheusScrapePath string `json:"prometheusScrapePath,omitempty"`
+
+	// UseHTTPSIngress toggles whether traffic from ingress to backend pods uses HTTPS.
+	// +optional
+	UseHTTPSIngress bool `json:"useHTTPSIngress,omitempty"`
+
+	// DefaultIngressBackendProtocol is the default protocol, http or https, of the traffic from ingress to backend pods;
+	// when unset, the protocol follows UseHTTPSIngress.
+	// +optional
+	DefaultIngressBackendProtocol string `json:"defaultIngressBackendProtocol,omitempty"`
+
+	// StripForwardedHeaders toggles whether the proxies strip the X-Forwarded-* headers of the requests they receive.
+	// +optional
+	StripForwardedHeaders bool `json:"stripForwardedHeaders,omitempty"`
+
+	// EnvoyLogLevel is the log level of the Envoy proxies.
+	// +optional
+	EnvoyLogLevel string `json:"envoyLogLevel,omitempty"`
+
+	// MeshCIDRRanges is the list of CIDR ranges for in-mesh traffic, required when egress is enabled.
+	// +optional
+	MeshCIDRRanges []string `json:"meshCIDRRanges,omitempty"`
+
+	// EgressAllowedDomains is the list of external domains, possibly wildcard domains such as *.example.com, egress is allowed to.
+	// +optional
+	EgressAllowedDomains []string `json:"egressAllowedDomains,omitempty"`
+
+	// EgressDNSResolution is how Envoy resolves the addresses of the egress clusters: STRICT_DNS, LOGICAL_DNS or STATIC.
+	// +optional
+	EgressDNSResolution string `json:"egressDNSResolution,omitempty"`
+
+	// EgressCABundle is the reference, of the form <namespace>/<name>[/<key>], to the Secret key holding the CA bundle
+	// the TLS connections originated to the external hosts are verified with.
+	// +optional
+	EgressCABundle string `json:"egressCABundle,omitempty"`
+
+	// OutboundPortExclusionList is the list of ports for which outbound traffic bypasses the proxy.
+	// +optional
+	OutboundPortExclusionList []int `json:"outboundPortExclusionList,omitempty"`
+
+	// InboundPortExclusionList is the list of ports for which inbound traffic bypasses the proxy.
+	// +optional
+	InboundPortExclusionList []int `json:"inboundPortExclusionList,omitempty"`
+
+	// LocalityAwareRouting enables the preference of the proxies for the endpoints in their own region and zone.
+	// +optional
+	LocalityAwareRouting bool `json:"localityAwareRouting,omitempty"`
+
+	// LocalityRegion overrides the region of the proxies, which is otherwise derived from the labels of their node.
+	// +optional
+	LocalityRegion string `json:"localityRegion,omitempty"`
+
+	// LocalityZone overrides the zone of the proxies, which is otherwise derived from the labels of their node.
+	// +optional
+	LocalityZone string `json:"localityZone,omitempty"`
+
+	// EnvoyLogFormat is the format of the logs of the Envoy processes, as opposed to their access logs: text or json.
+	// +optional
+	EnvoyLogFormat string `json:"envoyLogFormat,omitempty"`
+
+	// EnableAccessLogging enables the access logs of the Envoy proxies.
+	// +optional
+	EnableAccessLogging bool `json:"enableAccessLogging,omitempty"`
+
+	// AccessLogFormat is the format of the access logs of the Envoy proxies: text or json.
+	// +optional
+	AccessLogFormat string `json:"accessLogFormat,omitempty"`
+
+	// EnvoyAdminPort is the port the admin interface of the Envoy proxies listens on.
+	// +optional
+	EnvoyAdminPort int `json:"envoyAdminPort,omitempty"`
+
+	// EnvoyConnectionIdleTimeout is the duration, as a Go duration string, after which the proxies close idle connections.
+	// +optional
+	EnvoyConnectionIdleTimeout string `json:"envoyConnectionIdleTimeout,omitempty"`
+
+	// EnvoyRequestTimeout is the duration, as a Go duration string, after which the proxies time out requests.
+	// An unset value means the default timeout, whereas "0s" means no timeout.
+	// +optional
+	EnvoyRequestTimeout string `json:"envoyRequestTimeout,omitempty"`
+
+	// XDSServerResponseTimeout is the duration, as a Go duration string, within which the controller must send an xDS
+	// response to a proxy before closing its stream.
+	// +optional
+	XDSServerResponseTimeout string `json:"xdsServerResponseTimeout,omitempty"`
+
+	// XDSKeepaliveTime is the duration, as a Go duration string, of inactivity of an xDS connection after which the
+	// controller and the proxies ping each other.
+	// +optional
+	XDSKeepaliveTime string `json:"xdsKeepaliveTime,omitempty"`
+
+	// XDSKeepaliveTimeout is the duration, as a Go duration string, after which an xDS connection whose keepalive ping
+	// is not acknowledged is closed.
+	// +optional
+	XDSKeepaliveTimeout string `json:"xdsKeepaliveTimeout,omitempty"`
+
+	// DisabledXDSTypes is the list of xDS resource types, by short name such as RDS, the controller does not send to the proxies.
+	// +optional
+	DisabledXDSTypes []string `json:"disabledXDSTypes,omitempty"`
+
+	// MaxDataPlaneConnections is the maximum number of Envoy proxies connected to the controller; 0 means unlimited.
+	// +optional
+	MaxDataPlaneConnections int `json:"maxDataPlaneConnections,omitempty"`
+
+	// MaxEndpointsPerCluster is the maximum number of endpoints of each cluster sent to the proxies over EDS; 0 means unlimited.
+	// +optional
+	MaxEndpointsPerCluster int `json:"maxEndpointsPerCluster,omitempty"`
+
+	// LeaderElection is the lease timings of the leader election of the controllers.
+	// +optional
+	LeaderElection LeaderElectionSpec `json:"leaderElection,omitempty"`
+
+	// EnableDebugServer enables the debug server of the controller, which exposes its internals.
+	// +optional
+	EnableDebugServer bool `json:"enableDebugServer,omitempty"`
+
+	// ServiceCertValidityDuration is the validity duration, as a Go duration string, of the service certificates.
+	// +optional
+	ServiceCertValidityDuration string `json:"serviceCertValidityDuration,omitempty"`
+
+	// TrustDomain is the trust domain of the mesh, which the common names of the service certificates end with.
+	// +optional
+	TrustDomain string `json:"trustDomain,omitempty"`
+
+	// MeshTLSMinVersion is the oldest TLS protocol version, from TLS1_0 to TLS1_3, of the mesh-internal connections.
+	// +optional
+	MeshTLSMinVersion string `json:"meshTLSMinVersion,omitempty"`
+
+	// MeshTLSMaxVersion is the newest TLS protocol version, from TLS1_0 to TLS1_3, of the mesh-internal connections.
+	// +optional
+	MeshTLSMaxVersion string `json:"meshTLSMaxVersion,omitempty"`
+
+	// MeshCipherSuites is the list, by order of preference, of the TLS cipher suites of the mesh-internal connections;
+	// an empty list leaves the cipher suites to Envoy.
+	// +optional
+	MeshCipherSuites []string `json:"meshCipherSuites,omitempty"`
+
+	// MTLSExemptSourceCIDRs is the list of source CIDR ranges whose plaintext connections the inbound listeners accept
+	// without mTLS.
+	// +optional
+	MTLSExemptSourceCIDRs []string `json:"mtlsExemptSourceCIDRs,omitempty"`
+
+	// CertificateProvider is the certificate provider issuing the certificates of the mesh: tresor, vault or cert-manager;
+	// when unset, the provider is the one the controller is started with. Like the configs of the providers, it is only
+	// read when the controller starts.
+	// +optional
+	CertificateProvider string `json:"certificateProvider,omitempty"`
+
+	// Vault is the config of the Hashicorp Vault certificate provider.
+	// +optional
+	Vault VaultSpec `json:"vault,omitempty"`
+
+	// CertManager is the config of the cert-manager certificate provider.
+	// +optional
+	CertManager CertManagerSpec `json:"certManager,omitempty"`
+
+	// RetryPolicy is the default retry policy of the routes.
+	// +optional
+	RetryPolicy RetryPolicySpec `json:"retryPolicy,omitempty"`
+
+	// CircuitBreaking is the default circuit breaking thresholds of the upstream clusters.
+	// +optional
+	CircuitBreaking CircuitBreakingSpec `json:"circuitBreaking,omitempty"`
+
+	// DefaultLBAlgorithm is the load balancing algorithm of the upstream clusters: ROUND_ROBIN, LEAST_REQUEST, RING_HASH, RANDOM or MAGLEV.
+	// +optional
+	DefaultLBAlgorithm string `json:"defaultLBAlgorithm,omitempty"`
+
+	// OutlierDetection is the outlier detection ejecting the failing hosts of the upstream clusters from the load balancing.
+	// +optional
+	OutlierDetection OutlierDetectionSpec `json:"outlierDetection,omitempty"`
+
+	// InboundExternalAuth is the external authorization service the inbound requests are authorized with.
+	// +optional
+	InboundExternalAuth InboundExternalAuthSpec `json:"inboundExternalAuth,omitempty"`
+
+	// MulticlusterEnabled toggles the federation of the mesh with the meshes of other clusters.
+	// +optional
+	MulticlusterEnabled bool `json:"multiclusterEnabled,omitempty"`
+
+	// MulticlusterGateway is the gateway the traffic to the other clusters of the mesh goes through.
+	// +optional
+	MulticlusterGateway MulticlusterGatewaySpec `json:"multiclusterGateway,omitempty"`
+
+	// SidecarResources is the resource requests and limits of the injected Envoy sidecars.
+	// +optional
+	SidecarResources SidecarResourcesSpec `json:"sidecarResources,omitempty"`
+
+	// EnvoyImage is the image reference of the injected Envoy sidecars.
+	// +optional
+	EnvoyImage string `json:"envoyImage,omitempty"`
+
+	// InitContainerImage is the image reference of the injected init containers.
+	// +optional
+	InitContainerImage string `json:"initContainerImage,omitempty"`
+
+	// EnableSidecarInjection toggles the injection of the Envoy sidecars into the new pods of the mesh; it defaults to true when unset.
+	// +optional
+	EnableSidecarInjection *bool `json:"enableSidecarInjection,omitempty"`
+
+	// ExcludedNamespaces is the list of namespaces, such as kube-system, whose pods are never meshed.
+	// +optional
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+
+	// ProxyProbe is the timings of the readiness and liveness probes of the injected Envoy sidecars.
+	// +optional
+	ProxyProbe ProbeSpec `json:"proxyProbe,omitempty"`
+
+	// ProxyBootstrapConfigOverride is a YAML fragment deep-merged over the bootstrap config of the Envoy sidecars.
+	// +optional
+	ProxyBootstrapConfigOverride string `json:"proxyBootstrapConfigOverride,omitempty"`
+
+	// ProxyBindAddress is the address the admin listener of the Envoy sidecars binds to: an IP address, or pod-ip.
+	// +optional
+	ProxyBindAddress string `json:"proxyBindAddress,omitempty"`
+
+	// EnvoyConcurrency is the number of worker threads of the Envoy sidecars; 0 lets Envoy run one worker per CPU core.
+	// +optional
+	EnvoyConcurrency int `json:"envoyConcurrency,omitempty"`
+
+	// ProxyEnvVars is the set of extra environment variables, keyed by the variable name, of the Envoy sidecars.
+	// +optional
+	ProxyEnvVars map[string]string `json:"proxyEnvVars,omitempty"`
+
+	// ProxyDrainTime is the duration, as a Go duration string, during which the Envoy sidecars drain their connections
+	// on a hot restart or shutdown.
+	// +optional
+	ProxyDrainTime string `json:"proxyDrainTime,omitempty"`
+
+	// ProxyParentShutdownTime is the duration, as a Go duration string, after which the parent Envoy process is shut
+	// down on a hot restart; it must not be shorter than ProxyDrainTime.
+	// +optional
+	ProxyParentShutdownTime string `json:"proxyParentShutdownTime,omitempty"`
+
+	// OverloadManager is the heap limit of the Envoy sidecars, approaching which they shed load.
+	// +optional
+	OverloadManager OverloadManagerSpec `json:"overloadManager,omitempty"`
+
+	// Tracing is the tracing configuration of the proxies.
+	// +optional
+	Tracing TracingSpec `json:"tracing,omitempty"`
+
+	// StatsPrefix is the prefix of the names of the HTTP stats of the Envoy proxies.
+	// +optional
+	StatsPrefix string `json:"statsPrefix,omitempty"`
+
+	// StatsTags is the set of tags, keyed by the tag name, added to all the stats of the Envoy proxies.
+	// +optional
+	StatsTags map[string]string `json:"statsTags,omitempty"`
+
+	// StatsSink is the statsd sink the Envoy proxies push their stats to.
+	// +optional
+	StatsSink StatsSinkSpec `json:"statsSink,omitempty"`
+
+	// WASMExtensions is the list of the WebAssembly extensions run by the Envoy proxies on the HTTP requests, in order.
+	// +optional
+	WASMExtensions []WASMExtensionSpec `json:"wasmExtensions,omitempty"`
+
+	// FeatureFlags is the set of experimental features toggled on or off, keyed by the feature name.
+	// +optional
+	FeatureFlags map[string]bool `json:"featureFlags,omitempty"`
+}
+
+// CircuitBreakingSpec is the default circuit breaking thresholds of the upstream clusters; Envoy's defaults apply to the 0 thresholds.
+type CircuitBreakingSpec struct {
+	// MaxConnections is the maximum number of connections to a cluster.
+	// +optional
+	MaxConnections uint32 `json:"maxConnections,omitempty"`
+
+	// MaxPendingRequests is the maximum number of requests to a cluster waiting for a connection.
+	// +optional
+	MaxPendingRequests uint32 `json:"maxPendingRequests,omitempty"`
+
+	// MaxRequests is the maximum number of parallel requests to a cluster.
+	// +optional
+	MaxRequests uint32 `json:"maxRequests,omitempty"`
+
+	// MaxRetries is the maximum number of parallel retries to a cluster.
+	// +optional
+	MaxRetries uint32 `json:"maxRetries,omitempty"`
+}
+
+// OutlierDetectionSpec is the outlier detection of the upstream clusters, which is disabled unless enabled; the defaults apply to the unset fields.
+type OutlierDetectionSpec struct {
+	// Enable toggles the outlier detection of the upstream clusters.
+	// +optional
+	Enable bool `json:"enable,omitempty"`
+
+	// Consecutive5xx is the number of consecutive 5xx responses, or connection failures, after which a host is ejected.
+	// +optional
+	Consecutive5xx uint32 `json:"consecutive5xx,omitempty"`
+
+	// Interval is the interval, as a Go duration string, between two sweeps of the hosts for ejection.
+	// +optional
+	Interval string `json:"interval,omitempty"`
+
+	// BaseEjectionTime is the duration, as a Go duration string, a host is ejected for, multiplied by the number of times it has been ejected.
+	// +optional
+	BaseEjectionTime string `json:"baseEjectionTime,omitempty"`
+
+	// MaxEjectionPercent is the maximum percentage of the hosts of a cluster which can be ejected at the same time.
+	// +optional
+	MaxEjectionPercent uint32 `json:"maxEjectionPercent,omitempty"`
+}
+
+// InboundExternalAuthSpec is the external authorization service, such as OPA, the inbound requests are authorized with over gRPC.
+type InboundExternalAuthSpec struct {
+	// Enable toggles the authorization of the inbound requests by the external authorization service.
+	// +optional
+	Enable bool `json:"enable,omitempty"`
+
+	// Address is the IP address or DNS name of the external authorization service.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// Port is the port of the external authorization service.
+	// +optional
+	Port uint32 `json:"port,omitempty"`
+
+	// StatPrefix is the name the stats of the external authorization service cluster are emitted under.
+	// +optional
+	StatPrefix string `json:"statPrefix,omitempty"`
+
+	// Timeout is the timeout, as a Go duration string, of each authorization request.
+	// +optional
+	Timeout string `json:"timeout,omitempty"`
+
+	// FailureModeAllow lets the requests through when the external authorization service cannot be reached.
+	// +optional
+	FailureModeAllow bool `json:"failureModeAllow,omitempty"`
+}
+
+// MulticlusterGatewaySpec is the gateway, in the cluster of the proxies, the traffic to the other clusters of the mesh goes through.
+type MulticlusterGatewaySpec struct {
+	// Host is the IP address or DNS name of the gateway.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Port is the port of the gateway.
+	// +optional
+	Port uint32 `json:"port,omitempty"`
+}
+
+// StatsSinkSpec is the statsd sink the Envoy proxies push their stats to; it is disabled when its type is unset.
+type StatsSinkSpec struct {
+	// Type is the protocol of the sink: statsd or dogstatsd.
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Address is the IP address of the sink.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// Port is the UDP port of the sink.
+	// +optional
+	Port uint32 `json:"port,omitempty"`
+
+	// Prefix is the prefix of the stats pushed to the sink.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// VaultSpec is the config of the Hashicorp Vault certificate provider.
+type VaultSpec struct {
+	// Protocol is the protocol of the Vault API: http or https.
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+
+	// Host is the host name or IP address of Vault.
+	// +optional
+	Host string `json:"host,omitempty"`
+
+	// Port is the port of the Vault API.
+	// +optional
+	Port uint32 `json:"port,omitempty"`
+
+	// TokenSecretRef is the reference, of the form <namespace>/<name>/<key>, to the Secret key holding the token the
+	// controller authenticates to Vault with.
+	// +optional
+	TokenSecretRef string `json:"tokenSecretRef,omitempty"`
+
+	// Role is the name of the Vault role dedicated to OSM.
+	// +optional
+	Role string `json:"role,omitempty"`
+}
+
+// CertManagerSpec is the config of the cert-manager certificate provider.
+type CertManagerSpec struct {
+	// IssuerName is the name of the cert-manager issuer signing the certificates.
+	// +optional
+	IssuerName string `json:"issuerName,omitempty"`
+
+	// IssuerKind is the kind of the issuer, such as Issuer or ClusterIssuer.
+	// +optional
+	IssuerKind string `json:"issuerKind,omitempty"`
+
+	// IssuerGroup is the API group of the issuer.
+	// +optional
+	IssuerGroup string `json:"issuerGroup,omitempty"`
+}
+
+// OverloadManagerSpec is the heap limit of the Envoy sidecars; it is disabled when the maximum heap size is unset.
+type OverloadManagerSpec struct {
+	// MaxHeapBytes is the maximum heap size, in bytes, of the Envoy sidecars.
+	// +optional
+	MaxHeapBytes uint64 `json:"maxHeapBytes,omitempty"`
+
+	// ShrinkHeapThreshold is the fraction, between 0 and 1, of the maximum heap size above which the Envoy sidecars
+	// release the free memory of their heap.
+	// +optional
+	ShrinkHeapThreshold string `json:"shrinkHeapThreshold,omitempty"`
+
+	// StopAcceptingThreshold is the fraction, between 0 and 1, of the maximum heap size above which the Envoy sidecars
+	// stop accepting requests.
+	// +optional
+	StopAcceptingThreshold string `json:"stopAcceptingThreshold,omitempty"`
+}
+
+// LeaderElectionSpec is the lease timings, as Go duration strings, of the leader election of the controllers; the timings
+// which are unset use their default.
+type LeaderElectionSpec struct {
+	// LeaseDuration is the duration the candidates wait, after the last renewal of the leadership, before acquiring it.
+	// +optional
+	LeaseDuration string `json:"leaseDuration,omitempty"`
+
+	// RenewDeadline is the duration the leader keeps retrying to renew its leadership before giving it up.
+	// +optional
+	RenewDeadline string `json:"renewDeadline,omitempty"`
+
+	// RetryPeriod is the interval between two attempts of the candidates to acquire or renew the leadership.
+	// +optional
+	RetryPeriod string `json:"retryPeriod,omitempty"`
+}
+
+// SidecarResourcesSpec is the resource requests and limits, as Kubernetes quantity strings, of the injected Envoy sidecars.
+type SidecarResourcesSpec struct {
+	// CPURequest is the CPU requested by a sidecar.
+	// +optional
+	CPURequest string `json:"cpuRequest,omitempty"`
+
+	// CPULimit is the maximum CPU used by a sidecar.
+	// +optional
+	CPULimit string `json:"cpuLimit,omitempty"`
+
+	// MemoryRequest is the memory requested by a sidecar.
+	// +optional
+	MemoryRequest string `json:"memoryRequest,omitempty"`
+
+	// MemoryLimit is the maximum memory used by a sidecar.
+	// +optional
+	MemoryLimit string `json:"memoryLimit,omitempty"`
+}
+
+// ProbeSpec is the timings of the probes of the injected Envoy sidecars; the timings which are unset use their default.
+type ProbeSpec struct {
+	// InitialDelaySeconds is the delay after a sidecar starts before it is first probed.
+	// +optional
+	InitialDelaySeconds int32 `json:"initialDelaySeconds,omitempty"`
+
+	// PeriodSeconds is the interval between two probes.
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// TimeoutSeconds is the duration after which a probe times out.
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// FailureThreshold is the number of consecutive failed probes after which a probe fails.
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+// RetryPolicySpec is the default retry policy of the routes.
+type RetryPolicySpec struct {
+	// NumRetries is the number of times a request is retried; retries are disabled when 0.
+	// +optional
+	NumRetries uint32 `json:"numRetries,omitempty"`
+
+	// PerTryTimeout is the timeout, as a Go duration string, of each try.
+	// +optional
+	PerTryTimeout string `json:"perTryTimeout,omitempty"`
+
+	// RetryOn is the comma separated list of conditions on which requests are retried.
+	// +optional
+	RetryOn string `json:"retryOn,omitempty"`
+}
+
+// TracingSpec is the tracing configuration of the proxies.
+type TracingSpec struct {
+	// Enable toggles tracing.
+	// +optional
+	Enable bool `json:"enable,omitempty"`
+
+	// Address is the address of the tracing collector, or the comma separated addresses, in order of preference,
+	// of the collectors the proxies fail over between.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// Port is the port of the tracing collector.
+	// +optional
+	Port int `json:"port,omitempty"`
+
+	// Endpoint is the API endpoint of the tracing collector.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// SamplingRate is the fraction, between 0 and 1, of requests that are traced.
+	// The rate is a string, e.g. "0.25", since floating point numbers are discouraged in Kubernetes APIs.
+	// +optional
+	SamplingRate string `json:"samplingRate,omitempty"`
+
+	// Backend is the type of the tracing collector: zipkin, jaeger or otlp.
+	// +optional
+	Backend string `json:"backend,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MeshConfigList is the list of MeshConfig objects.
+type MeshConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []MeshConfig `json:"items"`
+}
+
+// WASMExtensionSpec is a WebAssembly extension run by Envoy's wasm HTTP filter on the inbound or outbound requests of the proxies.
+type WASMExtensionSpec struct {
+	// Name is the unique name of the extension.
+	Name string `json:"name"`
+
+	// URI is the location of the WebAssembly module in the proxy container: an absolute path, or a file:// URI.
+	URI string `json:"uri"`
+
+	// RootID is the ID of the root context of the module the extension runs.
+	// +optional
+	RootID string `json:"rootID,omitempty"`
+
+	// Config is the configuration passed as is to the extension when it starts.
+	// +optional
+	Config string `json:"config,omitempty"`
+
+	// InsertionPoint is whether the extension runs on the inbound or the outbound requests; it defaults to inbound.
+	// +optional
+	InsertionPoint string `json:"insertionPoint,omitempty"`
+}
@@ -7,12 +7,13 @@ import (
 
 // GetCertificateForService returns the certificate the given proxy uses for mTLS to the XDS server.
 func (mc *MeshCatalog) GetCertificateForService(meshService service.MeshService) (certificate.Certificater, error) {
-	cn := meshService.GetCommonName()
+	cn := meshService.GetCommonNameForTrustDomain(mc.configurator.GetTrustDomain())
 
 	cert, err := mc.certManager.GetCertificate(cn)
 	if err != nil {
 		// Certificate was not found in CertManager's cache, issue one
-		newCert, err := mc.certManager.IssueCertificate(cn, nil)
+		validityPeriod := mc.configurator.GetServiceCertValidityDuration()
+		newCert, err := mc.certManager.IssueCertificate(cn, &validityPeriod)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error issuing a new certificate for service:%s, CN: %s", meshService, cn)
 			return nil, err
@@ -61,19 +61,21 @@ func (mc *MeshCatalog) ListDisconnectedProxies() map[certificate.CommonName]time
 	return proxies
 }
 
-// ListSMIPolicies returns all policies OSM is aware of.
+// ListSMIPolicies returns all policies OSM is aware of, the TrafficSplits and TrafficTargets being these of the SMI spec
+// version set in the OSM config.
 func (mc *MeshCatalog) ListSMIPolicies() ([]*split.TrafficSplit, []service.WeightedService, []service.K8sServiceAccount, []*spec.HTTPRouteGroup, []*target.TrafficTarget, []*corev1.Service) {
-	trafficSplits := mc.meshSpec.ListTrafficSplits()
-	splitServices := mc.meshSpec.ListTrafficSplitServices()
-	serviceAccouns := mc.meshSpec.ListServiceAccounts()
+	smiSpecVersion := mc.configurator.GetSMISpecVersion()
+	trafficSplits := mc.meshSpec.ListTrafficSplits(smiSpecVersion)
+	splitServices := mc.meshSpec.ListTrafficSplitServices(smiSpecVersion)
+	serviceAccouns := mc.meshSpec.ListServiceAccounts(smiSpecVersion)
 	trafficSpecs := mc.meshSpec.ListHTTPTrafficSpecs()
-	trafficTargets := mc.meshSpec.ListTrafficTargets()
+	trafficTargets := mc.meshSpec.ListTrafficTargets(smiSpecVersion)
 	services := mc.meshSpec.ListServices()
 
 	return trafficSplits, splitServices, serviceAccouns, trafficSpecs, trafficTargets, services
 }
 
-// ListMonitoredNamespaces returns all namespaces that the mesh is monitoring.
+// ListMonitoredNamespaces returns all namespaces that the mesh is monitoring, except the namespaces excluded from the mesh in the OSM config.
 func (mc *MeshCatalog) ListMonitoredNamespaces() []string {
 	namespaces, err := mc.namespaceController.ListMonitoredNamespaces()
 
@@ -81,5 +83,14 @@ func (mc *MeshCatalog) ListMonitoredNamespaces() []string {
 		return nil
 	}
 
-	return namespaces
+	excludedNamespaces := mc.configurator.GetExcludedNamespaces()
+	var monitoredNamespaces []string
+	for _, namespace := range namespaces {
+		if excludedNamespaces[namespace] {
+			continue
+		}
+		monitoredNamespaces = append(monitoredNamespaces, namespace)
+	}
+
+	return monitoredNamespaces
 }
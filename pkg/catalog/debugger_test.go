@@ -1,12 +1,19 @@
 package catalog
 
 import (
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/smi"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
@@ -87,6 +94,21 @@ var _ = Describe("Test catalog proxy register/unregister", func() {
 			}
 			Expect(actual).To(Equal(expected))
 		})
+
+		It("skips the namespaces excluded from the mesh", func() {
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockNsController := k8s.NewMockNamespaceController(mockCtrl)
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mc := &MeshCatalog{
+				namespaceController: mockNsController,
+				configurator:        mockConfigurator,
+			}
+
+			mockNsController.EXPECT().ListMonitoredNamespaces().Return([]string{"kube-system", tests.BookstoreService.Namespace}, nil).Times(1)
+			mockConfigurator.EXPECT().GetExcludedNamespaces().Return(map[string]bool{"kube-system": true}).Times(1)
+
+			Expect(mc.ListMonitoredNamespaces()).To(Equal([]string{tests.BookstoreService.Namespace}))
+		})
 	})
 
 	Context("Test ListSMIPolicies", func() {
@@ -104,5 +126,54 @@ var _ = Describe("Test catalog proxy register/unregister", func() {
 			Expect(services[0].Name).To(Equal(tests.BookstoreServiceName))
 
 		})
+
+		It("lists the TrafficSplits and TrafficTargets of the SMI spec version set in the OSM config", func() {
+			// The TrafficSplits and TrafficTargets of the fake Mesh Spec are of the v1alpha4 SMI spec version
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mc := &MeshCatalog{
+				meshSpec:     smi.NewFakeMeshSpecClient(),
+				configurator: mockConfigurator,
+			}
+
+			mockConfigurator.EXPECT().GetSMISpecVersion().Return(configurator.SMISpecVersionV1alpha3).Times(1)
+			trafficSplits, weightedServices, serviceAccounts, routeGroups, trafficTargets, _ := mc.ListSMIPolicies()
+			Expect(trafficSplits).To(BeEmpty())
+			Expect(weightedServices).To(BeEmpty())
+			Expect(serviceAccounts).To(BeEmpty())
+			Expect(trafficTargets).To(BeEmpty())
+			Expect(routeGroups[0].Name).To(Equal("bookstore-service-routes"))
+
+			mockConfigurator.EXPECT().GetSMISpecVersion().Return(configurator.SMISpecVersionV1alpha4).Times(1)
+			trafficSplits, weightedServices, serviceAccounts, _, trafficTargets, _ = mc.ListSMIPolicies()
+			Expect(trafficSplits[0].Spec.Service).To(Equal("bookstore-apex"))
+			Expect(weightedServices[0].Service).To(Equal(tests.BookstoreService))
+			Expect(serviceAccounts[0].String()).To(Equal("default/bookstore"))
+			Expect(trafficTargets[0].Name).To(Equal(tests.TrafficTargetName))
+		})
+
+		It("lists the TrafficSplits and TrafficTargets of the latest SMI spec version when the one set in the OSM config is unsupported", func() {
+			osmNamespace := "-test-osm-namespace-"
+			osmConfigMapName := "-test-osm-config-map-"
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					"smi_spec_version": "v1alpha2",
+				},
+			}
+			stop := make(chan struct{})
+			defer close(stop)
+			mc := &MeshCatalog{
+				meshSpec:     smi.NewFakeMeshSpecClient(),
+				configurator: configurator.NewConfigurator(testclient.NewSimpleClientset(&configMap), stop, osmNamespace, osmConfigMapName),
+			}
+
+			trafficSplits, _, _, _, trafficTargets, _ := mc.ListSMIPolicies()
+			Expect(trafficSplits[0].Spec.Service).To(Equal("bookstore-apex"))
+			Expect(trafficTargets[0].Name).To(Equal(tests.TrafficTargetName))
+		})
 	})
 })
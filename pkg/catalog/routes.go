@@ -31,6 +31,12 @@ func (mc *MeshCatalog) ListTrafficPolicies(service service.MeshService) ([]traff
 		return trafficPolicies, nil
 	}
 
+	return mc.listSMITrafficPolicies(service)
+}
+
+// listSMITrafficPolicies returns the traffic policies for a given service built from SMI, whether or not the permissive
+// traffic policy mode is enabled.
+func (mc *MeshCatalog) listSMITrafficPolicies(service service.MeshService) ([]trafficpolicy.TrafficTarget, error) {
 	// Build traffic policies from SMI
 	allRoutes, err := mc.getHTTPPathsPerRoute()
 	if err != nil {
@@ -50,8 +56,14 @@ func (mc *MeshCatalog) ListTrafficPolicies(service service.MeshService) ([]traff
 // This is a bimodal function:
 //   - it could list services that are allowed to connect to the given service (inbound)
 //   - it could list services that the given service can connect to (outbound)
-func (mc *MeshCatalog) getAllowedDirectionalServices(svc service.MeshService, directn direction) ([]service.MeshService, error) {
-	allTrafficPolicies, err := mc.ListTrafficPolicies(svc)
+// The services are derived from the SMI policies alone when smiOnly is set, even in permissive traffic policy mode.
+func (mc *MeshCatalog) getAllowedDirectionalServices(svc service.MeshService, directn direction, smiOnly bool) ([]service.MeshService, error) {
+	listTrafficPolicies := mc.ListTrafficPolicies
+	if smiOnly {
+		listTrafficPolicies = mc.listSMITrafficPolicies
+	}
+
+	allTrafficPolicies, err := listTrafficPolicies(svc)
 	if err != nil {
 		log.Error().Err(err).Msg("Failed listing traffic routes")
 		return nil, err
@@ -93,12 +105,18 @@ func (mc *MeshCatalog) getAllowedDirectionalServices(svc service.MeshService, di
 
 // ListAllowedInboundServices lists the inbound services allowed to connect to the given service.
 func (mc *MeshCatalog) ListAllowedInboundServices(destinationService service.MeshService) ([]service.MeshService, error) {
-	return mc.getAllowedDirectionalServices(destinationService, inbound)
+	return mc.getAllowedDirectionalServices(destinationService, inbound, false)
+}
+
+// ListSMIAllowedInboundServices lists the inbound services the SMI policies allow to connect to the given service,
+// whether or not the permissive traffic policy mode is enabled.
+func (mc *MeshCatalog) ListSMIAllowedInboundServices(destinationService service.MeshService) ([]service.MeshService, error) {
+	return mc.getAllowedDirectionalServices(destinationService, inbound, true)
 }
 
 // ListAllowedOutboundServices lists the services the given service is allowed outbound connections to.
 func (mc *MeshCatalog) ListAllowedOutboundServices(sourceService service.MeshService) ([]service.MeshService, error) {
-	return mc.getAllowedDirectionalServices(sourceService, outbound)
+	return mc.getAllowedDirectionalServices(sourceService, outbound, false)
 }
 
 //GetWeightedClusterForService returns the weighted cluster for a given service
@@ -110,7 +128,7 @@ func (mc *MeshCatalog) GetWeightedClusterForService(svc service.MeshService) (se
 	}
 
 	// Retrieve the weighted clusters from traffic split
-	servicesList := mc.meshSpec.ListTrafficSplitServices()
+	servicesList := mc.meshSpec.ListTrafficSplitServices(mc.configurator.GetSMISpecVersion())
 	for _, activeService := range servicesList {
 		if activeService.Service == svc {
 			return service.WeightedCluster{
@@ -145,7 +163,7 @@ func (mc *MeshCatalog) GetHostnamesForService(meshService service.MeshService) (
 	}
 
 	// Retrieve the domain name from traffic split
-	servicesList := mc.meshSpec.ListTrafficSplitServices()
+	servicesList := mc.meshSpec.ListTrafficSplitServices(mc.configurator.GetSMISpecVersion())
 	for _, activeService := range servicesList {
 		if activeService.Service == meshService {
 			log.Trace().Msgf("Getting hostnames for service %s", meshService)
@@ -255,7 +273,7 @@ func getTrafficPoliciesForService(mc *MeshCatalog, routePolicies map[trafficpoli
 	// is a part of.
 	var matchedTrafficTargets []trafficpolicy.TrafficTarget
 
-	for _, trafficTargets := range mc.meshSpec.ListTrafficTargets() {
+	for _, trafficTargets := range mc.meshSpec.ListTrafficTargets(mc.configurator.GetSMISpecVersion()) {
 		log.Debug().Msgf("Discovered TrafficTarget resource: %s/%s", trafficTargets.Namespace, trafficTargets.Name)
 		if trafficTargets.Spec.Rules == nil || len(trafficTargets.Spec.Rules) == 0 {
 			log.Error().Msgf("TrafficTarget %s/%s has no spec routes; Skipping...", trafficTargets.Namespace, trafficTargets.Name)
@@ -141,6 +141,15 @@ var _ = Describe("Catalog tests", func() {
 		})
 	})
 
+	Context("Test ListSMIAllowedInboundServices()", func() {
+		It("returns the list of server names the SMI policies allow to communicate with the hosted service", func() {
+			actualList, err := mc.ListSMIAllowedInboundServices(tests.BookstoreService)
+			Expect(err).ToNot(HaveOccurred())
+			expectedList := []service.MeshService{tests.BookbuyerService}
+			Expect(actualList).To(Equal(expectedList))
+		})
+	})
+
 	Context("Testing buildAllowPolicyForSourceToDest", func() {
 		It("Returns a trafficpolicy.TrafficTarget object to build an allow policy from source to destination service ", func() {
 			selectors := map[string]string{
@@ -64,6 +64,10 @@ type MeshCataloger interface {
 	// ListAllowedInboundServices lists the inbound services allowed to connect to the given service.
 	ListAllowedInboundServices(service.MeshService) ([]service.MeshService, error)
 
+	// ListSMIAllowedInboundServices lists the inbound services the SMI policies allow to connect to the given service,
+	// whether or not the permissive traffic policy mode is enabled.
+	ListSMIAllowedInboundServices(service.MeshService) ([]service.MeshService, error)
+
 	// ListAllowedOutboundServices lists the services the given service is allowed outbound connections to.
 	ListAllowedOutboundServices(service.MeshService) ([]service.MeshService, error)
 
@@ -89,6 +93,9 @@ type MeshCataloger interface {
 	// GetServicesFromEnvoyCertificate returns a list of services the given Envoy is a member of based on the certificate provided, which is a cert issued to an Envoy for XDS communication (not Envoy-to-Envoy).
 	GetServicesFromEnvoyCertificate(certificate.CommonName) ([]service.MeshService, error)
 
+	// GetConfigChannelFromEnvoyCertificate returns the config channel selected by the pod of the Envoy the given XDS certificate was issued to
+	GetConfigChannelFromEnvoyCertificate(certificate.CommonName) (string, error)
+
 	// RegisterProxy registers a newly connected proxy with the service mesh catalog.
 	RegisterProxy(*envoy.Proxy)
 
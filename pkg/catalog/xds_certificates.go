@@ -10,6 +10,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/utils"
@@ -52,11 +53,21 @@ func (mc *MeshCatalog) GetServicesFromEnvoyCertificate(cn certificate.CommonName
 	return serviceList, nil
 }
 
+// GetConfigChannelFromEnvoyCertificate returns the config channel selected by the osm.io/config-channel label of the pod
+// of the Envoy the given certificate was issued to, which is a cert issued to an Envoy for XDS communication (not Envoy-to-Envoy).
+func (mc *MeshCatalog) GetConfigChannelFromEnvoyCertificate(cn certificate.CommonName) (string, error) {
+	pod, err := GetPodFromCertificate(cn, mc.kubeClient)
+	if err != nil {
+		return "", err
+	}
+	return configurator.GetConfigChannel(pod.Labels), nil
+}
+
 // filterTrafficSplitServices takes a list of services and removes from it the ones
 // that have been split via an SMI TrafficSplit.
 func (mc *MeshCatalog) filterTrafficSplitServices(services []v1.Service) []v1.Service {
 	excludeTheseServices := make(map[service.MeshService]interface{})
-	for _, trafficSplit := range mc.meshSpec.ListTrafficSplits() {
+	for _, trafficSplit := range mc.meshSpec.ListTrafficSplits(mc.configurator.GetSMISpecVersion()) {
 		svc := service.MeshService{
 			Namespace: trafficSplit.Namespace,
 			Name:      trafficSplit.Spec.Service,
@@ -13,6 +13,7 @@ import (
 	testclient "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
@@ -67,6 +68,37 @@ var _ = Describe("Test XDS certificate tooling", func() {
 		})
 	})
 
+	Context("Test GetConfigChannelFromEnvoyCertificate()", func() {
+		It("returns the config channel of the pod of the Envoy", func() {
+			namespace := uuid.New().String()
+			stagingEnvoyUID := uuid.New().String()
+			stagingPod := tests.NewPodTestFixture(namespace, fmt.Sprintf("pod-0-%s", uuid.New()))
+			stagingPod.Labels[constants.EnvoyUniqueIDLabelName] = stagingEnvoyUID
+			stagingPod.Labels[configurator.ConfigChannelLabel] = configurator.StagingConfigChannel
+			_, err := kubeClient.CoreV1().Pods(namespace).Create(context.TODO(), &stagingPod, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			primaryEnvoyUID := uuid.New().String()
+			primaryPod := tests.NewPodTestFixture(namespace, fmt.Sprintf("pod-1-%s", uuid.New()))
+			primaryPod.Labels[constants.EnvoyUniqueIDLabelName] = primaryEnvoyUID
+			_, err = kubeClient.CoreV1().Pods(namespace).Create(context.TODO(), &primaryPod, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			channel, err := mc.GetConfigChannelFromEnvoyCertificate(certificate.CommonName(fmt.Sprintf("%s.%s.%s", stagingEnvoyUID, tests.BookstoreServiceAccountName, namespace)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(channel).To(Equal(configurator.StagingConfigChannel))
+
+			channel, err = mc.GetConfigChannelFromEnvoyCertificate(certificate.CommonName(fmt.Sprintf("%s.%s.%s", primaryEnvoyUID, tests.BookstoreServiceAccountName, namespace)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(channel).To(Equal(configurator.PrimaryConfigChannel))
+		})
+
+		It("returns an error with an invalid CN", func() {
+			_, err := mc.GetConfigChannelFromEnvoyCertificate("getAllowedDirectionalServices")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Context("Test getServiceFromCertificate()", func() {
 		It("works as expected", func() {
 
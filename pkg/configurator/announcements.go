@@ -0,0 +1,430 @@
+package configurator
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// announcementsBufferSize is the number of typed announcements buffered for each consumer;
+	// typed announcements are dropped rather than block the ConfigMap informer when a consumer falls this far behind.
+	announcementsBufferSize = 128
+
+	// defaultAnnouncementDebounceWindow is the default window within which a burst of ConfigMap events is coalesced into a single announcement
+	defaultAnnouncementDebounceWindow = 250 * time.Millisecond
+
+	// lastAppliedConfigSource is the source of the changes of a ConfigMap which has no managed fields, but was
+	// last applied with kubectl apply
+	lastAppliedConfigSource = "kubectl-client-side-apply"
+)
+
+// subscriber is a channel returned by Subscribe, along with the fields whose changes are sent to it
+type subscriber struct {
+	ch chan ConfigChangeEvent
+
+	// fields is the set of MeshConfig field names the subscriber is interested in; empty means every field
+	fields map[string]interface{}
+}
+
+// configChangeCallback is a callback registered with OnConfigChange; callbacks are registered by pointer, so the same
+// function can be registered, and unregistered, more than once
+type configChangeCallback struct {
+	cb func(ConfigChangeEvent)
+}
+
+// WithAnnouncementDebounceWindow sets the window within which a burst of ConfigMap events is coalesced into a single
+// announcement, in place of the default 250ms; a window of 0 announces every event right away.
+func WithAnnouncementDebounceWindow(window time.Duration) Option {
+	return func(c *Client) {
+		c.announcementDebounceWindow = window
+	}
+}
+
+// dispatchAnnouncements turns the ConfigMap informer events into announcements until the stop channel is closed.
+// Each event restarts the debounce timer, so a burst of events results in a single announcement of the final config
+// once no event has been received for the debounce window. The events leaving the config unchanged are not announced.
+// The initial sync of the informer caches is not announced: run seeds the config from the synced caches on startup,
+// and the events handled before, or leaving the seeded config unchanged, are dropped, so consumers start from the config
+// the getters return rather than all recomputing it on startup.
+func (c *Client) dispatchAnnouncements(stop <-chan struct{}) {
+	defer close(c.dispatcherStopped)
+
+	var debounceTimer *time.Timer
+	var debounceTimerFired <-chan time.Time
+	var pendingEvent interface{}
+	for {
+		select {
+		case <-stop:
+			if debounceTimer != nil {
+				debounceTimer.Stop()
+				// The pending event is flushed, so the last change before stopping reaches the consumers
+				c.flushConfigMapEvent(pendingEvent)
+			}
+			return
+		case event := <-c.configMapEvents:
+			if c.announcementDebounceWindow <= 0 {
+				c.handleConfigMapEvent(event)
+				continue
+			}
+			if debounceTimer != nil {
+				debounceTimer.Stop()
+			}
+			pendingEvent = event
+			debounceTimer = time.NewTimer(c.announcementDebounceWindow)
+			debounceTimerFired = debounceTimer.C
+		case <-debounceTimerFired:
+			debounceTimer, debounceTimerFired = nil, nil
+			c.handleConfigMapEvent(pendingEvent)
+			pendingEvent = nil
+		}
+	}
+}
+
+// handleConfigMapEvent caches the config of the ConfigMap the informer event is about and announces it when it changed
+func (c *Client) handleConfigMapEvent(event interface{}) {
+	c.announce(event, c.applyConfigMapEvent())
+}
+
+// flushConfigMapEvent handles the informer event pending the debounce window when the dispatcher stops; it is announced
+// even when the client is being closed, since Close only closes the channels once the dispatcher has stopped
+func (c *Client) flushConfigMapEvent(event interface{}) {
+	c.dispatchAnnouncement(event, c.applyConfigMapEvent())
+}
+
+// applyConfigMapEvent caches the config of the ConfigMap in effect in the informer caches on an informer event, and
+// returns the config change event to announce, or nil when there is nothing to announce
+func (c *Client) applyConfigMapEvent() *ConfigChangeEvent {
+	c.eventLock.Lock()
+	defer c.eventLock.Unlock()
+	// The events of the initial sync are already reflected by the caches run seeds the config from, once synced
+	if !c.isCacheSynced() {
+		return nil
+	}
+	// The informer caches are updated before the event is delivered, so the config is read from the caches;
+	// this also covers events from the MeshConfig informer affecting which config source is in effect.
+	return c.applyConfigMap(c.getEventConfigMap())
+}
+
+// applyConfigMap caches the config of the given ConfigMap, and returns the config change event to announce, or nil when
+// the config is unchanged or the ConfigMap is rejected; it must be called with eventLock held
+func (c *Client) applyConfigMap(configMap *v1.ConfigMap) *ConfigChangeEvent {
+	resourceVersion := ""
+	if configMap != nil {
+		resourceVersion = configMap.ResourceVersion
+	}
+
+	// An oversized ConfigMap may hold truncated data, e.g. a cut-off override blob, so none of it is applied
+	if err := c.checkConfigMapSize(configMap); err != nil {
+		log.Error().Err(err).Msgf("Rejecting ConfigMap %s at resourceVersion %q; Keeping the current config", c.getConfigMapCacheKey(), resourceVersion)
+		c.setLastConfigError(err)
+		c.metrics.recordRejection(err)
+		return nil
+	}
+
+	newConfig, provenance := c.getConfigFromConfigMap(configMap)
+	if err := c.runValidators(newConfig); err != nil {
+		log.Error().Err(err).Msgf("Rejecting ConfigMap %s at resourceVersion %q; Keeping the current config", c.getConfigMapCacheKey(), resourceVersion)
+		c.setLastConfigError(err)
+		c.metrics.recordRejection(err)
+		return nil
+	}
+	c.setLastConfigError(nil)
+
+	existed := c.configExists.Load().(bool)
+	oldStagingConfig, _ := c.stagingConfig.Load().(*MeshConfig)
+
+	// The cached config is swapped before announcing, so consumers never observe a stale config after an event.
+	oldConfig := c.setParsedConfig(configMap, newConfig, provenance)
+	c.setStagingConfig(configMap)
+	c.metrics.recordReload(nil)
+	newStagingConfig, _ := c.stagingConfig.Load().(*MeshConfig)
+	source := getUpdateSource(configMap)
+	log.Debug().Msgf("Updated config from ConfigMap %s at resourceVersion %q by %q", c.getConfigMapCacheKey(), resourceVersion, source)
+
+	typedEvent := ConfigChangeEvent{
+		ChangedFields:   getChangedFields(oldConfig, newConfig),
+		Old:             oldConfig.redactedCopy(),
+		New:             newConfig.redactedCopy(),
+		ResourceVersion: resourceVersion,
+		Source:          source,
+	}
+
+	// A ConfigMap re-applied with identical content, e.g. by GitOps, or a resync of the informer, leaves the config
+	// unchanged once merged over the default config, so there is nothing to announce.
+	if len(typedEvent.ChangedFields) == 0 && existed == (configMap != nil) && reflect.DeepEqual(oldStagingConfig, newStagingConfig) {
+		log.Debug().Msgf("Config from ConfigMap %s at resourceVersion %q is unchanged; Skipping announcement", c.getConfigMapCacheKey(), resourceVersion)
+		return nil
+	}
+	return &typedEvent
+}
+
+// announce dispatches the config change event returned by applyConfigMap until the client is closed.
+// It must be called without holding eventLock, so the callbacks can reload or update the config.
+func (c *Client) announce(event interface{}, typedEvent *ConfigChangeEvent) {
+	// Once closed, the client no longer forwards the announcements and is about to close the channels
+	if c.isClosed() {
+		return
+	}
+	c.dispatchAnnouncement(event, typedEvent)
+}
+
+// dispatchAnnouncement sends the config change event, unless it is nil, to the typed announcements channel, the
+// callbacks and the subscribers, and queues the informer event on the announcements channel
+func (c *Client) dispatchAnnouncement(event interface{}, typedEvent *ConfigChangeEvent) {
+	if typedEvent == nil {
+		return
+	}
+	c.sendTypedAnnouncement(*typedEvent)
+	c.invokeCallbacks(*typedEvent)
+	c.notifySubscribers(*typedEvent)
+	c.queueAnnouncement(event)
+}
+
+// sendTypedAnnouncement sends the config change event on the typed announcements channel, once it has been created and
+// until Close has closed it
+func (c *Client) sendTypedAnnouncement(event ConfigChangeEvent) {
+	c.typedAnnouncementsLock.Lock()
+	defer c.typedAnnouncementsLock.Unlock()
+	// Close unsets the channel once it has closed it, so it is never sent to once closed
+	if c.typedAnnouncements == nil {
+		return
+	}
+	select {
+	case c.typedAnnouncements <- event:
+	default:
+		log.Warn().Msgf("Typed announcements channel for ConfigMap %s is full; Dropping announcement", c.getConfigMapCacheKey())
+	}
+}
+
+// queueAnnouncement queues the informer event for forwardAnnouncements to send on the announcements channel
+func (c *Client) queueAnnouncement(event interface{}) {
+	c.pendingAnnouncementsLock.Lock()
+	c.pendingAnnouncements = append(c.pendingAnnouncements, event)
+	c.pendingAnnouncementsLock.Unlock()
+
+	select {
+	case c.announcementQueued <- struct{}{}:
+	default:
+		// forwardAnnouncements has yet to pick up the previous signal, and with it this announcement
+	}
+}
+
+// forwardAnnouncements sends the queued announcements, in order, on the unbuffered announcements channel until the client
+// is closed. Like the informer event handlers, every announcement is delivered and none is dropped, whereas the handling
+// of the ConfigMap events never waits for the consumer of the announcements.
+func (c *Client) forwardAnnouncements() {
+	defer close(c.forwarderStopped)
+
+	for {
+		c.pendingAnnouncementsLock.Lock()
+		var next interface{}
+		hasNext := len(c.pendingAnnouncements) > 0
+		if hasNext {
+			next = c.pendingAnnouncements[0]
+		}
+		c.pendingAnnouncementsLock.Unlock()
+
+		if !hasNext {
+			select {
+			case <-c.closed:
+				return
+			case <-c.announcementQueued:
+			}
+			continue
+		}
+
+		select {
+		case <-c.closed:
+			return
+		case c.announcements <- next:
+			c.pendingAnnouncementsLock.Lock()
+			c.pendingAnnouncements[0] = nil
+			c.pendingAnnouncements = c.pendingAnnouncements[1:]
+			c.pendingAnnouncementsLock.Unlock()
+		}
+	}
+}
+
+// Subscribe returns a channel receiving the config change events in which one of the given MeshConfig fields changed,
+// or in which any field changed when no field is given. Like the typed announcements, events are dropped rather than
+// block the ConfigMap informer when the subscriber falls behind.
+func (c *Client) Subscribe(fields ...string) <-chan ConfigChangeEvent {
+	fieldSet := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if _, ok := reflect.TypeOf(MeshConfig{}).FieldByName(field); !ok {
+			log.Warn().Msgf("Subscribing to unknown MeshConfig field %q; No change of the field will be announced", field)
+		}
+		fieldSet[field] = nil
+	}
+
+	ch := make(chan ConfigChangeEvent, announcementsBufferSize)
+	c.subscribersLock.Lock()
+	defer c.subscribersLock.Unlock()
+	select {
+	case <-c.closed:
+		// The client no longer sends events, so the subscriber is closed right away
+		close(ch)
+		return ch
+	default:
+	}
+	c.subscribers[ch] = &subscriber{
+		ch:     ch,
+		fields: fieldSet,
+	}
+	return ch
+}
+
+// Unsubscribe stops the config change events to the given channel returned by Subscribe, and closes it.
+// Unsubscribing a channel which is not subscribed, e.g. because it was already unsubscribed, does nothing.
+func (c *Client) Unsubscribe(ch <-chan ConfigChangeEvent) {
+	c.subscribersLock.Lock()
+	defer c.subscribersLock.Unlock()
+	sub, ok := c.subscribers[ch]
+	if !ok {
+		return
+	}
+	delete(c.subscribers, ch)
+	close(sub.ch)
+}
+
+// WatchConfig returns a fresh channel receiving the config change events in which any field changed, which is closed,
+// and whose watcher is removed, once the given context is done; it is a subscriber to every field bound to the lifetime
+// of the context, so consumers do not leak goroutines on shutdown.
+func (c *Client) WatchConfig(ctx context.Context) <-chan ConfigChangeEvent {
+	ch := c.Subscribe()
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.Unsubscribe(ch)
+		case <-c.closed:
+		}
+	}()
+	return ch
+}
+
+// notifySubscribers sends the config change event to the subscribers interested in one of the changed fields
+func (c *Client) notifySubscribers(event ConfigChangeEvent) {
+	c.subscribersLock.Lock()
+	defer c.subscribersLock.Unlock()
+	for _, sub := range c.subscribers {
+		if !sub.isInterested(event.ChangedFields) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			log.Warn().Msgf("Subscriber channel for ConfigMap %s is full; Dropping announcement", c.getConfigMapCacheKey())
+		}
+	}
+}
+
+// isInterested returns whether the subscriber is interested in one of the given changed fields
+func (sub *subscriber) isInterested(changedFields []string) bool {
+	if len(changedFields) == 0 {
+		return false
+	}
+	if len(sub.fields) == 0 {
+		return true
+	}
+	for _, field := range changedFields {
+		if _, ok := sub.fields[field]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// OnConfigChange registers a callback invoked with each config change event, and returns a function unregistering it.
+// The callbacks are invoked synchronously, in registration order, after the cached config is swapped and before the
+// announcements are sent, so they must not block; a panicking callback is recovered from and logged. The event lock is
+// released before the callbacks are invoked, so a callback may call ReloadNow or SetConfigField.
+func (c *Client) OnConfigChange(cb func(ConfigChangeEvent)) (unregister func()) {
+	callback := &configChangeCallback{cb: cb}
+	c.callbacksLock.Lock()
+	defer c.callbacksLock.Unlock()
+	c.callbacks = append(c.callbacks, callback)
+
+	return func() {
+		c.callbacksLock.Lock()
+		defer c.callbacksLock.Unlock()
+		for i, registeredCallback := range c.callbacks {
+			if registeredCallback == callback {
+				c.callbacks = append(c.callbacks[:i:i], c.callbacks[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// invokeCallbacks invokes the registered callbacks with the config change event. The callbacks are invoked without
+// holding the lock, so a callback can register or unregister callbacks, which takes effect from the next event.
+func (c *Client) invokeCallbacks(event ConfigChangeEvent) {
+	c.callbacksLock.Lock()
+	callbacks := c.callbacks
+	c.callbacksLock.Unlock()
+
+	for _, callback := range callbacks {
+		c.invokeCallback(callback, event)
+	}
+}
+
+// invokeCallback invokes the callback with the config change event, recovering from a panic of the callback
+func (c *Client) invokeCallback(callback *configChangeCallback, event ConfigChangeEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().Msgf("Recovered from panic in config change callback for ConfigMap %s: %v", c.getConfigMapCacheKey(), r)
+		}
+	}()
+	callback.cb(event)
+}
+
+// getChangedFields returns the names of the exported MeshConfig fields whose values differ between the two configs
+func getChangedFields(oldConfig, newConfig *MeshConfig) []string {
+	var changedFields []string
+	oldValue := reflect.ValueOf(oldConfig).Elem()
+	newValue := reflect.ValueOf(newConfig).Elem()
+	for i := 0; i < oldValue.NumField(); i++ {
+		field := oldValue.Type().Field(i)
+		if field.PkgPath != "" {
+			// Unexported field
+			continue
+		}
+		if !reflect.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			changedFields = append(changedFields, field.Name)
+		}
+	}
+	return changedFields
+}
+
+// getUpdateSource returns the client which last updated the given ConfigMap, as told by its metadata: the manager of
+// its most recently updated managed fields, such as kubectl or a controller, else kubectl apply when the ConfigMap has
+// the last-applied-configuration annotation. It is empty when the ConfigMap is nil or its metadata does not tell.
+func getUpdateSource(configMap *v1.ConfigMap) string {
+	if configMap == nil {
+		return ""
+	}
+
+	var latestEntry *metav1.ManagedFieldsEntry
+	for i := range configMap.ManagedFields {
+		entry := &configMap.ManagedFields[i]
+		if entry.Manager == "" {
+			continue
+		}
+		// The entries without a time are not known to be older, so the last of them wins
+		if latestEntry == nil || !entry.Time.Before(latestEntry.Time) {
+			latestEntry = entry
+		}
+	}
+	if latestEntry != nil {
+		return latestEntry.Manager
+	}
+
+	if _, ok := configMap.Annotations[v1.LastAppliedConfigAnnotation]; ok {
+		return lastAppliedConfigSource
+	}
+	return ""
+}
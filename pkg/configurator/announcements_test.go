@@ -0,0 +1,547 @@
+package configurator
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Test OSM ConfigMap announcements", func() {
+	Context("create OSM config and flip fields one at a time", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		typedAnnouncements := cfg.GetTypedAnnouncementsChannel()
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				egressKey: "true",
+			},
+		}
+
+		It("announces the fields set by the newly created ConfigMap", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+			event := <-typedAnnouncements
+
+			Expect(event.ChangedFields).To(Equal([]string{"Egress"}))
+			Expect(event.Old.Egress).To(BeFalse())
+			Expect(event.New.Egress).To(BeTrue())
+		})
+
+		It("announces only the field which was flipped", func() {
+			configMap.Data[tracingEnableKey] = "true"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+			event := <-typedAnnouncements
+
+			Expect(event.ChangedFields).To(Equal([]string{"TracingEnable"}))
+			Expect(event.Old.TracingEnable).To(BeFalse())
+			Expect(event.New.TracingEnable).To(BeTrue())
+			Expect(event.New.Egress).To(BeTrue())
+		})
+	})
+
+	Context("debounce a burst of ConfigMap updates", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		typedAnnouncements := cfg.GetTypedAnnouncementsChannel()
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				tracingEnableKey: "true",
+			},
+		}
+
+		It("announces the created ConfigMap", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+			<-typedAnnouncements
+		})
+
+		It("announces five rapid updates once, carrying the final config", func() {
+			for port := 9411; port < 9416; port++ {
+				configMap.Data[tracingPortKey] = strconv.Itoa(port)
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			Eventually(cfg.GetAnnouncementsChannel(), time.Second).Should(Receive())
+			var event ConfigChangeEvent
+			Eventually(typedAnnouncements, time.Second).Should(Receive(&event))
+			Expect(event.Old.TracingPort).To(Equal(defaultConfig.TracingPort))
+			Expect(event.New.TracingPort).To(Equal(9415))
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(9415)))
+
+			Consistently(cfg.GetAnnouncementsChannel(), 2*defaultAnnouncementDebounceWindow).ShouldNot(Receive())
+		})
+	})
+
+	Context("announce every ConfigMap update without debouncing", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, WithAnnouncementDebounceWindow(0))
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("announces each update", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			<-cfg.GetAnnouncementsChannel()
+
+			for port := 9411; port < 9416; port++ {
+				configMap.Data[tracingPortKey] = strconv.Itoa(port)
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+				Eventually(cfg.GetAnnouncementsChannel(), time.Second).Should(Receive())
+			}
+		})
+	})
+
+	Context("deliver the announcements to a slow consumer", func() {
+		It("delivers every announcement in order, well past the typed announcements buffer", func() {
+			cfg := newClient("-test-osm-namespace-", "-test-osm-config-map-")
+			for i := 0; i < 2*announcementsBufferSize; i++ {
+				cfg.queueAnnouncement(i)
+			}
+
+			for i := 0; i < 2*announcementsBufferSize; i++ {
+				Eventually(cfg.GetAnnouncementsChannel()).Should(Receive(Equal(i)))
+			}
+		})
+
+		It("does not buffer typed announcements until the typed announcements channel is requested", func() {
+			cfg := newClient("-test-osm-namespace-", "-test-osm-config-map-")
+			cfg.sendTypedAnnouncement(ConfigChangeEvent{ChangedFields: []string{"Egress"}})
+
+			typedAnnouncements := cfg.GetTypedAnnouncementsChannel()
+			Expect(typedAnnouncements).ToNot(Receive())
+
+			cfg.sendTypedAnnouncement(ConfigChangeEvent{ChangedFields: []string{"TracingEnable"}})
+			var event ConfigChangeEvent
+			Expect(typedAnnouncements).To(Receive(&event))
+			Expect(event.ChangedFields).To(Equal([]string{"TracingEnable"}))
+		})
+	})
+
+	Context("re-apply the ConfigMap with identical content", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, WithAnnouncementDebounceWindow(0))
+		typedAnnouncements := cfg.GetTypedAnnouncementsChannel()
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				egressKey: "true",
+			},
+		}
+
+		It("announces the created ConfigMap", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+			<-typedAnnouncements
+		})
+
+		It("announces nothing when the config is unchanged", func() {
+			subscriber := cfg.Subscribe()
+			changedFields := make(chan []string, 1)
+			unregister := cfg.OnConfigChange(func(event ConfigChangeEvent) {
+				changedFields <- event.ChangedFields
+			})
+			defer unregister()
+
+			for i := 0; i < 3; i++ {
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+			}
+			// The tracing port is set to its default value, which leaves the config merged over the default config unchanged
+			configMap.Data[tracingPortKey] = strconv.Itoa(defaultConfig.TracingPort)
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Consistently(cfg.GetAnnouncementsChannel(), 500*time.Millisecond).ShouldNot(Receive())
+			Expect(typedAnnouncements).ToNot(Receive())
+			Expect(subscriber).ToNot(Receive())
+			Expect(changedFields).ToNot(Receive())
+		})
+
+		It("announces the next change", func() {
+			configMap.Data[egressKey] = "false"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+			event := <-typedAnnouncements
+
+			Expect(event.ChangedFields).To(Equal([]string{"Egress"}))
+			close(stop)
+		})
+	})
+
+	Context("subscribe to the changes of specific fields", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, WithAnnouncementDebounceWindow(0))
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+		egressSubscriber := cfg.Subscribe("Egress")
+		allFieldsSubscriber := cfg.Subscribe()
+
+		It("does not wake a subscriber to Egress on a TracingEnable-only change", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			<-cfg.GetAnnouncementsChannel()
+
+			configMap.Data[tracingEnableKey] = "true"
+			_, err = kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			<-cfg.GetAnnouncementsChannel()
+
+			var event ConfigChangeEvent
+			Expect(allFieldsSubscriber).To(Receive(&event))
+			Expect(event.ChangedFields).To(Equal([]string{"TracingEnable"}))
+			Expect(egressSubscriber).ToNot(Receive())
+		})
+
+		It("wakes a subscriber to Egress on an Egress change", func() {
+			configMap.Data[egressKey] = "true"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			<-cfg.GetAnnouncementsChannel()
+
+			var event ConfigChangeEvent
+			Expect(egressSubscriber).To(Receive(&event))
+			Expect(event.New.Egress).To(BeTrue())
+			Expect(allFieldsSubscriber).To(Receive())
+		})
+
+		It("closes the channel of an unsubscribed subscriber", func() {
+			cfg.Unsubscribe(egressSubscriber)
+			Expect(egressSubscriber).To(BeClosed())
+
+			// Unsubscribing twice does nothing
+			cfg.Unsubscribe(egressSubscriber)
+
+			configMap.Data[egressKey] = "false"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(allFieldsSubscriber).To(Receive())
+		})
+	})
+
+	Context("watch the config changes for the lifetime of a context", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, WithAnnouncementDebounceWindow(0))
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		watcher := cfg.WatchConfig(ctx)
+
+		hasWatcher := func() bool {
+			cfg.subscribersLock.Lock()
+			defer cfg.subscribersLock.Unlock()
+			_, ok := cfg.subscribers[watcher]
+			return ok
+		}
+
+		It("sends the config changes to the watcher", func() {
+			configMap.Data[egressKey] = "true"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			<-cfg.GetAnnouncementsChannel()
+
+			var event ConfigChangeEvent
+			Expect(watcher).To(Receive(&event))
+			Expect(event.ChangedFields).To(ContainElement("Egress"))
+			Expect(hasWatcher()).To(BeTrue())
+		})
+
+		It("closes the channel and removes the watcher once the context is canceled", func() {
+			cancel()
+			Eventually(watcher).Should(BeClosed())
+			Expect(hasWatcher()).To(BeFalse())
+		})
+
+		It("closes the channel right away for a context which is already done", func() {
+			doneCtx, doneCancel := context.WithCancel(context.Background())
+			doneCancel()
+			Eventually(cfg.WatchConfig(doneCtx)).Should(BeClosed())
+		})
+	})
+
+	Context("register callbacks for the config changes", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, WithAnnouncementDebounceWindow(0))
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		// The callbacks run before the announcements are sent, so they have run once an announcement is received
+		var invocations []string
+		var events []ConfigChangeEvent
+		var cachedEgress []bool
+		unregisterFirst := cfg.OnConfigChange(func(event ConfigChangeEvent) {
+			invocations = append(invocations, "first")
+			events = append(events, event)
+		})
+		cfg.OnConfigChange(func(ConfigChangeEvent) {
+			invocations = append(invocations, "panicking")
+			panic("config change callback failed")
+		})
+		cfg.OnConfigChange(func(ConfigChangeEvent) {
+			invocations = append(invocations, "last")
+			cachedEgress = append(cachedEgress, cfg.IsEgressEnabled())
+		})
+
+		It("invokes the callbacks in registration order despite a panicking callback", func() {
+			configMap.Data[egressKey] = "true"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(invocations).To(Equal([]string{"first", "panicking", "last"}))
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].ChangedFields).To(Equal([]string{"Egress"}))
+			Expect(events[0].New.Egress).To(BeTrue())
+
+			// The cached config is swapped before the callbacks are invoked
+			Expect(cachedEgress).To(Equal([]bool{true}))
+		})
+
+		It("stops invoking an unregistered callback", func() {
+			invocations = nil
+			unregisterFirst()
+			// Unregistering twice does nothing
+			unregisterFirst()
+
+			configMap.Data[egressKey] = "false"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(invocations).To(Equal([]string{"panicking", "last"}))
+			Expect(events).To(HaveLen(1))
+			Expect(cachedEgress).To(Equal([]bool{true, false}))
+		})
+	})
+
+	Context("reload and update the config from a callback", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, WithAnnouncementDebounceWindow(0))
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				egressKey: "true",
+			},
+		}
+
+		It("reloads and updates the config without waiting for the event being handled", func() {
+			errs := make(chan error, 2)
+			unregister := cfg.OnConfigChange(func(event ConfigChangeEvent) {
+				// The update below is announced too, while this callback is still being invoked
+				if event.Old.Egress || !event.New.Egress {
+					return
+				}
+				errs <- cfg.ReloadNow()
+				errs <- cfg.SetConfigField("TracingPort", 14268)
+			})
+			defer unregister()
+
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// SetConfigField would otherwise wait for the informer event until it times out
+			Eventually(errs, setConfigFieldTimeout/2).Should(Receive(BeNil()))
+			Eventually(errs, setConfigFieldTimeout/2).Should(Receive(BeNil()))
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(14268)))
+			close(stop)
+		})
+	})
+
+	Context("annotate the announcements with the source of the change", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, WithAnnouncementDebounceWindow(0))
+		typedAnnouncements := cfg.GetTypedAnnouncementsChannel()
+		createdAt := metav1.NewTime(time.Now().Add(-time.Hour))
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+				ManagedFields: []metav1.ManagedFieldsEntry{{
+					Manager:   "osm-bootstrap",
+					Operation: metav1.ManagedFieldsOperationUpdate,
+					Time:      &createdAt,
+				}},
+			},
+			Data: map[string]string{},
+		}
+
+		It("announces the manager of the managed fields as the source", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+			event := <-typedAnnouncements
+
+			Expect(event.Source).To(Equal("osm-bootstrap"))
+		})
+
+		It("announces the manager of the most recently updated managed fields as the source", func() {
+			updatedAt := metav1.Now()
+			configMap.ManagedFields = append([]metav1.ManagedFieldsEntry{{
+				Manager:   "kubectl-edit",
+				Operation: metav1.ManagedFieldsOperationUpdate,
+				Time:      &updatedAt,
+			}}, configMap.ManagedFields...)
+			configMap.Data[egressKey] = "true"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+			event := <-typedAnnouncements
+
+			Expect(event.ChangedFields).To(Equal([]string{"Egress"}))
+			Expect(event.Source).To(Equal("kubectl-edit"))
+			close(stop)
+		})
+	})
+
+	Context("get the source of the change of a ConfigMap", func() {
+		It("returns kubectl apply when the ConfigMap only has the last-applied-configuration annotation", func() {
+			configMap := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{v1.LastAppliedConfigAnnotation: "{}"},
+				},
+			}
+			Expect(getUpdateSource(configMap)).To(Equal(lastAppliedConfigSource))
+		})
+
+		It("returns no source when the ConfigMap does not tell or does not exist", func() {
+			Expect(getUpdateSource(&v1.ConfigMap{})).To(BeEmpty())
+			Expect(getUpdateSource(nil)).To(BeEmpty())
+		})
+	})
+
+	Context("compute the changed fields of two configs", func() {
+		It("returns no fields for identical configs", func() {
+			Expect(getChangedFields(&MeshConfig{Egress: true}, &MeshConfig{Egress: true})).To(BeEmpty())
+		})
+
+		It("returns every field which differs", func() {
+			oldConfig := &MeshConfig{Egress: true, EnvoyLogLevel: "debug"}
+			newConfig := &MeshConfig{Egress: false, EnvoyLogLevel: "info", TracingPort: 9411}
+			Expect(getChangedFields(oldConfig, newConfig)).To(Equal([]string{"Egress", "TracingPort", "EnvoyLogLevel"}))
+		})
+	})
+
+	Context("do not announce the initial sync of the informer", func() {
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				egressKey: "true",
+			},
+		}
+		kubeClient := testclient.NewSimpleClientset(&configMap)
+		stop := make(chan struct{})
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, WithAnnouncementDebounceWindow(0))
+		typedAnnouncements := cfg.GetTypedAnnouncementsChannel()
+		announcements := cfg.GetAnnouncementsChannel()
+
+		It("serves the config found on startup without announcing it", func() {
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+			Consistently(typedAnnouncements, 500*time.Millisecond).ShouldNot(Receive())
+			Consistently(announcements, 100*time.Millisecond).ShouldNot(Receive())
+		})
+
+		It("announces the updates after the initial sync", func() {
+			updatedConfigMap := configMap.DeepCopy()
+			updatedConfigMap.Data[egressKey] = "false"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), updatedConfigMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			var event ConfigChangeEvent
+			Eventually(typedAnnouncements).Should(Receive(&event))
+			Expect(event.ChangedFields).To(Equal([]string{"Egress"}))
+			Expect(event.Old.Egress).To(BeTrue())
+			Expect(event.New.Egress).To(BeFalse())
+			Eventually(announcements).Should(Receive())
+			close(stop)
+		})
+	})
+})
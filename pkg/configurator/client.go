@@ -5,83 +5,306 @@ import (
 	"reflect"
 	"strconv"
 
+	"gopkg.in/yaml.v2"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/openservicemesh/osm/pkg/constants"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 )
 
 const (
-	permissiveTrafficPolicyModeKey = "permissive_traffic_policy_mode"
-	egressKey                      = "egress"
-	prometheusScrapingKey          = "prometheus_scraping"
-	meshCIDRRangesKey              = "mesh_cidr_ranges"
-	useHTTPSIngressKey             = "use_https_ingress"
-	tracingEnableKey               = "tracing_enable"
-	tracingAddressKey              = "tracing_address"
-	tracingPortKey                 = "tracing_port"
-	tracingEndpointKey             = "tracing_endpoint"
-	defaultInMeshCIDR              = ""
-	envoyLogLevel                  = "envoy_log_level"
+	permissiveTrafficPolicyModeKey   = "permissive_traffic_policy_mode"
+	permissiveModeAuditLoggingKey    = "permissive_mode_audit_logging"
+	smiSpecVersionKey                = "smi_spec_version"
+	egressKey                        = "egress"
+	egressModeKey                    = "egress_mode"
+	egressAllowedDomainsKey          = "egress_allowed_domains"
+	egressDNSResolutionKey           = "egress_dns_resolution"
+	egressCABundleKey                = "egress_ca_bundle"
+	prometheusScrapingKey            = "prometheus_scraping"
+	prometheusScrapePortKey          = "prometheus_scrape_port"
+	prometheusScrapePathKey          = "prometheus_scrape_path"
+	meshCIDRRangesKey                = "mesh_cidr_ranges"
+	useHTTPSIngressKey               = "use_https_ingress"
+	defaultIngressBackendProtocolKey = "default_ingress_backend_protocol"
+	tracingEnableKey                 = "tracing_enable"
+	tracingAddressKey                = "tracing_address"
+	tracingPortKey                   = "tracing_port"
+	tracingEndpointKey               = "tracing_endpoint"
+	tracingSamplingRateKey           = "tracing_sampling_rate"
+	tracingBackendKey                = "tracing_backend"
+	defaultInMeshCIDR                = ""
+	minPort                          = 1
+	maxPort                          = 65535
+	envoyLogLevel                    = "envoy_log_level"
+	envoyLogFormatKey                = "envoy_log_format"
+	outboundPortExclusionListKey     = "outbound_port_exclusion_list"
+	inboundPortExclusionListKey      = "inbound_port_exclusion_list"
+	localityAwareRoutingKey          = "locality_aware_routing"
+	localityRegionKey                = "locality_region"
+	localityZoneKey                  = "locality_zone"
+	featureFlagsKey                  = "feature_flags"
+	wasmExtensionsKey                = "wasm_extensions"
+	envoyConnectionIdleTimeoutKey    = "envoy_connection_idle_timeout"
+	envoyRequestTimeoutKey           = "envoy_request_timeout"
+	retryPolicyKey                   = "retry_policy"
+	circuitBreakingKey               = "circuit_breaking"
+	defaultLBAlgorithmKey            = "default_lb_algorithm"
+	outlierDetectionKey              = "outlier_detection"
+	serviceCertValidityDurationKey   = "service_cert_validity_duration"
+	trustDomainKey                   = "trust_domain"
+	meshTLSMinVersionKey             = "mesh_tls_min_version"
+	meshTLSMaxVersionKey             = "mesh_tls_max_version"
+	meshCipherSuitesKey              = "mesh_cipher_suites"
+	mtlsExemptSourceCIDRsKey         = "mtls_exempt_source_cidrs"
+	certificateProviderKey           = "certificate_provider"
+	vaultKey                         = "vault"
+	certManagerKey                   = "cert_manager"
+	envoyAdminPortKey                = "envoy_admin_port"
+	enableAccessLoggingKey           = "enable_access_logging"
+	accessLogFormatKey               = "access_log_format"
+	maxDataPlaneConnectionsKey       = "max_data_plane_connections"
+	maxEndpointsPerClusterKey        = "max_endpoints_per_cluster"
+	leaderElectionKey                = "leader_election"
+	sidecarResourcesKey              = "sidecar_resources"
+	xdsServerResponseTimeoutKey      = "xds_server_response_timeout"
+	xdsKeepaliveTimeKey              = "xds_keepalive_time"
+	xdsKeepaliveTimeoutKey           = "xds_keepalive_timeout"
+	disabledXDSTypesKey              = "disabled_xds_types"
+	envoyImageKey                    = "envoy_image"
+	initContainerImageKey            = "init_container_image"
+	enableSidecarInjectionKey        = "enable_sidecar_injection"
+	excludedNamespacesKey            = "excluded_namespaces"
+	proxyProbeKey                    = "proxy_probe"
+	enableDebugServerKey             = "enable_debug_server"
+	stripForwardedHeadersKey         = "strip_forwarded_headers"
+	proxyBootstrapOverrideKey        = "proxy_bootstrap_config_override"
+	proxyBindAddressKey              = "proxy_bind_address"
+	statsPrefixKey                   = "stats_prefix"
+	statsTagsKey                     = "stats_tags"
+	statsSinkKey                     = "stats_sink"
+	envoyConcurrencyKey              = "envoy_concurrency"
+	proxyEnvVarsKey                  = "proxy_env_vars"
+	proxyDrainTimeKey                = "proxy_drain_time"
+	proxyParentShutdownTimeKey       = "proxy_parent_shutdown_time"
+	overloadManagerKey               = "overload_manager"
+	inboundExternalAuthKey           = "inbound_external_auth"
+	multiclusterEnabledKey           = "multicluster_enabled"
+	multiclusterGatewayKey           = "multicluster_gateway"
+
+	// maxEnvoyConcurrency is the maximum number of worker threads of the Envoy proxies, above which the configured number is clamped
+	maxEnvoyConcurrency = 128
+
+	// defaultShrinkHeapThreshold is the fraction of the maximum heap size above which the Envoy sidecars release the free
+	// memory of their heap, unless it is configured
+	defaultShrinkHeapThreshold = 0.95
+
+	// defaultStopAcceptingThreshold is the fraction of the maximum heap size above which the Envoy sidecars stop accepting
+	// requests, unless it is configured
+	defaultStopAcceptingThreshold = 0.98
+
+	// defaultVaultProtocol, defaultVaultPort and defaultVaultRole are the protocol, port and role of the Vault certificate
+	// provider, unless they are configured
+	defaultVaultProtocol = "http"
+	defaultVaultPort     = 8200
+	defaultVaultRole     = "openservicemesh"
+
+	// defaultCertManagerIssuerKind and defaultCertManagerIssuerGroup are the kind and API group of the issuer of the
+	// cert-manager certificate provider, unless they are configured
+	defaultCertManagerIssuerKind  = "Issuer"
+	defaultCertManagerIssuerGroup = "cert-manager.io"
+
+	// maxOutlierEjectionPercent is the maximum percentage of the hosts of a cluster ejected by the outlier detection,
+	// above which the configured percentage is clamped
+	maxOutlierEjectionPercent = 100
+
+	// osmTag is the struct tag holding the OSM specific options of the config fields
+	osmTag = "osm"
+
+	// sensitiveTagValue marks the config fields, such as credentials, which GetRedactedConfigMap masks
+	sensitiveTagValue = "sensitive"
+
+	// redactedValue is the value the sensitive fields are masked with
+	redactedValue = "***"
 )
 
 // NewConfigurator implements configurator.Configurator and creates the Kubernetes client to manage namespaces.
-func NewConfigurator(kubeClient kubernetes.Interface, stop <-chan struct{}, osmNamespace, osmConfigMapName string) Configurator {
-	return newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+func NewConfigurator(kubeClient kubernetes.Interface, stop <-chan struct{}, osmNamespace, osmConfigMapName string, options ...Option) Configurator {
+	return newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, options...)
 }
 
-func newConfigurator(kubeClient kubernetes.Interface, stop <-chan struct{}, osmNamespace, osmConfigMapName string) *Client {
+func newConfigurator(kubeClient kubernetes.Interface, stop <-chan struct{}, osmNamespace, osmConfigMapName string, options ...Option) *Client {
 	informerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, k8s.DefaultKubeEventResyncInterval, informers.WithNamespace(osmNamespace))
 	informer := informerFactory.Core().V1().ConfigMaps().Informer()
-	client := Client{
-		informer:         informer,
-		cache:            informer.GetStore(),
-		cacheSynced:      make(chan interface{}),
-		announcements:    make(chan interface{}),
-		osmNamespace:     osmNamespace,
-		osmConfigMapName: osmConfigMapName,
+	client := newClient(osmNamespace, osmConfigMapName)
+	client.informer = informer
+	client.cache = informer.GetStore()
+	client.kubeClient = kubeClient
+	stop = client.stopOnClose(stop)
+
+	for _, option := range options {
+		option(client)
 	}
 
 	// Ensure this exclusively watches only the Namespace where OSM in installed and the particular ConfigMap we need.
 	shouldObserve := func(obj interface{}) bool {
 		ns := reflect.ValueOf(obj).Elem().FieldByName("ObjectMeta").FieldByName("Namespace").String()
 		name := reflect.ValueOf(obj).Elem().FieldByName("ObjectMeta").FieldByName("Name").String()
-		return ns == osmNamespace && name == osmConfigMapName
+		return ns == osmNamespace && (name == osmConfigMapName || (client.stagingConfigMapName != "" && name == client.stagingConfigMapName))
 	}
 
 	informerName := "ConfigMap"
 	providerName := "OSMConfigMap"
-	informer.AddEventHandler(k8s.GetKubernetesEventHandlers(informerName, providerName, client.announcements, shouldObserve))
+	informer.AddEventHandler(k8s.GetKubernetesEventHandlers(informerName, providerName, client.configMapEvents, shouldObserve))
 
+	if client.meshConfigClient != nil {
+		client.addMeshConfigInformer()
+	}
+
+	go client.dispatchAnnouncements(stop)
 	client.run(stop)
 
-	return &client
+	return client
+}
+
+// newClient returns a Client serving the default config, without a config source
+func newClient(osmNamespace, osmConfigMapName string) *Client {
+	client := &Client{
+		cacheSynced:        make(chan interface{}),
+		closed:             make(chan struct{}),
+		dispatcherStopped:  make(chan struct{}),
+		forwarderStopped:   make(chan struct{}),
+		announcements:      make(chan interface{}),
+		announcementQueued: make(chan struct{}, 1),
+		configMapEvents:    make(chan interface{}),
+		subscribers:        make(map[<-chan ConfigChangeEvent]*subscriber),
+		osmNamespace:       osmNamespace,
+		osmConfigMapName:   osmConfigMapName,
+		metrics:            newConfigMetrics(),
+
+		maxConfigMapSize:                   defaultMaxConfigMapSize,
+		announcementDebounceWindow:         defaultAnnouncementDebounceWindow,
+		defaultServiceCertValidityDuration: constants.DefaultServiceCertValidityDuration,
+		defaultEnvoyImage:                  constants.DefaultEnvoyImage,
+		defaultInitContainerImage:          constants.DefaultInitContainerImage,
+	}
+	client.setConfig(mergeOverDefaultConfig(nil), "", getConfigProvenance(nil))
+	client.configExists.Store(false)
+	client.setLastConfigError(nil)
+	go client.forwardAnnouncements()
+	return client
+}
+
+// Close stops the informers of the client, announces the ConfigMap event pending the debounce window to the typed
+// announcements channel, the callbacks and the subscribers, and then closes the announcements channels and the channels
+// of the subscribers; the announcements yet to be received from GetAnnouncementsChannel are dropped. The getters keep
+// returning the last config, and ReloadNow and SetConfigField fail. It is safe to call more than once, and always
+// returns nil.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+
+		// The channels are closed only once nothing sends to them anymore
+		<-c.dispatcherStopped
+		<-c.forwarderStopped
+		close(c.announcements)
+
+		c.typedAnnouncementsLock.Lock()
+		if c.typedAnnouncements != nil {
+			close(c.typedAnnouncements)
+			c.typedAnnouncements = nil
+		}
+		c.typedAnnouncementsLock.Unlock()
+
+		c.subscribersLock.Lock()
+		defer c.subscribersLock.Unlock()
+		for ch, sub := range c.subscribers {
+			delete(c.subscribers, ch)
+			close(sub.ch)
+		}
+	})
+	return nil
+}
+
+// isClosed returns whether the client has been closed
+func (c *Client) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// stopOnClose returns a channel closed once either the given stop channel is closed or the client is closed
+func (c *Client) stopOnClose(stop <-chan struct{}) <-chan struct{} {
+	select {
+	case <-stop:
+		return stop
+	default:
+	}
+
+	stopOrClosed := make(chan struct{})
+	go func() {
+		defer close(stopOrClosed)
+		select {
+		case <-stop:
+		case <-c.closed:
+		}
+	}()
+	return stopOrClosed
 }
 
-// This struct must match the shape of the "osm-config" ConfigMap
+// MeshConfig is the OSM config parsed from the "osm-config" ConfigMap. This struct must match the shape of the ConfigMap
 // which was created in the OSM namespace.
-type osmConfig struct {
+type MeshConfig struct {
 	// PermissiveTrafficPolicyMode is a bool toggle, which when TRUE ignores SMI policies and
 	// allows existing Kubernetes services to communicate with each other uninterrupted.
 	// This is useful whet set TRUE in brownfield configurations, where we first want to observe
 	// existing traffic patterns.
 	PermissiveTrafficPolicyMode bool `yaml:"permissive_traffic_policy_mode"`
 
+	// PermissiveModeAuditLogging is a bool toggle used to have the proxies log the requests the SMI policies would deny,
+	// without denying them, while in permissive traffic policy mode
+	PermissiveModeAuditLogging bool `yaml:"permissive_mode_audit_logging"`
+
+	// SMISpecVersion is the version of the SMI APIs, such as the TrafficTarget and TrafficSplit APIs, the SMI policies
+	// are honored in
+	SMISpecVersion string `yaml:"smi_spec_version"`
+
 	// Egress is a bool toggle used to enable or disable egress globally within the mesh
 	Egress bool `yaml:"egress"`
 
+	// EgressMode is the egress mode of the mesh: disabled, global or policy; when unset, the mode follows Egress
+	EgressMode string `yaml:"egress_mode"`
+
 	// PrometheusScraping is a bool toggle used to enable or disable metrics scraping by Prometheus
 	PrometheusScraping bool `yaml:"prometheus_scraping"`
 
+	// PrometheusScrapePort is the port on which Prometheus scrapes the metrics of the proxies
+	PrometheusScrapePort int `yaml:"prometheus_scrape_port"`
+
+	// PrometheusScrapePath is the path on which Prometheus scrapes the metrics of the proxies
+	PrometheusScrapePath string `yaml:"prometheus_scrape_path"`
+
 	// UseHTTPSIngress is a bool toggle enabling HTTPS protocol between ingress and backend pods
 	UseHTTPSIngress bool `yaml:"use_https_ingress"`
 
+	// DefaultIngressBackendProtocol is the default protocol, http or https, of the traffic from ingress to backend pods;
+	// when unset, the protocol follows UseHTTPSIngress
+	DefaultIngressBackendProtocol string `yaml:"default_ingress_backend_protocol"`
+
+	// StripForwardedHeaders is a bool toggle used to strip the X-Forwarded-* headers of the requests received by the proxies
+	StripForwardedHeaders bool `yaml:"strip_forwarded_headers"`
+
 	// TracingEnabled is a bool toggle used to enable or disable tracing
 	TracingEnable bool `yaml:"tracing_enable"`
 
-	// TracingAddress is the address of the listener cluster
+	// TracingAddress is the address of the listener cluster, or the comma separated addresses, in order of preference,
+	// of the collectors the proxies fail over between
 	TracingAddress string `yaml:"tracing_address"`
 
 	// TracingPort remote port for the listener
@@ -90,61 +313,423 @@ type osmConfig struct {
 	// TracingEndpoint is the collector endpoint on the listener
 	TracingEndpoint string `yaml:"tracing_endpoint"`
 
+	// TracingSamplingRate is the fraction of requests, between 0.0 and 1.0, for which traces are sampled; nil when unset
+	TracingSamplingRate *float64 `yaml:"tracing_sampling_rate"`
+
+	// TracingBackend is the type of the tracing collector: zipkin, jaeger or otlp
+	TracingBackend string `yaml:"tracing_backend"`
+
 	// MeshCIDRRanges is the list of CIDR ranges for in-mesh traffic
 	MeshCIDRRanges string `yaml:"mesh_cidr_ranges"`
 
+	// EgressAllowedDomains is the list of external domains, possibly wildcard domains such as *.example.com, egress is allowed to
+	EgressAllowedDomains string `yaml:"egress_allowed_domains"`
+
+	// EgressDNSResolution is how Envoy resolves the addresses of the egress clusters: STRICT_DNS, LOGICAL_DNS or STATIC
+	EgressDNSResolution string `yaml:"egress_dns_resolution"`
+
+	// EgressCABundle is the reference, of the form <namespace>/<name>[/<key>], to the Secret key holding the CA bundle
+	// the TLS connections originated to the external hosts are verified with
+	EgressCABundle string `yaml:"egress_ca_bundle"`
+
 	// EnvoyLogLevel is a string that defines the log level for envoy proxies
 	EnvoyLogLevel string `yaml:"envoy_log_level"`
+
+	// EnvoyLogFormat is the format of the logs of the Envoy processes, as opposed to their access logs: text or json
+	EnvoyLogFormat string `yaml:"envoy_log_format"`
+
+	// EnableAccessLogging is a bool toggle used to enable or disable the Envoy access logs
+	EnableAccessLogging bool `yaml:"enable_access_logging"`
+
+	// AccessLogFormat is the format of the Envoy access logs: text or json
+	AccessLogFormat string `yaml:"access_log_format"`
+
+	// EnvoyAdminPort is the port Envoy's admin interface listens on
+	EnvoyAdminPort int `yaml:"envoy_admin_port"`
+
+	// EnvoyConnectionIdleTimeout is the duration, as a Go duration string, after which Envoy closes idle connections
+	EnvoyConnectionIdleTimeout string `yaml:"envoy_connection_idle_timeout"`
+
+	// EnvoyRequestTimeout is the duration, as a Go duration string, after which Envoy times out requests.
+	// An unset value means the default timeout, whereas "0s" means no timeout.
+	EnvoyRequestTimeout string `yaml:"envoy_request_timeout"`
+
+	// RetryPolicy is the default retry policy of the routes; retries are disabled when NumRetries is 0
+	RetryPolicy RetryPolicy `yaml:"retry_policy"`
+
+	// CircuitBreaking is the default circuit breaking thresholds of the upstream clusters; Envoy's defaults apply to the 0 thresholds
+	CircuitBreaking CircuitBreaking `yaml:"circuit_breaking"`
+
+	// DefaultLBAlgorithm is the load balancing algorithm of the upstream clusters: ROUND_ROBIN, LEAST_REQUEST, RING_HASH, RANDOM or MAGLEV
+	DefaultLBAlgorithm string `yaml:"default_lb_algorithm"`
+
+	// OutlierDetection is the outlier detection ejecting the failing hosts of the upstream clusters from the load balancing
+	OutlierDetection OutlierDetection `yaml:"outlier_detection"`
+
+	// InboundExternalAuth is the external authorization service the inbound requests are authorized with
+	InboundExternalAuth InboundExternalAuth `yaml:"inbound_external_auth"`
+
+	// MulticlusterEnabled is a bool toggle used to enable or disable the federation of the mesh with the meshes of other clusters
+	MulticlusterEnabled bool `yaml:"multicluster_enabled"`
+
+	// MulticlusterGateway is the gateway the traffic to the other clusters of the mesh goes through
+	MulticlusterGateway MulticlusterGateway `yaml:"multicluster_gateway"`
+
+	// SidecarResources is the resource requests and limits of the injected Envoy sidecars
+	SidecarResources SidecarResources `yaml:"sidecar_resources"`
+
+	// EnvoyImage is the image reference, such as envoyproxy/envoy-alpine:v1.15.0, of the injected Envoy sidecars
+	EnvoyImage string `yaml:"envoy_image"`
+
+	// InitContainerImage is the image reference, such as openservicemesh/init:v0.3.0, of the injected init containers
+	// programming the iptables redirection to the Envoy sidecars
+	InitContainerImage string `yaml:"init_container_image"`
+
+	// EnableSidecarInjection toggles the injection of the Envoy sidecars into the new pods of the mesh
+	EnableSidecarInjection bool `yaml:"enable_sidecar_injection"`
+
+	// ExcludedNamespaces is the list of namespaces, such as kube-system, whose pods are never meshed
+	ExcludedNamespaces string `yaml:"excluded_namespaces"`
+
+	// ProxyProbe is the timings of the readiness and liveness probes of the injected Envoy sidecars
+	ProxyProbe ProbeSpec `yaml:"proxy_probe"`
+
+	// ProxyBootstrapConfigOverride is a YAML fragment deep-merged over the bootstrap config generated for the Envoy sidecars
+	ProxyBootstrapConfigOverride string `yaml:"proxy_bootstrap_config_override"`
+
+	// ProxyBindAddress is the address the admin listener of the Envoy sidecars binds to: an IP address, or pod-ip to
+	// bind to the IP address of the pod
+	ProxyBindAddress string `yaml:"proxy_bind_address"`
+
+	// EnvoyConcurrency is the number of worker threads of the Envoy sidecars; 0 lets Envoy run one worker per CPU core
+	EnvoyConcurrency int `yaml:"envoy_concurrency"`
+
+	// ProxyEnvVars is the set of extra environment variables, keyed by the variable name, of the Envoy sidecars
+	ProxyEnvVars map[string]string `yaml:"proxy_env_vars"`
+
+	// ProxyDrainTime is the duration, as a Go duration string, during which the Envoy sidecars drain their connections
+	// on a hot restart or shutdown
+	ProxyDrainTime string `yaml:"proxy_drain_time"`
+
+	// ProxyParentShutdownTime is the duration, as a Go duration string, after which the parent Envoy process is shut
+	// down on a hot restart; it is never shorter than ProxyDrainTime
+	ProxyParentShutdownTime string `yaml:"proxy_parent_shutdown_time"`
+
+	// OverloadManager is the heap limit of the Envoy sidecars, approaching which they shed load
+	OverloadManager OverloadManager `yaml:"overload_manager"`
+
+	// ServiceCertValidityDuration is the validity duration, as a Go duration string, of the service certificates
+	ServiceCertValidityDuration string `yaml:"service_cert_validity_duration"`
+
+	// TrustDomain is the trust domain of the mesh, which the common names of the service certificates end with
+	TrustDomain string `yaml:"trust_domain"`
+
+	// MeshTLSMinVersion is the oldest TLS protocol version, from TLS1_0 to TLS1_3, of the mesh-internal connections
+	MeshTLSMinVersion string `yaml:"mesh_tls_min_version"`
+
+	// MeshTLSMaxVersion is the newest TLS protocol version, from TLS1_0 to TLS1_3, of the mesh-internal connections
+	MeshTLSMaxVersion string `yaml:"mesh_tls_max_version"`
+
+	// MeshCipherSuites is the list, by order of preference, of the TLS cipher suites of the mesh-internal connections;
+	// an empty list leaves the cipher suites to Envoy
+	MeshCipherSuites string `yaml:"mesh_cipher_suites"`
+
+	// MTLSExemptSourceCIDRs is the list of source CIDR ranges, such as the node IPs the kubelet health checks come from,
+	// whose plaintext connections the inbound listeners accept without mTLS
+	MTLSExemptSourceCIDRs string `yaml:"mtls_exempt_source_cidrs"`
+
+	// CertificateProvider is the certificate provider issuing the certificates of the mesh: tresor, vault or cert-manager;
+	// when unset, the provider is the one the controller is started with. Like the configs of the providers, it is only
+	// read when the controller starts, so a change takes effect once the controller restarts
+	CertificateProvider string `yaml:"certificate_provider"`
+
+	// Vault is the config of the Hashicorp Vault certificate provider
+	Vault VaultConfig `yaml:"vault"`
+
+	// CertManager is the config of the cert-manager certificate provider
+	CertManager CertManagerConfig `yaml:"cert_manager"`
+
+	// XDSServerResponseTimeout is the duration, as a Go duration string, within which the controller must send an xDS
+	// response to an Envoy proxy before closing its stream
+	XDSServerResponseTimeout string `yaml:"xds_server_response_timeout"`
+
+	// XDSKeepaliveTime is the duration, as a Go duration string, of inactivity of an xDS connection after which the
+	// controller and the proxies ping each other to keep the connection alive
+	XDSKeepaliveTime string `yaml:"xds_keepalive_time"`
+
+	// XDSKeepaliveTimeout is the duration, as a Go duration string, after which an xDS connection whose keepalive ping is
+	// not acknowledged is closed
+	XDSKeepaliveTimeout string `yaml:"xds_keepalive_timeout"`
+
+	// DisabledXDSTypes is the list of xDS resource types, by short name such as RDS, the controller does not send to the proxies
+	DisabledXDSTypes string `yaml:"disabled_xds_types"`
+
+	// MaxDataPlaneConnections is the maximum number of Envoy proxies connected to the controller; 0 means unlimited
+	MaxDataPlaneConnections int `yaml:"max_data_plane_connections"`
+
+	// MaxEndpointsPerCluster is the maximum number of endpoints of each cluster sent to the proxies over EDS; 0 means unlimited
+	MaxEndpointsPerCluster int `yaml:"max_endpoints_per_cluster"`
+
+	// LeaderElection is the lease timings of the leader election of the controllers
+	LeaderElection LeaderElection `yaml:"leader_election"`
+
+	// EnableDebugServer toggles the debug server of the controller, which exposes its internals
+	EnableDebugServer bool `yaml:"enable_debug_server"`
+
+	// OutboundPortExclusionList is the list of ports for which outbound traffic bypasses the proxy
+	OutboundPortExclusionList string `yaml:"outbound_port_exclusion_list"`
+
+	// InboundPortExclusionList is the list of ports for which inbound traffic bypasses the proxy
+	InboundPortExclusionList string `yaml:"inbound_port_exclusion_list"`
+
+	// LocalityAwareRouting toggles the preference of the proxies for the endpoints in their own region and zone
+	LocalityAwareRouting bool `yaml:"locality_aware_routing"`
+
+	// LocalityRegion overrides the region of the proxies, which is otherwise derived from the labels of their node
+	LocalityRegion string `yaml:"locality_region"`
+
+	// LocalityZone overrides the zone of the proxies, which is otherwise derived from the labels of their node
+	LocalityZone string `yaml:"locality_zone"`
+
+	// StatsPrefix is the prefix of the names of the HTTP stats of the Envoy proxies
+	StatsPrefix string `yaml:"stats_prefix"`
+
+	// StatsTags is the set of tags, keyed by the tag name, added to all the stats of the Envoy proxies
+	StatsTags map[string]string `yaml:"stats_tags"`
+
+	// StatsSink is the statsd sink the Envoy proxies push their stats to, besides being scraped by Prometheus
+	StatsSink StatsSink `yaml:"stats_sink"`
+
+	// WASMExtensions is the list of the WebAssembly extensions run by the Envoy proxies on the HTTP requests, in order
+	WASMExtensions []WASMExtensionSpec `yaml:"wasm_extensions"`
+
+	// FeatureFlags is the set of experimental features toggled on or off, keyed by the feature name
+	FeatureFlags map[string]bool `yaml:"feature_flags"`
 }
 
 func (c *Client) run(stop <-chan struct{}) {
 	go c.informer.Run(stop)
 	log.Info().Msgf("Started OSM ConfigMap informer - watching for %s", c.getConfigMapCacheKey())
+	hasSynced := []cache.InformerSynced{c.informer.HasSynced}
+
+	if c.stagingConfigMapName != "" {
+		log.Info().Msgf("Watching for staging ConfigMap %s", c.getStagingConfigMapCacheKey())
+	}
+
+	if c.meshConfigInformer != nil {
+		go c.meshConfigInformer.Run(stop)
+		log.Info().Msgf("Started OSM MeshConfig informer - watching for %s", c.getMeshConfigCacheKey())
+		hasSynced = append(hasSynced, c.meshConfigInformer.HasSynced)
+	}
+
 	log.Info().Msg("[ConfigMap Client] Waiting for ConfigMap informer's cache to sync")
-	if !cache.WaitForCacheSync(stop, c.informer.HasSynced) {
+	if !cache.WaitForCacheSync(stop, hasSynced...) {
 		log.Error().Msg("Failed initial cache sync for ConfigMap informer")
 		return
 	}
 
+	// Seed the cached config, so it is available before the informer events have been dispatched. The event lock is
+	// held until the caches are marked synced, so every event is either seeded here or handled once seeded.
+	c.eventLock.Lock()
+	defer c.eventLock.Unlock()
+	configMap := c.getSeedConfigMap()
+	c.setConfigFromConfigMap(configMap)
+	c.setStagingConfig(configMap)
+	if !c.configExists.Load().(bool) {
+		log.Error().Err(errConfigMapNotFound).Msgf("ConfigMap %s does not exist; Using the default config until it is created", c.getConfigMapCacheKey())
+	}
+
 	// Closing the cacheSynced channel signals to the rest of the system that caches have been synced.
 	close(c.cacheSynced)
 	log.Info().Msg("[ConfigMap Client] Cache sync for ConfigMap informer finished")
 }
 
+// getSeedConfigMap returns the ConfigMap in effect the config is seeded from on startup, or nil, for the default config,
+// when it is rejected for its size or by a validator; the rejection is recorded like the rejection of a revision.
+func (c *Client) getSeedConfigMap() *v1.ConfigMap {
+	configMap := c.getEffectiveConfigMap()
+	err := c.checkConfigMapSize(configMap)
+	if err == nil {
+		config, _ := c.getConfigFromConfigMap(configMap)
+		err = c.runValidators(config)
+	}
+	if err != nil {
+		log.Error().Err(err).Msgf("Rejecting ConfigMap %s; Using the default config until it is fixed", c.getConfigMapCacheKey())
+		c.setLastConfigError(err)
+		c.metrics.recordRejection(err)
+		return nil
+	}
+	return configMap
+}
+
 func (c *Client) getConfigMapCacheKey() string {
 	return fmt.Sprintf("%s/%s", c.osmNamespace, c.osmConfigMapName)
 }
 
-func (c *Client) getConfigMap() *osmConfig {
+// setConfigFromConfigMap caches the config parsed from the given ConfigMap and merged over the default config, or the
+// default config when the ConfigMap is nil, and returns the previous and the new config. The environment variables
+// override the ConfigMap when the environment overlay is enabled.
+func (c *Client) setConfigFromConfigMap(configMap *v1.ConfigMap) (*MeshConfig, *MeshConfig) {
+	newConfig, provenance := c.getConfigFromConfigMap(configMap)
+	return c.setParsedConfig(configMap, newConfig, provenance), newConfig
+}
+
+// getConfigFromConfigMap returns the config setConfigFromConfigMap caches for the given ConfigMap, along with its provenance
+func (c *Client) getConfigFromConfigMap(configMap *v1.ConfigMap) (*MeshConfig, map[string]string) {
+	configMap, overlaidFields := c.overlayEnvironment(configMap)
+	provenance := getConfigProvenance(configMap)
+	for _, field := range overlaidFields {
+		if provenance[field] == ProvenanceConfigMap {
+			provenance[field] = ProvenanceEnvironment
+		}
+	}
+	return mergeOverDefaultConfig(configMap), provenance
+}
+
+// setParsedConfig caches the config returned by getConfigFromConfigMap for the given ConfigMap, and returns the previous config
+func (c *Client) setParsedConfig(configMap *v1.ConfigMap, config *MeshConfig, provenance map[string]string) *MeshConfig {
+	c.configExists.Store(configMap != nil)
+	resourceVersion := ""
+	if configMap != nil {
+		resourceVersion = configMap.ResourceVersion
+	}
+	return c.setConfig(config, resourceVersion, provenance)
+}
+
+// setConfig swaps the cached config, resourceVersion and provenance for the given ones, updates the config metrics,
+// and returns the previous config
+func (c *Client) setConfig(config *MeshConfig, resourceVersion string, provenance map[string]string) *MeshConfig {
+	c.configLock.Lock()
+	defer c.configLock.Unlock()
+	oldConfig := c.getConfigMap()
+	c.config.Store(config)
+	c.resourceVersion.Store(resourceVersion)
+	c.provenance.Store(provenance)
+	c.metrics.update(config, c.GetEnvoyLogLevel())
+	return oldConfig
+}
+
+// getConfigMap returns the cached config; the returned struct must not be modified
+func (c *Client) getConfigMap() *MeshConfig {
+	if config, ok := c.config.Load().(*MeshConfig); ok {
+		return config
+	}
+	return &MeshConfig{}
+}
+
+// getConfigMapFromInformerCache returns the OSM ConfigMap from the informer cache, or nil when it does not exist
+func (c *Client) getConfigMapFromInformerCache() *v1.ConfigMap {
 	configMapCacheKey := c.getConfigMapCacheKey()
 	item, exists, err := c.cache.GetByKey(configMapCacheKey)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error getting ConfigMap from cache with key %s", configMapCacheKey)
-		return &osmConfig{}
+		return nil
 	}
 
 	if !exists {
 		log.Error().Msgf("ConfigMap %s does not exist in cache", configMapCacheKey)
-		return &osmConfig{}
+		return nil
 	}
 
-	configMap := item.(*v1.ConfigMap)
+	return item.(*v1.ConfigMap)
+}
 
-	osmConfigMap := osmConfig{
+// parseOSMConfigMap converts the data of the given ConfigMap into a MeshConfig
+func parseOSMConfigMap(configMap *v1.ConfigMap) *MeshConfig {
+	osmConfigMap := MeshConfig{
 		PermissiveTrafficPolicyMode: getBoolValueForKey(configMap, permissiveTrafficPolicyModeKey),
+		PermissiveModeAuditLogging:  getBoolValueForKey(configMap, permissiveModeAuditLoggingKey),
+		SMISpecVersion:              getStringValueForKey(configMap, smiSpecVersionKey),
 		Egress:                      getBoolValueForKey(configMap, egressKey),
+		EgressMode:                  getStringValueForKey(configMap, egressModeKey),
 		PrometheusScraping:          getBoolValueForKey(configMap, prometheusScrapingKey),
+		PrometheusScrapePort:        getIntValueForKey(configMap, prometheusScrapePortKey),
+		PrometheusScrapePath:        getStringValueForKey(configMap, prometheusScrapePathKey),
 		MeshCIDRRanges:              getEgressCIDR(configMap),
+		EgressAllowedDomains:        getStringValueForKey(configMap, egressAllowedDomainsKey),
+		EgressDNSResolution:         getStringValueForKey(configMap, egressDNSResolutionKey),
+		EgressCABundle:              getStringValueForKey(configMap, egressCABundleKey),
 		UseHTTPSIngress:             getBoolValueForKey(configMap, useHTTPSIngressKey),
+		StripForwardedHeaders:       getBoolValueForKey(configMap, stripForwardedHeadersKey),
+
+		DefaultIngressBackendProtocol: getStringValueForKey(configMap, defaultIngressBackendProtocolKey),
 
 		TracingEnable: getBoolValueForKey(configMap, tracingEnableKey),
 		EnvoyLogLevel: getStringValueForKey(configMap, envoyLogLevel),
+
+		EnvoyLogFormat: getStringValueForKey(configMap, envoyLogFormatKey),
+
+		EnableAccessLogging: getBoolValueForKey(configMap, enableAccessLoggingKey),
+		AccessLogFormat:     getStringValueForKey(configMap, accessLogFormatKey),
+
+		EnvoyAdminPort: getIntValueForKey(configMap, envoyAdminPortKey),
+
+		EnvoyConnectionIdleTimeout: getStringValueForKey(configMap, envoyConnectionIdleTimeoutKey),
+		EnvoyRequestTimeout:        getStringValueForKey(configMap, envoyRequestTimeoutKey),
+		DefaultLBAlgorithm:         getStringValueForKey(configMap, defaultLBAlgorithmKey),
+		MulticlusterEnabled:        getBoolValueForKey(configMap, multiclusterEnabledKey),
+
+		EnvoyImage:         getStringValueForKey(configMap, envoyImageKey),
+		InitContainerImage: getStringValueForKey(configMap, initContainerImageKey),
+
+		EnableSidecarInjection: getBoolValueForKey(configMap, enableSidecarInjectionKey),
+		ExcludedNamespaces:     getStringValueForKey(configMap, excludedNamespacesKey),
+
+		ProxyBootstrapConfigOverride: getStringValueForKey(configMap, proxyBootstrapOverrideKey),
+		ProxyBindAddress:             getStringValueForKey(configMap, proxyBindAddressKey),
+		EnvoyConcurrency:             getIntValueForKey(configMap, envoyConcurrencyKey),
+		ProxyDrainTime:               getStringValueForKey(configMap, proxyDrainTimeKey),
+		ProxyParentShutdownTime:      getStringValueForKey(configMap, proxyParentShutdownTimeKey),
+
+		ServiceCertValidityDuration: getStringValueForKey(configMap, serviceCertValidityDurationKey),
+		TrustDomain:                 getStringValueForKey(configMap, trustDomainKey),
+		MeshTLSMinVersion:           getStringValueForKey(configMap, meshTLSMinVersionKey),
+		MeshTLSMaxVersion:           getStringValueForKey(configMap, meshTLSMaxVersionKey),
+		MeshCipherSuites:            getStringValueForKey(configMap, meshCipherSuitesKey),
+		MTLSExemptSourceCIDRs:       getStringValueForKey(configMap, mtlsExemptSourceCIDRsKey),
+		CertificateProvider:         getStringValueForKey(configMap, certificateProviderKey),
+
+		XDSServerResponseTimeout: getStringValueForKey(configMap, xdsServerResponseTimeoutKey),
+		XDSKeepaliveTime:         getStringValueForKey(configMap, xdsKeepaliveTimeKey),
+		XDSKeepaliveTimeout:      getStringValueForKey(configMap, xdsKeepaliveTimeoutKey),
+		DisabledXDSTypes:         getStringValueForKey(configMap, disabledXDSTypesKey),
+		MaxDataPlaneConnections:  getIntValueForKey(configMap, maxDataPlaneConnectionsKey),
+		MaxEndpointsPerCluster:   getIntValueForKey(configMap, maxEndpointsPerClusterKey),
+		EnableDebugServer:        getBoolValueForKey(configMap, enableDebugServerKey),
+
+		OutboundPortExclusionList: getStringValueForKey(configMap, outboundPortExclusionListKey),
+		InboundPortExclusionList:  getStringValueForKey(configMap, inboundPortExclusionListKey),
+
+		LocalityAwareRouting: getBoolValueForKey(configMap, localityAwareRoutingKey),
+		LocalityRegion:       getStringValueForKey(configMap, localityRegionKey),
+		LocalityZone:         getStringValueForKey(configMap, localityZoneKey),
+
+		StatsPrefix: getStringValueForKey(configMap, statsPrefixKey),
 	}
 
+	getYAMLValueForKey(configMap, retryPolicyKey, &osmConfigMap.RetryPolicy)
+	getYAMLValueForKey(configMap, circuitBreakingKey, &osmConfigMap.CircuitBreaking)
+	getYAMLValueForKey(configMap, outlierDetectionKey, &osmConfigMap.OutlierDetection)
+	getYAMLValueForKey(configMap, inboundExternalAuthKey, &osmConfigMap.InboundExternalAuth)
+	getYAMLValueForKey(configMap, multiclusterGatewayKey, &osmConfigMap.MulticlusterGateway)
+	getYAMLValueForKey(configMap, sidecarResourcesKey, &osmConfigMap.SidecarResources)
+	getYAMLValueForKey(configMap, proxyProbeKey, &osmConfigMap.ProxyProbe)
+	getYAMLValueForKey(configMap, proxyEnvVarsKey, &osmConfigMap.ProxyEnvVars)
+	getYAMLValueForKey(configMap, overloadManagerKey, &osmConfigMap.OverloadManager)
+	getYAMLValueForKey(configMap, vaultKey, &osmConfigMap.Vault)
+	getYAMLValueForKey(configMap, certManagerKey, &osmConfigMap.CertManager)
+	getYAMLValueForKey(configMap, leaderElectionKey, &osmConfigMap.LeaderElection)
+	getYAMLValueForKey(configMap, statsTagsKey, &osmConfigMap.StatsTags)
+	getYAMLValueForKey(configMap, statsSinkKey, &osmConfigMap.StatsSink)
+	getYAMLValueForKey(configMap, wasmExtensionsKey, &osmConfigMap.WASMExtensions)
+	getYAMLValueForKey(configMap, featureFlagsKey, &osmConfigMap.FeatureFlags)
+
 	if osmConfigMap.TracingEnable {
 		osmConfigMap.TracingAddress = getStringValueForKey(configMap, tracingAddressKey)
 		osmConfigMap.TracingPort = getIntValueForKey(configMap, tracingPortKey)
 		osmConfigMap.TracingEndpoint = getStringValueForKey(configMap, tracingEndpointKey)
+		osmConfigMap.TracingSamplingRate = getFloatValueForKey(configMap, tracingSamplingRateKey)
+		osmConfigMap.TracingBackend = getStringValueForKey(configMap, tracingBackendKey)
 	}
 
 	return &osmConfigMap
@@ -165,8 +750,7 @@ func getEgressCIDR(configMap *v1.ConfigMap) string {
 func getBoolValueForKey(configMap *v1.ConfigMap, key string) bool {
 	configMapStringValue, ok := configMap.Data[key]
 	if !ok {
-		log.Debug().Msgf("Key %s does not exist in ConfigMap %s/%s (%s)",
-			key, configMap.Namespace, configMap.Name, configMap.Data)
+		log.Debug().Msgf("Key %s does not exist in ConfigMap %s/%s", key, configMap.Namespace, configMap.Name)
 		return false
 	}
 
@@ -182,8 +766,7 @@ func getBoolValueForKey(configMap *v1.ConfigMap, key string) bool {
 func getIntValueForKey(configMap *v1.ConfigMap, key string) int {
 	configMapStringValue, ok := configMap.Data[key]
 	if !ok {
-		log.Debug().Msgf("Key %s does not exist in ConfigMap %s/%s (%s)",
-			key, configMap.Namespace, configMap.Name, configMap.Data)
+		log.Debug().Msgf("Key %s does not exist in ConfigMap %s/%s", key, configMap.Namespace, configMap.Name)
 		return 0
 	}
 
@@ -196,11 +779,47 @@ func getIntValueForKey(configMap *v1.ConfigMap, key string) int {
 	return int(configMapIntValue)
 }
 
+// getFloatValueForKey returns nil when the key is missing or its value cannot be parsed
+func getFloatValueForKey(configMap *v1.ConfigMap, key string) *float64 {
+	configMapStringValue, ok := configMap.Data[key]
+	if !ok {
+		log.Debug().Msgf("Key %s does not exist in ConfigMap %s/%s", key, configMap.Namespace, configMap.Name)
+		return nil
+	}
+
+	configMapFloatValue, err := strconv.ParseFloat(configMapStringValue, 64)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error converting ConfigMap %s/%s key %s with value %+v to float", configMap.Namespace, configMap.Name, key, configMapStringValue)
+		return nil
+	}
+
+	return &configMapFloatValue
+}
+
+// getYAMLValueForKey unmarshals the YAML value held by the key into out, which must be a pointer, and returns whether
+// the key exists and its value could be parsed; out is left untouched otherwise. The value is never logged, since it
+// may hold credentials.
+func getYAMLValueForKey(configMap *v1.ConfigMap, key string, out interface{}) bool {
+	configMapStringValue, ok := configMap.Data[key]
+	if !ok {
+		log.Debug().Msgf("Key %s does not exist in ConfigMap %s/%s", key, configMap.Namespace, configMap.Name)
+		return false
+	}
+
+	// The value is unmarshaled into a copy, so a value failing to parse halfway does not leave out partially set
+	value := reflect.New(reflect.TypeOf(out).Elem())
+	if err := yaml.Unmarshal([]byte(configMapStringValue), value.Interface()); err != nil {
+		log.Error().Err(err).Msgf("Error converting ConfigMap %s/%s key %s to %T", configMap.Namespace, configMap.Name, key, value.Elem().Interface())
+		return false
+	}
+	reflect.ValueOf(out).Elem().Set(value.Elem())
+	return true
+}
+
 func getStringValueForKey(configMap *v1.ConfigMap, key string) string {
 	configMapStringValue, ok := configMap.Data[key]
 	if !ok {
-		log.Debug().Msgf("Key %s does not exist in ConfigMap %s/%s (%s)",
-			key, configMap.Namespace, configMap.Name, configMap.Data)
+		log.Debug().Msgf("Key %s does not exist in ConfigMap %s/%s", key, configMap.Namespace, configMap.Name)
 		return ""
 	}
 	return configMapStringValue
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
+	"sync"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -11,6 +14,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/openservicemesh/osm/pkg/kubernetes"
 )
@@ -98,24 +102,89 @@ var _ = Describe("Test OSM ConfigMap parsing", func() {
 
 		It("Tag matches const key for all fields of OSM ConfigMap struct", func() {
 			fieldNameTag := map[string]string{
-				"PermissiveTrafficPolicyMode": permissiveTrafficPolicyModeKey,
-				"Egress":                      egressKey,
-				"PrometheusScraping":          prometheusScrapingKey,
-				"TracingEnable":               tracingEnableKey,
-				"TracingAddress":              tracingAddressKey,
-				"TracingPort":                 tracingPortKey,
-				"TracingEndpoint":             tracingEndpointKey,
-				"MeshCIDRRanges":              meshCIDRRangesKey,
-				"UseHTTPSIngress":             useHTTPSIngressKey,
-				"EnvoyLogLevel":               envoyLogLevel,
+				"PermissiveTrafficPolicyMode":   permissiveTrafficPolicyModeKey,
+				"PermissiveModeAuditLogging":    permissiveModeAuditLoggingKey,
+				"SMISpecVersion":                smiSpecVersionKey,
+				"Egress":                        egressKey,
+				"PrometheusScraping":            prometheusScrapingKey,
+				"TracingEnable":                 tracingEnableKey,
+				"TracingAddress":                tracingAddressKey,
+				"TracingPort":                   tracingPortKey,
+				"TracingEndpoint":               tracingEndpointKey,
+				"TracingSamplingRate":           tracingSamplingRateKey,
+				"TracingBackend":                tracingBackendKey,
+				"MeshCIDRRanges":                meshCIDRRangesKey,
+				"EgressAllowedDomains":          egressAllowedDomainsKey,
+				"EgressDNSResolution":           egressDNSResolutionKey,
+				"EgressCABundle":                egressCABundleKey,
+				"UseHTTPSIngress":               useHTTPSIngressKey,
+				"DefaultIngressBackendProtocol": defaultIngressBackendProtocolKey,
+				"EnvoyLogLevel":                 envoyLogLevel,
+				"EnvoyLogFormat":                envoyLogFormatKey,
+				"OutboundPortExclusionList":     outboundPortExclusionListKey,
+				"InboundPortExclusionList":      inboundPortExclusionListKey,
+				"LocalityAwareRouting":          localityAwareRoutingKey,
+				"LocalityRegion":                localityRegionKey,
+				"LocalityZone":                  localityZoneKey,
+				"FeatureFlags":                  featureFlagsKey,
+				"EnvoyConnectionIdleTimeout":    envoyConnectionIdleTimeoutKey,
+				"EnvoyRequestTimeout":           envoyRequestTimeoutKey,
+				"XDSServerResponseTimeout":      xdsServerResponseTimeoutKey,
+				"XDSKeepaliveTime":              xdsKeepaliveTimeKey,
+				"XDSKeepaliveTimeout":           xdsKeepaliveTimeoutKey,
+				"DisabledXDSTypes":              disabledXDSTypesKey,
+				"RetryPolicy":                   retryPolicyKey,
+				"EgressMode":                    egressModeKey,
+				"ServiceCertValidityDuration":   serviceCertValidityDurationKey,
+				"TrustDomain":                   trustDomainKey,
+				"MeshTLSMinVersion":             meshTLSMinVersionKey,
+				"MeshTLSMaxVersion":             meshTLSMaxVersionKey,
+				"MeshCipherSuites":              meshCipherSuitesKey,
+				"MTLSExemptSourceCIDRs":         mtlsExemptSourceCIDRsKey,
+				"CertificateProvider":           certificateProviderKey,
+				"Vault":                         vaultKey,
+				"CertManager":                   certManagerKey,
+				"EnvoyAdminPort":                envoyAdminPortKey,
+				"EnableAccessLogging":           enableAccessLoggingKey,
+				"AccessLogFormat":               accessLogFormatKey,
+				"MaxDataPlaneConnections":       maxDataPlaneConnectionsKey,
+				"MaxEndpointsPerCluster":        maxEndpointsPerClusterKey,
+				"LeaderElection":                leaderElectionKey,
+				"PrometheusScrapePort":          prometheusScrapePortKey,
+				"PrometheusScrapePath":          prometheusScrapePathKey,
+				"CircuitBreaking":               circuitBreakingKey,
+				"DefaultLBAlgorithm":            defaultLBAlgorithmKey,
+				"OutlierDetection":              outlierDetectionKey,
+				"InboundExternalAuth":           inboundExternalAuthKey,
+				"MulticlusterEnabled":           multiclusterEnabledKey,
+				"MulticlusterGateway":           multiclusterGatewayKey,
+				"SidecarResources":              sidecarResourcesKey,
+				"EnvoyImage":                    envoyImageKey,
+				"InitContainerImage":            initContainerImageKey,
+				"EnableSidecarInjection":        enableSidecarInjectionKey,
+				"ExcludedNamespaces":            excludedNamespacesKey,
+				"ProxyProbe":                    proxyProbeKey,
+				"EnableDebugServer":             enableDebugServerKey,
+				"StripForwardedHeaders":         stripForwardedHeadersKey,
+				"ProxyBootstrapConfigOverride":  proxyBootstrapOverrideKey,
+				"ProxyBindAddress":              proxyBindAddressKey,
+				"StatsPrefix":                   statsPrefixKey,
+				"StatsTags":                     statsTagsKey,
+				"StatsSink":                     statsSinkKey,
+				"WASMExtensions":                wasmExtensionsKey,
+				"EnvoyConcurrency":              envoyConcurrencyKey,
+				"ProxyEnvVars":                  proxyEnvVarsKey,
+				"ProxyDrainTime":                proxyDrainTimeKey,
+				"ProxyParentShutdownTime":       proxyParentShutdownTimeKey,
+				"OverloadManager":               overloadManagerKey,
 			}
-			t := reflect.TypeOf(osmConfig{})
+			t := reflect.TypeOf(MeshConfig{})
 
 			actualNumberOfFields := t.NumField()
-			expectedNumberOfFields := 10
+			expectedNumberOfFields := 75
 			Expect(actualNumberOfFields).To(
 				Equal(expectedNumberOfFields),
-				fmt.Sprintf("Fields have been added or removed from the osmConfig struct -- expected %d, actual %d; please correct this unit test", expectedNumberOfFields, actualNumberOfFields))
+				fmt.Sprintf("Fields have been added or removed from the MeshConfig struct -- expected %d, actual %d; please correct this unit test", expectedNumberOfFields, actualNumberOfFields))
 
 			for fieldName, expectedTag := range fieldNameTag {
 				f, _ := t.FieldByName("PermissiveTrafficPolicyMode")
@@ -140,6 +209,15 @@ var _ = Describe("Test OSM ConfigMap parsing", func() {
 			Expect(getIntValueForKey(cm0, egressKey)).To(Equal(0))
 		})
 
+		It("Test getFloatValueForKey()", func() {
+			cm := &v1.ConfigMap{Data: map[string]string{tracingSamplingRateKey: "0.5", tracingEndpointKey: "foo"}}
+			Expect(*getFloatValueForKey(cm, tracingSamplingRateKey)).To(Equal(0.5))
+			Expect(getFloatValueForKey(cm, tracingEndpointKey)).To(BeNil())
+
+			cm0 := &v1.ConfigMap{Data: map[string]string{}}
+			Expect(getFloatValueForKey(cm0, tracingSamplingRateKey)).To(BeNil())
+		})
+
 		It("Test getStringValueForKey()", func() {
 			cm := &v1.ConfigMap{Data: map[string]string{tracingEndpointKey: "foo"}}
 			Expect(getStringValueForKey(cm, tracingEndpointKey)).To(Equal("foo"))
@@ -147,5 +225,246 @@ var _ = Describe("Test OSM ConfigMap parsing", func() {
 			cm0 := &v1.ConfigMap{Data: map[string]string{}}
 			Expect(getStringValueForKey(cm0, tracingEndpointKey)).To(Equal(""))
 		})
+
+		It("Test getYAMLValueForKey()", func() {
+			cm := &v1.ConfigMap{Data: map[string]string{
+				retryPolicyKey:  "num_retries: 3\nretry_on: 5xx",
+				statsTagsKey:    "mesh: osm",
+				featureFlagsKey: "backpressure: [true",
+			}}
+			var retryPolicy RetryPolicy
+			Expect(getYAMLValueForKey(cm, retryPolicyKey, &retryPolicy)).To(BeTrue())
+			Expect(retryPolicy).To(Equal(RetryPolicy{NumRetries: 3, RetryOn: "5xx"}))
+
+			var statsTags map[string]string
+			Expect(getYAMLValueForKey(cm, statsTagsKey, &statsTags)).To(BeTrue())
+			Expect(statsTags).To(Equal(map[string]string{"mesh": "osm"}))
+
+			featureFlags := map[string]bool{"backpressure": false}
+			Expect(getYAMLValueForKey(cm, featureFlagsKey, &featureFlags)).To(BeFalse())
+			Expect(featureFlags).To(Equal(map[string]bool{"backpressure": false}))
+
+			var leaderElection LeaderElection
+			Expect(getYAMLValueForKey(cm, leaderElectionKey, &leaderElection)).To(BeFalse())
+			Expect(leaderElection).To(Equal(LeaderElection{}))
+		})
+	})
+})
+
+var _ = Describe("Test concurrent access to the OSM ConfigMap", func() {
+	kubeClient := testclient.NewSimpleClientset()
+
+	osmNamespace := "-test-osm-namespace-"
+	osmConfigMapName := "-test-osm-config-map-"
+	stop := make(<-chan struct{})
+	cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+	It("returns the latest config to concurrent readers while the ConfigMap is updated", func() {
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				egressKey: "false",
+			},
+		}
+		_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		<-cfg.GetAnnouncementsChannel()
+
+		done := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				for {
+					select {
+					case <-done:
+						return
+					default:
+						cfg.IsEgressEnabled()
+					}
+				}
+			}()
+		}
+
+		for i := 1; i <= 10; i++ {
+			egress := i%2 == 1
+			configMap.Data[egressKey] = strconv.FormatBool(egress)
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsEgressEnabled()).To(Equal(egress))
+		}
+
+		close(done)
+		wg.Wait()
+	})
+})
+
+var _ = Describe("Test the OSM ConfigMap accessors", func() {
+	It("returns the name and namespace the configurator was given", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		close(stop)
+		cfg := NewConfigurator(kubeClient, stop, "-test-osm-namespace-", "-test-osm-config-map-")
+
+		Expect(cfg.GetConfigMapName()).To(Equal("-test-osm-config-map-"))
+		Expect(cfg.GetConfigMapNamespace()).To(Equal("-test-osm-namespace-"))
+		Expect(cfg.GetOSMNamespace()).To(Equal("-test-osm-namespace-"))
+	})
+})
+
+var _ = Describe("Test OSM config readiness", func() {
+	osmNamespace := "-test-osm-namespace-"
+	osmConfigMapName := "-test-osm-config-map-"
+
+	It("is not ready before the informer cache has been synced", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		close(stop)
+		cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		Expect(cfg.getConfigStatus()).To(Equal(errConfigNotSynced))
+		Expect(cfg.IsConfigReady()).To(BeFalse())
+	})
+
+	It("is not ready until the ConfigMap exists", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		defer close(stop)
+		cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		Expect(cfg.getConfigStatus()).To(Equal(errConfigMapNotFound))
+		Expect(cfg.IsConfigReady()).To(BeFalse())
+
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+		}
+		_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		<-cfg.GetAnnouncementsChannel()
+
+		Expect(cfg.getConfigStatus()).ToNot(HaveOccurred())
+		Expect(cfg.IsConfigReady()).To(BeTrue())
+
+		err = kubeClient.CoreV1().ConfigMaps(osmNamespace).Delete(context.TODO(), osmConfigMapName, metav1.DeleteOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		<-cfg.GetAnnouncementsChannel()
+
+		Expect(cfg.getConfigStatus()).To(Equal(errConfigMapNotFound))
+		Expect(cfg.IsConfigReady()).To(BeFalse())
+	})
+})
+
+var _ = Describe("Test closing the OSM configurator", func() {
+	osmNamespace := "-test-osm-namespace-"
+	osmConfigMapName := "-test-osm-config-map-"
+
+	It("closes the announcements and the subscribers, and can be closed again", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		defer close(stop)
+		cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		subscriber := cfg.Subscribe("Egress")
+		watcher := cfg.WatchConfig(context.Background())
+
+		Expect(cfg.Close()).To(Succeed())
+		Expect(func() { Expect(cfg.Close()).To(Succeed()) }).ToNot(Panic())
+
+		Expect(cfg.GetAnnouncementsChannel()).To(BeClosed())
+		Expect(cfg.GetTypedAnnouncementsChannel()).To(BeClosed())
+		Expect(subscriber).To(BeClosed())
+		Expect(watcher).To(BeClosed())
+		Expect(cfg.subscribers).To(BeEmpty())
+
+		// Subscribing after the client has been closed returns a closed channel
+		Expect(cfg.Subscribe()).To(BeClosed())
+		Expect(cfg.subscribers).To(BeEmpty())
+	})
+
+	It("announces the event pending the debounce window before closing the channels", func() {
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				egressKey: "true",
+			},
+		}
+
+		// The informer never runs: the event is sent by the test, so it is pending in the dispatcher once sent
+		cfg := newClient(osmNamespace, osmConfigMapName)
+		cfg.cache = cache.NewStore(cache.MetaNamespaceKeyFunc)
+		close(cfg.cacheSynced)
+		cfg.announcementDebounceWindow = time.Minute
+		typedAnnouncements := cfg.GetTypedAnnouncementsChannel()
+		subscriber := cfg.Subscribe("Egress")
+		go cfg.dispatchAnnouncements(cfg.stopOnClose(make(chan struct{})))
+
+		Expect(cfg.cache.Add(&configMap)).To(Succeed())
+		cfg.configMapEvents <- kubernetes.Event{
+			Type:  kubernetes.CreateEvent,
+			Value: &configMap,
+		}
+		Expect(cfg.Close()).To(Succeed())
+
+		var event ConfigChangeEvent
+		Expect(typedAnnouncements).To(Receive(&event))
+		Expect(event.ChangedFields).To(Equal([]string{"Egress"}))
+		Expect(subscriber).To(Receive())
+		Expect(typedAnnouncements).To(BeClosed())
+		Expect(subscriber).To(BeClosed())
+	})
+
+	It("does not announce anything once closed", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		defer close(stop)
+		cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		invoked := false
+		cfg.OnConfigChange(func(ConfigChangeEvent) {
+			invoked = true
+		})
+		Expect(cfg.Close()).To(Succeed())
+
+		cfg.announce(kubernetes.Event{Type: kubernetes.UpdateEvent}, &ConfigChangeEvent{ChangedFields: []string{"Egress"}})
+		Expect(invoked).To(BeFalse())
+		Expect(cfg.pendingAnnouncements).To(BeEmpty())
+	})
+
+	It("stops the informer and keeps serving the last config", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		defer close(stop)
+		cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				egressKey: "true",
+			},
+		}
+		_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		<-cfg.GetAnnouncementsChannel()
+
+		Expect(cfg.Close()).To(Succeed())
+
+		configMap.Data[egressKey] = "false"
+		_, err = kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Consistently(cfg.IsEgressEnabled, 500*time.Millisecond).Should(BeTrue())
 	})
 })
@@ -0,0 +1,96 @@
+package configurator
+
+import (
+	// Imported for go:embed
+	_ "embed"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+)
+
+// defaultsJSON holds the default OSM config, keyed by the ConfigMap keys.
+// JSON being a subset of YAML, it is parsed with the yaml tags of the MeshConfig.
+//
+//go:embed defaults.json
+var defaultsJSON []byte
+
+// defaultConfig is the OSM config used for the keys the ConfigMap does not set, and by the getters in place of invalid values
+var defaultConfig = mustLoadDefaultConfig(defaultsJSON)
+
+// mustLoadDefaultConfig parses and validates the embedded default config; the defaults are part of the build,
+// so a problem with them is a programming error
+func mustLoadDefaultConfig(defaults []byte) MeshConfig {
+	var config MeshConfig
+	if err := yaml.UnmarshalStrict(defaults, &config); err != nil {
+		panic(fmt.Sprintf("Error parsing the embedded default OSM config: %v", err))
+	}
+	if errs := config.validate(); len(errs) != 0 {
+		panic(fmt.Sprintf("Invalid embedded default OSM config: %v", errs))
+	}
+	return config
+}
+
+// mergeOverDefaultConfig returns the config parsed from the given ConfigMap, with the values of the keys the ConfigMap
+// does not set, or sets to a value which parses to nothing, taken from the default config. A bool value set in the
+// ConfigMap is always kept, since false cannot be told apart from unset otherwise.
+func mergeOverDefaultConfig(configMap *v1.ConfigMap) *MeshConfig {
+	mergedConfig := defaultConfig.deepCopy()
+	if configMap == nil {
+		return &mergedConfig
+	}
+
+	parsedConfig := reflect.ValueOf(*parseOSMConfigMap(configMap))
+	configValue := reflect.ValueOf(&mergedConfig).Elem()
+	configType := configValue.Type()
+	for i := 0; i < configType.NumField(); i++ {
+		if isSetInConfigMap(configMap, configType.Field(i), parsedConfig.Field(i)) {
+			configValue.Field(i).Set(parsedConfig.Field(i))
+		}
+	}
+
+	return &mergedConfig
+}
+
+// getConfigProvenance returns, for each field of the config merged from the given ConfigMap, whether its value is taken
+// from the ConfigMap or from the default config, keyed by the field name
+func getConfigProvenance(configMap *v1.ConfigMap) map[string]string {
+	provenance := make(map[string]string)
+	configType := reflect.TypeOf(MeshConfig{})
+	if configMap == nil {
+		for i := 0; i < configType.NumField(); i++ {
+			provenance[configType.Field(i).Name] = ProvenanceDefault
+		}
+		return provenance
+	}
+
+	parsedConfig := reflect.ValueOf(*parseOSMConfigMap(configMap))
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		provenance[field.Name] = ProvenanceDefault
+		if isSetInConfigMap(configMap, field, parsedConfig.Field(i)) {
+			provenance[field.Name] = ProvenanceConfigMap
+		}
+	}
+	return provenance
+}
+
+// isSetInConfigMap returns whether the value of the field is taken from the ConfigMap when merging it over the default
+// config: the key of the field must be present in the ConfigMap, and its value must parse to something
+func isSetInConfigMap(configMap *v1.ConfigMap, field reflect.StructField, parsedField reflect.Value) bool {
+	key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+	if _, ok := configMap.Data[key]; !ok {
+		return false
+	}
+	return field.Type.Kind() == reflect.Bool || !parsedField.IsZero()
+}
+
+// getDefaultDuration returns the given duration of the default config; the default config is validated when loaded,
+// so the duration always parses
+func getDefaultDuration(duration string) time.Duration {
+	parsedDuration, _ := time.ParseDuration(duration)
+	return parsedDuration
+}
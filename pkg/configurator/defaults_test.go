@@ -0,0 +1,159 @@
+package configurator
+
+import (
+	"context"
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Test the embedded default OSM config", func() {
+	Context("load the default config", func() {
+		It("parses the embedded defaults", func() {
+			Expect(defaultConfig.PrometheusScrapePort).To(Equal(15010))
+			Expect(defaultConfig.TracingBackend).To(Equal(TracingBackendZipkin))
+			Expect(*defaultConfig.TracingSamplingRate).To(Equal(1.0))
+			Expect(defaultConfig.CircuitBreaking.MaxRetries).To(Equal(uint32(3)))
+		})
+
+		It("rejects unknown keys", func() {
+			Expect(func() { mustLoadDefaultConfig([]byte(`{"tracing_prot": 9411}`)) }).To(Panic())
+		})
+
+		It("rejects invalid defaults", func() {
+			Expect(func() { mustLoadDefaultConfig([]byte(`{"envoy_request_timeout": "soon"}`)) }).To(Panic())
+		})
+	})
+
+	Context("merge the ConfigMap over the default config", func() {
+		It("returns the default config for a missing ConfigMap", func() {
+			Expect(*mergeOverDefaultConfig(nil)).To(Equal(defaultConfig))
+		})
+
+		It("keeps the default values of the keys the ConfigMap does not set", func() {
+			configMap := &v1.ConfigMap{
+				Data: map[string]string{
+					tracingEnableKey:      "true",
+					tracingPortKey:        "9415",
+					envoyLogLevel:         "info",
+					circuitBreakingKey:    "max_connections: 100",
+					prometheusScrapingKey: "false",
+				},
+			}
+
+			expectedConfig := defaultConfig.deepCopy()
+			expectedConfig.TracingEnable = true
+			expectedConfig.TracingPort = 9415
+			expectedConfig.EnvoyLogLevel = "info"
+			expectedConfig.CircuitBreaking = CircuitBreaking{MaxConnections: 100}
+			Expect(*mergeOverDefaultConfig(configMap)).To(Equal(expectedConfig))
+		})
+
+		It("keeps the default values of the keys which parse to nothing", func() {
+			configMap := &v1.ConfigMap{
+				Data: map[string]string{
+					tracingEnableKey:  "false",
+					tracingPortKey:    "9415",
+					envoyAdminPortKey: "admin",
+				},
+			}
+			Expect(*mergeOverDefaultConfig(configMap)).To(Equal(defaultConfig))
+		})
+
+		It("does not share the default config", func() {
+			config := mergeOverDefaultConfig(nil)
+			*config.TracingSamplingRate = 0.5
+			Expect(*defaultConfig.TracingSamplingRate).To(Equal(1.0))
+		})
+	})
+
+	Context("create OSM config from an empty ConfigMap", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("uses exactly the embedded defaults", func() {
+			Expect(*cfg.getConfigMap()).To(Equal(defaultConfig))
+
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(*cfg.getConfigMap()).To(Equal(defaultConfig))
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(defaultConfig.TracingPort)))
+			Expect(cfg.GetEnvoyRequestTimeout()).To(Equal(getDefaultDuration(defaultConfig.EnvoyRequestTimeout)))
+			Expect(cfg.GetDefaultCircuitBreaking()).To(Equal(defaultConfig.CircuitBreaking))
+		})
+	})
+
+	Context("track the provenance of the config fields", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("reports every field as defaulted without a ConfigMap", func() {
+			provenance := cfg.GetConfigProvenance()
+			Expect(provenance).To(HaveLen(reflect.TypeOf(MeshConfig{}).NumField()))
+			for field, source := range provenance {
+				Expect(source).To(Equal(ProvenanceDefault), field)
+			}
+		})
+
+		It("reports the fields set in the ConfigMap", func() {
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					egressKey:          "false",
+					tracingEnableKey:   "true",
+					tracingPortKey:     "9415",
+					envoyAdminPortKey:  "admin",
+					circuitBreakingKey: "max_connections: 100",
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			provenance := cfg.GetConfigProvenance()
+			Expect(provenance).To(HaveLen(reflect.TypeOf(MeshConfig{}).NumField()))
+			for field, source := range provenance {
+				switch field {
+				case "Egress", "TracingEnable", "TracingPort", "CircuitBreaking":
+					Expect(source).To(Equal(ProvenanceConfigMap), field)
+				default:
+					// The admin port does not parse, so it is defaulted
+					Expect(source).To(Equal(ProvenanceDefault), field)
+				}
+			}
+		})
+
+		It("returns a copy of the provenance", func() {
+			cfg.GetConfigProvenance()["Egress"] = ProvenanceDefault
+			Expect(cfg.GetConfigProvenance()["Egress"]).To(Equal(ProvenanceConfigMap))
+		})
+	})
+})
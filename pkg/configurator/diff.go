@@ -0,0 +1,56 @@
+package configurator
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// DiffConfig returns the MeshConfig fields whose effective values, once merged over the default config, differ between
+// the two given OSM ConfigMap manifests, in the order of the MeshConfig fields. The manifests are YAML or JSON; an
+// empty manifest stands for a missing ConfigMap, so the config is the default config. Unlike a textual diff, a key
+// added with its default value, or a key set to a value which parses to nothing, is not a change.
+func DiffConfig(oldManifest, newManifest []byte) ([]FieldChange, error) {
+	oldConfig, err := parseConfigMapManifest(oldManifest)
+	if err != nil {
+		return nil, errors.Wrap(err, "old ConfigMap")
+	}
+	newConfig, err := parseConfigMapManifest(newManifest)
+	if err != nil {
+		return nil, errors.Wrap(err, "new ConfigMap")
+	}
+
+	// The changes are computed from the configs as they are, so a change of a sensitive field is reported, masked
+	var changes []FieldChange
+	redactedOldConfig, redactedNewConfig := oldConfig.redactedCopy(), newConfig.redactedCopy()
+	oldValue := reflect.ValueOf(&redactedOldConfig).Elem()
+	newValue := reflect.ValueOf(&redactedNewConfig).Elem()
+	for _, fieldName := range getChangedFields(oldConfig, newConfig) {
+		field, _ := oldValue.Type().FieldByName(fieldName)
+		changes = append(changes, FieldChange{
+			Field: fieldName,
+			Key:   strings.Split(field.Tag.Get("yaml"), ",")[0],
+			Old:   oldValue.FieldByName(fieldName).Interface(),
+			New:   newValue.FieldByName(fieldName).Interface(),
+		})
+	}
+	return changes, nil
+}
+
+// parseConfigMapManifest returns the config parsed from the given ConfigMap manifest merged over the default config,
+// or the default config when the manifest is empty
+func parseConfigMapManifest(manifest []byte) (*MeshConfig, error) {
+	if len(bytes.TrimSpace(manifest)) == 0 {
+		return mergeOverDefaultConfig(nil), nil
+	}
+
+	var configMap v1.ConfigMap
+	if err := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(manifest), len(manifest)).Decode(&configMap); err != nil {
+		return nil, errors.Wrap(errInvalidConfigMap, err.Error())
+	}
+	return mergeOverDefaultConfig(&configMap), nil
+}
@@ -0,0 +1,84 @@
+package configurator
+
+import (
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test diffing OSM ConfigMaps", func() {
+	configMapManifest := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: osm-config
+  namespace: osm-system
+data:
+  egress: "false"
+  envoy_log_level: error
+  tracing_port: "9411"
+`
+
+	It("returns no change for identical ConfigMaps, whatever their format", func() {
+		changes, err := DiffConfig([]byte(configMapManifest), []byte(configMapManifest))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(BeEmpty())
+
+		changes, err = DiffConfig([]byte(configMapManifest), []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "data": {"tracing_port": "9411", "egress": "false", "envoy_log_level": "error"}}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("returns the effective values of a changed field", func() {
+		changes, err := DiffConfig([]byte(configMapManifest), []byte(`
+apiVersion: v1
+kind: ConfigMap
+data:
+  egress: "true"
+  envoy_log_level: error
+  tracing_port: "9411"
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(Equal([]FieldChange{{
+			Field: "Egress",
+			Key:   egressKey,
+			Old:   false,
+			New:   true,
+		}}))
+	})
+
+	It("returns no change when the defaulting masks the changes of the ConfigMap", func() {
+		// The tracing port is removed, and the log level set to its default value
+		changes, err := DiffConfig([]byte(`
+apiVersion: v1
+kind: ConfigMap
+data:
+  tracing_port: "9411"
+`), []byte(`
+apiVersion: v1
+kind: ConfigMap
+data:
+  envoy_log_level: debug
+  tracing_port: "not-a-port"
+`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(BeEmpty())
+	})
+
+	It("diffs against the default config when a ConfigMap is missing", func() {
+		changes, err := DiffConfig(nil, []byte(configMapManifest))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changes).To(Equal([]FieldChange{{
+			Field: "EnvoyLogLevel",
+			Key:   envoyLogLevel,
+			Old:   "debug",
+			New:   "error",
+		}}))
+	})
+
+	It("returns an error for a malformed ConfigMap manifest", func() {
+		_, err := DiffConfig([]byte(configMapManifest), []byte("data: [egress"))
+		Expect(errors.Cause(err)).To(Equal(errInvalidConfigMap))
+	})
+})
@@ -0,0 +1,137 @@
+package configurator
+
+import (
+	"os"
+	"reflect"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// environmentVariables maps the name of each config field to the environment variable overriding its value when the
+// configurator is created WithEnvironmentOverlay. The values of the environment variables are formatted like the
+// values of the ConfigMap, e.g. OSM_CONFIG_RETRY_POLICY holds the retry policy as YAML.
+var environmentVariables = map[string]string{
+	"PermissiveTrafficPolicyMode":   "OSM_CONFIG_PERMISSIVE_TRAFFIC_POLICY_MODE",
+	"PermissiveModeAuditLogging":    "OSM_CONFIG_PERMISSIVE_MODE_AUDIT_LOGGING",
+	"SMISpecVersion":                "OSM_CONFIG_SMI_SPEC_VERSION",
+	"Egress":                        "OSM_CONFIG_EGRESS",
+	"EgressMode":                    "OSM_CONFIG_EGRESS_MODE",
+	"PrometheusScraping":            "OSM_CONFIG_PROMETHEUS_SCRAPING",
+	"PrometheusScrapePort":          "OSM_CONFIG_PROMETHEUS_SCRAPE_PORT",
+	"PrometheusScrapePath":          "OSM_CONFIG_PROMETHEUS_SCRAPE_PATH",
+	"UseHTTPSIngress":               "OSM_CONFIG_USE_HTTPS_INGRESS",
+	"DefaultIngressBackendProtocol": "OSM_CONFIG_DEFAULT_INGRESS_BACKEND_PROTOCOL",
+	"StripForwardedHeaders":         "OSM_CONFIG_STRIP_FORWARDED_HEADERS",
+	"TracingEnable":                 "OSM_CONFIG_TRACING_ENABLE",
+	"TracingAddress":                "OSM_CONFIG_TRACING_ADDRESS",
+	"TracingPort":                   "OSM_CONFIG_TRACING_PORT",
+	"TracingEndpoint":               "OSM_CONFIG_TRACING_ENDPOINT",
+	"TracingSamplingRate":           "OSM_CONFIG_TRACING_SAMPLING_RATE",
+	"TracingBackend":                "OSM_CONFIG_TRACING_BACKEND",
+	"MeshCIDRRanges":                "OSM_CONFIG_MESH_CIDR_RANGES",
+	"EgressAllowedDomains":          "OSM_CONFIG_EGRESS_ALLOWED_DOMAINS",
+	"EgressDNSResolution":           "OSM_CONFIG_EGRESS_DNS_RESOLUTION",
+	"EgressCABundle":                "OSM_CONFIG_EGRESS_CA_BUNDLE",
+	"EnvoyLogLevel":                 "OSM_CONFIG_ENVOY_LOG_LEVEL",
+	"EnvoyLogFormat":                "OSM_CONFIG_ENVOY_LOG_FORMAT",
+	"EnableAccessLogging":           "OSM_CONFIG_ENABLE_ACCESS_LOGGING",
+	"AccessLogFormat":               "OSM_CONFIG_ACCESS_LOG_FORMAT",
+	"EnvoyAdminPort":                "OSM_CONFIG_ENVOY_ADMIN_PORT",
+	"EnvoyConnectionIdleTimeout":    "OSM_CONFIG_ENVOY_CONNECTION_IDLE_TIMEOUT",
+	"EnvoyRequestTimeout":           "OSM_CONFIG_ENVOY_REQUEST_TIMEOUT",
+	"RetryPolicy":                   "OSM_CONFIG_RETRY_POLICY",
+	"CircuitBreaking":               "OSM_CONFIG_CIRCUIT_BREAKING",
+	"DefaultLBAlgorithm":            "OSM_CONFIG_DEFAULT_LB_ALGORITHM",
+	"OutlierDetection":              "OSM_CONFIG_OUTLIER_DETECTION",
+	"InboundExternalAuth":           "OSM_CONFIG_INBOUND_EXTERNAL_AUTH",
+	"MulticlusterEnabled":           "OSM_CONFIG_MULTICLUSTER_ENABLED",
+	"MulticlusterGateway":           "OSM_CONFIG_MULTICLUSTER_GATEWAY",
+	"SidecarResources":              "OSM_CONFIG_SIDECAR_RESOURCES",
+	"EnvoyImage":                    "OSM_CONFIG_ENVOY_IMAGE",
+	"InitContainerImage":            "OSM_CONFIG_INIT_CONTAINER_IMAGE",
+	"EnableSidecarInjection":        "OSM_CONFIG_ENABLE_SIDECAR_INJECTION",
+	"ExcludedNamespaces":            "OSM_CONFIG_EXCLUDED_NAMESPACES",
+	"ProxyProbe":                    "OSM_CONFIG_PROXY_PROBE",
+	"ProxyBootstrapConfigOverride":  "OSM_CONFIG_PROXY_BOOTSTRAP_CONFIG_OVERRIDE",
+	"ProxyBindAddress":              "OSM_CONFIG_PROXY_BIND_ADDRESS",
+	"EnvoyConcurrency":              "OSM_CONFIG_ENVOY_CONCURRENCY",
+	"ProxyEnvVars":                  "OSM_CONFIG_PROXY_ENV_VARS",
+	"ProxyDrainTime":                "OSM_CONFIG_PROXY_DRAIN_TIME",
+	"ProxyParentShutdownTime":       "OSM_CONFIG_PROXY_PARENT_SHUTDOWN_TIME",
+	"OverloadManager":               "OSM_CONFIG_OVERLOAD_MANAGER",
+	"ServiceCertValidityDuration":   "OSM_CONFIG_SERVICE_CERT_VALIDITY_DURATION",
+	"TrustDomain":                   "OSM_CONFIG_TRUST_DOMAIN",
+	"MeshTLSMinVersion":             "OSM_CONFIG_MESH_TLS_MIN_VERSION",
+	"MeshTLSMaxVersion":             "OSM_CONFIG_MESH_TLS_MAX_VERSION",
+	"MeshCipherSuites":              "OSM_CONFIG_MESH_CIPHER_SUITES",
+	"MTLSExemptSourceCIDRs":         "OSM_CONFIG_MTLS_EXEMPT_SOURCE_CIDRS",
+	"CertificateProvider":           "OSM_CONFIG_CERTIFICATE_PROVIDER",
+	"Vault":                         "OSM_CONFIG_VAULT",
+	"CertManager":                   "OSM_CONFIG_CERT_MANAGER",
+	"XDSServerResponseTimeout":      "OSM_CONFIG_XDS_SERVER_RESPONSE_TIMEOUT",
+	"XDSKeepaliveTime":              "OSM_CONFIG_XDS_KEEPALIVE_TIME",
+	"XDSKeepaliveTimeout":           "OSM_CONFIG_XDS_KEEPALIVE_TIMEOUT",
+	"DisabledXDSTypes":              "OSM_CONFIG_DISABLED_XDS_TYPES",
+	"MaxDataPlaneConnections":       "OSM_CONFIG_MAX_DATA_PLANE_CONNECTIONS",
+	"MaxEndpointsPerCluster":        "OSM_CONFIG_MAX_ENDPOINTS_PER_CLUSTER",
+	"LeaderElection":                "OSM_CONFIG_LEADER_ELECTION",
+	"EnableDebugServer":             "OSM_CONFIG_ENABLE_DEBUG_SERVER",
+	"OutboundPortExclusionList":     "OSM_CONFIG_OUTBOUND_PORT_EXCLUSION_LIST",
+	"InboundPortExclusionList":      "OSM_CONFIG_INBOUND_PORT_EXCLUSION_LIST",
+	"LocalityAwareRouting":          "OSM_CONFIG_LOCALITY_AWARE_ROUTING",
+	"LocalityRegion":                "OSM_CONFIG_LOCALITY_REGION",
+	"LocalityZone":                  "OSM_CONFIG_LOCALITY_ZONE",
+	"StatsPrefix":                   "OSM_CONFIG_STATS_PREFIX",
+	"StatsTags":                     "OSM_CONFIG_STATS_TAGS",
+	"StatsSink":                     "OSM_CONFIG_STATS_SINK",
+	"WASMExtensions":                "OSM_CONFIG_WASM_EXTENSIONS",
+	"FeatureFlags":                  "OSM_CONFIG_FEATURE_FLAGS",
+}
+
+// WithEnvironmentOverlay makes the environment variables of environmentVariables override the config fields: a field
+// set by an environment variable takes its value from it, else from the ConfigMap, else from the default config.
+// This is meant for the CI and local runs in which mounting a ConfigMap is inconvenient.
+func WithEnvironmentOverlay() Option {
+	return func(c *Client) {
+		c.lookupEnv = os.LookupEnv
+	}
+}
+
+// overlayEnvironment returns a copy of the given ConfigMap whose data is overridden by the environment variables
+// which are set, along with the names of the fields they set. The ConfigMap is returned as is when the environment
+// overlay is disabled or no environment variable is set; it may be nil, in which case it is only created for the
+// environment variables.
+func (c *Client) overlayEnvironment(configMap *v1.ConfigMap) (*v1.ConfigMap, []string) {
+	if c.lookupEnv == nil {
+		return configMap, nil
+	}
+
+	var overlaidFields []string
+	data := make(map[string]string)
+	configType := reflect.TypeOf(MeshConfig{})
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		value, ok := c.lookupEnv(environmentVariables[field.Name])
+		if !ok {
+			continue
+		}
+		data[strings.Split(field.Tag.Get("yaml"), ",")[0]] = value
+		overlaidFields = append(overlaidFields, field.Name)
+	}
+	if len(overlaidFields) == 0 {
+		return configMap, nil
+	}
+
+	overlaidConfigMap := &v1.ConfigMap{}
+	if configMap != nil {
+		overlaidConfigMap = configMap.DeepCopy()
+	}
+	if overlaidConfigMap.Data == nil {
+		overlaidConfigMap.Data = make(map[string]string)
+	}
+	for key, value := range data {
+		overlaidConfigMap.Data[key] = value
+	}
+	return overlaidConfigMap, overlaidFields
+}
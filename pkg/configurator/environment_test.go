@@ -0,0 +1,96 @@
+package configurator
+
+import (
+	"os"
+	"reflect"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Test OSM config environment overlay", func() {
+	osmNamespace := "-test-osm-namespace-"
+	osmConfigMapName := "-test-osm-config-map-"
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: osmNamespace,
+			Name:      osmConfigMapName,
+		},
+		Data: map[string]string{
+			egressKey:               "false",
+			envoyAdminPortKey:       "15001",
+			envoyLogLevel:           "info",
+			prometheusScrapePathKey: "/stats",
+		},
+	}
+	environment := map[string]string{
+		"OSM_CONFIG_EGRESS":           "true",
+		"OSM_CONFIG_ENVOY_ADMIN_PORT": "15002",
+		"OSM_CONFIG_ENVOY_LOG_LEVEL":  "warn",
+	}
+
+	BeforeEach(func() {
+		for name, value := range environment {
+			Expect(os.Setenv(name, value)).To(Succeed())
+		}
+	})
+
+	AfterEach(func() {
+		for name := range environment {
+			Expect(os.Unsetenv(name)).To(Succeed())
+		}
+	})
+
+	It("ignores the environment variables unless the overlay is enabled", func() {
+		stop := make(chan struct{})
+		defer close(stop)
+		cfg := NewConfigurator(testclient.NewSimpleClientset(configMap), stop, osmNamespace, osmConfigMapName)
+
+		Expect(cfg.IsEgressEnabled()).To(BeFalse())
+		Expect(cfg.GetEnvoyAdminPort()).To(Equal(uint32(15001)))
+		Expect(cfg.GetEnvoyLogLevel()).To(Equal("info"))
+	})
+
+	It("overrides the ConfigMap with the environment variables", func() {
+		stop := make(chan struct{})
+		defer close(stop)
+		cfg := NewConfigurator(testclient.NewSimpleClientset(configMap), stop, osmNamespace, osmConfigMapName, WithEnvironmentOverlay())
+
+		Expect(cfg.IsEgressEnabled()).To(BeTrue())
+		Expect(cfg.GetEnvoyAdminPort()).To(Equal(uint32(15002)))
+		Expect(cfg.GetEnvoyLogLevel()).To(Equal("warn"))
+		Expect(cfg.GetPrometheusScrapePath()).To(Equal("/stats"))
+
+		provenance := cfg.GetConfigProvenance()
+		Expect(provenance["Egress"]).To(Equal(ProvenanceEnvironment))
+		Expect(provenance["EnvoyAdminPort"]).To(Equal(ProvenanceEnvironment))
+		Expect(provenance["EnvoyLogLevel"]).To(Equal(ProvenanceEnvironment))
+		Expect(provenance["PrometheusScrapePath"]).To(Equal(ProvenanceConfigMap))
+		Expect(provenance["EnvoyImage"]).To(Equal(ProvenanceDefault))
+	})
+
+	It("overrides the default config with the environment variables when the ConfigMap does not exist", func() {
+		stop := make(chan struct{})
+		defer close(stop)
+		cfg := NewConfigurator(testclient.NewSimpleClientset(), stop, osmNamespace, osmConfigMapName, WithEnvironmentOverlay())
+
+		Expect(cfg.IsConfigReady()).To(BeFalse())
+		Expect(cfg.IsEgressEnabled()).To(BeTrue())
+		Expect(cfg.GetEnvoyAdminPort()).To(Equal(uint32(15002)))
+		Expect(cfg.GetEnvoyLogLevel()).To(Equal("warn"))
+		Expect(cfg.GetPrometheusScrapePath()).To(Equal(mergeOverDefaultConfig(nil).PrometheusScrapePath))
+		Expect(cfg.GetConfigProvenance()["Egress"]).To(Equal(ProvenanceEnvironment))
+	})
+
+	It("maps every config field to an environment variable", func() {
+		configType := reflect.TypeOf(MeshConfig{})
+		Expect(environmentVariables).To(HaveLen(configType.NumField()))
+		for i := 0; i < configType.NumField(); i++ {
+			Expect(environmentVariables).To(HaveKey(configType.Field(i).Name))
+		}
+	})
+})
@@ -4,4 +4,48 @@ import "github.com/pkg/errors"
 
 var (
 	errMissingKeyInConfigMap = errors.New("missing key in ConfigMap")
+	errNoValidMeshCIDRRanges = errors.New("no valid mesh CIDR ranges in ConfigMap")
+	errConfigNotSynced       = errors.New("ConfigMap informer cache not synced")
+	errConfigMapNotFound     = errors.New("ConfigMap not found")
+	errConfigMapTooLarge     = errors.New("ConfigMap above the maximum size")
+	errInvalidLogLevel       = errors.New("invalid Envoy log level")
+	errInvalidPort           = errors.New("invalid port")
+	errInvalidCIDR           = errors.New("invalid CIDR")
+	errInvalidDuration       = errors.New("invalid duration")
+	errInvalidEnumValue      = errors.New("unsupported value")
+	errInvalidSamplingRate   = errors.New("tracing sampling rate not within [0, 1]")
+	errInvalidThreshold      = errors.New("overload manager threshold not within [0, 1]")
+	errNegativeValue         = errors.New("negative value")
+	errValueTooLarge         = errors.New("value above the maximum")
+	errInvalidPath           = errors.New("path not starting with /")
+	errInvalidValueType      = errors.New("value of the wrong type")
+	errInvalidConfigMap      = errors.New("invalid OSM ConfigMap")
+	errInvalidQuantity       = errors.New("invalid resource quantity")
+	errInvalidHost           = errors.New("invalid host")
+	errInvalidIPAddress      = errors.New("invalid IP address")
+	errInvalidBindAddress    = errors.New("invalid proxy bind address")
+	errInvalidDomain         = errors.New("invalid domain")
+	errInvalidNamespace      = errors.New("invalid namespace name")
+	errInvalidCABundleRef    = errors.New("invalid CA bundle reference")
+	errInvalidSecretKeyRef   = errors.New("invalid Secret key reference")
+	errInvalidImage          = errors.New("invalid image reference")
+	errInvalidYAML           = errors.New("invalid YAML fragment")
+	errInvalidStatsName      = errors.New("invalid Prometheus metric or label name")
+	errInvalidEnvVarName     = errors.New("invalid environment variable name")
+	errReservedEnvVarName    = errors.New("environment variable name reserved for OSM")
+	errInvalidConfigJSON     = errors.New("invalid OSM config JSON")
+	errSchemaViolation       = errors.New("OSM config does not match the schema")
+	errUnknownConfigField    = errors.New("unknown OSM config field")
+	errConfigMapNotWritable  = errors.New("OSM config not read from a writable ConfigMap")
+	errConfigUpdateTimeout   = errors.New("OSM config update not observed in time")
+	errConfigNotReloadable   = errors.New("OSM config not read from a Kubernetes ConfigMap")
+	errInvalidLabelValue     = errors.New("invalid label value")
+	errShutdownBeforeDrain   = errors.New("proxy parent shutdown time shorter than the drain time")
+	errInvertedTLSVersions   = errors.New("mesh TLS min version newer than the max version")
+	errInvalidWASMExtension  = errors.New("invalid WASM extension")
+	errInvertedLeaseTimings  = errors.New("leader election lease timings not in decreasing order")
+	errInvertedThresholds    = errors.New("overload manager shrink heap threshold above the stop accepting threshold")
+	errValidatorPanicked     = errors.New("OSM config validator panicked")
+	errMissingField          = errors.New("missing required field")
+	errClientClosed          = errors.New("OSM configurator closed")
 )
@@ -0,0 +1,143 @@
+package configurator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+// NewFileConfigurator implements configurator.Configurator with the OSM config read from the JSON file at the given
+// path, in place of the OSM ConfigMap, for local and development runs outside of Kubernetes. The file is an object
+// keyed like the data of the ConfigMap, such as {"egress": true, "tracing_port": 9411}; it is read again, and the
+// change announced, every time the process receives SIGHUP until the stop channel is closed.
+func NewFileConfigurator(path string, stop <-chan struct{}, options ...Option) Configurator {
+	return newFileConfigurator(path, stop, make(chan os.Signal, 1), options...)
+}
+
+// newFileConfigurator returns a Client serving the config of the file at the given path, which is reloaded
+// every time a signal is received on the reload channel
+func newFileConfigurator(path string, stop <-chan struct{}, reload chan os.Signal, options ...Option) *Client {
+	// The ConfigMap read from the file is named after the file, so the logs naming the ConfigMap name the file
+	client := newClient(filepath.Dir(path), filepath.Base(path))
+	client.cache = cache.NewStore(cache.MetaNamespaceKeyFunc)
+	stop = client.stopOnClose(stop)
+
+	for _, option := range options {
+		option(client)
+	}
+
+	resourceVersion := 1
+	if _, err := client.loadConfigFile(path, resourceVersion); err != nil {
+		log.Error().Err(err).Msgf("Error reading OSM config file %s; Using the default config until it is fixed", path)
+	}
+	client.setConfigFromConfigMap(client.getSeedConfigMap())
+	if !client.configExists.Load().(bool) {
+		log.Error().Err(errConfigMapNotFound).Msgf("OSM config file %s does not exist; Using the default config until it is created", path)
+	}
+	close(client.cacheSynced)
+
+	signal.Notify(reload, syscall.SIGHUP)
+	go client.dispatchAnnouncements(stop)
+	go func() {
+		defer signal.Stop(reload)
+		for {
+			select {
+			case <-stop:
+				return
+			case <-reload:
+				resourceVersion++
+				configMap, err := client.loadConfigFile(path, resourceVersion)
+				if err != nil {
+					log.Error().Err(err).Msgf("Error reloading OSM config file %s; Keeping the current config", path)
+					client.metrics.recordReload(err)
+					continue
+				}
+				log.Info().Msgf("Reloaded OSM config file %s", path)
+				select {
+				case client.configMapEvents <- k8s.Event{
+					Type:  k8s.UpdateEvent,
+					Value: configMap,
+				}:
+				case <-stop:
+					return
+				}
+			}
+		}
+	}()
+
+	return client
+}
+
+// loadConfigFile replaces the content of the cache with the ConfigMap read from the file at the given path, with the
+// given resourceVersion, and returns the ConfigMap; the cache is emptied when the file does not exist, and left untouched
+// when the file cannot be read
+func (c *Client) loadConfigFile(path string, resourceVersion int) (*v1.ConfigMap, error) {
+	configMap, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []interface{}
+	if configMap != nil {
+		configMap.ResourceVersion = strconv.Itoa(resourceVersion)
+		items = append(items, configMap)
+	}
+	return configMap, c.cache.Replace(items, strconv.Itoa(resourceVersion))
+}
+
+// readConfigFile returns the ConfigMap whose data is read from the JSON file at the given path, or nil when the
+// file does not exist. The nested objects, such as the retry policy, are stored as YAML like in the ConfigMap.
+func readConfigFile(path string) (*v1.ConfigMap, error) {
+	contents, err := ioutil.ReadFile(filepath.Clean(path))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(contents, &fields); err != nil {
+		return nil, errors.Wrapf(errInvalidConfigJSON, "%s: %s", path, err)
+	}
+
+	data := make(map[string]string, len(fields))
+	for key, value := range fields {
+		switch value := value.(type) {
+		case nil:
+			continue
+		case string:
+			data[key] = value
+		case bool:
+			data[key] = strconv.FormatBool(value)
+		case float64:
+			data[key] = strconv.FormatFloat(value, 'f', -1, 64)
+		default:
+			encoded, err := yaml.Marshal(value)
+			if err != nil {
+				return nil, errors.Wrapf(errInvalidConfigJSON, "%s: %s: %s", path, key, err)
+			}
+			data[key] = string(encoded)
+		}
+	}
+
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: filepath.Dir(path),
+			Name:      filepath.Base(path),
+		},
+		Data: data,
+	}, nil
+}
@@ -0,0 +1,108 @@
+package configurator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test OSM config file", func() {
+	Context("read the OSM config from a file and reload it", func() {
+		dir, err := ioutil.TempDir("", "osm-config")
+		if err != nil {
+			panic(err)
+		}
+		path := filepath.Join(dir, "osm-config.json")
+		writeConfigFile := func(contents string) {
+			Expect(ioutil.WriteFile(path, []byte(contents), 0600)).To(Succeed())
+		}
+		initialContents := `{"egress": true, "mesh_cidr_ranges": "10.0.0.0/16", "tracing_port": 9411, "retry_policy": {"num_retries": 3, "retry_on": "5xx"}}`
+		if err := ioutil.WriteFile(path, []byte(initialContents), 0600); err != nil {
+			panic(err)
+		}
+
+		stop := make(chan struct{})
+		reload := make(chan os.Signal, 1)
+		cfg := newFileConfigurator(path, stop, reload, WithAnnouncementDebounceWindow(0))
+		typedAnnouncements := cfg.GetTypedAnnouncementsChannel()
+
+		It("serves the config of the file right away", func() {
+			Expect(cfg.IsConfigReady()).To(BeTrue())
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(9411)))
+			Expect(cfg.GetDefaultRetryPolicy().NumRetries).To(Equal(uint32(3)))
+			Expect(cfg.GetConfigResourceVersion()).To(Equal("1"))
+		})
+
+		It("reloads the file and announces the change on SIGHUP", func() {
+			writeConfigFile(`{"egress": false, "tracing_port": 14268, "envoy_log_level": "debug"}`)
+			reload <- syscall.SIGHUP
+
+			<-cfg.GetAnnouncementsChannel()
+			event := <-typedAnnouncements
+
+			for _, field := range []string{"Egress", "TracingPort", "EnvoyLogLevel"} {
+				Expect(event.ChangedFields).To(ContainElement(field))
+			}
+			Expect(cfg.IsEgressEnabled()).To(BeFalse())
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(14268)))
+			Expect(cfg.GetEnvoyLogLevel()).To(Equal("debug"))
+			Expect(cfg.GetConfigResourceVersion()).To(Equal("2"))
+		})
+
+		It("keeps the current config when the file is malformed", func() {
+			writeConfigFile(`{"egress": true,`)
+			reload <- syscall.SIGHUP
+
+			Consistently(cfg.GetAnnouncementsChannel(), 100*time.Millisecond).ShouldNot(Receive())
+			Expect(cfg.IsEgressEnabled()).To(BeFalse())
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(14268)))
+		})
+
+		It("falls back on the default config when the file is removed", func() {
+			Expect(os.Remove(path)).To(Succeed())
+			reload <- syscall.SIGHUP
+
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsConfigReady()).To(BeFalse())
+			Expect(cfg.GetEnvoyLogLevel()).To(Equal(mergeOverDefaultConfig(nil).EnvoyLogLevel))
+
+			close(stop)
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+	})
+
+	Context("read the OSM config file", func() {
+		It("converts the values of the file into ConfigMap values", func() {
+			dir, err := ioutil.TempDir("", "osm-config")
+			Expect(err).ToNot(HaveOccurred())
+			defer func() {
+				Expect(os.RemoveAll(dir)).To(Succeed())
+			}()
+			path := filepath.Join(dir, "osm-config.json")
+			Expect(ioutil.WriteFile(path, []byte(`{"egress": true, "tracing_sampling_rate": 0.5, "tracing_address": null, "feature_flags": {"feature-a": true}}`), 0600)).To(Succeed())
+
+			configMap, err := readConfigFile(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(configMap.Namespace).To(Equal(dir))
+			Expect(configMap.Name).To(Equal("osm-config.json"))
+			Expect(configMap.Data).To(Equal(map[string]string{
+				egressKey:              "true",
+				tracingSamplingRateKey: "0.5",
+				featureFlagsKey:        "feature-a: true\n",
+			}))
+		})
+
+		It("returns no ConfigMap when the file does not exist", func() {
+			configMap, err := readConfigFile(filepath.Join(os.TempDir(), "-missing-osm-config-.json"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(configMap).To(BeNil())
+		})
+	})
+})
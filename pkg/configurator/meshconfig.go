@@ -0,0 +1,437 @@
+package configurator
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+	"github.com/openservicemesh/osm/pkg/osm_client/clientset/versioned"
+	osmInformers "github.com/openservicemesh/osm/pkg/osm_client/informers/externalversions"
+)
+
+// Option is a functional option of the configurator
+type Option func(*Client)
+
+// WithMeshConfig makes the configurator watch the MeshConfig custom resource with the given name
+// in the OSM namespace in addition to the OSM ConfigMap. When both exist, the MeshConfig takes precedence.
+func WithMeshConfig(meshConfigClient versioned.Interface, meshConfigName string) Option {
+	return func(c *Client) {
+		c.meshConfigClient = meshConfigClient
+		c.meshConfigName = meshConfigName
+	}
+}
+
+// WithDefaultServiceCertValidityDuration sets the validity duration of the service certificates used when the ConfigMap
+// does not set one, in place of constants.DefaultServiceCertValidityDuration
+func WithDefaultServiceCertValidityDuration(validityDuration time.Duration) Option {
+	return func(c *Client) {
+		c.defaultServiceCertValidityDuration = validityDuration
+	}
+}
+
+// WithDefaultEnvoyImage sets the image of the Envoy sidecars used when the ConfigMap does not set a valid one,
+// in place of constants.DefaultEnvoyImage
+func WithDefaultEnvoyImage(envoyImage string) Option {
+	return func(c *Client) {
+		c.defaultEnvoyImage = envoyImage
+	}
+}
+
+// WithDefaultInitContainerImage sets the image of the init containers used when the ConfigMap does not set a valid one,
+// in place of constants.DefaultInitContainerImage
+func WithDefaultInitContainerImage(initContainerImage string) Option {
+	return func(c *Client) {
+		c.defaultInitContainerImage = initContainerImage
+	}
+}
+
+func (c *Client) addMeshConfigInformer() {
+	informerFactory := osmInformers.NewSharedInformerFactoryWithOptions(c.meshConfigClient, k8s.DefaultKubeEventResyncInterval, osmInformers.WithNamespace(c.osmNamespace))
+	c.meshConfigInformer = informerFactory.Config().V1alpha1().MeshConfigs().Informer()
+	c.meshConfigCache = c.meshConfigInformer.GetStore()
+
+	// Ensure this exclusively watches only the particular MeshConfig we need.
+	shouldObserve := func(obj interface{}) bool {
+		ns := reflect.ValueOf(obj).Elem().FieldByName("ObjectMeta").FieldByName("Namespace").String()
+		name := reflect.ValueOf(obj).Elem().FieldByName("ObjectMeta").FieldByName("Name").String()
+		return ns == c.osmNamespace && name == c.meshConfigName
+	}
+
+	informerName := "MeshConfig"
+	providerName := "OSMMeshConfig"
+	c.meshConfigInformer.AddEventHandler(k8s.GetKubernetesEventHandlers(informerName, providerName, c.configMapEvents, shouldObserve))
+}
+
+func (c *Client) getMeshConfigCacheKey() string {
+	return fmt.Sprintf("%s/%s", c.osmNamespace, c.meshConfigName)
+}
+
+// getEffectiveConfigMap returns the ConfigMap the OSM config is parsed from, or nil when there is none.
+// When the MeshConfig is watched and exists, it is converted into the equivalent ConfigMap, which takes precedence over the OSM ConfigMap.
+func (c *Client) getEffectiveConfigMap() *v1.ConfigMap {
+	if c.meshConfigCache != nil {
+		if meshConfig := c.getMeshConfigFromInformerCache(); meshConfig != nil {
+			if _, exists, _ := c.cache.GetByKey(c.getConfigMapCacheKey()); exists {
+				log.Info().Msgf("Both MeshConfig %s and ConfigMap %s exist; Using MeshConfig %s", c.getMeshConfigCacheKey(), c.getConfigMapCacheKey(), c.getMeshConfigCacheKey())
+			}
+			return &v1.ConfigMap{
+				ObjectMeta: meshConfig.ObjectMeta,
+				Data:       getConfigMapDataFromMeshConfig(meshConfig.Spec),
+			}
+		}
+	}
+	return c.getConfigMapFromInformerCache()
+}
+
+// getMeshConfigFromInformerCache returns the MeshConfig from the informer cache, or nil when it does not exist
+func (c *Client) getMeshConfigFromInformerCache() *configv1alpha1.MeshConfig {
+	meshConfigCacheKey := c.getMeshConfigCacheKey()
+	item, exists, err := c.meshConfigCache.GetByKey(meshConfigCacheKey)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error getting MeshConfig from cache with key %s", meshConfigCacheKey)
+		return nil
+	}
+	if !exists {
+		return nil
+	}
+	return item.(*configv1alpha1.MeshConfig)
+}
+
+// getConfigMapDataFromMeshConfig converts the MeshConfig spec into the equivalent OSM ConfigMap data,
+// so that both config sources are parsed and validated the same way
+func getConfigMapDataFromMeshConfig(spec configv1alpha1.MeshConfigSpec) map[string]string {
+	data := map[string]string{
+		permissiveTrafficPolicyModeKey: strconv.FormatBool(spec.PermissiveTrafficPolicyMode),
+		egressKey:                      strconv.FormatBool(spec.Egress),
+		prometheusScrapingKey:          strconv.FormatBool(spec.PrometheusScraping),
+		useHTTPSIngressKey:             strconv.FormatBool(spec.UseHTTPSIngress),
+		envoyLogLevel:                  spec.EnvoyLogLevel,
+		outboundPortExclusionListKey:   joinPorts(spec.OutboundPortExclusionList),
+		inboundPortExclusionListKey:    joinPorts(spec.InboundPortExclusionList),
+		tracingEnableKey:               strconv.FormatBool(spec.Tracing.Enable),
+		tracingAddressKey:              spec.Tracing.Address,
+		tracingPortKey:                 strconv.Itoa(spec.Tracing.Port),
+		tracingEndpointKey:             spec.Tracing.Endpoint,
+		tracingBackendKey:              spec.Tracing.Backend,
+	}
+	if len(spec.MeshCIDRRanges) > 0 {
+		data[meshCIDRRangesKey] = strings.Join(spec.MeshCIDRRanges, " ")
+	}
+	if len(spec.EgressAllowedDomains) > 0 {
+		data[egressAllowedDomainsKey] = strings.Join(spec.EgressAllowedDomains, ",")
+	}
+	if spec.EgressMode != "" {
+		data[egressModeKey] = spec.EgressMode
+	}
+	if spec.DefaultIngressBackendProtocol != "" {
+		data[defaultIngressBackendProtocolKey] = spec.DefaultIngressBackendProtocol
+	}
+	if spec.EgressDNSResolution != "" {
+		data[egressDNSResolutionKey] = spec.EgressDNSResolution
+	}
+	if spec.EgressCABundle != "" {
+		data[egressCABundleKey] = spec.EgressCABundle
+	}
+	if spec.MaxDataPlaneConnections != 0 {
+		data[maxDataPlaneConnectionsKey] = strconv.Itoa(spec.MaxDataPlaneConnections)
+	}
+	if spec.MaxEndpointsPerCluster != 0 {
+		data[maxEndpointsPerClusterKey] = strconv.Itoa(spec.MaxEndpointsPerCluster)
+	}
+	if spec.LocalityAwareRouting {
+		data[localityAwareRoutingKey] = strconv.FormatBool(spec.LocalityAwareRouting)
+	}
+	if spec.LocalityRegion != "" {
+		data[localityRegionKey] = spec.LocalityRegion
+	}
+	if spec.LocalityZone != "" {
+		data[localityZoneKey] = spec.LocalityZone
+	}
+	if spec.EnableDebugServer {
+		data[enableDebugServerKey] = strconv.FormatBool(spec.EnableDebugServer)
+	}
+	if spec.PermissiveModeAuditLogging {
+		data[permissiveModeAuditLoggingKey] = strconv.FormatBool(spec.PermissiveModeAuditLogging)
+	}
+	if spec.StripForwardedHeaders {
+		data[stripForwardedHeadersKey] = strconv.FormatBool(spec.StripForwardedHeaders)
+	}
+	if spec.ServiceCertValidityDuration != "" {
+		data[serviceCertValidityDurationKey] = spec.ServiceCertValidityDuration
+	}
+	if spec.TrustDomain != "" {
+		data[trustDomainKey] = spec.TrustDomain
+	}
+	if spec.MeshTLSMinVersion != "" {
+		data[meshTLSMinVersionKey] = spec.MeshTLSMinVersion
+	}
+	if spec.MeshTLSMaxVersion != "" {
+		data[meshTLSMaxVersionKey] = spec.MeshTLSMaxVersion
+	}
+	if len(spec.MeshCipherSuites) > 0 {
+		data[meshCipherSuitesKey] = strings.Join(spec.MeshCipherSuites, ",")
+	}
+	if len(spec.MTLSExemptSourceCIDRs) > 0 {
+		data[mtlsExemptSourceCIDRsKey] = strings.Join(spec.MTLSExemptSourceCIDRs, " ")
+	}
+	if spec.CertificateProvider != "" {
+		data[certificateProviderKey] = spec.CertificateProvider
+	}
+	if spec.Vault != (configv1alpha1.VaultSpec{}) {
+		// Marshalling a struct of strings and an integer cannot fail
+		vaultConfig, _ := yaml.Marshal(VaultConfig{
+			Protocol:       spec.Vault.Protocol,
+			Host:           spec.Vault.Host,
+			Port:           spec.Vault.Port,
+			TokenSecretRef: spec.Vault.TokenSecretRef,
+			Role:           spec.Vault.Role,
+		})
+		data[vaultKey] = string(vaultConfig)
+	}
+	if spec.CertManager != (configv1alpha1.CertManagerSpec{}) {
+		// Marshalling a struct of strings cannot fail
+		certManagerConfig, _ := yaml.Marshal(CertManagerConfig{
+			IssuerName:  spec.CertManager.IssuerName,
+			IssuerKind:  spec.CertManager.IssuerKind,
+			IssuerGroup: spec.CertManager.IssuerGroup,
+		})
+		data[certManagerKey] = string(certManagerConfig)
+	}
+	if spec.PrometheusScrapePort != 0 {
+		data[prometheusScrapePortKey] = strconv.Itoa(spec.PrometheusScrapePort)
+	}
+	if spec.PrometheusScrapePath != "" {
+		data[prometheusScrapePathKey] = spec.PrometheusScrapePath
+	}
+	if spec.EnvoyLogFormat != "" {
+		data[envoyLogFormatKey] = spec.EnvoyLogFormat
+	}
+	if spec.EnableAccessLogging {
+		data[enableAccessLoggingKey] = strconv.FormatBool(spec.EnableAccessLogging)
+	}
+	if spec.AccessLogFormat != "" {
+		data[accessLogFormatKey] = spec.AccessLogFormat
+	}
+	if spec.EnvoyAdminPort != 0 {
+		data[envoyAdminPortKey] = strconv.Itoa(spec.EnvoyAdminPort)
+	}
+	if spec.EnvoyConnectionIdleTimeout != "" {
+		data[envoyConnectionIdleTimeoutKey] = spec.EnvoyConnectionIdleTimeout
+	}
+	if spec.EnvoyRequestTimeout != "" {
+		data[envoyRequestTimeoutKey] = spec.EnvoyRequestTimeout
+	}
+	if spec.EnvoyImage != "" {
+		data[envoyImageKey] = spec.EnvoyImage
+	}
+	if spec.InitContainerImage != "" {
+		data[initContainerImageKey] = spec.InitContainerImage
+	}
+	if spec.EnableSidecarInjection != nil {
+		data[enableSidecarInjectionKey] = strconv.FormatBool(*spec.EnableSidecarInjection)
+	}
+	if len(spec.ExcludedNamespaces) > 0 {
+		data[excludedNamespacesKey] = strings.Join(spec.ExcludedNamespaces, ",")
+	}
+	if spec.XDSServerResponseTimeout != "" {
+		data[xdsServerResponseTimeoutKey] = spec.XDSServerResponseTimeout
+	}
+	if spec.XDSKeepaliveTime != "" {
+		data[xdsKeepaliveTimeKey] = spec.XDSKeepaliveTime
+	}
+	if spec.XDSKeepaliveTimeout != "" {
+		data[xdsKeepaliveTimeoutKey] = spec.XDSKeepaliveTimeout
+	}
+	if len(spec.DisabledXDSTypes) > 0 {
+		data[disabledXDSTypesKey] = strings.Join(spec.DisabledXDSTypes, ",")
+	}
+	if spec.Tracing.SamplingRate != "" {
+		data[tracingSamplingRateKey] = spec.Tracing.SamplingRate
+	}
+	if spec.RetryPolicy != (configv1alpha1.RetryPolicySpec{}) {
+		// Marshalling a struct of strings and integers cannot fail
+		retryPolicy, _ := yaml.Marshal(RetryPolicy{
+			NumRetries:    spec.RetryPolicy.NumRetries,
+			PerTryTimeout: spec.RetryPolicy.PerTryTimeout,
+			RetryOn:       spec.RetryPolicy.RetryOn,
+		})
+		data[retryPolicyKey] = string(retryPolicy)
+	}
+	if spec.CircuitBreaking != (configv1alpha1.CircuitBreakingSpec{}) {
+		// Marshalling a struct of integers cannot fail
+		circuitBreaking, _ := yaml.Marshal(CircuitBreaking{
+			MaxConnections:     spec.CircuitBreaking.MaxConnections,
+			MaxPendingRequests: spec.CircuitBreaking.MaxPendingRequests,
+			MaxRequests:        spec.CircuitBreaking.MaxRequests,
+			MaxRetries:         spec.CircuitBreaking.MaxRetries,
+		})
+		data[circuitBreakingKey] = string(circuitBreaking)
+	}
+	if spec.SMISpecVersion != "" {
+		data[smiSpecVersionKey] = spec.SMISpecVersion
+	}
+	if spec.DefaultLBAlgorithm != "" {
+		data[defaultLBAlgorithmKey] = spec.DefaultLBAlgorithm
+	}
+	if spec.OutlierDetection != (configv1alpha1.OutlierDetectionSpec{}) {
+		// Marshalling a struct of strings, integers and bools cannot fail
+		outlierDetection, _ := yaml.Marshal(OutlierDetection{
+			Enable:             spec.OutlierDetection.Enable,
+			Consecutive5xx:     spec.OutlierDetection.Consecutive5xx,
+			Interval:           spec.OutlierDetection.Interval,
+			BaseEjectionTime:   spec.OutlierDetection.BaseEjectionTime,
+			MaxEjectionPercent: spec.OutlierDetection.MaxEjectionPercent,
+		})
+		data[outlierDetectionKey] = string(outlierDetection)
+	}
+	if spec.InboundExternalAuth != (configv1alpha1.InboundExternalAuthSpec{}) {
+		// Marshalling a struct of strings, integers and bools cannot fail
+		externalAuth, _ := yaml.Marshal(InboundExternalAuth{
+			Enable:           spec.InboundExternalAuth.Enable,
+			Address:          spec.InboundExternalAuth.Address,
+			Port:             spec.InboundExternalAuth.Port,
+			StatPrefix:       spec.InboundExternalAuth.StatPrefix,
+			Timeout:          spec.InboundExternalAuth.Timeout,
+			FailureModeAllow: spec.InboundExternalAuth.FailureModeAllow,
+		})
+		data[inboundExternalAuthKey] = string(externalAuth)
+	}
+	if spec.MulticlusterEnabled {
+		data[multiclusterEnabledKey] = strconv.FormatBool(spec.MulticlusterEnabled)
+	}
+	if spec.MulticlusterGateway != (configv1alpha1.MulticlusterGatewaySpec{}) {
+		// Marshalling a struct of a string and an integer cannot fail
+		gateway, _ := yaml.Marshal(MulticlusterGateway{
+			Host: spec.MulticlusterGateway.Host,
+			Port: spec.MulticlusterGateway.Port,
+		})
+		data[multiclusterGatewayKey] = string(gateway)
+	}
+	if spec.LeaderElection != (configv1alpha1.LeaderElectionSpec{}) {
+		// Marshalling a struct of strings cannot fail
+		leaderElection, _ := yaml.Marshal(LeaderElection{
+			LeaseDuration: spec.LeaderElection.LeaseDuration,
+			RenewDeadline: spec.LeaderElection.RenewDeadline,
+			RetryPeriod:   spec.LeaderElection.RetryPeriod,
+		})
+		data[leaderElectionKey] = string(leaderElection)
+	}
+	if spec.ProxyProbe != (configv1alpha1.ProbeSpec{}) {
+		// Marshalling a struct of integers cannot fail
+		proxyProbe, _ := yaml.Marshal(ProbeSpec{
+			InitialDelaySeconds: spec.ProxyProbe.InitialDelaySeconds,
+			PeriodSeconds:       spec.ProxyProbe.PeriodSeconds,
+			TimeoutSeconds:      spec.ProxyProbe.TimeoutSeconds,
+			FailureThreshold:    spec.ProxyProbe.FailureThreshold,
+		})
+		data[proxyProbeKey] = string(proxyProbe)
+	}
+	if spec.ProxyBootstrapConfigOverride != "" {
+		data[proxyBootstrapOverrideKey] = spec.ProxyBootstrapConfigOverride
+	}
+	if spec.ProxyBindAddress != "" {
+		data[proxyBindAddressKey] = spec.ProxyBindAddress
+	}
+	if spec.EnvoyConcurrency != 0 {
+		data[envoyConcurrencyKey] = strconv.Itoa(spec.EnvoyConcurrency)
+	}
+	if len(spec.ProxyEnvVars) > 0 {
+		// Marshalling a map of strings to strings cannot fail
+		proxyEnvVars, _ := yaml.Marshal(spec.ProxyEnvVars)
+		data[proxyEnvVarsKey] = string(proxyEnvVars)
+	}
+	if spec.ProxyDrainTime != "" {
+		data[proxyDrainTimeKey] = spec.ProxyDrainTime
+	}
+	if spec.ProxyParentShutdownTime != "" {
+		data[proxyParentShutdownTimeKey] = spec.ProxyParentShutdownTime
+	}
+	if spec.OverloadManager != (configv1alpha1.OverloadManagerSpec{}) {
+		// Marshalling a struct of numbers cannot fail
+		overloadManager, _ := yaml.Marshal(OverloadManager{
+			MaxHeapBytes:           spec.OverloadManager.MaxHeapBytes,
+			ShrinkHeapThreshold:    parseThreshold(spec.OverloadManager.ShrinkHeapThreshold),
+			StopAcceptingThreshold: parseThreshold(spec.OverloadManager.StopAcceptingThreshold),
+		})
+		data[overloadManagerKey] = string(overloadManager)
+	}
+	if spec.SidecarResources != (configv1alpha1.SidecarResourcesSpec{}) {
+		// Marshalling a struct of strings cannot fail
+		sidecarResources, _ := yaml.Marshal(SidecarResources{
+			CPURequest:    spec.SidecarResources.CPURequest,
+			CPULimit:      spec.SidecarResources.CPULimit,
+			MemoryRequest: spec.SidecarResources.MemoryRequest,
+			MemoryLimit:   spec.SidecarResources.MemoryLimit,
+		})
+		data[sidecarResourcesKey] = string(sidecarResources)
+	}
+	if spec.StatsPrefix != "" {
+		data[statsPrefixKey] = spec.StatsPrefix
+	}
+	if len(spec.StatsTags) > 0 {
+		// Marshalling a map of strings to strings cannot fail
+		statsTags, _ := yaml.Marshal(spec.StatsTags)
+		data[statsTagsKey] = string(statsTags)
+	}
+	if spec.StatsSink != (configv1alpha1.StatsSinkSpec{}) {
+		// Marshalling a struct of strings and an integer cannot fail
+		statsSink, _ := yaml.Marshal(StatsSink{
+			Type:    spec.StatsSink.Type,
+			Address: spec.StatsSink.Address,
+			Port:    spec.StatsSink.Port,
+			Prefix:  spec.StatsSink.Prefix,
+		})
+		data[statsSinkKey] = string(statsSink)
+	}
+	if len(spec.WASMExtensions) > 0 {
+		wasmExtensions := make([]WASMExtensionSpec, 0, len(spec.WASMExtensions))
+		for _, wasmExtension := range spec.WASMExtensions {
+			wasmExtensions = append(wasmExtensions, WASMExtensionSpec{
+				Name:           wasmExtension.Name,
+				URI:            wasmExtension.URI,
+				RootID:         wasmExtension.RootID,
+				Config:         wasmExtension.Config,
+				InsertionPoint: wasmExtension.InsertionPoint,
+			})
+		}
+		// Marshalling a list of structs of strings cannot fail
+		wasmExtensionsYAML, _ := yaml.Marshal(wasmExtensions)
+		data[wasmExtensionsKey] = string(wasmExtensionsYAML)
+	}
+	if len(spec.FeatureFlags) > 0 {
+		// Marshalling a map of strings to booleans cannot fail
+		featureFlags, _ := yaml.Marshal(spec.FeatureFlags)
+		data[featureFlagsKey] = string(featureFlags)
+	}
+	return data
+}
+
+// parseThreshold returns the given decimal threshold parsed, 0 when it is empty, or NaN when it is malformed, which the
+// validation of the overload manager reports
+func parseThreshold(threshold string) float64 {
+	if threshold == "" {
+		return 0
+	}
+	value, err := strconv.ParseFloat(threshold, 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return value
+}
+
+// joinPorts returns the given ports as a comma separated list
+func joinPorts(ports []int) string {
+	portStrs := make([]string, 0, len(ports))
+	for _, port := range ports {
+		portStrs = append(portStrs, strconv.Itoa(port))
+	}
+	return strings.Join(portStrs, ",")
+}
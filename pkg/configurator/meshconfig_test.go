@@ -0,0 +1,366 @@
+package configurator
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/constants"
+	fakeOSMClient "github.com/openservicemesh/osm/pkg/osm_client/clientset/versioned/fake"
+)
+
+var _ = Describe("Test OSM MeshConfig", func() {
+	Context("create OSM config from the MeshConfig and the ConfigMap", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		osmClient := fakeOSMClient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		meshConfigName := "-test-osm-mesh-config-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, WithMeshConfig(osmClient, meshConfigName))
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				permissiveTrafficPolicyModeKey: "true",
+				egressKey:                      "false",
+			},
+		}
+		meshConfig := configv1alpha1.MeshConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      meshConfigName,
+			},
+			Spec: configv1alpha1.MeshConfigSpec{
+				Egress:         true,
+				MeshCIDRRanges: []string{"10.0.0.0/16"},
+			},
+		}
+
+		It("uses the ConfigMap when the MeshConfig does not exist", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsPermissiveTrafficPolicyMode()).To(BeTrue())
+			Expect(cfg.IsEgressEnabled()).To(BeFalse())
+		})
+
+		It("uses the MeshConfig when both the MeshConfig and the ConfigMap exist", func() {
+			_, err := osmClient.ConfigV1alpha1().MeshConfigs(osmNamespace).Create(&meshConfig)
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsPermissiveTrafficPolicyMode()).To(BeFalse())
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+			Expect(cfg.GetMeshCIDRRanges()).To(Equal([]string{"10.0.0.0/16"}))
+		})
+
+		It("ignores ConfigMap updates while the MeshConfig exists", func() {
+			configMap.Data[envoyLogLevel] = "warning"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// The config in effect is unchanged, so the update is not announced
+			Consistently(cfg.GetAnnouncementsChannel(), 500*time.Millisecond).ShouldNot(Receive())
+
+			Expect(cfg.GetEnvoyLogLevel()).To(Equal(constants.DefaultEnvoyLogLevel))
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+		})
+
+		It("falls back to the ConfigMap once the MeshConfig is deleted", func() {
+			err := osmClient.ConfigV1alpha1().MeshConfigs(osmNamespace).Delete(meshConfigName, &metav1.DeleteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsPermissiveTrafficPolicyMode()).To(BeTrue())
+			Expect(cfg.IsEgressEnabled()).To(BeFalse())
+			Expect(cfg.GetEnvoyLogLevel()).To(Equal("warning"))
+		})
+	})
+
+	Context("convert the MeshConfig spec into the OSM ConfigMap data", func() {
+		It("parses the converted spec into the equivalent config", func() {
+			samplingRate := 0.5
+			enableSidecarInjection := false
+			spec := configv1alpha1.MeshConfigSpec{
+				PermissiveTrafficPolicyMode: true,
+				PermissiveModeAuditLogging:  true,
+				SMISpecVersion:              SMISpecVersionV1alpha4,
+				Egress:                      true,
+				EgressMode:                  EgressModePolicy,
+				PrometheusScraping:          true,
+				PrometheusScrapePort:        9090,
+				PrometheusScrapePath:        "/metrics",
+				UseHTTPSIngress:             true,
+				StripForwardedHeaders:       true,
+				EnvoyLogLevel:               "info",
+				EnvoyLogFormat:              EnvoyLogFormatJSON,
+				MeshCIDRRanges:              []string{"10.0.0.0/16", "fd00::/64"},
+				EgressAllowedDomains:        []string{"api.stripe.com", "*.example.com"},
+				EgressDNSResolution:         EgressDNSResolutionStrictDNS,
+				EgressCABundle:              "osm-system/egress-ca-bundle/ca.pem",
+				OutboundPortExclusionList:   []int{6379, 3306},
+				InboundPortExclusionList:    []int{9091},
+				LocalityAwareRouting:        true,
+				LocalityRegion:              "westus2",
+				LocalityZone:                "westus2-1",
+				EnableAccessLogging:         true,
+				AccessLogFormat:             AccessLogFormatJSON,
+				EnvoyAdminPort:              15100,
+				EnvoyConnectionIdleTimeout:  "1h",
+				EnvoyRequestTimeout:         "0s",
+				XDSServerResponseTimeout:    "1m",
+				XDSKeepaliveTime:            "5m",
+				XDSKeepaliveTimeout:         "10s",
+				DisabledXDSTypes:            []string{"RDS", "SDS"},
+				ServiceCertValidityDuration: "12h",
+				TrustDomain:                 "mesh.example.com",
+				MeshTLSMinVersion:           "TLS1_2",
+				MeshTLSMaxVersion:           "TLS1_3",
+				MeshCipherSuites:            []string{"ECDHE-ECDSA-AES128-GCM-SHA256", "ECDHE-RSA-AES128-GCM-SHA256"},
+				MTLSExemptSourceCIDRs:       []string{"10.240.0.0/16", "fd00:240::/64"},
+				MaxDataPlaneConnections:     1000,
+				MaxEndpointsPerCluster:      500,
+				EnableDebugServer:           true,
+				RetryPolicy: configv1alpha1.RetryPolicySpec{
+					NumRetries:    3,
+					PerTryTimeout: "1s",
+					RetryOn:       "5xx",
+				},
+				CircuitBreaking: configv1alpha1.CircuitBreakingSpec{
+					MaxConnections: 100,
+					MaxRetries:     5,
+				},
+				DefaultLBAlgorithm: LBAlgorithmLeastRequest,
+				OutlierDetection: configv1alpha1.OutlierDetectionSpec{
+					Enable:           true,
+					Consecutive5xx:   3,
+					BaseEjectionTime: "1m",
+				},
+				InboundExternalAuth: configv1alpha1.InboundExternalAuthSpec{
+					Enable:           true,
+					Address:          "opa.osm-system.svc.cluster.local",
+					Port:             9191,
+					FailureModeAllow: true,
+				},
+				MulticlusterEnabled: true,
+				MulticlusterGateway: configv1alpha1.MulticlusterGatewaySpec{
+					Host: "osm-multicluster-gateway.osm-system.svc.cluster.local",
+					Port: 14080,
+				},
+				SidecarResources: configv1alpha1.SidecarResourcesSpec{
+					CPURequest:  "250m",
+					MemoryLimit: "512Mi",
+				},
+				LeaderElection: configv1alpha1.LeaderElectionSpec{
+					LeaseDuration: "30s",
+					RetryPeriod:   "5s",
+				},
+				EnvoyImage:             "registry.example.com/envoyproxy/envoy-alpine:v1.15.0",
+				InitContainerImage:     "registry.example.com/openservicemesh/init:v0.3.0",
+				EnableSidecarInjection: &enableSidecarInjection,
+				ExcludedNamespaces:     []string{"kube-system", "monitoring"},
+				ProxyProbe: configv1alpha1.ProbeSpec{
+					InitialDelaySeconds: 5,
+					FailureThreshold:    10,
+				},
+				ProxyBootstrapConfigOverride: "stats_flush_interval: 10s",
+				ProxyBindAddress:             "pod-ip",
+				EnvoyConcurrency:             2,
+				ProxyEnvVars:                 map[string]string{"VENDOR_FEATURE_FLAGS": "tls-inspector"},
+				ProxyDrainTime:               "30s",
+				ProxyParentShutdownTime:      "45s",
+				Tracing: configv1alpha1.TracingSpec{
+					Enable:       true,
+					Address:      "jaeger.osm-system.svc.cluster.local",
+					Port:         9411,
+					Endpoint:     "/api/v2/spans",
+					SamplingRate: "0.5",
+					Backend:      TracingBackendJaeger,
+				},
+				StatsPrefix:  "osm",
+				StatsTags:    map[string]string{"mesh": "osm", "region": "westus"},
+				FeatureFlags: map[string]bool{"feature-a": true, "feature-b": false},
+				WASMExtensions: []configv1alpha1.WASMExtensionSpec{{
+					Name:   "headers",
+					URI:    "file:///etc/envoy/wasm/headers.wasm",
+					RootID: "add_header",
+					Config: `{"header": "x-mesh"}`,
+				}},
+				StatsSink: configv1alpha1.StatsSinkSpec{
+					Type:    StatsSinkTypeDogStatsd,
+					Address: "10.0.0.10",
+					Port:    8125,
+					Prefix:  "osm",
+				},
+				OverloadManager: configv1alpha1.OverloadManagerSpec{
+					MaxHeapBytes:        1 << 30,
+					ShrinkHeapThreshold: "0.9",
+				},
+				DefaultIngressBackendProtocol: IngressBackendProtocolHTTPS,
+				CertificateProvider:           CertificateProviderVault,
+				Vault: configv1alpha1.VaultSpec{
+					Protocol:       "https",
+					Host:           "vault.osm-system.svc.cluster.local",
+					TokenSecretRef: "osm-system/osm-vault-token/token",
+				},
+				CertManager: configv1alpha1.CertManagerSpec{
+					IssuerName: "osm-ca",
+					IssuerKind: "ClusterIssuer",
+				},
+			}
+
+			actual := parseOSMConfigMap(&v1.ConfigMap{Data: getConfigMapDataFromMeshConfig(spec)})
+			Expect(*actual).To(Equal(MeshConfig{
+				PermissiveTrafficPolicyMode: true,
+				PermissiveModeAuditLogging:  true,
+				SMISpecVersion:              SMISpecVersionV1alpha4,
+				Egress:                      true,
+				EgressMode:                  EgressModePolicy,
+				PrometheusScraping:          true,
+				PrometheusScrapePort:        9090,
+				PrometheusScrapePath:        "/metrics",
+				UseHTTPSIngress:             true,
+				StripForwardedHeaders:       true,
+				TracingEnable:               true,
+				TracingAddress:              "jaeger.osm-system.svc.cluster.local",
+				TracingPort:                 9411,
+				TracingEndpoint:             "/api/v2/spans",
+				TracingSamplingRate:         &samplingRate,
+				TracingBackend:              TracingBackendJaeger,
+				MeshCIDRRanges:              "10.0.0.0/16 fd00::/64",
+				EgressAllowedDomains:        "api.stripe.com,*.example.com",
+				EgressDNSResolution:         EgressDNSResolutionStrictDNS,
+				EgressCABundle:              "osm-system/egress-ca-bundle/ca.pem",
+				EnvoyLogLevel:               "info",
+				EnvoyLogFormat:              EnvoyLogFormatJSON,
+				EnableAccessLogging:         true,
+				AccessLogFormat:             AccessLogFormatJSON,
+				EnvoyAdminPort:              15100,
+				EnvoyConnectionIdleTimeout:  "1h",
+				EnvoyRequestTimeout:         "0s",
+				XDSServerResponseTimeout:    "1m",
+				XDSKeepaliveTime:            "5m",
+				XDSKeepaliveTimeout:         "10s",
+				DisabledXDSTypes:            "RDS,SDS",
+				ServiceCertValidityDuration: "12h",
+				TrustDomain:                 "mesh.example.com",
+				MeshTLSMinVersion:           "TLS1_2",
+				MeshTLSMaxVersion:           "TLS1_3",
+				MeshCipherSuites:            "ECDHE-ECDSA-AES128-GCM-SHA256,ECDHE-RSA-AES128-GCM-SHA256",
+				MTLSExemptSourceCIDRs:       "10.240.0.0/16 fd00:240::/64",
+				MaxDataPlaneConnections:     1000,
+				MaxEndpointsPerCluster:      500,
+				EnableDebugServer:           true,
+				RetryPolicy: RetryPolicy{
+					NumRetries:    3,
+					PerTryTimeout: "1s",
+					RetryOn:       "5xx",
+				},
+				CircuitBreaking: CircuitBreaking{
+					MaxConnections: 100,
+					MaxRetries:     5,
+				},
+				DefaultLBAlgorithm: LBAlgorithmLeastRequest,
+				OutlierDetection: OutlierDetection{
+					Enable:           true,
+					Consecutive5xx:   3,
+					BaseEjectionTime: "1m",
+				},
+				InboundExternalAuth: InboundExternalAuth{
+					Enable:           true,
+					Address:          "opa.osm-system.svc.cluster.local",
+					Port:             9191,
+					FailureModeAllow: true,
+				},
+				MulticlusterEnabled: true,
+				MulticlusterGateway: MulticlusterGateway{
+					Host: "osm-multicluster-gateway.osm-system.svc.cluster.local",
+					Port: 14080,
+				},
+				SidecarResources: SidecarResources{
+					CPURequest:  "250m",
+					MemoryLimit: "512Mi",
+				},
+				LeaderElection: LeaderElection{
+					LeaseDuration: "30s",
+					RetryPeriod:   "5s",
+				},
+				EnvoyImage:         "registry.example.com/envoyproxy/envoy-alpine:v1.15.0",
+				InitContainerImage: "registry.example.com/openservicemesh/init:v0.3.0",
+				ExcludedNamespaces: "kube-system,monitoring",
+				ProxyProbe: ProbeSpec{
+					InitialDelaySeconds: 5,
+					FailureThreshold:    10,
+				},
+				ProxyBootstrapConfigOverride: "stats_flush_interval: 10s",
+				ProxyBindAddress:             "pod-ip",
+				EnvoyConcurrency:             2,
+				ProxyEnvVars:                 map[string]string{"VENDOR_FEATURE_FLAGS": "tls-inspector"},
+				ProxyDrainTime:               "30s",
+				ProxyParentShutdownTime:      "45s",
+				OutboundPortExclusionList:    "6379,3306",
+				InboundPortExclusionList:     "9091",
+				LocalityAwareRouting:         true,
+				LocalityRegion:               "westus2",
+				LocalityZone:                 "westus2-1",
+				StatsPrefix:                  "osm",
+				StatsTags:                    map[string]string{"mesh": "osm", "region": "westus"},
+				FeatureFlags:                 map[string]bool{"feature-a": true, "feature-b": false},
+				WASMExtensions: []WASMExtensionSpec{{
+					Name:   "headers",
+					URI:    "file:///etc/envoy/wasm/headers.wasm",
+					RootID: "add_header",
+					Config: `{"header": "x-mesh"}`,
+				}},
+				StatsSink: StatsSink{
+					Type:    StatsSinkTypeDogStatsd,
+					Address: "10.0.0.10",
+					Port:    8125,
+					Prefix:  "osm",
+				},
+				OverloadManager: OverloadManager{
+					MaxHeapBytes:        1 << 30,
+					ShrinkHeapThreshold: 0.9,
+				},
+				DefaultIngressBackendProtocol: IngressBackendProtocolHTTPS,
+				CertificateProvider:           CertificateProviderVault,
+				Vault: VaultConfig{
+					Protocol:       "https",
+					Host:           "vault.osm-system.svc.cluster.local",
+					TokenSecretRef: "osm-system/osm-vault-token/token",
+				},
+				CertManager: CertManagerConfig{
+					IssuerName: "osm-ca",
+					IssuerKind: "ClusterIssuer",
+				},
+			}))
+		})
+
+		It("keeps the sidecar injection enabled unless the spec disables it", func() {
+			Expect(mergeOverDefaultConfig(&v1.ConfigMap{Data: getConfigMapDataFromMeshConfig(configv1alpha1.MeshConfigSpec{})}).EnableSidecarInjection).To(BeTrue())
+
+			enableSidecarInjection := false
+			spec := configv1alpha1.MeshConfigSpec{EnableSidecarInjection: &enableSidecarInjection}
+			Expect(mergeOverDefaultConfig(&v1.ConfigMap{Data: getConfigMapDataFromMeshConfig(spec)}).EnableSidecarInjection).To(BeFalse())
+		})
+
+		It("leaves the optional fields unset for an empty spec", func() {
+			actual := parseOSMConfigMap(&v1.ConfigMap{Data: getConfigMapDataFromMeshConfig(configv1alpha1.MeshConfigSpec{})})
+			Expect(*actual).To(Equal(MeshConfig{}))
+		})
+	})
+})
@@ -1,36 +1,449 @@
 package configurator
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"net"
+	"net/url"
+	"path"
+	"reflect"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/openservicemesh/osm/pkg/constants"
 )
 
 // The functions in this file implement the configurator.Configurator interface
 
+// validTracingBackends is the set of supported tracing collector types
+var validTracingBackends = map[string]interface{}{
+	TracingBackendZipkin: nil,
+	TracingBackendJaeger: nil,
+	TracingBackendOTLP:   nil,
+}
+
+// validEgressModes is the set of supported egress modes
+var validEgressModes = map[string]interface{}{
+	EgressModeDisabled: nil,
+	EgressModeGlobal:   nil,
+	EgressModePolicy:   nil,
+}
+
+// validCertificateProviders is the set of supported certificate providers
+var validCertificateProviders = map[string]interface{}{
+	CertificateProviderTresor:      nil,
+	CertificateProviderVault:       nil,
+	CertificateProviderCertManager: nil,
+}
+
+// validVaultProtocols is the set of supported protocols of the Vault API
+var validVaultProtocols = map[string]interface{}{
+	"http":  nil,
+	"https": nil,
+}
+
+// validIngressBackendProtocols is the set of supported protocols of the traffic from ingress to backend pods
+var validIngressBackendProtocols = map[string]interface{}{
+	IngressBackendProtocolHTTP:  nil,
+	IngressBackendProtocolHTTPS: nil,
+}
+
+// validEgressDNSResolutions is the set of supported egress DNS resolutions
+var validEgressDNSResolutions = map[string]interface{}{
+	EgressDNSResolutionStrictDNS:  nil,
+	EgressDNSResolutionLogicalDNS: nil,
+	EgressDNSResolutionStatic:     nil,
+}
+
+// validLBAlgorithms is the set of supported load balancing algorithms, named after Envoy's LB policies
+var validLBAlgorithms = map[string]interface{}{
+	LBAlgorithmRoundRobin:   nil,
+	LBAlgorithmLeastRequest: nil,
+	LBAlgorithmRingHash:     nil,
+	LBAlgorithmRandom:       nil,
+	LBAlgorithmMaglev:       nil,
+}
+
+// validSMISpecVersions is the set of the versions of the SMI APIs the SMI client implements
+var validSMISpecVersions = map[string]interface{}{
+	SMISpecVersionV1alpha3: nil,
+	SMISpecVersionV1alpha4: nil,
+}
+
+// validXDSTypes is the set of the short names of the xDS resource types sent to the proxies
+var validXDSTypes = map[string]interface{}{
+	"CDS": nil,
+	"EDS": nil,
+	"LDS": nil,
+	"RDS": nil,
+	"SDS": nil,
+}
+
+// validTLSVersions is the set of the TLS protocol versions the mesh-internal connections can be restricted to. Their
+// names sort from the oldest version to the newest, so they are ordered by comparing them as strings.
+var validTLSVersions = map[string]interface{}{
+	"TLS1_0": nil,
+	"TLS1_1": nil,
+	"TLS1_2": nil,
+	"TLS1_3": nil,
+}
+
+// validCipherSuites is the set of the TLS cipher suites, by their BoringSSL names, Envoy supports
+var validCipherSuites = map[string]interface{}{
+	"ECDHE-ECDSA-AES128-GCM-SHA256": nil,
+	"ECDHE-RSA-AES128-GCM-SHA256":   nil,
+	"ECDHE-ECDSA-AES256-GCM-SHA384": nil,
+	"ECDHE-RSA-AES256-GCM-SHA384":   nil,
+	"ECDHE-ECDSA-CHACHA20-POLY1305": nil,
+	"ECDHE-RSA-CHACHA20-POLY1305":   nil,
+	"ECDHE-PSK-CHACHA20-POLY1305":   nil,
+	"ECDHE-ECDSA-AES128-SHA":        nil,
+	"ECDHE-RSA-AES128-SHA":          nil,
+	"ECDHE-PSK-AES128-CBC-SHA":      nil,
+	"ECDHE-ECDSA-AES256-SHA":        nil,
+	"ECDHE-RSA-AES256-SHA":          nil,
+	"ECDHE-PSK-AES256-CBC-SHA":      nil,
+	"AES128-GCM-SHA256":             nil,
+	"AES256-GCM-SHA384":             nil,
+	"AES128-SHA":                    nil,
+	"PSK-AES128-CBC-SHA":            nil,
+	"AES256-SHA":                    nil,
+	"PSK-AES256-CBC-SHA":            nil,
+	"DES-CBC3-SHA":                  nil,
+}
+
+// validWASMInsertionPoints is the set of the requests the WebAssembly extensions can run on
+var validWASMInsertionPoints = map[string]interface{}{
+	WASMInsertionPointInbound:  nil,
+	WASMInsertionPointOutbound: nil,
+}
+
+// validAccessLogFormats is the set of supported Envoy access log formats
+var validAccessLogFormats = map[string]interface{}{
+	AccessLogFormatText: nil,
+	AccessLogFormatJSON: nil,
+}
+
+// validStatsSinkTypes is the set of supported stats sink types
+var validStatsSinkTypes = map[string]interface{}{
+	StatsSinkTypeStatsd:    nil,
+	StatsSinkTypeDogStatsd: nil,
+}
+
+// reservedProxyEnvVars is the set of environment variables the injector sets on the Envoy sidecars itself
+var reservedProxyEnvVars = map[string]interface{}{
+	"POD_IP": nil,
+}
+
+// validEnvoyLogFormats is the set of supported formats of the logs of the Envoy processes
+var validEnvoyLogFormats = map[string]interface{}{
+	EnvoyLogFormatText: nil,
+	EnvoyLogFormatJSON: nil,
+}
+
+// validEnvoyLogLevels is the set of log levels accepted by Envoy's --log-level flag
+var validEnvoyLogLevels = map[string]interface{}{
+	"trace":    nil,
+	"debug":    nil,
+	"info":     nil,
+	"warning":  nil,
+	"error":    nil,
+	"critical": nil,
+	"off":      nil,
+}
+
+// validRetryOnConditions is the set of retry conditions accepted by Envoy's x-envoy-retry-on and x-envoy-retry-grpc-on headers
+var validRetryOnConditions = map[string]interface{}{
+	"5xx":                    nil,
+	"gateway-error":          nil,
+	"reset":                  nil,
+	"connect-failure":        nil,
+	"retriable-4xx":          nil,
+	"refused-stream":         nil,
+	"retriable-status-codes": nil,
+	"retriable-headers":      nil,
+	"cancelled":              nil,
+	"deadline-exceeded":      nil,
+	"internal":               nil,
+	"resource-exhausted":     nil,
+	"unavailable":            nil,
+}
+
 // GetOSMNamespace returns the namespace in which the OSM controller pod resides.
 func (c *Client) GetOSMNamespace() string {
 	return c.osmNamespace
 }
 
-func marshalConfigToJSON(config *osmConfig) ([]byte, error) {
+// GetConfigMapName returns the name of the OSM ConfigMap.
+func (c *Client) GetConfigMapName() string {
+	return c.osmConfigMapName
+}
+
+// GetConfigMapNamespace returns the namespace of the OSM ConfigMap.
+func (c *Client) GetConfigMapNamespace() string {
+	return c.osmNamespace
+}
+
+func marshalConfigToJSON(config *MeshConfig) ([]byte, error) {
 	return json.MarshalIndent(config, "", "    ")
 }
 
-// GetConfigMap returns the ConfigMap in pretty JSON.
+// GetConfigMap returns the ConfigMap in pretty JSON, including the values of the fields tagged osm:"sensitive"; the
+// other paths serializing the config mask them.
 func (c *Client) GetConfigMap() ([]byte, error) {
 	cm, err := marshalConfigToJSON(c.getConfigMap())
 	if err != nil {
-		log.Error().Err(err).Msgf("Error marshaling ConfigMap %s: %+v", c.getConfigMapCacheKey(), c.getConfigMap())
+		log.Error().Err(err).Msgf("Error marshaling ConfigMap %s", c.getConfigMapCacheKey())
+		return nil, err
+	}
+	return cm, nil
+}
+
+// GetConfigMapYAML returns the ConfigMap in YAML, keyed like the data of the ConfigMap, so it can be compared with the
+// Helm values of the mesh; the fields tagged osm:"sensitive" are masked like GetRedactedConfigMap masks them.
+func (c *Client) GetConfigMapYAML() ([]byte, error) {
+	config := c.getConfigMap().redactedCopy()
+	cm, err := yaml.Marshal(&config)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshaling ConfigMap %s to YAML", c.getConfigMapCacheKey())
 		return nil, err
 	}
 	return cm, nil
 }
 
+// WriteConfigMap writes the ConfigMap in pretty JSON to the given writer, such as an HTTP response, as
+// GetRedactedConfigMap returns it followed by a newline, without holding a copy of the whole JSON for the caller.
+func (c *Client) WriteConfigMap(w io.Writer) error {
+	config := c.getConfigMap().redactedCopy()
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "    ")
+	if err := encoder.Encode(&config); err != nil {
+		log.Error().Err(err).Msgf("Error writing ConfigMap %s", c.getConfigMapCacheKey())
+		return err
+	}
+	return nil
+}
+
+// GetRedactedConfigMap returns the ConfigMap in pretty JSON, with the values of the fields tagged osm:"sensitive" masked,
+// so it can be exposed on the debug server or logged.
+func (c *Client) GetRedactedConfigMap() ([]byte, error) {
+	config := c.getConfigMap().redactedCopy()
+	cm, err := marshalConfigToJSON(&config)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshaling redacted ConfigMap %s", c.getConfigMapCacheKey())
+		return nil, err
+	}
+	return cm, nil
+}
+
+// GetConfigHash returns the SHA256 hex digest of the effective config, merged over the default config, so controllers
+// can be compared and config drift detected without diffing the full config. The config is serialized as JSON with
+// sorted keys, so identical configs hash identically across restarts. An empty hash means the config cannot be serialized.
+func (c *Client) GetConfigHash() string {
+	hash, err := hashConfig(c.getConfigMap())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error hashing the config of ConfigMap %s", c.getConfigMapCacheKey())
+		return ""
+	}
+	return hash
+}
+
+// hashConfig returns the SHA256 hex digest of the config serialized as JSON with sorted keys
+func hashConfig(config *MeshConfig) (string, error) {
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+
+	// encoding/json sorts the keys of maps but not the fields of structs, so the config is marshaled again as a map;
+	// decoding the numbers as json.Number preserves their exact representation
+	var fields map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(configBytes))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return "", err
+	}
+	sortedConfigBytes, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(sortedConfigBytes)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// redactedCopy returns a deep copy of the config with its fields tagged osm:"sensitive" masked by redactSensitiveFields
+func (config *MeshConfig) redactedCopy() MeshConfig {
+	redactedConfig := config.deepCopy()
+	redactSensitiveFields(reflect.ValueOf(&redactedConfig).Elem())
+	return redactedConfig
+}
+
+// redactSensitiveFields masks in place the fields tagged osm:"sensitive" of the given struct and of its nested structs:
+// the non-empty strings and the values of the string maps are replaced with redactedValue, and the fields of other types are cleared
+func redactSensitiveFields(value reflect.Value) {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := value.Field(i)
+		if valueType.Field(i).PkgPath != "" {
+			// Unexported fields are never marshaled
+			continue
+		}
+		if valueType.Field(i).Tag.Get(osmTag) != sensitiveTagValue {
+			if field.Kind() == reflect.Struct {
+				redactSensitiveFields(field)
+			}
+			continue
+		}
+
+		switch {
+		case field.Kind() == reflect.String:
+			if field.Len() != 0 {
+				field.SetString(redactedValue)
+			}
+		case field.Kind() == reflect.Map && field.Type().Elem().Kind() == reflect.String:
+			if field.IsNil() {
+				continue
+			}
+			redactedMap := reflect.MakeMapWithSize(field.Type(), field.Len())
+			for _, key := range field.MapKeys() {
+				redactedMap.SetMapIndex(key, reflect.ValueOf(redactedValue).Convert(field.Type().Elem()))
+			}
+			field.Set(redactedMap)
+		default:
+			field.Set(reflect.Zero(field.Type()))
+		}
+	}
+}
+
+// GetConfigProvenance returns a copy of the provenance of the config fields, keyed by the field name: ProvenanceEnvironment
+// for the fields whose value is taken from an environment variable, ProvenanceConfigMap for the fields whose value is
+// taken from the ConfigMap, and ProvenanceDefault for the others.
+func (c *Client) GetConfigProvenance() map[string]string {
+	provenance := make(map[string]string)
+	cachedProvenance, _ := c.provenance.Load().(map[string]string)
+	for field, source := range cachedProvenance {
+		provenance[field] = source
+	}
+	return provenance
+}
+
+// Snapshot returns the effective OSM config, with its fields tagged osm:"sensitive" masked, along with the hash of the
+// config, the resourceVersion of its ConfigMap, the provenance of its fields and the problems found in it, so they can
+// be rendered as one view, e.g. by the debug server. All of them are taken from the same ConfigMap revision, which the
+// separate getters do not guarantee across a ConfigMap change.
+func (c *Client) Snapshot() ConfigSnapshot {
+	c.configLock.Lock()
+	config := c.getConfigMap()
+	resourceVersion := c.GetConfigResourceVersion()
+	provenance := c.GetConfigProvenance()
+	c.configLock.Unlock()
+
+	snapshot := ConfigSnapshot{
+		Config:          config.redactedCopy(),
+		ResourceVersion: resourceVersion,
+		Provenance:      provenance,
+	}
+	hash, err := hashConfig(config)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error hashing the config of ConfigMap %s", c.getConfigMapCacheKey())
+	}
+	snapshot.Hash = hash
+	for _, validationErr := range config.validate() {
+		snapshot.ValidationErrors = append(snapshot.ValidationErrors, validationErr.Error())
+	}
+	return snapshot
+}
+
+// IsConfigReady returns whether the OSM config has been synced and parsed from an existing ConfigMap. While it is not ready,
+// the configurator serves the default config, which e.g. disables egress, so callers may want to wait or warn.
+func (c *Client) IsConfigReady() bool {
+	return c.getConfigStatus() == nil
+}
+
+// getConfigStatus returns errConfigNotSynced before the informer cache has been synced,
+// errConfigMapNotFound when neither the ConfigMap nor the MeshConfig exist, and nil otherwise
+func (c *Client) getConfigStatus() error {
+	if !c.isCacheSynced() {
+		return errConfigNotSynced
+	}
+	if exists, ok := c.configExists.Load().(bool); !ok || !exists {
+		return errConfigMapNotFound
+	}
+	return nil
+}
+
+// isCacheSynced returns whether the informer caches have completed their initial sync
+func (c *Client) isCacheSynced() bool {
+	select {
+	case <-c.cacheSynced:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetConfigResourceVersion returns the metadata.resourceVersion of the ConfigMap the current OSM config was parsed from;
+// it is empty when the ConfigMap does not exist.
+func (c *Client) GetConfigResourceVersion() string {
+	resourceVersion, _ := c.resourceVersion.Load().(string)
+	return resourceVersion
+}
+
+// GetMeshConfig returns a snapshot of the current OSM config. The returned value is a copy: all of its
+// fields come from the same ConfigMap revision, it is not updated on later ConfigMap changes, and
+// modifying it has no effect on the configurator.
+func (c *Client) GetMeshConfig() MeshConfig {
+	return c.getConfigMap().deepCopy()
+}
+
+// deepCopy returns a copy of the config that shares no memory with the original
+func (config *MeshConfig) deepCopy() MeshConfig {
+	configCopy := *config
+	if config.TracingSamplingRate != nil {
+		samplingRate := *config.TracingSamplingRate
+		configCopy.TracingSamplingRate = &samplingRate
+	}
+	if config.StatsTags != nil {
+		configCopy.StatsTags = make(map[string]string, len(config.StatsTags))
+		for name, value := range config.StatsTags {
+			configCopy.StatsTags[name] = value
+		}
+	}
+	if config.ProxyEnvVars != nil {
+		configCopy.ProxyEnvVars = make(map[string]string, len(config.ProxyEnvVars))
+		for name, value := range config.ProxyEnvVars {
+			configCopy.ProxyEnvVars[name] = value
+		}
+	}
+	if config.WASMExtensions != nil {
+		configCopy.WASMExtensions = append([]WASMExtensionSpec(nil), config.WASMExtensions...)
+	}
+	if config.FeatureFlags != nil {
+		configCopy.FeatureFlags = make(map[string]bool, len(config.FeatureFlags))
+		for name, enabled := range config.FeatureFlags {
+			configCopy.FeatureFlags[name] = enabled
+		}
+	}
+	return configCopy
+}
+
 // IsPermissiveTrafficPolicyMode tells us whether the OSM Control Plane is in permissive mode,
 // where all existing traffic is allowed to flow as it is,
 // or it is in SMI Spec mode, in which only traffic between source/destinations
@@ -39,9 +452,116 @@ func (c *Client) IsPermissiveTrafficPolicyMode() bool {
 	return c.getConfigMap().PermissiveTrafficPolicyMode
 }
 
-// IsEgressEnabled determines whether egress is globally enabled in the mesh or not.
+// IsPermissiveAuditLoggingEnabled returns whether the proxies log the requests the SMI policies would deny, without
+// denying them. It only applies in permissive traffic policy mode, since the SMI policies are enforced otherwise.
+func (c *Client) IsPermissiveAuditLoggingEnabled() bool {
+	config := c.getConfigMap()
+	return config.PermissiveTrafficPolicyMode && config.PermissiveModeAuditLogging
+}
+
+// GetSMISpecVersion returns the version of the SMI APIs the SMI policies are honored in, defaulting to the latest
+// supported version, v1alpha4, when it is unset or unsupported
+func (c *Client) GetSMISpecVersion() string {
+	smiSpecVersion := c.getConfigMap().SMISpecVersion
+	if smiSpecVersion == "" {
+		return defaultConfig.SMISpecVersion
+	}
+	if _, ok := validSMISpecVersions[smiSpecVersion]; !ok {
+		log.Warn().Msgf("Unsupported SMI spec version %q for key %s in ConfigMap %s; Defaulting to %s", smiSpecVersion, smiSpecVersionKey, c.getConfigMapCacheKey(), defaultConfig.SMISpecVersion)
+		return defaultConfig.SMISpecVersion
+	}
+	return smiSpecVersion
+}
+
+// IsEgressEnabled determines whether egress is enabled in the mesh or not, either globally or based on policies.
 func (c *Client) IsEgressEnabled() bool {
-	return c.getConfigMap().Egress
+	return c.IsEgressEnabledForChannel(PrimaryConfigChannel)
+}
+
+// IsEgressEnabledForChannel determines whether egress is enabled for the pods of the given config channel
+func (c *Client) IsEgressEnabledForChannel(channel string) bool {
+	return c.GetEgressModeForChannel(channel) != EgressModeDisabled
+}
+
+// GetEgressMode returns the egress mode of the mesh. When the egress mode is unset, it is global or disabled depending on
+// whether egress is enabled, for backward compatibility; an invalid egress mode defaults to disabled.
+func (c *Client) GetEgressMode() string {
+	return c.GetEgressModeForChannel(PrimaryConfigChannel)
+}
+
+// GetEgressModeForChannel returns the egress mode for the pods of the given config channel, as GetEgressMode does for the mesh
+func (c *Client) GetEgressModeForChannel(channel string) string {
+	config := c.getConfigForChannel(channel)
+	if _, ok := validEgressModes[config.EgressMode]; config.EgressMode != "" && !ok {
+		log.Warn().Msgf("Invalid egress mode %q for key %s in ConfigMap %s; Defaulting to %s", config.EgressMode, egressModeKey, c.getConfigMapCacheKeyForChannel(channel), EgressModeDisabled)
+	}
+	return config.getEgressMode()
+}
+
+// GetEgressDNSResolution returns how Envoy resolves the addresses of the egress clusters of the external hosts, defaulting
+// to the resolution of the default config, LOGICAL_DNS, when it is unset or invalid
+func (c *Client) GetEgressDNSResolution() string {
+	dnsResolution := c.getConfigMap().EgressDNSResolution
+	if dnsResolution == "" {
+		return defaultConfig.EgressDNSResolution
+	}
+	if _, ok := validEgressDNSResolutions[dnsResolution]; !ok {
+		log.Warn().Msgf("Invalid egress DNS resolution %q for key %s in ConfigMap %s; Defaulting to %s", dnsResolution, egressDNSResolutionKey, c.getConfigMapCacheKey(), defaultConfig.EgressDNSResolution)
+		return defaultConfig.EgressDNSResolution
+	}
+	return dnsResolution
+}
+
+// GetEgressCABundleRef returns the reference to the Secret key holding the CA bundle the TLS connections originated to the
+// external hosts are verified with, or nil when it is unset. The key defaults to ca.crt; an invalid reference is ignored.
+func (c *Client) GetEgressCABundleRef() *CABundleRef {
+	egressCABundle := c.getConfigMap().EgressCABundle
+	if egressCABundle == "" {
+		return nil
+	}
+	caBundleRef, err := parseCABundleRef(egressCABundle)
+	if err != nil {
+		log.Error().Err(err).Msgf("Invalid CA bundle reference for key %s in ConfigMap %s; Verifying the egress TLS connections without a CA bundle", egressCABundleKey, c.getConfigMapCacheKey())
+		return nil
+	}
+	return caBundleRef
+}
+
+// GetEgressAllowedDomains returns the deduplicated and sorted list of external domains egress is allowed to, lowercased
+// and without trailing dots, so they can be matched against the SNI of the egress TLS connections
+func (c *Client) GetEgressAllowedDomains() []string {
+	domainSet := make(map[string]interface{})
+	for _, domain := range parseDelimitedList(c.getConfigMap().EgressAllowedDomains) {
+		normalizedDomain := normalizeDomain(domain)
+		if !isValidDomain(normalizedDomain) {
+			log.Warn().Msgf("Invalid domain %q for key %s in ConfigMap %s; Skipping domain", domain, egressAllowedDomainsKey, c.getConfigMapCacheKey())
+			continue
+		}
+		domainSet[normalizedDomain] = nil
+	}
+
+	var domains []string
+	for domain := range domainSet {
+		domains = append(domains, domain)
+	}
+
+	sort.Strings(domains)
+
+	return domains
+}
+
+// getEgressMode returns the egress mode of the config, without logging invalid egress modes
+func (config *MeshConfig) getEgressMode() string {
+	if config.EgressMode == "" {
+		if config.Egress {
+			return EgressModeGlobal
+		}
+		return EgressModeDisabled
+	}
+	if _, ok := validEgressModes[config.EgressMode]; !ok {
+		return EgressModeDisabled
+	}
+	return config.EgressMode
 }
 
 // IsPrometheusScrapingEnabled determines whether Prometheus is enabled for scraping metrics
@@ -49,84 +569,1499 @@ func (c *Client) IsPrometheusScrapingEnabled() bool {
 	return c.getConfigMap().PrometheusScraping
 }
 
+// GetPrometheusScrapePort returns the port on which Prometheus scrapes the metrics of the proxies
+func (c *Client) GetPrometheusScrapePort() uint32 {
+	scrapePort := c.getConfigMap().PrometheusScrapePort
+	if scrapePort == 0 {
+		return uint32(defaultConfig.PrometheusScrapePort)
+	}
+	if !isValidPort(scrapePort) {
+		log.Warn().Msgf("Invalid port %d for key %s in ConfigMap %s; Defaulting to %d", scrapePort, prometheusScrapePortKey, c.getConfigMapCacheKey(), defaultConfig.PrometheusScrapePort)
+		return uint32(defaultConfig.PrometheusScrapePort)
+	}
+	return uint32(scrapePort)
+}
+
+// GetPrometheusScrapePath returns the path on which Prometheus scrapes the metrics of the proxies
+func (c *Client) GetPrometheusScrapePath() string {
+	scrapePath := c.getConfigMap().PrometheusScrapePath
+	if scrapePath == "" {
+		return defaultConfig.PrometheusScrapePath
+	}
+	if !strings.HasPrefix(scrapePath, "/") {
+		log.Warn().Msgf("Invalid path %q for key %s in ConfigMap %s; Defaulting to %s", scrapePath, prometheusScrapePathKey, c.getConfigMapCacheKey(), defaultConfig.PrometheusScrapePath)
+		return defaultConfig.PrometheusScrapePath
+	}
+	return scrapePath
+}
+
 // IsTracingEnabled returns whether tracing is enabled
 func (c *Client) IsTracingEnabled() bool {
 	return c.getConfigMap().TracingEnable
 }
 
-// GetTracingHost is the host to which we send tracing spans
+// GetTracingHost is the host to which we send tracing spans, which is the first of the hosts returned by GetTracingHosts
 func (c *Client) GetTracingHost() string {
+	return c.GetTracingHosts()[0]
+}
+
+// GetTracingHosts returns the deduplicated hosts, in order of preference, to which we send tracing spans, parsed from
+// the comma separated tracing address. The addresses which are not valid hosts, such as addresses with a scheme,
+// a port or a path, are skipped; the default host is returned when none of them is valid.
+func (c *Client) GetTracingHosts() []string {
+	defaultTracingHost := fmt.Sprintf("%s.%s.svc.cluster.local", constants.DefaultTracingHost, c.GetOSMNamespace())
 	tracingAddress := c.getConfigMap().TracingAddress
-	if tracingAddress != "" {
-		return tracingAddress
+	if tracingAddress == "" {
+		return []string{defaultTracingHost}
 	}
-	return fmt.Sprintf("%s.%s.svc.cluster.local", constants.DefaultTracingHost, c.GetOSMNamespace())
+
+	var hosts []string
+	hostSet := make(map[string]interface{})
+	for _, host := range parseCommaSeparatedList(tracingAddress) {
+		if !isValidHost(host) {
+			log.Warn().Msgf("Invalid host %q for key %s in ConfigMap %s; Skipping host", host, tracingAddressKey, c.getConfigMapCacheKey())
+			continue
+		}
+		if _, ok := hostSet[host]; ok {
+			continue
+		}
+		hostSet[host] = nil
+		hosts = append(hosts, host)
+	}
+	if len(hosts) == 0 {
+		log.Warn().Msgf("No valid host in %q for key %s in ConfigMap %s; Defaulting to %s", tracingAddress, tracingAddressKey, c.getConfigMapCacheKey(), defaultTracingHost)
+		return []string{defaultTracingHost}
+	}
+	return hosts
 }
 
 // GetTracingPort returns the tracing listener port
 func (c *Client) GetTracingPort() uint32 {
 	tracingPort := c.getConfigMap().TracingPort
-	if tracingPort != 0 {
-		return uint32(tracingPort)
+	if tracingPort == 0 {
+		return uint32(defaultConfig.TracingPort)
+	}
+	if !isValidPort(tracingPort) {
+		log.Warn().Msgf("Invalid port %d for key %s in ConfigMap %s; Defaulting to %d", tracingPort, tracingPortKey, c.getConfigMapCacheKey(), defaultConfig.TracingPort)
+		return uint32(defaultConfig.TracingPort)
 	}
-	return constants.DefaultTracingPort
+	return uint32(tracingPort)
 }
 
-// GetTracingEndpoint returns the listener's collector endpoint
+// GetTracingEndpoint returns the listener's collector endpoint, a path beginning with a slash.
+// The endpoint is trimmed of surrounding whitespace and given a leading slash when it lacks one; an endpoint with a
+// scheme or a host, rather than a bare path, is replaced by the default endpoint.
 func (c *Client) GetTracingEndpoint() string {
 	tracingEndpoint := c.getConfigMap().TracingEndpoint
-	if tracingEndpoint != "" {
-		return tracingEndpoint
+	if strings.TrimSpace(tracingEndpoint) == "" {
+		return defaultConfig.TracingEndpoint
+	}
+	normalizedEndpoint, ok := normalizeTracingEndpoint(tracingEndpoint)
+	if !ok {
+		log.Warn().Msgf("Invalid tracing endpoint %q for key %s in ConfigMap %s; Expected a path, without a scheme or a host; Defaulting to %s", tracingEndpoint, tracingEndpointKey, c.getConfigMapCacheKey(), defaultConfig.TracingEndpoint)
+		return defaultConfig.TracingEndpoint
+	}
+	return normalizedEndpoint
+}
+
+// normalizeTracingEndpoint returns the tracing endpoint trimmed of surrounding whitespace and with a leading slash,
+// and whether the endpoint is a bare path, without a scheme, a host or inner whitespace
+func normalizeTracingEndpoint(endpoint string) (string, bool) {
+	endpoint = strings.TrimSpace(endpoint)
+	if strings.IndexFunc(endpoint, unicode.IsSpace) != -1 || strings.HasPrefix(endpoint, "//") {
+		return "", false
+	}
+	parsedEndpoint, err := url.Parse(endpoint)
+	if err != nil || parsedEndpoint.Scheme != "" || parsedEndpoint.Host != "" || parsedEndpoint.Opaque != "" {
+		return "", false
+	}
+	if !strings.HasPrefix(endpoint, "/") {
+		endpoint = "/" + endpoint
+	}
+	return endpoint, true
+}
+
+// GetTracingSamplingRate returns the fraction of requests for which traces are sampled
+func (c *Client) GetTracingSamplingRate() float64 {
+	samplingRate := c.getConfigMap().TracingSamplingRate
+	if samplingRate == nil {
+		return *defaultConfig.TracingSamplingRate
+	}
+	if math.IsNaN(*samplingRate) {
+		log.Warn().Msgf("Invalid tracing sampling rate %v in ConfigMap %s; Defaulting to %v", *samplingRate, c.getConfigMapCacheKey(), *defaultConfig.TracingSamplingRate)
+		return *defaultConfig.TracingSamplingRate
+	}
+	if *samplingRate < 0 {
+		log.Warn().Msgf("Tracing sampling rate %v in ConfigMap %s is below 0; Using 0", *samplingRate, c.getConfigMapCacheKey())
+		return 0
+	}
+	if *samplingRate > 1 {
+		log.Warn().Msgf("Tracing sampling rate %v in ConfigMap %s is above 1; Using 1", *samplingRate, c.getConfigMapCacheKey())
+		return 1
+	}
+	return *samplingRate
+}
+
+// GetTracingBackend returns the type of the tracing collector
+func (c *Client) GetTracingBackend() string {
+	tracingBackend := c.getConfigMap().TracingBackend
+	if tracingBackend == "" {
+		return defaultConfig.TracingBackend
+	}
+	if _, ok := validTracingBackends[tracingBackend]; !ok {
+		log.Warn().Msgf("Invalid tracing backend %q in ConfigMap %s; Defaulting to %s", tracingBackend, c.getConfigMapCacheKey(), defaultConfig.TracingBackend)
+		return defaultConfig.TracingBackend
 	}
-	return constants.DefaultTracingEndpoint
+	return tracingBackend
 }
 
 // GetMeshCIDRRanges returns a list of mesh CIDR ranges
 func (c *Client) GetMeshCIDRRanges() []string {
-	noSpaces := strings.ReplaceAll(c.getConfigMap().MeshCIDRRanges, " ", ",")
-	commaSeparatedCIDRs := strings.Split(noSpaces, ",")
-
 	cidrSet := make(map[string]interface{})
-	for _, cidr := range commaSeparatedCIDRs {
-		trimmedCIDR := strings.Trim(cidr, " ")
-		if len(trimmedCIDR) == 0 {
-			continue
-		}
+	for cidr := range c.parseMeshCIDRRanges() {
+		cidrSet[cidr] = nil
+	}
 
-		_, _, err := net.ParseCIDR(trimmedCIDR)
-		if err != nil {
-			log.Error().Err(err).Msgf("Found incorrectly formatted in-mesh CIDR %s from ConfigMap %s/%s; Skipping CIDR", trimmedCIDR, c.osmNamespace, c.osmConfigMapName)
-			continue
-		}
+	var cidrs []string
+	for cidr := range cidrSet {
+		cidrs = append(cidrs, cidr)
+	}
+
+	sort.Strings(cidrs)
+
+	return cidrs
+}
 
-		cidrSet[trimmedCIDR] = nil
+// GetMeshCIDRRangesParsed returns the deduplicated list of parsed mesh CIDR ranges, sorted by their string representation.
+// An error is returned when egress is enabled and the ConfigMap does not hold a single valid CIDR.
+func (c *Client) GetMeshCIDRRangesParsed() ([]*net.IPNet, error) {
+	ipNets := getSortedIPNets(c.parseMeshCIDRRanges())
+	if len(ipNets) == 0 && c.IsEgressEnabled() {
+		return nil, errNoValidMeshCIDRRanges
+	}
+
+	return ipNets, nil
+}
+
+// GetMTLSExemptSourceCIDRs returns the deduplicated list of source CIDR ranges, sorted by their string representation,
+// whose plaintext connections the inbound listeners accept without mTLS; the list is empty unless it is configured,
+// so that mTLS is enforced for every source. The malformed CIDRs are skipped.
+func (c *Client) GetMTLSExemptSourceCIDRs() []*net.IPNet {
+	return getSortedIPNets(c.parseCIDRList(mtlsExemptSourceCIDRsKey, c.getConfigMap().MTLSExemptSourceCIDRs))
+}
+
+// getSortedIPNets returns the given parsed CIDRs deduplicated and sorted by their string representation
+func getSortedIPNets(parsedCIDRs map[string]*net.IPNet) []*net.IPNet {
+	ipNetSet := make(map[string]*net.IPNet)
+	for _, ipNet := range parsedCIDRs {
+		ipNetSet[ipNet.String()] = ipNet
 	}
 
 	var cidrs []string
-	for cidr := range cidrSet {
+	for cidr := range ipNetSet {
+		cidrs = append(cidrs, cidr)
+	}
+
+	sort.Strings(cidrs)
+
+	ipNets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		ipNets = append(ipNets, ipNetSet[cidr])
+	}
+
+	return ipNets
+}
+
+// GetMeshCIDRRangesByFamily returns the list of IPv4 mesh CIDR ranges and the list of IPv6 mesh CIDR ranges, each sorted
+func (c *Client) GetMeshCIDRRangesByFamily() ([]string, []string) {
+	parsedCIDRs := c.parseMeshCIDRRanges()
+
+	var cidrs []string
+	for cidr := range parsedCIDRs {
 		cidrs = append(cidrs, cidr)
 	}
 
 	sort.Strings(cidrs)
 
+	var ipv4CIDRs, ipv6CIDRs []string
+	for _, cidr := range cidrs {
+		if parsedCIDRs[cidr].IP.To4() != nil {
+			ipv4CIDRs = append(ipv4CIDRs, cidr)
+		} else {
+			ipv6CIDRs = append(ipv6CIDRs, cidr)
+		}
+	}
+
+	return ipv4CIDRs, ipv6CIDRs
+}
+
+// parseMeshCIDRRanges returns the valid CIDRs from the space or comma separated list of mesh CIDR ranges,
+// keyed by the CIDR as it appears in the ConfigMap. The malformed CIDRs are logged once per call rather than
+// once per CIDR, since the list is parsed on every call and may hold any number of entries.
+func (c *Client) parseMeshCIDRRanges() map[string]*net.IPNet {
+	return c.parseCIDRList(meshCIDRRangesKey, c.getConfigMap().MeshCIDRRanges)
+}
+
+// parseCIDRList returns the valid CIDRs from the space or comma separated list of CIDRs held by the given key, keyed
+// by the CIDR as it appears in the ConfigMap; the malformed CIDRs are logged once per call.
+func (c *Client) parseCIDRList(key, rawCIDRs string) map[string]*net.IPNet {
+	cidrs := make(map[string]*net.IPNet)
+	var invalidCIDRs int
+	var firstErr error
+	for _, cidr := range parseDelimitedList(rawCIDRs) {
+		ipNet, err := parseCIDR(key, cidr)
+		if err != nil {
+			if invalidCIDRs == 0 {
+				firstErr = err
+			}
+			invalidCIDRs++
+			continue
+		}
+
+		cidrs[cidr] = ipNet
+	}
+
+	if invalidCIDRs > 0 {
+		log.Error().Err(firstErr).Msgf("Found %d incorrectly formatted CIDRs for key %s in ConfigMap %s; Skipping CIDRs", invalidCIDRs, key, c.getConfigMapCacheKey())
+	}
+
 	return cidrs
 }
 
-// UseHTTPSIngress determines whether traffic between ingress and backend pods should use HTTPS protocol
+// maxCIDRLength is the length of the longest CIDR in canonical notation
+const maxCIDRLength = len("ffff:ffff:ffff:ffff:ffff:ffff:255.255.255.255/128")
+
+// parseCIDR parses the given CIDR held by the given key. The entries longer than any CIDR in canonical notation are
+// rejected without being parsed, and at most maxCIDRLength characters of the entry are quoted in the returned error.
+func parseCIDR(key, cidr string) (*net.IPNet, error) {
+	if len(cidr) > maxCIDRLength {
+		return nil, errors.Wrapf(errInvalidCIDR, "%s=%.*q... is longer than %d characters", key, maxCIDRLength, cidr, maxCIDRLength)
+	}
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, errors.Wrapf(errInvalidCIDR, "%s=%q", key, cidr)
+	}
+	return ipNet, nil
+}
+
+// parseDelimitedList returns the entries of the list separated by commas, whitespace or a mix of both,
+// without the empty entries; e.g. "a, b\tc,,d " results in [a b c d]
+func parseDelimitedList(raw string) []string {
+	return strings.FieldsFunc(raw, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
+// parseCommaSeparatedList returns the trimmed entries of the list separated by commas, without the empty entries;
+// unlike parseDelimitedList, whitespace within an entry is kept, e.g. "a, b c,,d " results in [a "b c" d]
+func parseCommaSeparatedList(raw string) []string {
+	var entries []string
+	for _, entry := range strings.Split(raw, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// UseHTTPSIngress determines whether traffic between ingress and backend pods should use HTTPS protocol, i.e. whether
+// the default ingress backend protocol is https
 func (c *Client) UseHTTPSIngress() bool {
-	return c.getConfigMap().UseHTTPSIngress
+	return c.GetDefaultIngressBackendProtocol() == IngressBackendProtocolHTTPS
+}
+
+// GetDefaultIngressBackendProtocol returns the default protocol, http or https, of the traffic from ingress to backend
+// pods. When the protocol is unset or invalid, it is https or http depending on whether HTTPS ingress is enabled, for
+// backward compatibility.
+func (c *Client) GetDefaultIngressBackendProtocol() string {
+	config := c.getConfigMap()
+	if config.DefaultIngressBackendProtocol == "" {
+		return config.getLegacyIngressBackendProtocol()
+	}
+	protocol := strings.ToLower(config.DefaultIngressBackendProtocol)
+	if _, ok := validIngressBackendProtocols[protocol]; !ok {
+		fallback := config.getLegacyIngressBackendProtocol()
+		log.Warn().Msgf("Invalid default ingress backend protocol %q for key %s in ConfigMap %s; Defaulting to %s", config.DefaultIngressBackendProtocol, defaultIngressBackendProtocolKey, c.getConfigMapCacheKey(), fallback)
+		return fallback
+	}
+	return protocol
+}
+
+// getLegacyIngressBackendProtocol returns the ingress backend protocol implied by the HTTPS ingress toggle
+func (config *MeshConfig) getLegacyIngressBackendProtocol() string {
+	if config.UseHTTPSIngress {
+		return IngressBackendProtocolHTTPS
+	}
+	return IngressBackendProtocolHTTP
+}
+
+// StripForwardedHeaders returns whether the proxies strip the X-Forwarded-* headers of the requests they receive, rather
+// than preserving them and appending to X-Forwarded-For. It defaults to false. It applies to the traffic from ingress
+// as well, whether or not UseHTTPSIngress is enabled, so the backends no longer learn the client address and protocol
+// the ingress forwarded.
+func (c *Client) StripForwardedHeaders() bool {
+	return c.getConfigMap().StripForwardedHeaders
 }
 
 // GetEnvoyLogLevel returns the envoy log level
 func (c *Client) GetEnvoyLogLevel() string {
-	logLevel := c.getConfigMap().EnvoyLogLevel
-	if logLevel != "" {
-		return logLevel
-	}
-	return constants.DefaultEnvoyLogLevel
+	return c.GetEnvoyLogLevelForChannel(PrimaryConfigChannel)
 }
 
-// GetAnnouncementsChannel returns a channel, which is used to announce when changes have been made to the OSM ConfigMap.
+// GetEnvoyLogLevelForChannel returns the envoy log level for the pods of the given config channel
+func (c *Client) GetEnvoyLogLevelForChannel(channel string) string {
+	logLevel := c.getConfigForChannel(channel).EnvoyLogLevel
+	if logLevel == "" {
+		return defaultConfig.EnvoyLogLevel
+	}
+	if !isValidEnvoyLogLevel(logLevel) {
+		log.Warn().Msgf("Invalid Envoy log level %q in ConfigMap %s; Defaulting to %s", logLevel, c.getConfigMapCacheKeyForChannel(channel), defaultConfig.EnvoyLogLevel)
+		return defaultConfig.EnvoyLogLevel
+	}
+	return strings.ToLower(logLevel)
+}
+
+// GetEnvoyLogFormat returns the format of the logs of the Envoy processes, defaulting to text when it is unset or invalid
+func (c *Client) GetEnvoyLogFormat() string {
+	logFormat := c.getConfigMap().EnvoyLogFormat
+	if logFormat == "" {
+		return defaultConfig.EnvoyLogFormat
+	}
+	if _, ok := validEnvoyLogFormats[logFormat]; !ok {
+		log.Warn().Msgf("Invalid Envoy log format %q for key %s in ConfigMap %s; Defaulting to %s", logFormat, envoyLogFormatKey, c.getConfigMapCacheKey(), defaultConfig.EnvoyLogFormat)
+		return defaultConfig.EnvoyLogFormat
+	}
+	return logFormat
+}
+
+// IsAccessLoggingEnabled returns whether the Envoy proxies write access logs
+func (c *Client) IsAccessLoggingEnabled() bool {
+	return c.getConfigMap().EnableAccessLogging
+}
+
+// GetAccessLogFormat returns the format of the Envoy access logs, defaulting to the format of the default config when it is unset or invalid
+func (c *Client) GetAccessLogFormat() string {
+	accessLogFormat := c.getConfigMap().AccessLogFormat
+	if accessLogFormat == "" {
+		return defaultConfig.AccessLogFormat
+	}
+	if _, ok := validAccessLogFormats[accessLogFormat]; !ok {
+		log.Warn().Msgf("Invalid access log format %q for key %s in ConfigMap %s; Defaulting to %s", accessLogFormat, accessLogFormatKey, c.getConfigMapCacheKey(), defaultConfig.AccessLogFormat)
+		return defaultConfig.AccessLogFormat
+	}
+	return accessLogFormat
+}
+
+// GetEnvoyAdminPort returns the port Envoy's admin interface listens on
+func (c *Client) GetEnvoyAdminPort() uint32 {
+	adminPort := c.getConfigMap().EnvoyAdminPort
+	if adminPort == 0 {
+		return uint32(defaultConfig.EnvoyAdminPort)
+	}
+	if !isValidPort(adminPort) {
+		log.Warn().Msgf("Invalid port %d for key %s in ConfigMap %s; Defaulting to %d", adminPort, envoyAdminPortKey, c.getConfigMapCacheKey(), defaultConfig.EnvoyAdminPort)
+		return uint32(defaultConfig.EnvoyAdminPort)
+	}
+	return uint32(adminPort)
+}
+
+// GetEnvoyConnectionIdleTimeout returns the duration after which Envoy closes idle connections; 0 disables the timeout
+func (c *Client) GetEnvoyConnectionIdleTimeout() time.Duration {
+	idleTimeout := c.getConfigMap().EnvoyConnectionIdleTimeout
+	if idleTimeout == "" {
+		return getDefaultDuration(defaultConfig.EnvoyConnectionIdleTimeout)
+	}
+	duration, err := time.ParseDuration(idleTimeout)
+	if err != nil || duration < 0 {
+		log.Warn().Msgf("Invalid duration %q for key %s in ConfigMap %s; Defaulting to %s", idleTimeout, envoyConnectionIdleTimeoutKey, c.getConfigMapCacheKey(), defaultConfig.EnvoyConnectionIdleTimeout)
+		return getDefaultDuration(defaultConfig.EnvoyConnectionIdleTimeout)
+	}
+	return duration
+}
+
+// GetEnvoyRequestTimeout returns the duration after which Envoy times out requests on the routes.
+// An unset value falls back to the default timeout, whereas an explicit zero duration such as "0s" is preserved
+// and disables the timeout.
+func (c *Client) GetEnvoyRequestTimeout() time.Duration {
+	requestTimeout := c.getConfigMap().EnvoyRequestTimeout
+	if requestTimeout == "" {
+		return getDefaultDuration(defaultConfig.EnvoyRequestTimeout)
+	}
+	duration, err := time.ParseDuration(requestTimeout)
+	if err != nil || duration < 0 {
+		log.Warn().Msgf("Invalid duration %q for key %s in ConfigMap %s; Defaulting to %s", requestTimeout, envoyRequestTimeoutKey, c.getConfigMapCacheKey(), defaultConfig.EnvoyRequestTimeout)
+		return getDefaultDuration(defaultConfig.EnvoyRequestTimeout)
+	}
+	return duration
+}
+
+// GetXDSServerResponseTimeout returns the duration within which an xDS response must be sent to an Envoy proxy,
+// after which the controller closes the stream and the proxy reconnects. Invalid and non-positive durations fall back to the default.
+func (c *Client) GetXDSServerResponseTimeout() time.Duration {
+	responseTimeout := c.getConfigMap().XDSServerResponseTimeout
+	if responseTimeout == "" {
+		return getDefaultDuration(defaultConfig.XDSServerResponseTimeout)
+	}
+	duration, err := time.ParseDuration(responseTimeout)
+	if err != nil || duration <= 0 {
+		log.Warn().Msgf("Invalid duration %q for key %s in ConfigMap %s; Defaulting to %s", responseTimeout, xdsServerResponseTimeoutKey, c.getConfigMapCacheKey(), defaultConfig.XDSServerResponseTimeout)
+		return getDefaultDuration(defaultConfig.XDSServerResponseTimeout)
+	}
+	return duration
+}
+
+// GetXDSKeepaliveTime returns the duration of inactivity of an xDS connection after which the controller and the
+// proxies ping each other to keep it alive, such as behind a load balancer closing the idle connections. It defaults to
+// the 2h of gRPC; invalid and non-positive durations fall back to the default.
+func (c *Client) GetXDSKeepaliveTime() time.Duration {
+	keepaliveTime := c.getConfigMap().XDSKeepaliveTime
+	if keepaliveTime == "" {
+		return getDefaultDuration(defaultConfig.XDSKeepaliveTime)
+	}
+	duration, err := time.ParseDuration(keepaliveTime)
+	if err != nil || duration <= 0 {
+		log.Warn().Msgf("Invalid duration %q for key %s in ConfigMap %s; Defaulting to %s", keepaliveTime, xdsKeepaliveTimeKey, c.getConfigMapCacheKey(), defaultConfig.XDSKeepaliveTime)
+		return getDefaultDuration(defaultConfig.XDSKeepaliveTime)
+	}
+	return duration
+}
+
+// GetXDSKeepaliveTimeout returns the duration after which an xDS connection whose keepalive ping is not acknowledged is
+// closed. It defaults to the 20s of gRPC; invalid and non-positive durations fall back to the default.
+func (c *Client) GetXDSKeepaliveTimeout() time.Duration {
+	keepaliveTimeout := c.getConfigMap().XDSKeepaliveTimeout
+	if keepaliveTimeout == "" {
+		return getDefaultDuration(defaultConfig.XDSKeepaliveTimeout)
+	}
+	duration, err := time.ParseDuration(keepaliveTimeout)
+	if err != nil || duration <= 0 {
+		log.Warn().Msgf("Invalid duration %q for key %s in ConfigMap %s; Defaulting to %s", keepaliveTimeout, xdsKeepaliveTimeoutKey, c.getConfigMapCacheKey(), defaultConfig.XDSKeepaliveTimeout)
+		return getDefaultDuration(defaultConfig.XDSKeepaliveTimeout)
+	}
+	return duration
+}
+
+// GetDisabledXDSTypes returns the set of the short names, such as RDS, of the xDS resource types the controller does not
+// send to the proxies. Unknown type names are skipped.
+func (c *Client) GetDisabledXDSTypes() map[string]bool {
+	disabledTypes := make(map[string]bool)
+	for _, xdsType := range parseDelimitedList(c.getConfigMap().DisabledXDSTypes) {
+		if _, ok := validXDSTypes[xdsType]; !ok {
+			log.Warn().Msgf("Invalid xDS type %q for key %s in ConfigMap %s; Skipping xDS type", xdsType, disabledXDSTypesKey, c.getConfigMapCacheKey())
+			continue
+		}
+		disabledTypes[xdsType] = true
+	}
+	return disabledTypes
+}
+
+// GetDefaultRetryPolicy returns the default retry policy of the routes, or nil when NumRetries is unset or 0.
+// Unknown retry conditions and an invalid per-try timeout are dropped; the retry conditions default to
+// the retry conditions of the default config when none of the configured ones are valid.
+func (c *Client) GetDefaultRetryPolicy() *RetryPolicy {
+	retryPolicy := c.getConfigMap().RetryPolicy
+	if retryPolicy.NumRetries == 0 {
+		return nil
+	}
+
+	if retryPolicy.PerTryTimeout != "" {
+		perTryTimeout, err := time.ParseDuration(retryPolicy.PerTryTimeout)
+		if err != nil || perTryTimeout <= 0 {
+			log.Warn().Msgf("Invalid per-try timeout %q for key %s in ConfigMap %s; Ignoring per-try timeout", retryPolicy.PerTryTimeout, retryPolicyKey, c.getConfigMapCacheKey())
+			retryPolicy.PerTryTimeout = ""
+		}
+	}
+
+	retryPolicy.RetryOn = c.parseRetryOn(retryPolicy.RetryOn)
+	if retryPolicy.RetryOn == "" {
+		retryPolicy.RetryOn = defaultConfig.RetryPolicy.RetryOn
+	}
+
+	return &retryPolicy
+}
+
+// GetServiceCertValidityDuration returns the validity duration of the service certificates. It defaults to 24h,
+// or to the duration set with the WithDefaultServiceCertValidityDuration option, when the ConfigMap does not set a
+// valid duration; durations shorter than constants.MinServiceCertValidityDuration are raised to the minimum.
+func (c *Client) GetServiceCertValidityDuration() time.Duration {
+	validityDuration := c.getConfigMap().ServiceCertValidityDuration
+	if validityDuration == "" {
+		return c.defaultServiceCertValidityDuration
+	}
+	duration, err := time.ParseDuration(validityDuration)
+	if err != nil {
+		log.Warn().Msgf("Invalid duration %q for key %s in ConfigMap %s; Defaulting to %s", validityDuration, serviceCertValidityDurationKey, c.getConfigMapCacheKey(), c.defaultServiceCertValidityDuration)
+		return c.defaultServiceCertValidityDuration
+	}
+	if duration < constants.MinServiceCertValidityDuration {
+		log.Warn().Msgf("Duration %s for key %s in ConfigMap %s is shorter than the minimum; Using the minimum %s", duration, serviceCertValidityDurationKey, c.getConfigMapCacheKey(), constants.MinServiceCertValidityDuration)
+		return constants.MinServiceCertValidityDuration
+	}
+	return duration
+}
+
+// GetTrustDomain returns the trust domain of the mesh, which the common names of the service certificates end with.
+// It defaults to constants.DefaultTrustDomain, cluster.local, when the ConfigMap does not set a valid DNS name.
+func (c *Client) GetTrustDomain() string {
+	trustDomain := c.getConfigMap().TrustDomain
+	if trustDomain == "" {
+		return constants.DefaultTrustDomain
+	}
+	if !isValidTrustDomain(trustDomain) {
+		log.Warn().Msgf("Invalid trust domain %q for key %s in ConfigMap %s; Defaulting to %s", trustDomain, trustDomainKey, c.getConfigMapCacheKey(), constants.DefaultTrustDomain)
+		return constants.DefaultTrustDomain
+	}
+	return trustDomain
+}
+
+// GetMeshTLSVersions returns the oldest and the newest TLS protocol versions, from TLS1_0 to TLS1_3, of the
+// mesh-internal connections, defaulting to TLS1_2 and TLS1_3 when they are unset or invalid. When the oldest version is
+// newer than the newest version, the two are swapped.
+func (c *Client) GetMeshTLSVersions() (minVersion, maxVersion string) {
+	minVersion = c.getTLSVersion(meshTLSMinVersionKey, c.getConfigMap().MeshTLSMinVersion, defaultConfig.MeshTLSMinVersion)
+	maxVersion = c.getTLSVersion(meshTLSMaxVersionKey, c.getConfigMap().MeshTLSMaxVersion, defaultConfig.MeshTLSMaxVersion)
+	if minVersion > maxVersion {
+		log.Warn().Msgf("Mesh TLS min version %s for key %s in ConfigMap %s is newer than the max version %s; Swapping them", minVersion, meshTLSMinVersionKey, c.getConfigMapCacheKey(), maxVersion)
+		return maxVersion, minVersion
+	}
+	return minVersion, maxVersion
+}
+
+// GetMeshCipherSuites returns the TLS cipher suites, by order of preference, of the mesh-internal connections, or nil to
+// leave the cipher suites to Envoy. Unknown and repeated cipher suites are skipped.
+func (c *Client) GetMeshCipherSuites() []string {
+	var cipherSuites []string
+	seen := make(map[string]bool)
+	for _, cipherSuite := range parseDelimitedList(c.getConfigMap().MeshCipherSuites) {
+		if _, ok := validCipherSuites[cipherSuite]; !ok {
+			log.Warn().Msgf("Invalid cipher suite %q for key %s in ConfigMap %s; Skipping cipher suite", cipherSuite, meshCipherSuitesKey, c.getConfigMapCacheKey())
+			continue
+		}
+		if seen[cipherSuite] {
+			continue
+		}
+		seen[cipherSuite] = true
+		cipherSuites = append(cipherSuites, cipherSuite)
+	}
+	return cipherSuites
+}
+
+// GetCertificateProvider returns the certificate provider, tresor, vault or cert-manager, issuing the certificates of the
+// mesh. It is empty when unset or invalid, in which case the provider the controller is started with is kept.
+func (c *Client) GetCertificateProvider() string {
+	provider := c.getConfigMap().CertificateProvider
+	if _, ok := validCertificateProviders[provider]; provider != "" && !ok {
+		log.Warn().Msgf("Invalid certificate provider %q for key %s in ConfigMap %s; Ignoring certificate provider", provider, certificateProviderKey, c.getConfigMapCacheKey())
+		return ""
+	}
+	return provider
+}
+
+// GetVaultConfig returns the config of the Hashicorp Vault certificate provider, with its protocol, port and role
+// defaulted to http, 8200 and openservicemesh, or nil when its host or token Secret reference is missing or any of it
+// is invalid
+func (c *Client) GetVaultConfig() *VaultConfig {
+	vaultConfig := c.getConfigMap().Vault.withDefaults()
+	if errs := vaultConfig.validate(true); len(errs) > 0 {
+		log.Error().Msgf("Invalid Vault config for key %s in ConfigMap %s: %v; Ignoring Vault config", vaultKey, c.getConfigMapCacheKey(), errs)
+		return nil
+	}
+	return &vaultConfig
+}
+
+// GetTokenSecretRef returns the reference to the Secret key holding the Vault token, or nil when it is unset or invalid
+func (vaultConfig VaultConfig) GetTokenSecretRef() *SecretKeyRef {
+	if vaultConfig.TokenSecretRef == "" {
+		return nil
+	}
+	secretKeyRef, err := parseSecretKeyRef(vaultConfig.TokenSecretRef)
+	if err != nil {
+		return nil
+	}
+	return secretKeyRef
+}
+
+// withDefaults returns the Vault config with its unset protocol, port and role set to their defaults
+func (vaultConfig VaultConfig) withDefaults() VaultConfig {
+	if vaultConfig.Protocol == "" {
+		vaultConfig.Protocol = defaultVaultProtocol
+	}
+	if vaultConfig.Port == 0 {
+		vaultConfig.Port = defaultVaultPort
+	}
+	if vaultConfig.Role == "" {
+		vaultConfig.Role = defaultVaultRole
+	}
+	return vaultConfig
+}
+
+// GetCertManagerConfig returns the config of the cert-manager certificate provider, with its issuer kind and group
+// defaulted to Issuer and cert-manager.io, or nil when its issuer name is missing
+func (c *Client) GetCertManagerConfig() *CertManagerConfig {
+	certManagerConfig := c.getConfigMap().CertManager.withDefaults()
+	if errs := certManagerConfig.validate(true); len(errs) > 0 {
+		log.Error().Msgf("Invalid cert-manager config for key %s in ConfigMap %s: %v; Ignoring cert-manager config", certManagerKey, c.getConfigMapCacheKey(), errs)
+		return nil
+	}
+	return &certManagerConfig
+}
+
+// withDefaults returns the cert-manager config with its unset issuer kind and group set to their defaults
+func (certManagerConfig CertManagerConfig) withDefaults() CertManagerConfig {
+	if certManagerConfig.IssuerKind == "" {
+		certManagerConfig.IssuerKind = defaultCertManagerIssuerKind
+	}
+	if certManagerConfig.IssuerGroup == "" {
+		certManagerConfig.IssuerGroup = defaultCertManagerIssuerGroup
+	}
+	return certManagerConfig
+}
+
+// getTLSVersion returns the given TLS protocol version, or the given default when it is unset or invalid
+func (c *Client) getTLSVersion(key, version, defaultVersion string) string {
+	if version == "" {
+		return defaultVersion
+	}
+	if _, ok := validTLSVersions[version]; !ok {
+		log.Warn().Msgf("Invalid TLS version %q for key %s in ConfigMap %s; Defaulting to %s", version, key, c.getConfigMapCacheKey(), defaultVersion)
+		return defaultVersion
+	}
+	return version
+}
+
+// GetEnvoyImage returns the image reference of the injected Envoy sidecars. It defaults to constants.DefaultEnvoyImage,
+// or to the image set with the WithDefaultEnvoyImage option, when the ConfigMap does not set a well-formed image reference.
+func (c *Client) GetEnvoyImage() string {
+	return c.getImageReference(envoyImageKey, c.getConfigMap().EnvoyImage, c.defaultEnvoyImage)
+}
+
+// GetInitContainerImage returns the image reference of the injected init containers. It defaults to
+// constants.DefaultInitContainerImage, or to the image set with the WithDefaultInitContainerImage option, when the
+// ConfigMap does not set a well-formed image reference.
+func (c *Client) GetInitContainerImage() string {
+	return c.getImageReference(initContainerImageKey, c.getConfigMap().InitContainerImage, c.defaultInitContainerImage)
+}
+
+// IsSidecarInjectionEnabled returns whether the Envoy sidecars are injected into the new pods of the mesh.
+// It defaults to true, so the injection is only disabled when the ConfigMap explicitly sets it to false.
+func (c *Client) IsSidecarInjectionEnabled() bool {
+	return c.getConfigMap().EnableSidecarInjection
+}
+
+// GetExcludedNamespaces returns the set of the namespaces whose pods are never meshed, regardless of their labels and
+// annotations. The names are kept as they are, since namespace names are case-sensitive; invalid names are skipped.
+func (c *Client) GetExcludedNamespaces() map[string]bool {
+	excludedNamespaces := make(map[string]bool)
+	for _, namespace := range parseDelimitedList(c.getConfigMap().ExcludedNamespaces) {
+		if !isValidNamespace(namespace) {
+			log.Warn().Msgf("Invalid namespace %q for key %s in ConfigMap %s; Skipping namespace", namespace, excludedNamespacesKey, c.getConfigMapCacheKey())
+			continue
+		}
+		excludedNamespaces[namespace] = true
+	}
+	return excludedNamespaces
+}
+
+// GetProxyProbeSpec returns the timings, without a handler, of the readiness and liveness probes of the injected Envoy
+// sidecars. Each timing which is unset, 0 or negative is replaced by the timing of the default config, independently of
+// the other timings.
+func (c *Client) GetProxyProbeSpec() v1.Probe {
+	probeSpec := c.getConfigMap().ProxyProbe
+	defaultProbeSpec := defaultConfig.ProxyProbe
+	return v1.Probe{
+		InitialDelaySeconds: c.getProbeTiming("initial_delay_seconds", probeSpec.InitialDelaySeconds, defaultProbeSpec.InitialDelaySeconds),
+		PeriodSeconds:       c.getProbeTiming("period_seconds", probeSpec.PeriodSeconds, defaultProbeSpec.PeriodSeconds),
+		TimeoutSeconds:      c.getProbeTiming("timeout_seconds", probeSpec.TimeoutSeconds, defaultProbeSpec.TimeoutSeconds),
+		FailureThreshold:    c.getProbeTiming("failure_threshold", probeSpec.FailureThreshold, defaultProbeSpec.FailureThreshold),
+	}
+}
+
+// GetProxyBootstrapOverride returns the YAML fragment deep-merged over the bootstrap config generated for the Envoy
+// sidecars, decoded into maps keyed by strings, or nil when it is unset. It returns an error when the fragment is not
+// a YAML mapping.
+func (c *Client) GetProxyBootstrapOverride() (map[string]interface{}, error) {
+	return parseYAMLMapping(proxyBootstrapOverrideKey, c.getConfigMap().ProxyBootstrapConfigOverride)
+}
+
+// GetProxyBindAddress returns the address the admin listener of the Envoy sidecars binds to: an IP address, or
+// ProxyBindAddressPodIP to bind to the IP address of the pod. It defaults to the wildcard address 0.0.0.0 when the
+// address is unset or invalid.
+func (c *Client) GetProxyBindAddress() string {
+	bindAddress := c.getConfigMap().ProxyBindAddress
+	if bindAddress == "" {
+		return defaultConfig.ProxyBindAddress
+	}
+	if !isValidBindAddress(bindAddress) {
+		log.Warn().Msgf("Invalid proxy bind address %q for key %s in ConfigMap %s; Defaulting to %s", bindAddress, proxyBindAddressKey, c.getConfigMapCacheKey(), defaultConfig.ProxyBindAddress)
+		return defaultConfig.ProxyBindAddress
+	}
+	return bindAddress
+}
+
+// GetEnvoyConcurrency returns the number of worker threads of the Envoy sidecars, passed to Envoy as its --concurrency
+// flag; 0, the default, lets Envoy run one worker per CPU core. A negative number is ignored, and a number above 128 is
+// clamped to 128.
+func (c *Client) GetEnvoyConcurrency() uint32 {
+	concurrency := c.getConfigMap().EnvoyConcurrency
+	if concurrency < 0 {
+		log.Warn().Msgf("Negative Envoy concurrency %d for key %s in ConfigMap %s; Letting Envoy decide", concurrency, envoyConcurrencyKey, c.getConfigMapCacheKey())
+		return 0
+	}
+	if concurrency > maxEnvoyConcurrency {
+		log.Warn().Msgf("Envoy concurrency %d for key %s in ConfigMap %s is above the maximum; Clamping to %d", concurrency, envoyConcurrencyKey, c.getConfigMapCacheKey(), maxEnvoyConcurrency)
+		return maxEnvoyConcurrency
+	}
+	return uint32(concurrency)
+}
+
+// GetProxyEnvVars returns the extra environment variables of the Envoy sidecars, sorted by name. The variables whose
+// name is not a valid environment variable name, or is reserved for the variables OSM sets itself, are dropped.
+func (c *Client) GetProxyEnvVars() []v1.EnvVar {
+	proxyEnvVars := c.getConfigMap().ProxyEnvVars
+	var names []string
+	for name := range proxyEnvVars {
+		if len(validation.IsEnvVarName(name)) > 0 {
+			log.Warn().Msgf("Invalid proxy env var name %q for key %s in ConfigMap %s; Dropping env var", name, proxyEnvVarsKey, c.getConfigMapCacheKey())
+			continue
+		}
+		if isReservedProxyEnvVar(name) {
+			log.Warn().Msgf("Reserved proxy env var name %q for key %s in ConfigMap %s; Dropping env var", name, proxyEnvVarsKey, c.getConfigMapCacheKey())
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var envVars []v1.EnvVar
+	for _, name := range names {
+		envVars = append(envVars, v1.EnvVar{
+			Name:  name,
+			Value: proxyEnvVars[name],
+		})
+	}
+	return envVars
+}
+
+// GetProxyDrainTime returns the duration during which the Envoy sidecars drain their connections on a hot restart or
+// shutdown, passed to Envoy as its --drain-time-s flag. Invalid durations and durations shorter than a second fall back
+// to the default of 10 minutes.
+func (c *Client) GetProxyDrainTime() time.Duration {
+	drainTime, err := parseProxyShutdownTime(c.getConfigMap().ProxyDrainTime, defaultConfig.ProxyDrainTime)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Invalid duration for key %s in ConfigMap %s; Defaulting to %s", proxyDrainTimeKey, c.getConfigMapCacheKey(), defaultConfig.ProxyDrainTime)
+		return getDefaultDuration(defaultConfig.ProxyDrainTime)
+	}
+	return drainTime
+}
+
+// GetProxyParentShutdownTime returns the duration after which the parent Envoy process is shut down on a hot restart,
+// passed to Envoy as its --parent-shutdown-time-s flag. Invalid durations and durations shorter than a second fall back
+// to the default of 15 minutes. Since Envoy must not shut the parent down before it is drained, a parent shutdown time
+// shorter than the drain time is raised to the drain time.
+func (c *Client) GetProxyParentShutdownTime() time.Duration {
+	parentShutdownTime, err := parseProxyShutdownTime(c.getConfigMap().ProxyParentShutdownTime, defaultConfig.ProxyParentShutdownTime)
+	if err != nil {
+		log.Warn().Err(err).Msgf("Invalid duration for key %s in ConfigMap %s; Defaulting to %s", proxyParentShutdownTimeKey, c.getConfigMapCacheKey(), defaultConfig.ProxyParentShutdownTime)
+		parentShutdownTime = getDefaultDuration(defaultConfig.ProxyParentShutdownTime)
+	}
+
+	drainTime := c.GetProxyDrainTime()
+	if parentShutdownTime < drainTime {
+		log.Warn().Msgf("Proxy parent shutdown time %s for key %s in ConfigMap %s is shorter than the drain time %s; Raising it to the drain time", parentShutdownTime, proxyParentShutdownTimeKey, c.getConfigMapCacheKey(), drainTime)
+		return drainTime
+	}
+	return parentShutdownTime
+}
+
+// GetOverloadManager returns the heap limit of the Envoy sidecars, with the unset thresholds defaulted to 0.95 of the
+// maximum heap size to shrink the heap and 0.98 to stop accepting requests, or nil when the maximum heap size is 0. An
+// overload manager with a threshold outside of [0, 1], or shrinking the heap above the threshold to stop accepting
+// requests, is disabled.
+func (c *Client) GetOverloadManager() *OverloadManager {
+	overloadManager := c.getConfigMap().OverloadManager
+	if overloadManager.MaxHeapBytes == 0 {
+		return nil
+	}
+
+	if errs := overloadManager.validate(); len(errs) > 0 {
+		log.Error().Msgf("Invalid overload manager for key %s in ConfigMap %s: %v; Disabling overload manager", overloadManagerKey, c.getConfigMapCacheKey(), errs)
+		return nil
+	}
+
+	overloadManager = overloadManager.withDefaultThresholds()
+	return &overloadManager
+}
+
+// withDefaultThresholds returns a copy of the overload manager config with its unset thresholds defaulted
+func (overloadManager OverloadManager) withDefaultThresholds() OverloadManager {
+	if overloadManager.ShrinkHeapThreshold == 0 {
+		overloadManager.ShrinkHeapThreshold = defaultShrinkHeapThreshold
+	}
+	if overloadManager.StopAcceptingThreshold == 0 {
+		overloadManager.StopAcceptingThreshold = defaultStopAcceptingThreshold
+	}
+	return overloadManager
+}
+
+// parseProxyShutdownTime returns the given duration string parsed, or the given default when it is empty. Since Envoy
+// takes its drain and parent shutdown times in seconds, durations shorter than a second are invalid.
+func parseProxyShutdownTime(duration, defaultDuration string) (time.Duration, error) {
+	if duration == "" {
+		duration = defaultDuration
+	}
+	parsedDuration, err := time.ParseDuration(duration)
+	if err != nil {
+		return 0, errors.Wrapf(errInvalidDuration, "%q", duration)
+	}
+	if parsedDuration < time.Second {
+		return 0, errors.Wrapf(errInvalidDuration, "%q is shorter than 1s", duration)
+	}
+	return parsedDuration, nil
+}
+
+// parseYAMLMapping returns the YAML mapping of the given key decoded into maps keyed by strings, or nil when it is empty
+func parseYAMLMapping(key, fragment string) (map[string]interface{}, error) {
+	if strings.TrimSpace(fragment) == "" {
+		return nil, nil
+	}
+	var mapping map[interface{}]interface{}
+	if err := yaml.Unmarshal([]byte(fragment), &mapping); err != nil {
+		return nil, errors.Wrapf(errInvalidYAML, "%s: %s", key, err)
+	}
+	if mapping == nil {
+		return nil, nil
+	}
+	return stringifyYAMLKeys(mapping).(map[string]interface{}), nil
+}
+
+// stringifyYAMLKeys returns the given decoded YAML value with the keys of its mappings, at any depth, turned into strings
+func stringifyYAMLKeys(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		mapping := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			mapping[fmt.Sprint(key)] = stringifyYAMLKeys(item)
+		}
+		return mapping
+	case []interface{}:
+		items := make([]interface{}, len(value))
+		for i, item := range value {
+			items[i] = stringifyYAMLKeys(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+// getProbeTiming returns the probe timing, or the default timing when the timing is unset, 0 or negative
+func (c *Client) getProbeTiming(field string, timing, defaultTiming int32) int32 {
+	if timing < 0 {
+		log.Warn().Msgf("Negative value %d for %s of key %s in ConfigMap %s; Defaulting to %d", timing, field, proxyProbeKey, c.getConfigMapCacheKey(), defaultTiming)
+		return defaultTiming
+	}
+	if timing == 0 {
+		return defaultTiming
+	}
+	return timing
+}
+
+// getImageReference returns the image reference of the given key, or the default image when it is unset or malformed
+func (c *Client) getImageReference(key, image, defaultImage string) string {
+	if image == "" {
+		return defaultImage
+	}
+	if !isValidImageReference(image) {
+		log.Warn().Msgf("Invalid image reference %q for key %s in ConfigMap %s; Defaulting to %s", image, key, c.getConfigMapCacheKey(), defaultImage)
+		return defaultImage
+	}
+	return image
+}
+
+// GetDefaultCircuitBreaking returns the circuit breaking thresholds of the upstream clusters.
+// A threshold which is unset or 0 means Envoy's default threshold rather than allowing nothing, so it is replaced by the threshold of the default config.
+func (c *Client) GetDefaultCircuitBreaking() CircuitBreaking {
+	circuitBreaking := c.getConfigMap().CircuitBreaking
+	if circuitBreaking.MaxConnections == 0 {
+		circuitBreaking.MaxConnections = defaultConfig.CircuitBreaking.MaxConnections
+	}
+	if circuitBreaking.MaxPendingRequests == 0 {
+		circuitBreaking.MaxPendingRequests = defaultConfig.CircuitBreaking.MaxPendingRequests
+	}
+	if circuitBreaking.MaxRequests == 0 {
+		circuitBreaking.MaxRequests = defaultConfig.CircuitBreaking.MaxRequests
+	}
+	if circuitBreaking.MaxRetries == 0 {
+		circuitBreaking.MaxRetries = defaultConfig.CircuitBreaking.MaxRetries
+	}
+	return circuitBreaking
+}
+
+// GetDefaultLBAlgorithm returns the load balancing algorithm of the upstream clusters, defaulting to ROUND_ROBIN when
+// it is unset or unsupported
+func (c *Client) GetDefaultLBAlgorithm() string {
+	lbAlgorithm := c.getConfigMap().DefaultLBAlgorithm
+	if lbAlgorithm == "" {
+		return defaultConfig.DefaultLBAlgorithm
+	}
+	if _, ok := validLBAlgorithms[lbAlgorithm]; !ok {
+		log.Warn().Msgf("Invalid load balancing algorithm %q for key %s in ConfigMap %s; Defaulting to %s", lbAlgorithm, defaultLBAlgorithmKey, c.getConfigMapCacheKey(), defaultConfig.DefaultLBAlgorithm)
+		return defaultConfig.DefaultLBAlgorithm
+	}
+	return lbAlgorithm
+}
+
+// GetOutlierDetection returns the outlier detection of the upstream clusters, or nil when it is disabled, which it is
+// by default. The thresholds and durations which are unset, invalid or not positive are replaced by the ones of the
+// default config, independently of each other; a maximum ejection percentage above 100 is clamped to 100.
+func (c *Client) GetOutlierDetection() *OutlierDetection {
+	outlierDetection := c.getConfigMap().OutlierDetection
+	if !outlierDetection.Enable {
+		return nil
+	}
+
+	defaultOutlierDetection := defaultConfig.OutlierDetection
+	if outlierDetection.Consecutive5xx == 0 {
+		outlierDetection.Consecutive5xx = defaultOutlierDetection.Consecutive5xx
+	}
+	outlierDetection.Interval = c.getOutlierDetectionDuration("interval", outlierDetection.Interval, defaultOutlierDetection.Interval)
+	outlierDetection.BaseEjectionTime = c.getOutlierDetectionDuration("base_ejection_time", outlierDetection.BaseEjectionTime, defaultOutlierDetection.BaseEjectionTime)
+	if outlierDetection.MaxEjectionPercent == 0 {
+		outlierDetection.MaxEjectionPercent = defaultOutlierDetection.MaxEjectionPercent
+	} else if outlierDetection.MaxEjectionPercent > maxOutlierEjectionPercent {
+		log.Warn().Msgf("Max ejection percent %d for key %s in ConfigMap %s is above the maximum; Clamping to %d", outlierDetection.MaxEjectionPercent, outlierDetectionKey, c.getConfigMapCacheKey(), maxOutlierEjectionPercent)
+		outlierDetection.MaxEjectionPercent = maxOutlierEjectionPercent
+	}
+
+	return &outlierDetection
+}
+
+// getOutlierDetectionDuration returns the outlier detection duration, or the default duration when the duration is
+// unset, invalid or not positive
+func (c *Client) getOutlierDetectionDuration(field, duration, defaultDuration string) string {
+	if duration == "" {
+		return defaultDuration
+	}
+	if parsedDuration, err := time.ParseDuration(duration); err != nil || parsedDuration <= 0 {
+		log.Warn().Msgf("Invalid duration %q for %s of key %s in ConfigMap %s; Defaulting to %s", duration, field, outlierDetectionKey, c.getConfigMapCacheKey(), defaultDuration)
+		return defaultDuration
+	}
+	return duration
+}
+
+// GetInboundExternalAuthConfig returns the config of the external authorization service the inbound requests are
+// authorized with, or nil when external authorization is disabled. The stat prefix and the timeout default to the ones
+// of the default config when they are unset or invalid. An invalid address or port leaves no service to authorize the
+// requests with, so external authorization is then disabled and an error logged.
+func (c *Client) GetInboundExternalAuthConfig() *InboundExternalAuth {
+	externalAuth := c.getConfigMap().InboundExternalAuth
+	if !externalAuth.Enable {
+		return nil
+	}
+
+	if !isValidHost(externalAuth.Address) {
+		log.Error().Err(errInvalidHost).Msgf("Invalid address %q for key %s in ConfigMap %s; Disabling inbound external authorization", externalAuth.Address, inboundExternalAuthKey, c.getConfigMapCacheKey())
+		return nil
+	}
+	if !isValidPort(int(externalAuth.Port)) {
+		log.Error().Err(errInvalidPort).Msgf("Invalid port %d for key %s in ConfigMap %s; Disabling inbound external authorization", externalAuth.Port, inboundExternalAuthKey, c.getConfigMapCacheKey())
+		return nil
+	}
+
+	if externalAuth.StatPrefix == "" {
+		externalAuth.StatPrefix = defaultConfig.InboundExternalAuth.StatPrefix
+	}
+	if externalAuth.Timeout == "" {
+		externalAuth.Timeout = defaultConfig.InboundExternalAuth.Timeout
+	} else if timeout, err := time.ParseDuration(externalAuth.Timeout); err != nil || timeout <= 0 {
+		log.Warn().Msgf("Invalid timeout %q for key %s in ConfigMap %s; Defaulting to %s", externalAuth.Timeout, inboundExternalAuthKey, c.getConfigMapCacheKey(), defaultConfig.InboundExternalAuth.Timeout)
+		externalAuth.Timeout = defaultConfig.InboundExternalAuth.Timeout
+	}
+
+	return &externalAuth
+}
+
+// IsMulticlusterEnabled returns whether the mesh is federated with the meshes of other clusters
+func (c *Client) IsMulticlusterEnabled() bool {
+	return c.getConfigMap().MulticlusterEnabled
+}
+
+// GetMulticlusterGateway returns the gateway the traffic to the other clusters of the mesh goes through, or the zero
+// value when multicluster is disabled. An invalid host or port leaves no gateway to go through, so the zero value is
+// then returned and an error logged.
+func (c *Client) GetMulticlusterGateway() MulticlusterGateway {
+	config := c.getConfigMap()
+	if !config.MulticlusterEnabled {
+		return MulticlusterGateway{}
+	}
+
+	gateway := config.MulticlusterGateway
+	if !isValidHost(gateway.Host) {
+		log.Error().Err(errInvalidHost).Msgf("Invalid host %q for key %s in ConfigMap %s; Ignoring multicluster gateway", gateway.Host, multiclusterGatewayKey, c.getConfigMapCacheKey())
+		return MulticlusterGateway{}
+	}
+	if !isValidPort(int(gateway.Port)) {
+		log.Error().Err(errInvalidPort).Msgf("Invalid port %d for key %s in ConfigMap %s; Ignoring multicluster gateway", gateway.Port, multiclusterGatewayKey, c.getConfigMapCacheKey())
+		return MulticlusterGateway{}
+	}
+	return gateway
+}
+
+// GetSidecarResources returns the resource requests and limits of the injected Envoy sidecars. Each quantity which is
+// unset or invalid is replaced by the quantity of the default config, independently of the other quantities, and is
+// left out when the default config does not set it either. A request above its limit is lowered to the limit, since
+// Kubernetes rejects such containers.
+func (c *Client) GetSidecarResources() v1.ResourceRequirements {
+	sidecarResources := c.getConfigMap().SidecarResources
+	defaultResources := defaultConfig.SidecarResources
+
+	requests := v1.ResourceList{}
+	c.addSidecarResourceQuantity(requests, v1.ResourceCPU, "cpu_request", sidecarResources.CPURequest, defaultResources.CPURequest)
+	c.addSidecarResourceQuantity(requests, v1.ResourceMemory, "memory_request", sidecarResources.MemoryRequest, defaultResources.MemoryRequest)
+
+	limits := v1.ResourceList{}
+	c.addSidecarResourceQuantity(limits, v1.ResourceCPU, "cpu_limit", sidecarResources.CPULimit, defaultResources.CPULimit)
+	c.addSidecarResourceQuantity(limits, v1.ResourceMemory, "memory_limit", sidecarResources.MemoryLimit, defaultResources.MemoryLimit)
+
+	for name, limit := range limits {
+		if request, ok := requests[name]; ok && request.Cmp(limit) > 0 {
+			log.Warn().Msgf("Sidecar %s request %s in ConfigMap %s is above the limit; Using the limit %s", name, request.String(), c.getConfigMapCacheKey(), limit.String())
+			requests[name] = limit
+		}
+	}
+
+	var resources v1.ResourceRequirements
+	if len(requests) != 0 {
+		resources.Requests = requests
+	}
+	if len(limits) != 0 {
+		resources.Limits = limits
+	}
+	return resources
+}
+
+// addSidecarResourceQuantity adds the parsed quantity of the named resource to the resource list, or the default
+// quantity when the quantity is unset or invalid, or nothing when neither is set
+func (c *Client) addSidecarResourceQuantity(resources v1.ResourceList, name v1.ResourceName, field, quantity, defaultQuantity string) {
+	if quantity != "" {
+		parsedQuantity, err := resource.ParseQuantity(quantity)
+		if err == nil && parsedQuantity.Sign() >= 0 {
+			resources[name] = parsedQuantity
+			return
+		}
+		log.Warn().Msgf("Invalid quantity %q for %s of key %s in ConfigMap %s; Defaulting to %q", quantity, field, sidecarResourcesKey, c.getConfigMapCacheKey(), defaultQuantity)
+	}
+	if defaultQuantity != "" {
+		resources[name] = resource.MustParse(defaultQuantity)
+	}
+}
+
+// parseRetryOn returns the deduplicated valid retry conditions from the comma separated list of retry conditions, in their original order
+func (c *Client) parseRetryOn(retryOn string) string {
+	var conditions []string
+	conditionSet := make(map[string]interface{})
+	for _, condition := range parseDelimitedList(retryOn) {
+		if _, ok := validRetryOnConditions[condition]; !ok {
+			log.Warn().Msgf("Found unknown retry condition %s for key %s in ConfigMap %s; Skipping retry condition", condition, retryPolicyKey, c.getConfigMapCacheKey())
+			continue
+		}
+
+		if _, ok := conditionSet[condition]; ok {
+			continue
+		}
+		conditionSet[condition] = nil
+		conditions = append(conditions, condition)
+	}
+	return strings.Join(conditions, ",")
+}
+
+// isValidEnvoyLogLevel returns whether the given log level is one Envoy understands; the comparison is case-insensitive
+func isValidEnvoyLogLevel(logLevel string) bool {
+	_, ok := validEnvoyLogLevels[strings.ToLower(logLevel)]
+	return ok
+}
+
+// GetMaxDataPlaneConnections returns the maximum number of Envoy proxies connected to the controller; 0 means unlimited.
+// Negative values are treated as unlimited.
+func (c *Client) GetMaxDataPlaneConnections() int {
+	maxConnections := c.getConfigMap().MaxDataPlaneConnections
+	if maxConnections < 0 {
+		log.Warn().Msgf("Invalid maximum number of data plane connections %d for key %s in ConfigMap %s; Defaulting to unlimited", maxConnections, maxDataPlaneConnectionsKey, c.getConfigMapCacheKey())
+		return 0
+	}
+	return maxConnections
+}
+
+// GetMaxEndpointsPerCluster returns the maximum number of endpoints of each cluster sent to the proxies over EDS; 0, the
+// default, means unlimited. A negative number is ignored.
+func (c *Client) GetMaxEndpointsPerCluster() uint32 {
+	maxEndpoints := c.getConfigMap().MaxEndpointsPerCluster
+	if maxEndpoints < 0 {
+		log.Warn().Msgf("Invalid maximum number of endpoints per cluster %d for key %s in ConfigMap %s; Defaulting to unlimited", maxEndpoints, maxEndpointsPerClusterKey, c.getConfigMapCacheKey())
+		return 0
+	}
+	return uint32(maxEndpoints)
+}
+
+// GetLeaderElectionConfig returns the lease timings of the leader election of the controllers. Each timing which is
+// unset, invalid or not positive is replaced by the timing of the default config, independently of the other timings.
+// Since the leadership would flap otherwise, the lease duration must be longer than the renew deadline, itself longer
+// than the retry period; timings out of this order fall back to the default timings altogether.
+func (c *Client) GetLeaderElectionConfig() LeaderElectionConfig {
+	leaderElection := c.getConfigMap().LeaderElection
+	defaultLeaderElection := defaultConfig.LeaderElection
+	leaderElectionConfig := LeaderElectionConfig{
+		LeaseDuration: c.getLeaseTiming("lease_duration", leaderElection.LeaseDuration, defaultLeaderElection.LeaseDuration),
+		RenewDeadline: c.getLeaseTiming("renew_deadline", leaderElection.RenewDeadline, defaultLeaderElection.RenewDeadline),
+		RetryPeriod:   c.getLeaseTiming("retry_period", leaderElection.RetryPeriod, defaultLeaderElection.RetryPeriod),
+	}
+
+	if leaderElectionConfig.LeaseDuration <= leaderElectionConfig.RenewDeadline || leaderElectionConfig.RenewDeadline <= leaderElectionConfig.RetryPeriod {
+		defaultLeaderElectionConfig := LeaderElectionConfig{
+			LeaseDuration: getDefaultDuration(defaultLeaderElection.LeaseDuration),
+			RenewDeadline: getDefaultDuration(defaultLeaderElection.RenewDeadline),
+			RetryPeriod:   getDefaultDuration(defaultLeaderElection.RetryPeriod),
+		}
+		log.Warn().Err(errInvertedLeaseTimings).Msgf("Lease duration %s, renew deadline %s and retry period %s for key %s in ConfigMap %s are not in decreasing order; Defaulting to %s, %s and %s",
+			leaderElectionConfig.LeaseDuration, leaderElectionConfig.RenewDeadline, leaderElectionConfig.RetryPeriod, leaderElectionKey, c.getConfigMapCacheKey(),
+			defaultLeaderElectionConfig.LeaseDuration, defaultLeaderElectionConfig.RenewDeadline, defaultLeaderElectionConfig.RetryPeriod)
+		return defaultLeaderElectionConfig
+	}
+	return leaderElectionConfig
+}
+
+// getLeaseTiming returns the parsed lease timing, or the parsed default timing when the timing is unset, invalid or not positive
+func (c *Client) getLeaseTiming(field, timing, defaultTiming string) time.Duration {
+	if timing == "" {
+		return getDefaultDuration(defaultTiming)
+	}
+	parsedTiming, err := time.ParseDuration(timing)
+	if err != nil || parsedTiming <= 0 {
+		log.Warn().Msgf("Invalid duration %q for %s of key %s in ConfigMap %s; Defaulting to %s", timing, field, leaderElectionKey, c.getConfigMapCacheKey(), defaultTiming)
+		return getDefaultDuration(defaultTiming)
+	}
+	return parsedTiming
+}
+
+// IsDebugServerEnabled returns whether the debug server of the controller is enabled. It defaults to false, since the
+// debug server exposes the internals of the controller.
+func (c *Client) IsDebugServerEnabled() bool {
+	return c.getConfigMap().EnableDebugServer
+}
+
+// IsLocalityAwareRoutingEnabled returns whether the proxies prefer the endpoints in their own region and zone. It defaults to false.
+func (c *Client) IsLocalityAwareRoutingEnabled() bool {
+	return c.getConfigMap().LocalityAwareRouting
+}
+
+// GetLocality returns the region and zone overrides of the proxies. The region or zone is empty when it is unset or
+// is not a valid label value, in which case the data plane derives it from the topology labels of the node of each proxy.
+func (c *Client) GetLocality() Locality {
+	config := c.getConfigMap()
+	return Locality{
+		Region: c.getLocalityLabelValue(localityRegionKey, config.LocalityRegion),
+		Zone:   c.getLocalityLabelValue(localityZoneKey, config.LocalityZone),
+	}
+}
+
+// getLocalityLabelValue returns the given region or zone, or an empty string when it is not a valid label value
+func (c *Client) getLocalityLabelValue(key, value string) string {
+	if value != "" && len(validation.IsValidLabelValue(value)) > 0 {
+		log.Warn().Msgf("Invalid label value %q for key %s in ConfigMap %s; Deriving it from the node labels", value, key, c.getConfigMapCacheKey())
+		return ""
+	}
+	return value
+}
+
+// GetOutboundPortExclusionList returns the sorted list of ports for which outbound traffic bypasses the proxy
+func (c *Client) GetOutboundPortExclusionList() []int {
+	return c.parsePortList(c.getConfigMap().OutboundPortExclusionList, outboundPortExclusionListKey)
+}
+
+// GetInboundPortExclusionList returns the sorted list of ports for which inbound traffic bypasses the proxy
+func (c *Client) GetInboundPortExclusionList() []int {
+	return c.parsePortList(c.getConfigMap().InboundPortExclusionList, inboundPortExclusionListKey)
+}
+
+// parsePortList returns the deduplicated and sorted valid ports from the space or comma separated list of ports
+func (c *Client) parsePortList(portList string, key string) []int {
+	portSet := make(map[int]interface{})
+	for _, port := range parseDelimitedList(portList) {
+		portNumber, err := strconv.Atoi(port)
+		if err != nil || !isValidPort(portNumber) {
+			log.Warn().Msgf("Found invalid port %s for key %s in ConfigMap %s; Skipping port", port, key, c.getConfigMapCacheKey())
+			continue
+		}
+
+		portSet[portNumber] = nil
+	}
+
+	var ports []int
+	for port := range portSet {
+		ports = append(ports, port)
+	}
+
+	sort.Ints(ports)
+
+	return ports
+}
+
+// GetStatsPrefix returns the prefix of the names of the HTTP stats of the Envoy proxies, or an empty string when it is
+// unset or is not a valid Prometheus metric name, which consists of letters, digits, underscores and colons and does
+// not start with a digit.
+func (c *Client) GetStatsPrefix() string {
+	statsPrefix := c.getConfigMap().StatsPrefix
+	if statsPrefix != "" && !prometheusMetricNamePattern.MatchString(statsPrefix) {
+		log.Warn().Msgf("Invalid stats prefix %q for key %s in ConfigMap %s; Ignoring stats prefix", statsPrefix, statsPrefixKey, c.getConfigMapCacheKey())
+		return ""
+	}
+	return statsPrefix
+}
+
+// GetStatsTags returns a copy of the tags, keyed by the tag name, added to all the stats of the Envoy proxies. The tags
+// whose name is not a valid Prometheus label name, which consists of letters, digits and underscores and does not
+// start with a digit, are dropped.
+func (c *Client) GetStatsTags() map[string]string {
+	statsTags := make(map[string]string)
+	for name, value := range c.getConfigMap().StatsTags {
+		if !prometheusLabelNamePattern.MatchString(name) {
+			log.Warn().Msgf("Invalid stats tag name %q for key %s in ConfigMap %s; Dropping stats tag", name, statsTagsKey, c.getConfigMapCacheKey())
+			continue
+		}
+		statsTags[name] = value
+	}
+	return statsTags
+}
+
+// GetStatsSink returns the statsd sink the Envoy proxies push their stats to, or nil when the sink is disabled. An
+// unknown type, an address which is not an IP address or an invalid port leaves no sink to push the stats to, so the
+// sink is then disabled and an error logged.
+func (c *Client) GetStatsSink() *StatsSink {
+	statsSink := c.getConfigMap().StatsSink
+	if statsSink.Type == "" {
+		return nil
+	}
+
+	if _, ok := validStatsSinkTypes[statsSink.Type]; !ok {
+		log.Error().Err(errInvalidEnumValue).Msgf("Invalid type %q for key %s in ConfigMap %s; Disabling stats sink", statsSink.Type, statsSinkKey, c.getConfigMapCacheKey())
+		return nil
+	}
+	if net.ParseIP(statsSink.Address) == nil {
+		log.Error().Err(errInvalidIPAddress).Msgf("Invalid address %q for key %s in ConfigMap %s; Disabling stats sink", statsSink.Address, statsSinkKey, c.getConfigMapCacheKey())
+		return nil
+	}
+	if !isValidPort(int(statsSink.Port)) {
+		log.Error().Err(errInvalidPort).Msgf("Invalid port %d for key %s in ConfigMap %s; Disabling stats sink", statsSink.Port, statsSinkKey, c.getConfigMapCacheKey())
+		return nil
+	}
+
+	return &statsSink
+}
+
+// GetWASMExtensions returns the WebAssembly extensions run by the Envoy proxies on the HTTP requests, in order, with
+// their insertion point defaulted to inbound. The extensions without a name or a local module, with an unknown
+// insertion point, or with the name of a previous extension are skipped.
+func (c *Client) GetWASMExtensions() []WASMExtensionSpec {
+	var wasmExtensions []WASMExtensionSpec
+	names := make(map[string]bool)
+	for i, wasmExtension := range c.getConfigMap().WASMExtensions {
+		if errs := wasmExtension.validate(i); len(errs) > 0 {
+			log.Warn().Msgf("Invalid WASM extension %q for key %s in ConfigMap %s: %v; Skipping WASM extension", wasmExtension.Name, wasmExtensionsKey, c.getConfigMapCacheKey(), errs)
+			continue
+		}
+		if names[wasmExtension.Name] {
+			log.Warn().Msgf("Duplicate WASM extension %q for key %s in ConfigMap %s; Skipping WASM extension", wasmExtension.Name, wasmExtensionsKey, c.getConfigMapCacheKey())
+			continue
+		}
+		names[wasmExtension.Name] = true
+
+		if wasmExtension.InsertionPoint == "" {
+			wasmExtension.InsertionPoint = WASMInsertionPointInbound
+		}
+		wasmExtensions = append(wasmExtensions, wasmExtension)
+	}
+	return wasmExtensions
+}
+
+// ModulePath returns the path, in the proxy container, of the WebAssembly module of the extension, whose URI is an
+// absolute path or a file:// URI, or false when the URI is neither.
+func (wasmExtension WASMExtensionSpec) ModulePath() (string, bool) {
+	parsedURI, err := url.Parse(wasmExtension.URI)
+	if err != nil {
+		return "", false
+	}
+	if parsedURI.Scheme == "file" && parsedURI.Host == "" && path.IsAbs(parsedURI.Path) {
+		return parsedURI.Path, true
+	}
+	if parsedURI.Scheme == "" && path.IsAbs(wasmExtension.URI) {
+		return wasmExtension.URI, true
+	}
+	return "", false
+}
+
+// IsFeatureEnabled returns whether the feature flag with the given name is enabled; unknown flags are disabled.
+func (c *Client) IsFeatureEnabled(name string) bool {
+	return c.getConfigMap().FeatureFlags[name]
+}
+
+// GetFeatureFlags returns a copy of the feature flags, keyed by the feature name.
+func (c *Client) GetFeatureFlags() map[string]bool {
+	featureFlags := make(map[string]bool)
+	for name, enabled := range c.getConfigMap().FeatureFlags {
+		featureFlags[name] = enabled
+	}
+	return featureFlags
+}
+
+// GetMetricsCollector returns the Prometheus collector exposing the active OSM config as gauges.
+func (c *Client) GetMetricsCollector() prometheus.Collector {
+	return c.metrics
+}
+
+// isValidPort returns whether the given port is a valid TCP/UDP port number
+func isValidPort(port int) bool {
+	return port >= minPort && port <= maxPort
+}
+
+// prometheusMetricNamePattern matches the valid Prometheus metric names
+var prometheusMetricNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// prometheusLabelNamePattern matches the valid Prometheus label names
+var prometheusLabelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// reservedProxyEnvVarPrefix is the prefix of the environment variables reserved for OSM
+const reservedProxyEnvVarPrefix = "OSM_"
+
+// isReservedProxyEnvVar returns whether the given environment variable is reserved for the variables OSM sets itself
+func isReservedProxyEnvVar(name string) bool {
+	_, reserved := reservedProxyEnvVars[name]
+	return reserved || strings.HasPrefix(name, reservedProxyEnvVarPrefix)
+}
+
+// imageReferencePattern matches the container image references: an optional registry, which is localhost, a domain
+// name with several labels, or a host with a port, followed by a lowercase repository path, an optional tag and an
+// optional sha256 digest
+var imageReferencePattern = regexp.MustCompile(`^((localhost|` + domainLabelPattern + `(\.` + domainLabelPattern + `)+)(:[0-9]+)?/|` + domainLabelPattern + `:[0-9]+/)?` +
+	`[a-z0-9]+([._-][a-z0-9]+)*(/[a-z0-9]+([._-][a-z0-9]+)*)*` +
+	`(:[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127})?(@sha256:[a-f0-9]{64})?$`)
+
+// domainLabelPattern matches a label of the domain name of an image registry
+const domainLabelPattern = `[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?`
+
+// isValidImageReference returns whether the given image is a well-formed container image reference, such as
+// registry.example.com:5000/envoyproxy/envoy-alpine:v1.15.0
+func isValidImageReference(image string) bool {
+	return imageReferencePattern.MatchString(image)
+}
+
+// isValidHost returns whether the given address is an IP address or a DNS name, without a scheme, a port or a path;
+// DNS names are case-insensitive and may be fully qualified with a trailing dot
+func isValidHost(address string) bool {
+	if net.ParseIP(address) != nil {
+		return true
+	}
+	return len(validation.IsDNS1123Subdomain(strings.ToLower(strings.TrimSuffix(address, ".")))) == 0
+}
+
+// isValidBindAddress returns whether the given address is an IP address or ProxyBindAddressPodIP
+func isValidBindAddress(address string) bool {
+	return address == ProxyBindAddressPodIP || net.ParseIP(address) != nil
+}
+
+// normalizeDomain returns the domain lowercased and without its trailing dot
+func normalizeDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(domain), ".")
+}
+
+// isValidDomain returns whether the given normalized domain is a DNS name, or a wildcard DNS name such as *.example.com
+func isValidDomain(domain string) bool {
+	if strings.HasPrefix(domain, "*.") {
+		return len(validation.IsWildcardDNS1123Subdomain(domain)) == 0
+	}
+	return len(validation.IsDNS1123Subdomain(domain)) == 0
+}
+
+// isValidTrustDomain returns whether the given trust domain is a lowercase DNS name without a trailing dot
+func isValidTrustDomain(trustDomain string) bool {
+	return len(validation.IsDNS1123Subdomain(trustDomain)) == 0
+}
+
+// isValidNamespace returns whether the given namespace is a valid namespace name, i.e. a DNS label
+func isValidNamespace(namespace string) bool {
+	return len(validation.IsDNS1123Label(namespace)) == 0
+}
+
+// parseCABundleRef parses the given reference, of the form <namespace>/<name>[/<key>], to the Secret key holding a CA
+// bundle; the key defaults to ca.crt
+func parseCABundleRef(ref string) (*CABundleRef, error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, errors.Wrapf(errInvalidCABundleRef, "%q is not of the form <namespace>/<name>[/<key>]", ref)
+	}
+
+	caBundleRef := &CABundleRef{
+		Namespace: parts[0],
+		Name:      parts[1],
+		Key:       constants.KubernetesOpaqueSecretCAKey,
+	}
+	if len(parts) == 3 {
+		caBundleRef.Key = parts[2]
+	}
+
+	if !isValidNamespace(caBundleRef.Namespace) {
+		return nil, errors.Wrapf(errInvalidCABundleRef, "%q has an invalid namespace %q", ref, caBundleRef.Namespace)
+	}
+	if len(validation.IsDNS1123Subdomain(caBundleRef.Name)) > 0 {
+		return nil, errors.Wrapf(errInvalidCABundleRef, "%q has an invalid Secret name %q", ref, caBundleRef.Name)
+	}
+	if len(validation.IsConfigMapKey(caBundleRef.Key)) > 0 {
+		return nil, errors.Wrapf(errInvalidCABundleRef, "%q has an invalid Secret key %q", ref, caBundleRef.Key)
+	}
+	return caBundleRef, nil
+}
+
+// parseSecretKeyRef parses the given reference, of the form <namespace>/<name>/<key>, to the key of a Secret
+func parseSecretKeyRef(ref string) (*SecretKeyRef, error) {
+	parts := strings.Split(ref, "/")
+	if len(parts) != 3 {
+		return nil, errors.Wrapf(errInvalidSecretKeyRef, "%q is not of the form <namespace>/<name>/<key>", ref)
+	}
+
+	secretKeyRef := &SecretKeyRef{
+		Namespace: parts[0],
+		Name:      parts[1],
+		Key:       parts[2],
+	}
+	if !isValidNamespace(secretKeyRef.Namespace) {
+		return nil, errors.Wrapf(errInvalidSecretKeyRef, "%q has an invalid namespace %q", ref, secretKeyRef.Namespace)
+	}
+	if len(validation.IsDNS1123Subdomain(secretKeyRef.Name)) > 0 {
+		return nil, errors.Wrapf(errInvalidSecretKeyRef, "%q has an invalid Secret name %q", ref, secretKeyRef.Name)
+	}
+	if len(validation.IsConfigMapKey(secretKeyRef.Key)) > 0 {
+		return nil, errors.Wrapf(errInvalidSecretKeyRef, "%q has an invalid Secret key %q", ref, secretKeyRef.Key)
+	}
+	return secretKeyRef, nil
+}
+
+// GetAnnouncementsChannel returns a channel, which is used to announce when changes have been made to the OSM ConfigMap.
 func (c *Client) GetAnnouncementsChannel() <-chan interface{} {
 	return c.announcements
 }
+
+// GetTypedAnnouncementsChannel returns a channel, which is used to announce which fields of the OSM ConfigMap changed.
+// The channel is created on the first call, so only the changes made from then on are announced on it.
+func (c *Client) GetTypedAnnouncementsChannel() <-chan ConfigChangeEvent {
+	c.typedAnnouncementsLock.Lock()
+	defer c.typedAnnouncementsLock.Unlock()
+	if c.typedAnnouncements == nil {
+		if c.isClosed() {
+			// The client no longer sends events, so a closed channel is returned
+			closed := make(chan ConfigChangeEvent)
+			close(closed)
+			return closed
+		}
+		c.typedAnnouncements = make(chan ConfigChangeEvent, announcementsBufferSize)
+	}
+	return c.typedAnnouncements
+}
@@ -0,0 +1,72 @@
+//go:build go1.18
+// +build go1.18
+
+package configurator
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// FuzzGetMeshCIDRRanges checks that the mesh CIDR ranges are a sorted list of distinct valid CIDRs whatever the
+// ConfigMap holds
+func FuzzGetMeshCIDRRanges(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"10.0.0.0/16",
+		",  8.8.8.8/24   ,  ,  1.1.0.0/8,   8.8.8.8/24   , someIncorrectlyFormattedCIDR ",
+		"fd00::/8 10.2.0.0/16, 2001:db8::/32 10.0.0.0/16 fd00::/8",
+		"10.0.0.0/33 10.0.0.0/-1 10.0.0.0 /8 ::ffff:10.0.0.0/104",
+		" 10.0.0.0/8 fd00::/8\u0085\xff10.1.0.0/16\xff",
+		strings.Repeat(",\t", 1024),
+		"10.0.0.0/" + strings.Repeat("0", 1024) + "8",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, meshCIDRRanges string) {
+		cfg := newClient("-test-osm-namespace-", "-test-osm-config-map-")
+		cfg.setConfig(&MeshConfig{MeshCIDRRanges: meshCIDRRanges}, "", nil)
+
+		cidrs := cfg.GetMeshCIDRRanges()
+		if !sort.StringsAreSorted(cidrs) {
+			t.Fatalf("mesh CIDR ranges %q are not sorted", cidrs)
+		}
+		for i, cidr := range cidrs {
+			if i > 0 && cidrs[i-1] == cidr {
+				t.Fatalf("mesh CIDR range %q is duplicated in %q", cidr, cidrs)
+			}
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				t.Fatalf("mesh CIDR range %q is invalid: %v", cidr, err)
+			}
+		}
+
+		ipv4CIDRs, ipv6CIDRs := cfg.GetMeshCIDRRangesByFamily()
+		if len(ipv4CIDRs)+len(ipv6CIDRs) != len(cidrs) {
+			t.Fatalf("mesh CIDR ranges %q are split into %q and %q", cidrs, ipv4CIDRs, ipv6CIDRs)
+		}
+
+		ipNets, err := cfg.GetMeshCIDRRangesParsed()
+		if err != nil {
+			t.Fatalf("error parsing mesh CIDR ranges %q with egress disabled: %v", cidrs, err)
+		}
+		if len(ipNets) > len(cidrs) {
+			t.Fatalf("mesh CIDR ranges %q are parsed into %d networks", cidrs, len(ipNets))
+		}
+
+		// The config validation reports every entry skipped by the parsing
+		parsedCIDRs := cfg.parseMeshCIDRRanges()
+		skippedCIDRs := 0
+		for _, cidr := range parseDelimitedList(meshCIDRRanges) {
+			if _, ok := parsedCIDRs[cidr]; !ok {
+				skippedCIDRs++
+			}
+		}
+		config := MeshConfig{MeshCIDRRanges: meshCIDRRanges}
+		if errs := config.validateMeshCIDRRanges(); len(errs) != skippedCIDRs {
+			t.Fatalf("validation reports %d errors for the %d mesh CIDR ranges skipped in %q", len(errs), skippedCIDRs, meshCIDRRanges)
+		}
+	})
+}
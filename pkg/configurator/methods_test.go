@@ -1,14 +1,25 @@
 package configurator
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testclient "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/openservicemesh/osm/pkg/constants"
 )
 
 var _ = Describe("Test Envoy configuration creation", func() {
@@ -35,6 +46,31 @@ var _ = Describe("Test Envoy configuration creation", func() {
 		})
 	})
 
+	Context("parse delimited lists", func() {
+		It("splits on commas and whitespace and drops the empty entries", func() {
+			for raw, expected := range map[string][]string{
+				"":                       nil,
+				" ,\t, ":                 nil,
+				"a":                      {"a"},
+				"a,b,c":                  {"a", "b", "c"},
+				"a b c":                  {"a", "b", "c"},
+				"a\tb\t\tc":              {"a", "b", "c"},
+				"a    b":                 {"a", "b"},
+				"a,b,c,":                 {"a", "b", "c"},
+				",a,,b":                  {"a", "b"},
+				"a, b ,c  d,\te\n":       {"a", "b", "c", "d", "e"},
+				"10.0.0.0/8, fd00::/64 ": {"10.0.0.0/8", "fd00::/64"},
+			} {
+				actual := parseDelimitedList(raw)
+				if len(expected) == 0 {
+					Expect(actual).To(BeEmpty(), raw)
+					continue
+				}
+				Expect(actual).To(Equal(expected), raw)
+			}
+		})
+	})
+
 	Context("create OSM config with default values", func() {
 		kubeClient := testclient.NewSimpleClientset()
 		stop := make(chan struct{})
@@ -55,21 +91,299 @@ var _ = Describe("Test Envoy configuration creation", func() {
 
 			<-cfg.GetAnnouncementsChannel()
 
-			expectedConfig := &osmConfig{
-				PermissiveTrafficPolicyMode: false,
-				Egress:                      true,
-				PrometheusScraping:          true,
-				TracingEnable:               true,
-				MeshCIDRRanges:              testCIDRRanges,
-				EnvoyLogLevel:               testDebugEnvoyLogLevel,
-			}
-			expectedConfigBytes, err := marshalConfigToJSON(expectedConfig)
+			expectedConfig := defaultConfig.deepCopy()
+			expectedConfig.PermissiveTrafficPolicyMode = false
+			expectedConfig.Egress = true
+			expectedConfig.PrometheusScraping = true
+			expectedConfig.TracingEnable = true
+			expectedConfig.MeshCIDRRanges = testCIDRRanges
+			expectedConfig.EnvoyLogLevel = testDebugEnvoyLogLevel
+			expectedConfigBytes, err := marshalConfigToJSON(&expectedConfig)
 			Expect(err).ToNot(HaveOccurred())
 
 			configBytes, err := cfg.GetConfigMap()
 			Expect(err).ToNot(HaveOccurred())
 			Expect(string(configBytes)).To(Equal(string(expectedConfigBytes)))
 		})
+
+		It("streams the same JSON as GetConfigMap", func() {
+			configBytes, err := cfg.GetConfigMap()
+			Expect(err).ToNot(HaveOccurred())
+
+			var streamed bytes.Buffer
+			Expect(cfg.WriteConfigMap(&streamed)).To(Succeed())
+			Expect(streamed.Bytes()).To(Equal(append(configBytes, '\n')))
+		})
+
+		It("returns the same config in YAML as in JSON", func() {
+			configJSON, err := cfg.GetConfigMap()
+			Expect(err).ToNot(HaveOccurred())
+			configYAML, err := cfg.GetConfigMapYAML()
+			Expect(err).ToNot(HaveOccurred())
+
+			var configFromJSON, configFromYAML MeshConfig
+			Expect(json.Unmarshal(configJSON, &configFromJSON)).To(Succeed())
+			Expect(yaml.UnmarshalStrict(configYAML, &configFromYAML)).To(Succeed())
+			Expect(configFromYAML).To(Equal(configFromJSON))
+			Expect(string(configYAML)).To(ContainSubstring(envoyLogLevel + ": " + testDebugEnvoyLogLevel))
+		})
+	})
+
+	Context("create OSM config and redact the sensitive fields", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("leaves the config without sensitive fields intact", func() {
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: defaultConfigMap,
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			expectedConfigBytes, err := cfg.GetConfigMap()
+			Expect(err).ToNot(HaveOccurred())
+			redactedConfigBytes, err := cfg.GetRedactedConfigMap()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(redactedConfigBytes)).To(Equal(string(expectedConfigBytes)))
+		})
+
+		It("masks the sensitive fields and keeps the other fields", func() {
+			type credentials struct {
+				Username string
+				Password string `osm:"sensitive"`
+			}
+			type config struct {
+				Address     string
+				Token       string            `osm:"sensitive"`
+				EmptyToken  string            `osm:"sensitive"`
+				Headers     map[string]string `osm:"sensitive"`
+				Port        int               `osm:"sensitive"`
+				Credentials credentials
+				Labels      map[string]string
+			}
+			headers := map[string]string{"Authorization": "Bearer token"}
+			actual := config{
+				Address:     "vault.osm-system.svc.cluster.local",
+				Token:       "s.token",
+				Headers:     headers,
+				Port:        8200,
+				Credentials: credentials{Username: "osm", Password: "secret"},
+				Labels:      map[string]string{"app": "vault"},
+			}
+
+			redactSensitiveFields(reflect.ValueOf(&actual).Elem())
+			Expect(actual).To(Equal(config{
+				Address:     "vault.osm-system.svc.cluster.local",
+				Token:       redactedValue,
+				Headers:     map[string]string{"Authorization": redactedValue},
+				Credentials: credentials{Username: "osm", Password: redactedValue},
+				Labels:      map[string]string{"app": "vault"},
+			}))
+			// The masked map is a copy, so the config the redacted copy was made from keeps its values
+			Expect(headers).To(Equal(map[string]string{"Authorization": "Bearer token"}))
+		})
+	})
+
+	Context("create OSM config and hash it", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("hashes the effective config", func() {
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: defaultConfigMap,
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			expectedHash, err := hashConfig(mergeOverDefaultConfig(&configMap))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfg.GetConfigHash()).To(Equal(expectedHash))
+			Expect(cfg.GetConfigHash()).To(MatchRegexp("^[0-9a-f]{64}$"))
+		})
+
+		It("hashes equal configs identically and different configs differently", func() {
+			config := mergeOverDefaultConfig(&v1.ConfigMap{Data: defaultConfigMap})
+			equalConfig := config.deepCopy()
+			changedConfig := config.deepCopy()
+			changedConfig.EnvoyLogLevel = "trace"
+
+			hash, err := hashConfig(config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(hashConfig(&equalConfig)).To(Equal(hash))
+			Expect(hashConfig(&changedConfig)).ToNot(Equal(hash))
+		})
+	})
+
+	Context("create OSM config snapshot", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("returns a copy of the config rather than the cached config", func() {
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					egressKey:              "true",
+					tracingEnableKey:       "true",
+					tracingSamplingRateKey: "0.5",
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+
+			meshConfig := cfg.GetMeshConfig()
+			Expect(meshConfig).To(Equal(*cfg.getConfigMap()))
+			Expect(&meshConfig).ToNot(BeIdenticalTo(cfg.getConfigMap()))
+			Expect(meshConfig.TracingSamplingRate).ToNot(BeIdenticalTo(cfg.getConfigMap().TracingSamplingRate))
+
+			meshConfig.Egress = false
+			*meshConfig.TracingSamplingRate = 0.1
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+			Expect(cfg.GetTracingSamplingRate()).To(Equal(0.5))
+		})
+	})
+
+	Context("create OSM config and track the ConfigMap resourceVersion", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		typedAnnouncements := cfg.GetTypedAnnouncementsChannel()
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       osmNamespace,
+				Name:            osmConfigMapName,
+				ResourceVersion: "1",
+			},
+			Data: map[string]string{
+				egressKey: "true",
+			},
+		}
+
+		It("returns an empty resourceVersion when the ConfigMap does not exist", func() {
+			Expect(cfg.GetConfigResourceVersion()).To(BeEmpty())
+		})
+
+		It("returns the resourceVersion of the created ConfigMap", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+			event := <-typedAnnouncements
+
+			Expect(cfg.GetConfigResourceVersion()).To(Equal("1"))
+			Expect(event.ResourceVersion).To(Equal("1"))
+		})
+
+		It("returns the resourceVersion of the updated ConfigMap", func() {
+			configMap.ResourceVersion = "2"
+			configMap.Data[egressKey] = "false"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+			event := <-typedAnnouncements
+
+			Expect(cfg.GetConfigResourceVersion()).To(Equal("2"))
+			Expect(event.ResourceVersion).To(Equal("2"))
+		})
+	})
+
+	Context("create OSM config and take a snapshot of it", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       osmNamespace,
+				Name:            osmConfigMapName,
+				ResourceVersion: "1",
+			},
+			Data: map[string]string{
+				egressKey:     "true",
+				envoyLogLevel: "verbose",
+			},
+		}
+
+		It("returns a snapshot whose fields are consistent with the config", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			snapshot := cfg.Snapshot()
+			Expect(snapshot.Config).To(Equal(cfg.GetMeshConfig()))
+			Expect(snapshot.Config.Egress).To(BeTrue())
+
+			hash, err := hashConfig(&snapshot.Config)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(snapshot.Hash).To(Equal(hash))
+			Expect(snapshot.Hash).To(Equal(cfg.GetConfigHash()))
+
+			Expect(snapshot.ResourceVersion).To(Equal("1"))
+			Expect(snapshot.Provenance).To(Equal(cfg.GetConfigProvenance()))
+			Expect(snapshot.Provenance["Egress"]).To(Equal(ProvenanceConfigMap))
+			Expect(snapshot.Provenance["TracingPort"]).To(Equal(ProvenanceDefault))
+
+			Expect(snapshot.ValidationErrors).To(HaveLen(1))
+			Expect(snapshot.ValidationErrors[0]).To(ContainSubstring(errInvalidLogLevel.Error()))
+		})
+
+		It("returns a snapshot which is not modified by the later changes", func() {
+			snapshot := cfg.Snapshot()
+
+			configMap.ResourceVersion = "2"
+			configMap.Data[envoyLogLevel] = "info"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(snapshot.ResourceVersion).To(Equal("1"))
+			Expect(snapshot.Config.EnvoyLogLevel).To(Equal("verbose"))
+			Expect(snapshot.Hash).ToNot(Equal(cfg.GetConfigHash()))
+
+			newSnapshot := cfg.Snapshot()
+			Expect(newSnapshot.ResourceVersion).To(Equal("2"))
+			Expect(newSnapshot.Hash).To(Equal(cfg.GetConfigHash()))
+			Expect(newSnapshot.ValidationErrors).To(BeEmpty())
+		})
 	})
 
 	Context("create OSM config for permissive_traffic_policy_mode", func() {
@@ -125,6 +439,108 @@ var _ = Describe("Test Envoy configuration creation", func() {
 		})
 	})
 
+	Context("create OSM config for the permissive mode audit logging", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to disabling the audit logging when it is unset", func() {
+			Expect(cfg.IsPermissiveAuditLoggingEnabled()).To(BeFalse())
+			configMap.Data[permissiveTrafficPolicyModeKey] = "true"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsPermissiveAuditLoggingEnabled()).To(BeFalse())
+		})
+
+		It("correctly enables the audit logging only in permissive traffic policy mode", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, mode := range []struct {
+				permissive   string
+				auditLogging string
+				expected     bool
+			}{
+				{"true", "true", true},
+				{"false", "true", false},
+				{"true", "false", false},
+			} {
+				configMap.Data[permissiveTrafficPolicyModeKey] = mode.permissive
+				configMap.Data[permissiveModeAuditLoggingKey] = mode.auditLogging
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.IsPermissiveAuditLoggingEnabled()).To(Equal(mode.expected), fmt.Sprintf("%+v", mode))
+			}
+		})
+	})
+
+	Context("create OSM config for the SMI spec version", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to the latest supported version when it is unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetSMISpecVersion()).To(Equal(SMISpecVersionV1alpha4))
+		})
+
+		It("correctly returns the configured version, or falls back on the latest supported version when it is unsupported", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, smiSpecVersion := range []struct {
+				value    string
+				expected string
+			}{
+				{SMISpecVersionV1alpha3, SMISpecVersionV1alpha3},
+				{"v1alpha2", SMISpecVersionV1alpha4},
+				{"V1alpha3", SMISpecVersionV1alpha4},
+				{SMISpecVersionV1alpha4, SMISpecVersionV1alpha4},
+				{"v1alpha5", SMISpecVersionV1alpha4},
+			} {
+				configMap.Data[smiSpecVersionKey] = smiSpecVersion.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetSMISpecVersion()).To(Equal(smiSpecVersion.expected), "SMI spec version %q", smiSpecVersion.value)
+			}
+		})
+	})
+
 	Context("create OSM config for egress", func() {
 		kubeClient := testclient.NewSimpleClientset()
 		stop := make(chan struct{})
@@ -281,22 +697,24 @@ var _ = Describe("Test Envoy configuration creation", func() {
 		})
 	})
 
-	Context("create OSM config for mesh CIDR ranges", func() {
+	Context("create OSM config for the tracing sampling rate", func() {
 		kubeClient := testclient.NewSimpleClientset()
 		stop := make(chan struct{})
 		osmNamespace := "-test-osm-namespace-"
 		osmConfigMapName := "-test-osm-config-map-"
 		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				tracingEnableKey: "true",
+			},
+		}
 
-		It("correctly retrieves the mesh CIDR ranges", func() {
-			Expect(cfg.GetMeshCIDRRanges()).To(BeEmpty())
-			configMap := v1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: osmNamespace,
-					Name:      osmConfigMapName,
-				},
-				Data: defaultConfigMap,
-			}
+		It("correctly defaults the tracing sampling rate when unset", func() {
+			Expect(cfg.GetTracingSamplingRate()).To(Equal(1.0))
 			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
@@ -304,29 +722,48 @@ var _ = Describe("Test Envoy configuration creation", func() {
 			log.Info().Msg("Waiting for announcement")
 			<-cfg.GetAnnouncementsChannel()
 
-			expectedMeshCIDRRanges := []string{"10.0.0.0/16", "10.2.0.0/16"}
-			Expect(cfg.GetMeshCIDRRanges()).To(Equal(expectedMeshCIDRRanges))
+			Expect(cfg.GetTracingSamplingRate()).To(Equal(1.0))
+		})
+
+		It("correctly retrieves the tracing sampling rate", func() {
+			for value, expected := range map[string]float64{
+				"0.25": 0.25,
+				"0":    0,
+				"-0.5": 0,
+				"1.5":  1,
+			} {
+				configMap.Data[tracingSamplingRateKey] = value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetTracingSamplingRate()).To(Equal(expected), value)
+			}
 		})
 	})
 
-	Context("create OSM config for the Envoy proxy log level", func() {
+	Context("create OSM config for the tracing port", func() {
 		kubeClient := testclient.NewSimpleClientset()
 		stop := make(chan struct{})
 		osmNamespace := "-test-osm-namespace-"
 		osmConfigMapName := "-test-osm-config-map-"
-		testInfoEnvoyLogLevel := "info"
-		testErrorEnvoyLogLevel := "error"
 		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				tracingEnableKey: "true",
+				tracingPortKey:   "0",
+			},
+		}
 
-		It("correctly identifies that the Envoy log level is debug", func() {
-			Expect(cfg.GetEnvoyLogLevel()).To(Equal(testDebugEnvoyLogLevel))
-			configMap := v1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: osmNamespace,
-					Name:      osmConfigMapName,
-				},
-				Data: defaultConfigMap,
-			}
+		It("correctly defaults the tracing port when zero", func() {
+			Expect(cfg.GetTracingPort()).To(Equal(constants.DefaultTracingPort))
 			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
@@ -334,36 +771,3501 @@ var _ = Describe("Test Envoy configuration creation", func() {
 			log.Info().Msg("Waiting for announcement")
 			<-cfg.GetAnnouncementsChannel()
 
-			Expect(cfg.GetOSMNamespace()).To(Equal(osmNamespace))
-			Expect(err).ToNot(HaveOccurred())
-
-			Expect(cfg.GetEnvoyLogLevel()).To(Equal(testDebugEnvoyLogLevel))
+			Expect(cfg.GetTracingPort()).To(Equal(constants.DefaultTracingPort))
 		})
 
-		It("correctly identifies that Envoy log level is info", func() {
-			defaultConfigMap[envoyLogLevel] = testInfoEnvoyLogLevel
-			configMap := v1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Namespace: osmNamespace,
-					Name:      osmConfigMapName,
-				},
-				Data: defaultConfigMap,
+		It("correctly retrieves valid tracing ports and defaults out-of-range ones", func() {
+			for value, expected := range map[string]uint32{
+				"1":     1,
+				"9412":  9412,
+				"65535": 65535,
+				"65536": constants.DefaultTracingPort,
+				"99999": constants.DefaultTracingPort,
+				"-1":    constants.DefaultTracingPort,
+			} {
+				configMap.Data[tracingPortKey] = value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetTracingPort()).To(Equal(expected), value)
 			}
-			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+		})
+	})
+
+	Context("create OSM config for the tracing host", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				tracingEnableKey:  "true",
+				tracingAddressKey: "zipkin",
+			},
+		}
+		defaultTracingHost := "jaeger.-test-osm-namespace-.svc.cluster.local"
+
+		It("correctly retrieves a bare hostname", func() {
+			Expect(cfg.GetTracingHost()).To(Equal(defaultTracingHost))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
 			Expect(err).ToNot(HaveOccurred())
 
 			// Wait for the config map change to propagate to the cache.
 			log.Info().Msg("Waiting for announcement")
 			<-cfg.GetAnnouncementsChannel()
 
-			Expect(cfg.GetOSMNamespace()).To(Equal(osmNamespace))
-			Expect(err).ToNot(HaveOccurred())
+			Expect(cfg.GetTracingHost()).To(Equal("zipkin"))
+		})
 
-			Expect(cfg.GetEnvoyLogLevel()).To(Equal(testInfoEnvoyLogLevel))
+		It("correctly retrieves valid hosts and defaults invalid ones", func() {
+			for value, expected := range map[string]string{
+				"zipkin.osm-system.svc.cluster.local":  "zipkin.osm-system.svc.cluster.local",
+				"zipkin.osm-system.svc.cluster.local.": "zipkin.osm-system.svc.cluster.local.",
+				"Zipkin.Example.com":                   "Zipkin.Example.com",
+				"10.0.0.1":                             "10.0.0.1",
+				"fd00::1":                              "fd00::1",
+				"zipkin:9411":                          defaultTracingHost,
+				"http://zipkin":                        defaultTracingHost,
+				"zipkin/api/v2/spans":                  defaultTracingHost,
+				"zip kin":                              defaultTracingHost,
+			} {
+				configMap.Data[tracingAddressKey] = value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetTracingHost()).To(Equal(expected), value)
+			}
 		})
 
-		It("correctly identifies that Envoy log level is error", func() {
-			defaultConfigMap[envoyLogLevel] = testErrorEnvoyLogLevel
+		It("correctly retrieves the comma separated hosts in order, skipping the invalid ones", func() {
+			for _, hosts := range []struct {
+				value    string
+				expected []string
+			}{
+				{"zipkin", []string{"zipkin"}},
+				{"zipkin-0.osm-system, zipkin-1.osm-system,10.0.0.1", []string{"zipkin-0.osm-system", "zipkin-1.osm-system", "10.0.0.1"}},
+				{"zipkin-1,zipkin-0,zipkin-1", []string{"zipkin-1", "zipkin-0"}},
+				{"http://zipkin-0,zipkin-1,zipkin:9411,,zip kin", []string{"zipkin-1"}},
+				{"http://zipkin-0,zipkin:9411", []string{defaultTracingHost}},
+			} {
+				configMap.Data[tracingAddressKey] = hosts.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetTracingHosts()).To(Equal(hosts.expected), hosts.value)
+				Expect(cfg.GetTracingHost()).To(Equal(hosts.expected[0]), hosts.value)
+			}
+		})
+	})
+
+	Context("create OSM config for the tracing endpoint", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				tracingEnableKey:   "true",
+				tracingEndpointKey: "/api/v1/spans",
+			},
+		}
+		defaultTracingEndpoint := "/api/v2/spans"
+
+		It("correctly retrieves a path", func() {
+			Expect(cfg.GetTracingEndpoint()).To(Equal(defaultTracingEndpoint))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetTracingEndpoint()).To(Equal("/api/v1/spans"))
+		})
+
+		It("correctly normalizes paths and defaults invalid endpoints", func() {
+			for value, expected := range map[string]string{
+				"api/v2/spans":                    "/api/v2/spans",
+				"  /api/v2/spans\n":               "/api/v2/spans",
+				" api/v1/spans ":                  "/api/v1/spans",
+				"":                                defaultTracingEndpoint,
+				"   ":                             defaultTracingEndpoint,
+				"http://zipkin:9411/api/v2/spans": defaultTracingEndpoint,
+				"https://zipkin/api/v2/spans":     defaultTracingEndpoint,
+				"//zipkin/api/v2/spans":           defaultTracingEndpoint,
+				"zipkin:9411/api/v2/spans":        defaultTracingEndpoint,
+				"/api/v2/my spans":                defaultTracingEndpoint,
+			} {
+				configMap.Data[tracingEndpointKey] = value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetTracingEndpoint()).To(Equal(expected), value)
+			}
+		})
+	})
+
+	Context("create OSM config for the tracing backend", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				tracingEnableKey: "true",
+			},
+		}
+
+		It("correctly defaults the tracing backend when unset", func() {
+			Expect(cfg.GetTracingBackend()).To(Equal(constants.DefaultTracingBackend))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetTracingBackend()).To(Equal(TracingBackendZipkin))
+		})
+
+		It("correctly retrieves the tracing backend", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, backend := range []struct {
+				value    string
+				expected string
+			}{
+				{TracingBackendJaeger, TracingBackendJaeger},
+				{TracingBackendOTLP, TracingBackendOTLP},
+				{TracingBackendZipkin, TracingBackendZipkin},
+				{"datadog", TracingBackendZipkin},
+			} {
+				configMap.Data[tracingBackendKey] = backend.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetTracingBackend()).To(Equal(backend.expected), backend.value)
+			}
+		})
+	})
+
+	Context("create OSM config for mesh CIDR ranges", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("correctly retrieves the mesh CIDR ranges", func() {
+			Expect(cfg.GetMeshCIDRRanges()).To(BeEmpty())
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: defaultConfigMap,
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			expectedMeshCIDRRanges := []string{"10.0.0.0/16", "10.2.0.0/16"}
+			Expect(cfg.GetMeshCIDRRanges()).To(Equal(expectedMeshCIDRRanges))
+		})
+	})
+
+	Context("create OSM config for parsed mesh CIDR ranges", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("correctly retrieves deduplicated and sorted parsed mesh CIDR ranges", func() {
+			ipNets, err := cfg.GetMeshCIDRRangesParsed()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ipNets).To(BeEmpty())
+
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					egressKey:         "true",
+					meshCIDRRangesKey: "10.1.0.0/16 10.0.0.0/8,  fd00::/8   8.8.8.8/24,8.8.8.0/24 someIncorrectlyFormattedCIDR",
+				},
+			}
+			_, err = kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			ipNets, err = cfg.GetMeshCIDRRangesParsed()
+			Expect(err).ToNot(HaveOccurred())
+
+			var actual []string
+			for _, ipNet := range ipNets {
+				actual = append(actual, ipNet.String())
+			}
+			Expect(actual).To(Equal([]string{"10.0.0.0/8", "10.1.0.0/16", "8.8.8.0/24", "fd00::/8"}))
+		})
+
+		It("returns an error when egress is enabled without a single valid mesh CIDR range", func() {
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					egressKey:         "true",
+					meshCIDRRangesKey: "someIncorrectlyFormattedCIDR, 10.0.0.0/33",
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			ipNets, err := cfg.GetMeshCIDRRangesParsed()
+			Expect(err).To(Equal(errNoValidMeshCIDRRanges))
+			Expect(ipNets).To(BeNil())
+		})
+	})
+
+	Context("create OSM config for the mTLS exempt source CIDRs", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to enforcing mTLS for every source when it is unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetMTLSExemptSourceCIDRs()).To(BeEmpty())
+		})
+
+		It("correctly retrieves deduplicated and sorted source CIDRs, skipping the malformed ones", func() {
+			configMap.Data[mtlsExemptSourceCIDRsKey] = "10.240.0.0/16, fd00:240::/64\t10.240.0.1  10.240.0.0/16,10.1.0.0/33 192.168.1.10/24"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			var actual []string
+			for _, ipNet := range cfg.GetMTLSExemptSourceCIDRs() {
+				actual = append(actual, ipNet.String())
+			}
+			Expect(actual).To(Equal([]string{"10.240.0.0/16", "192.168.1.0/24", "fd00:240::/64"}))
+			Expect(errorCauses(cfg.ValidateConfig())).To(ConsistOf(errInvalidCIDR, errInvalidCIDR))
+		})
+	})
+
+	Context("create OSM config for mesh CIDR ranges of both IP families", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("correctly splits the mesh CIDR ranges by IP family", func() {
+			ipv4CIDRs, ipv6CIDRs := cfg.GetMeshCIDRRangesByFamily()
+			Expect(ipv4CIDRs).To(BeEmpty())
+			Expect(ipv6CIDRs).To(BeEmpty())
+
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					egressKey:         "true",
+					meshCIDRRangesKey: "fd00::/8 10.2.0.0/16, 2001:db8::/32 10.0.0.0/16 fd00::/8",
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			ipv4CIDRs, ipv6CIDRs = cfg.GetMeshCIDRRangesByFamily()
+			Expect(ipv4CIDRs).To(Equal([]string{"10.0.0.0/16", "10.2.0.0/16"}))
+			Expect(ipv6CIDRs).To(Equal([]string{"2001:db8::/32", "fd00::/8"}))
+		})
+	})
+
+	Context("create OSM config for the outbound port exclusion list", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				outboundPortExclusionListKey: "",
+			},
+		}
+
+		It("correctly returns no ports for an empty list", func() {
+			Expect(cfg.GetOutboundPortExclusionList()).To(BeNil())
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetOutboundPortExclusionList()).To(BeNil())
+		})
+
+		It("correctly retrieves the deduplicated, valid and sorted ports", func() {
+			configMap.Data[outboundPortExclusionListKey] = "6379, 3306 6379,, 0 65536 -1 notAPort 65535  1"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetOutboundPortExclusionList()).To(Equal([]int{1, 3306, 6379, 65535}))
+		})
+	})
+
+	Context("create OSM config for the inbound port exclusion list", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				inboundPortExclusionListKey: "  ",
+			},
+		}
+
+		It("correctly returns no ports for a whitespace-only list", func() {
+			Expect(cfg.GetInboundPortExclusionList()).To(BeNil())
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetInboundPortExclusionList()).To(BeNil())
+		})
+
+		It("correctly retrieves the deduplicated, valid and sorted ports", func() {
+			configMap.Data[inboundPortExclusionListKey] = "9091,8080 ,9091 0 70000 health"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetInboundPortExclusionList()).To(Equal([]int{8080, 9091}))
+			Expect(cfg.GetOutboundPortExclusionList()).To(BeNil())
+		})
+	})
+
+	Context("create OSM config for the feature flags", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				featureFlagsKey: "feature-a: true\nfeature-b: false\n",
+			},
+		}
+
+		It("correctly retrieves the feature flags", func() {
+			Expect(cfg.GetFeatureFlags()).To(BeEmpty())
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsFeatureEnabled("feature-a")).To(BeTrue())
+			Expect(cfg.IsFeatureEnabled("feature-b")).To(BeFalse())
+			Expect(cfg.GetFeatureFlags()).To(Equal(map[string]bool{"feature-a": true, "feature-b": false}))
+		})
+
+		It("correctly defaults unknown feature flags to disabled", func() {
+			Expect(cfg.IsFeatureEnabled("feature-unknown")).To(BeFalse())
+		})
+
+		It("correctly returns a copy of the feature flags", func() {
+			featureFlags := cfg.GetFeatureFlags()
+			featureFlags["feature-b"] = true
+			featureFlags["feature-c"] = true
+
+			Expect(cfg.IsFeatureEnabled("feature-b")).To(BeFalse())
+			Expect(cfg.IsFeatureEnabled("feature-c")).To(BeFalse())
+			Expect(cfg.GetFeatureFlags()).To(Equal(map[string]bool{"feature-a": true, "feature-b": false}))
+		})
+
+		It("correctly disables all feature flags when the value cannot be parsed", func() {
+			configMap.Data[featureFlagsKey] = "feature-a: enabled"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsFeatureEnabled("feature-a")).To(BeFalse())
+			Expect(cfg.GetFeatureFlags()).To(BeEmpty())
+		})
+	})
+
+	Context("create OSM config for the Envoy connection idle timeout", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				envoyConnectionIdleTimeoutKey: "0s",
+			},
+		}
+
+		It("correctly disables the idle timeout for a zero duration", func() {
+			Expect(cfg.GetEnvoyConnectionIdleTimeout()).To(Equal(constants.DefaultEnvoyConnectionIdleTimeout))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyConnectionIdleTimeout()).To(Equal(time.Duration(0)))
+		})
+
+		It("correctly retrieves the idle timeout", func() {
+			configMap.Data[envoyConnectionIdleTimeoutKey] = "1h"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyConnectionIdleTimeout()).To(Equal(time.Hour))
+		})
+
+		It("correctly falls back to the default idle timeout when the configured one cannot be parsed", func() {
+			configMap.Data[envoyConnectionIdleTimeoutKey] = "forever"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyConnectionIdleTimeout()).To(Equal(constants.DefaultEnvoyConnectionIdleTimeout))
+		})
+
+		It("correctly falls back to the default idle timeout when the configured one is negative", func() {
+			configMap.Data[envoyConnectionIdleTimeoutKey] = "-5m"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyConnectionIdleTimeout()).To(Equal(constants.DefaultEnvoyConnectionIdleTimeout))
+		})
+	})
+
+	Context("create OSM config for the Envoy request timeout", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults the request timeout when it is unset", func() {
+			Expect(cfg.GetEnvoyRequestTimeout()).To(Equal(constants.DefaultEnvoyRequestTimeout))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyRequestTimeout()).To(Equal(constants.DefaultEnvoyRequestTimeout))
+		})
+
+		It("correctly preserves a zero request timeout, which means no timeout", func() {
+			configMap.Data[envoyRequestTimeoutKey] = "0s"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyRequestTimeout()).To(Equal(time.Duration(0)))
+		})
+
+		It("correctly retrieves the request timeout", func() {
+			configMap.Data[envoyRequestTimeoutKey] = "30s"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyRequestTimeout()).To(Equal(30 * time.Second))
+		})
+
+		It("correctly falls back to the default request timeout when the configured one cannot be parsed", func() {
+			configMap.Data[envoyRequestTimeoutKey] = "30"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyRequestTimeout()).To(Equal(constants.DefaultEnvoyRequestTimeout))
+		})
+	})
+
+	Context("create OSM config for the xDS server response timeout", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+		defaultResponseTimeout := getDefaultDuration(defaultConfig.XDSServerResponseTimeout)
+
+		It("correctly defaults the response timeout when it is unset", func() {
+			Expect(defaultResponseTimeout).To(Equal(30 * time.Second))
+			Expect(cfg.GetXDSServerResponseTimeout()).To(Equal(defaultResponseTimeout))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetXDSServerResponseTimeout()).To(Equal(defaultResponseTimeout))
+		})
+
+		It("correctly retrieves the response timeout", func() {
+			configMap.Data[xdsServerResponseTimeoutKey] = "1m30s"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetXDSServerResponseTimeout()).To(Equal(90 * time.Second))
+		})
+
+		It("correctly falls back to the default response timeout when the configured one is invalid", func() {
+			for _, value := range []string{"30", "soon", "0s", "-5s"} {
+				configMap.Data[xdsServerResponseTimeoutKey] = value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetXDSServerResponseTimeout()).To(Equal(defaultResponseTimeout), value)
+			}
+		})
+	})
+
+	Context("create OSM config for the xDS keepalive", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults the keepalive to the gRPC defaults when it is unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetXDSKeepaliveTime()).To(Equal(2 * time.Hour))
+			Expect(cfg.GetXDSKeepaliveTimeout()).To(Equal(20 * time.Second))
+		})
+
+		It("correctly retrieves the keepalive, or falls back on the defaults when it is invalid", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, keepalive := range []struct {
+				time            string
+				timeout         string
+				expectedTime    time.Duration
+				expectedTimeout time.Duration
+			}{
+				{"5m", "10s", 5 * time.Minute, 10 * time.Second},
+				{"soon", "-1s", 2 * time.Hour, 20 * time.Second},
+				{"30s", "20", 30 * time.Second, 20 * time.Second},
+				{"0s", "5s", 2 * time.Hour, 5 * time.Second},
+			} {
+				configMap.Data[xdsKeepaliveTimeKey] = keepalive.time
+				configMap.Data[xdsKeepaliveTimeoutKey] = keepalive.timeout
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetXDSKeepaliveTime()).To(Equal(keepalive.expectedTime), "keepalive time %q", keepalive.time)
+				Expect(cfg.GetXDSKeepaliveTimeout()).To(Equal(keepalive.expectedTimeout), "keepalive timeout %q", keepalive.timeout)
+			}
+		})
+	})
+
+	Context("create OSM config for the default retry policy", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				retryPolicyKey: "retry_on: 5xx\n",
+			},
+		}
+
+		It("correctly returns no default retry policy when the number of retries is unset", func() {
+			Expect(cfg.GetDefaultRetryPolicy()).To(BeNil())
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetDefaultRetryPolicy()).To(BeNil())
+		})
+
+		It("correctly defaults the retry conditions when only the number of retries is set", func() {
+			configMap.Data[retryPolicyKey] = "num_retries: 3\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetDefaultRetryPolicy()).To(Equal(&RetryPolicy{
+				NumRetries: 3,
+				RetryOn:    constants.DefaultRetryOn,
+			}))
+		})
+
+		It("correctly drops the unknown and duplicate retry conditions", func() {
+			configMap.Data[retryPolicyKey] = "num_retries: 2\nper_try_timeout: 250ms\nretry_on: 5xx, teapot,reset,5xx\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetDefaultRetryPolicy()).To(Equal(&RetryPolicy{
+				NumRetries:    2,
+				PerTryTimeout: "250ms",
+				RetryOn:       "5xx,reset",
+			}))
+		})
+
+		It("correctly drops an invalid per-try timeout", func() {
+			configMap.Data[retryPolicyKey] = "num_retries: 1\nper_try_timeout: soon\nretry_on: teapot\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetDefaultRetryPolicy()).To(Equal(&RetryPolicy{
+				NumRetries: 1,
+				RetryOn:    constants.DefaultRetryOn,
+			}))
+		})
+
+		It("correctly returns no default retry policy when the value cannot be parsed", func() {
+			configMap.Data[retryPolicyKey] = "num_retries: many\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetDefaultRetryPolicy()).To(BeNil())
+		})
+	})
+
+	Context("create OSM config for the default circuit breaking thresholds", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				circuitBreakingKey: "max_connections: 100\nmax_requests: 0\n",
+			},
+		}
+		envoyDefaults := CircuitBreaking{
+			MaxConnections:     constants.DefaultCircuitBreakingMaxConnections,
+			MaxPendingRequests: constants.DefaultCircuitBreakingMaxPendingRequests,
+			MaxRequests:        constants.DefaultCircuitBreakingMaxRequests,
+			MaxRetries:         constants.DefaultCircuitBreakingMaxRetries,
+		}
+
+		It("correctly uses Envoy's defaults for the zero and unset thresholds", func() {
+			Expect(cfg.GetDefaultCircuitBreaking()).To(Equal(envoyDefaults))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetDefaultCircuitBreaking()).To(Equal(CircuitBreaking{
+				MaxConnections:     100,
+				MaxPendingRequests: constants.DefaultCircuitBreakingMaxPendingRequests,
+				MaxRequests:        constants.DefaultCircuitBreakingMaxRequests,
+				MaxRetries:         constants.DefaultCircuitBreakingMaxRetries,
+			}))
+		})
+
+		It("correctly retrieves the circuit breaking thresholds", func() {
+			configMap.Data[circuitBreakingKey] = "max_connections: 100\nmax_pending_requests: 10\nmax_requests: 200\nmax_retries: 1\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetDefaultCircuitBreaking()).To(Equal(CircuitBreaking{
+				MaxConnections:     100,
+				MaxPendingRequests: 10,
+				MaxRequests:        200,
+				MaxRetries:         1,
+			}))
+		})
+
+		It("correctly uses Envoy's defaults when the value cannot be parsed", func() {
+			configMap.Data[circuitBreakingKey] = "max_connections: -1\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetDefaultCircuitBreaking()).To(Equal(envoyDefaults))
+		})
+	})
+
+	Context("create OSM config for the default load balancing algorithm", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to ROUND_ROBIN when it is unset", func() {
+			Expect(cfg.GetDefaultLBAlgorithm()).To(Equal(LBAlgorithmRoundRobin))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetDefaultLBAlgorithm()).To(Equal(LBAlgorithmRoundRobin))
+		})
+
+		It("correctly returns the configured load balancing algorithm, or falls back on ROUND_ROBIN when it is invalid", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, lbAlgorithm := range []struct {
+				value    string
+				expected string
+			}{
+				{LBAlgorithmLeastRequest, LBAlgorithmLeastRequest},
+				{LBAlgorithmRingHash, LBAlgorithmRingHash},
+				{LBAlgorithmRandom, LBAlgorithmRandom},
+				{LBAlgorithmMaglev, LBAlgorithmMaglev},
+				{LBAlgorithmRoundRobin, LBAlgorithmRoundRobin},
+				{"least_request", LBAlgorithmRoundRobin},
+				{"CLUSTER_PROVIDED", LBAlgorithmRoundRobin},
+			} {
+				configMap.Data[defaultLBAlgorithmKey] = lbAlgorithm.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetDefaultLBAlgorithm()).To(Equal(lbAlgorithm.expected), "load balancing algorithm %q", lbAlgorithm.value)
+			}
+		})
+	})
+
+	Context("create OSM config for the outlier detection", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to disabling outlier detection", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetOutlierDetection()).To(BeNil())
+		})
+
+		It("correctly disables outlier detection when it is not enabled, regardless of its settings", func() {
+			configMap.Data[outlierDetectionKey] = "consecutive_5xx: 3\ninterval: 5s\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetOutlierDetection()).To(BeNil())
+		})
+
+		It("correctly defaults the unset settings when outlier detection is enabled", func() {
+			configMap.Data[outlierDetectionKey] = "enable: true\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetOutlierDetection()).To(Equal(&OutlierDetection{
+				Enable:             true,
+				Consecutive5xx:     5,
+				Interval:           "10s",
+				BaseEjectionTime:   "30s",
+				MaxEjectionPercent: 10,
+			}))
+		})
+
+		It("correctly returns the configured outlier detection", func() {
+			configMap.Data[outlierDetectionKey] = "enable: true\nconsecutive_5xx: 3\ninterval: 5s\nbase_ejection_time: 1m\nmax_ejection_percent: 50\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetOutlierDetection()).To(Equal(&OutlierDetection{
+				Enable:             true,
+				Consecutive5xx:     3,
+				Interval:           "5s",
+				BaseEjectionTime:   "1m",
+				MaxEjectionPercent: 50,
+			}))
+		})
+
+		It("correctly clamps a maximum ejection percentage above 100", func() {
+			configMap.Data[outlierDetectionKey] = "enable: true\nmax_ejection_percent: 150\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			outlierDetection := cfg.GetOutlierDetection()
+			Expect(outlierDetection).ToNot(BeNil())
+			Expect(outlierDetection.MaxEjectionPercent).To(Equal(uint32(100)))
+		})
+
+		It("correctly defaults the durations which are invalid or not positive", func() {
+			configMap.Data[outlierDetectionKey] = "enable: true\ninterval: 0s\nbase_ejection_time: forever\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			outlierDetection := cfg.GetOutlierDetection()
+			Expect(outlierDetection).ToNot(BeNil())
+			Expect(outlierDetection.Interval).To(Equal(defaultConfig.OutlierDetection.Interval))
+			Expect(outlierDetection.BaseEjectionTime).To(Equal(defaultConfig.OutlierDetection.BaseEjectionTime))
+		})
+	})
+
+	Context("create OSM config for the sidecar resources", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				sidecarResourcesKey: "cpu_limit: 1\n",
+			},
+		}
+
+		// quantities returns the quantities of the resource list as strings, which compare regardless of how they were parsed
+		quantities := func(resources v1.ResourceList) map[v1.ResourceName]string {
+			if resources == nil {
+				return nil
+			}
+			quantityStrings := make(map[v1.ResourceName]string, len(resources))
+			for name, quantity := range resources {
+				quantityStrings[name] = quantity.String()
+			}
+			return quantityStrings
+		}
+
+		It("correctly uses the default quantities for the unset quantities", func() {
+			resources := cfg.GetSidecarResources()
+			Expect(quantities(resources.Requests)).To(Equal(map[v1.ResourceName]string{v1.ResourceCPU: "100m", v1.ResourceMemory: "128Mi"}))
+			Expect(resources.Limits).To(BeNil())
+
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			resources = cfg.GetSidecarResources()
+			Expect(quantities(resources.Requests)).To(Equal(map[v1.ResourceName]string{v1.ResourceCPU: "100m", v1.ResourceMemory: "128Mi"}))
+			Expect(quantities(resources.Limits)).To(Equal(map[v1.ResourceName]string{v1.ResourceCPU: "1"}))
+		})
+
+		It("correctly drops the malformed quantities, each on its own", func() {
+			configMap.Data[sidecarResourcesKey] = "cpu_request: lots\ncpu_limit: 500m\nmemory_request: 256Mi\nmemory_limit: -1Gi\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			resources := cfg.GetSidecarResources()
+			Expect(quantities(resources.Requests)).To(Equal(map[v1.ResourceName]string{v1.ResourceCPU: "100m", v1.ResourceMemory: "256Mi"}))
+			Expect(quantities(resources.Limits)).To(Equal(map[v1.ResourceName]string{v1.ResourceCPU: "500m"}))
+		})
+
+		It("correctly lowers a request above its limit to the limit", func() {
+			configMap.Data[sidecarResourcesKey] = "memory_request: 1Gi\nmemory_limit: 512Mi\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			resources := cfg.GetSidecarResources()
+			Expect(quantities(resources.Requests)).To(Equal(map[v1.ResourceName]string{v1.ResourceCPU: "100m", v1.ResourceMemory: "512Mi"}))
+			Expect(quantities(resources.Limits)).To(Equal(map[v1.ResourceName]string{v1.ResourceMemory: "512Mi"}))
+		})
+	})
+
+	Context("create OSM config for the Envoy image", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults the Envoy image when it is unset", func() {
+			Expect(cfg.GetEnvoyImage()).To(Equal(constants.DefaultEnvoyImage))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyImage()).To(Equal(constants.DefaultEnvoyImage))
+		})
+
+		It("correctly retrieves the valid Envoy images and defaults the invalid ones", func() {
+			digest := "@sha256:" + strings.Repeat("a", 64)
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, image := range []struct {
+				value    string
+				expected string
+			}{
+				{"envoyproxy/envoy:v1.16.0", "envoyproxy/envoy:v1.16.0"},
+				{"envoy", "envoy"},
+				{"registry.example.com/mirror/envoyproxy/envoy:v1.16.0", "registry.example.com/mirror/envoyproxy/envoy:v1.16.0"},
+				{"localhost:5000/envoy:v1.16.0-debug", "localhost:5000/envoy:v1.16.0-debug"},
+				{"envoyproxy/envoy" + digest, "envoyproxy/envoy" + digest},
+				{"", constants.DefaultEnvoyImage},
+				{" ", constants.DefaultEnvoyImage},
+				{"EnvoyProxy/envoy:v1.16.0", constants.DefaultEnvoyImage},
+				{"envoyproxy/envoy:", constants.DefaultEnvoyImage},
+				{"envoyproxy/envoy:v1.16.0:latest", constants.DefaultEnvoyImage},
+				{"https://registry.example.com/envoy", constants.DefaultEnvoyImage},
+				{"envoyproxy//envoy", constants.DefaultEnvoyImage},
+			} {
+				configMap.Data[envoyImageKey] = image.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetEnvoyImage()).To(Equal(image.expected), image.value)
+			}
+		})
+	})
+
+	Context("create OSM config with a default Envoy image", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		cfg := NewConfigurator(kubeClient, stop, "-test-osm-namespace-", "-test-osm-config-map-", WithDefaultEnvoyImage("envoyproxy/envoy-alpine:v1.14.0"))
+
+		It("correctly defaults the Envoy image to the configured default", func() {
+			Expect(cfg.GetEnvoyImage()).To(Equal("envoyproxy/envoy-alpine:v1.14.0"))
+		})
+	})
+
+	Context("create OSM config for the init container image", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults the init container image when it is unset", func() {
+			Expect(cfg.GetInitContainerImage()).To(Equal(constants.DefaultInitContainerImage))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetInitContainerImage()).To(Equal(constants.DefaultInitContainerImage))
+		})
+
+		It("correctly retrieves the valid init container images and defaults the invalid ones", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, image := range []struct {
+				value    string
+				expected string
+			}{
+				{"openservicemesh/init:v0.4.0", "openservicemesh/init:v0.4.0"},
+				{"registry.example.com/openservicemesh/init:v0.4.0", "registry.example.com/openservicemesh/init:v0.4.0"},
+				{"", constants.DefaultInitContainerImage},
+				{"OpenServiceMesh/init:v0.4.0", constants.DefaultInitContainerImage},
+				{"openservicemesh/init:", constants.DefaultInitContainerImage},
+			} {
+				configMap.Data[initContainerImageKey] = image.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetInitContainerImage()).To(Equal(image.expected), image.value)
+			}
+		})
+	})
+
+	Context("create OSM config with a default init container image", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		cfg := NewConfigurator(kubeClient, stop, "-test-osm-namespace-", "-test-osm-config-map-", WithDefaultInitContainerImage("openservicemesh/init:v0.2.0"))
+
+		It("correctly defaults the init container image to the configured default", func() {
+			Expect(cfg.GetInitContainerImage()).To(Equal("openservicemesh/init:v0.2.0"))
+		})
+	})
+
+	Context("create OSM config for the sidecar injection", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults the sidecar injection to enabled when it is unset", func() {
+			Expect(cfg.IsSidecarInjectionEnabled()).To(BeTrue())
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsSidecarInjectionEnabled()).To(BeTrue())
+		})
+
+		It("correctly disables and re-enables the sidecar injection", func() {
+			for _, enabled := range []bool{false, true} {
+				configMap.Data[enableSidecarInjectionKey] = strconv.FormatBool(enabled)
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.IsSidecarInjectionEnabled()).To(Equal(enabled))
+			}
+		})
+	})
+
+	Context("create OSM config for the excluded namespaces", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to no excluded namespaces when they are unset", func() {
+			Expect(cfg.GetExcludedNamespaces()).To(BeEmpty())
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetExcludedNamespaces()).To(BeEmpty())
+		})
+
+		It("correctly parses the delimited namespaces, deduplicating them and skipping the invalid ones", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, value := range []struct {
+				value    string
+				expected map[string]bool
+			}{
+				{"kube-system", map[string]bool{"kube-system": true}},
+				{"kube-system, kube-public\tmonitoring,,", map[string]bool{"kube-system": true, "kube-public": true, "monitoring": true}},
+				{" monitoring,monitoring kube-system ", map[string]bool{"monitoring": true, "kube-system": true}},
+				{"Kube-System,osm_system,kube-system.local,kube-system", map[string]bool{"kube-system": true}},
+				{"Monitoring", map[string]bool{}},
+			} {
+				configMap.Data[excludedNamespacesKey] = value.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetExcludedNamespaces()).To(Equal(value.expected), value.value)
+			}
+		})
+	})
+
+	Context("create OSM config for the proxy probe timings", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+		defaultProbe := v1.Probe{
+			InitialDelaySeconds: 1,
+			PeriodSeconds:       10,
+			TimeoutSeconds:      1,
+			FailureThreshold:    3,
+		}
+
+		It("correctly defaults the probe timings when they are unset", func() {
+			Expect(cfg.GetProxyProbeSpec()).To(Equal(defaultProbe))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetProxyProbeSpec()).To(Equal(defaultProbe))
+		})
+
+		It("correctly defaults each unset timing of a partial specification", func() {
+			configMap.Data[proxyProbeKey] = "initial_delay_seconds: 30\nfailure_threshold: 10"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetProxyProbeSpec()).To(Equal(v1.Probe{
+				InitialDelaySeconds: 30,
+				PeriodSeconds:       10,
+				TimeoutSeconds:      1,
+				FailureThreshold:    10,
+			}))
+		})
+
+		It("correctly rejects the negative timings", func() {
+			configMap.Data[proxyProbeKey] = "period_seconds: -5\ntimeout_seconds: 5\nfailure_threshold: -1"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetProxyProbeSpec()).To(Equal(v1.Probe{
+				InitialDelaySeconds: 1,
+				PeriodSeconds:       10,
+				TimeoutSeconds:      5,
+				FailureThreshold:    3,
+			}))
+			Expect(errorCauses(cfg.ValidateConfig())).To(ConsistOf(errNegativeValue, errNegativeValue))
+		})
+	})
+
+	Context("create OSM config for the debug server", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults the debug server to disabled when it is unset", func() {
+			Expect(cfg.IsDebugServerEnabled()).To(BeFalse())
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsDebugServerEnabled()).To(BeFalse())
+		})
+
+		It("correctly enables and disables the debug server", func() {
+			for _, enabled := range []bool{true, false} {
+				configMap.Data[enableDebugServerKey] = strconv.FormatBool(enabled)
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.IsDebugServerEnabled()).To(Equal(enabled))
+			}
+		})
+	})
+
+	Context("create OSM config for the forwarded headers", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to preserving the forwarded headers when it is unset", func() {
+			Expect(cfg.StripForwardedHeaders()).To(BeFalse())
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.StripForwardedHeaders()).To(BeFalse())
+		})
+
+		It("correctly toggles the stripping of the forwarded headers", func() {
+			for _, strip := range []bool{true, false} {
+				configMap.Data[stripForwardedHeadersKey] = strconv.FormatBool(strip)
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.StripForwardedHeaders()).To(Equal(strip))
+			}
+		})
+	})
+
+	Context("create OSM config for the proxy bootstrap config override", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("returns no override when it is unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			override, err := cfg.GetProxyBootstrapOverride()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(override).To(BeNil())
+		})
+
+		It("correctly decodes a valid YAML fragment", func() {
+			configMap.Data[proxyBootstrapOverrideKey] = "stats_flush_interval: 10s\nlayered_runtime:\n  layers:\n  - name: static\n    static_layer:\n      overload.global_downstream_max_connections: 50000\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			override, err := cfg.GetProxyBootstrapOverride()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(override).To(Equal(map[string]interface{}{
+				"stats_flush_interval": "10s",
+				"layered_runtime": map[string]interface{}{
+					"layers": []interface{}{
+						map[string]interface{}{
+							"name": "static",
+							"static_layer": map[string]interface{}{
+								"overload.global_downstream_max_connections": 50000,
+							},
+						},
+					},
+				},
+			}))
+		})
+
+		It("returns an error for a malformed YAML fragment", func() {
+			for _, fragment := range []string{"stats_flush_interval: [10s", "- stats_flush_interval"} {
+				configMap.Data[proxyBootstrapOverrideKey] = fragment
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				override, err := cfg.GetProxyBootstrapOverride()
+				Expect(errors.Cause(err)).To(Equal(errInvalidYAML))
+				Expect(override).To(BeNil())
+				Expect(errorCauses(cfg.ValidateConfig())).To(ContainElement(errInvalidYAML))
+			}
+		})
+	})
+
+	Context("create OSM config for the proxy bind address", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to the wildcard address when it is unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetProxyBindAddress()).To(Equal(constants.WildcardIPAddr))
+		})
+
+		It("correctly returns an explicit IP address or the pod IP token, or falls back on the wildcard address when it is invalid", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, bindAddress := range []struct {
+				value    string
+				expected string
+			}{
+				{"10.0.0.1", "10.0.0.1"},
+				{ProxyBindAddressPodIP, ProxyBindAddressPodIP},
+				{"localhost", constants.WildcardIPAddr},
+				{"::1", "::1"},
+				{"Pod-IP", constants.WildcardIPAddr},
+			} {
+				configMap.Data[proxyBindAddressKey] = bindAddress.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetProxyBindAddress()).To(Equal(bindAddress.expected), "proxy bind address %q", bindAddress.value)
+			}
+			Expect(errorCauses(cfg.ValidateConfig())).To(ContainElement(errInvalidBindAddress))
+		})
+	})
+
+	Context("create OSM config for the stats prefix and tags", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to no stats prefix and tags when they are unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetStatsPrefix()).To(BeEmpty())
+			Expect(cfg.GetStatsTags()).To(BeEmpty())
+		})
+
+		It("correctly returns the valid stats prefix and tags", func() {
+			configMap.Data[statsPrefixKey] = "osm:mesh_1"
+			configMap.Data[statsTagsKey] = "mesh: osm\nregion: westus"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetStatsPrefix()).To(Equal("osm:mesh_1"))
+			Expect(cfg.GetStatsTags()).To(Equal(map[string]string{"mesh": "osm", "region": "westus"}))
+		})
+
+		It("ignores a stats prefix with invalid characters", func() {
+			for _, prefix := range []string{"osm-mesh", "osm.mesh", "1osm", "osm mesh"} {
+				configMap.Data[statsPrefixKey] = prefix
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetStatsPrefix()).To(BeEmpty())
+				Expect(errorCauses(cfg.ValidateConfig())).To(ConsistOf(errInvalidStatsName))
+			}
+		})
+
+		It("drops the stats tags whose name has illegal characters", func() {
+			configMap.Data[statsPrefixKey] = "osm"
+			configMap.Data[statsTagsKey] = "mesh: osm\ncluster-name: west\n1zone: a"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetStatsTags()).To(Equal(map[string]string{"mesh": "osm"}))
+			Expect(errorCauses(cfg.ValidateConfig())).To(ConsistOf(errInvalidStatsName, errInvalidStatsName))
+		})
+	})
+
+	Context("create OSM config for the Envoy concurrency", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to letting Envoy decide when it is unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyConcurrency()).To(Equal(uint32(0)))
+		})
+
+		It("correctly returns, or clamps, the configured Envoy concurrency", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, concurrency := range []struct {
+				value    string
+				expected uint32
+			}{
+				{"2", 2},
+				{"0", 0},
+				{"128", 128},
+				{"10000", 128},
+				{"-1", 0},
+			} {
+				configMap.Data[envoyConcurrencyKey] = concurrency.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetEnvoyConcurrency()).To(Equal(concurrency.expected), "concurrency %s", concurrency.value)
+			}
+		})
+	})
+
+	Context("create OSM config for the proxy env vars", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to no env vars when they are unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetProxyEnvVars()).To(BeEmpty())
+		})
+
+		It("correctly returns the configured env vars sorted by name", func() {
+			configMap.Data[proxyEnvVarsKey] = "VENDOR_FEATURE_FLAGS: tls-inspector\nvendor.log-level: debug\nAPP_REGION: westus"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetProxyEnvVars()).To(Equal([]v1.EnvVar{
+				{Name: "APP_REGION", Value: "westus"},
+				{Name: "VENDOR_FEATURE_FLAGS", Value: "tls-inspector"},
+				{Name: "vendor.log-level", Value: "debug"},
+			}))
+		})
+
+		It("drops the env vars with an invalid or reserved name", func() {
+			configMap.Data[proxyEnvVarsKey] = "VENDOR_FEATURE_FLAGS: tls-inspector\n1VENDOR_FLAGS: \"on\"\nVENDOR=FLAGS: \"on\"\nPOD_IP: 10.0.0.1\nOSM_PROXY_UID: \"0\""
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetProxyEnvVars()).To(Equal([]v1.EnvVar{
+				{Name: "VENDOR_FEATURE_FLAGS", Value: "tls-inspector"},
+			}))
+			Expect(errorCauses(cfg.ValidateConfig())).To(ConsistOf(
+				errInvalidEnvVarName,
+				errInvalidEnvVarName,
+				errReservedEnvVarName,
+				errReservedEnvVarName,
+			))
+		})
+	})
+
+	Context("create OSM config for the proxy drain and parent shutdown times", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to Envoy's own drain and parent shutdown times when they are unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetProxyDrainTime()).To(Equal(10 * time.Minute))
+			Expect(cfg.GetProxyParentShutdownTime()).To(Equal(15 * time.Minute))
+		})
+
+		It("correctly returns the configured drain and parent shutdown times, defaulting the invalid ones", func() {
+			for _, times := range []struct {
+				drainTime                  string
+				parentShutdownTime         string
+				expectedDrainTime          time.Duration
+				expectedParentShutdownTime time.Duration
+			}{
+				{"30s", "45s", 30 * time.Second, 45 * time.Second},
+				{"1m", "1m", time.Minute, time.Minute},
+				{"5m", "", 5 * time.Minute, 15 * time.Minute},
+				{"500ms", "1m", 10 * time.Minute, 10 * time.Minute},
+				{"30s", "forever", 30 * time.Second, 15 * time.Minute},
+			} {
+				configMap.Data[proxyDrainTimeKey] = times.drainTime
+				configMap.Data[proxyParentShutdownTimeKey] = times.parentShutdownTime
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetProxyDrainTime()).To(Equal(times.expectedDrainTime), "drain time %q", times.drainTime)
+				Expect(cfg.GetProxyParentShutdownTime()).To(Equal(times.expectedParentShutdownTime), "parent shutdown time %q", times.parentShutdownTime)
+			}
+		})
+
+		It("raises a parent shutdown time shorter than the drain time to the drain time", func() {
+			for _, times := range []struct {
+				drainTime                  string
+				parentShutdownTime         string
+				expectedParentShutdownTime time.Duration
+			}{
+				{"2m", "1m", 2 * time.Minute},
+				{"20m", "", 20 * time.Minute},
+				{"", "5m", 10 * time.Minute},
+			} {
+				configMap.Data[proxyDrainTimeKey] = times.drainTime
+				configMap.Data[proxyParentShutdownTimeKey] = times.parentShutdownTime
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetProxyParentShutdownTime()).To(Equal(times.expectedParentShutdownTime), "drain time %q, parent shutdown time %q", times.drainTime, times.parentShutdownTime)
+				Expect(cfg.GetProxyParentShutdownTime()).To(BeNumerically(">=", cfg.GetProxyDrainTime()))
+			}
+
+			configMap.Data[proxyDrainTimeKey] = "2m"
+			configMap.Data[proxyParentShutdownTimeKey] = "1m"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(errorCauses(cfg.ValidateConfig())).To(ConsistOf(errShutdownBeforeDrain))
+		})
+	})
+
+	Context("create OSM config for the overload manager", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to a disabled overload manager when it is unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetOverloadManager()).To(BeNil())
+		})
+
+		It("correctly returns the configured overload manager, defaulting the unset thresholds", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, overloadManager := range []struct {
+				value    string
+				expected *OverloadManager
+			}{
+				{"max_heap_bytes: 1073741824", &OverloadManager{MaxHeapBytes: 1 << 30, ShrinkHeapThreshold: 0.95, StopAcceptingThreshold: 0.98}},
+				{"max_heap_bytes: 1073741824\nshrink_heap_threshold: 0.8", &OverloadManager{MaxHeapBytes: 1 << 30, ShrinkHeapThreshold: 0.8, StopAcceptingThreshold: 0.98}},
+				{"max_heap_bytes: 536870912\nshrink_heap_threshold: 0.8\nstop_accepting_threshold: 0.9", &OverloadManager{MaxHeapBytes: 1 << 29, ShrinkHeapThreshold: 0.8, StopAcceptingThreshold: 0.9}},
+				{"shrink_heap_threshold: 0.8\nstop_accepting_threshold: 0.9", nil},
+			} {
+				configMap.Data[overloadManagerKey] = overloadManager.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetOverloadManager()).To(Equal(overloadManager.expected), "overload manager %q", overloadManager.value)
+			}
+		})
+
+		It("disables the overload manager with thresholds out of range or out of order", func() {
+			for _, overloadManager := range []struct {
+				value         string
+				expectedCause error
+			}{
+				{"max_heap_bytes: 1073741824\nshrink_heap_threshold: 1.5", errInvalidThreshold},
+				{"max_heap_bytes: 1073741824\nstop_accepting_threshold: -0.5", errInvalidThreshold},
+				{"max_heap_bytes: 1073741824\nshrink_heap_threshold: 0.99", errInvertedThresholds},
+				{"max_heap_bytes: 1073741824\nshrink_heap_threshold: 0.9\nstop_accepting_threshold: 0.8", errInvertedThresholds},
+			} {
+				configMap.Data[overloadManagerKey] = overloadManager.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetOverloadManager()).To(BeNil(), "overload manager %q", overloadManager.value)
+				Expect(errorCauses(cfg.ValidateConfig())).To(ConsistOf(overloadManager.expectedCause), "overload manager %q", overloadManager.value)
+			}
+		})
+	})
+
+	Context("create OSM config for the inbound external authorization", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to disabling external authorization", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetInboundExternalAuthConfig()).To(BeNil())
+		})
+
+		It("correctly returns the external authorization config when it is enabled", func() {
+			configMap.Data[inboundExternalAuthKey] = "enable: true\naddress: opa.osm-system.svc.cluster.local\nport: 9191\nfailure_mode_allow: true\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetInboundExternalAuthConfig()).To(Equal(&InboundExternalAuth{
+				Enable:           true,
+				Address:          "opa.osm-system.svc.cluster.local",
+				Port:             9191,
+				StatPrefix:       defaultConfig.InboundExternalAuth.StatPrefix,
+				Timeout:          defaultConfig.InboundExternalAuth.Timeout,
+				FailureModeAllow: true,
+			}))
+		})
+
+		It("correctly defaults to the default timeout when the timeout is invalid", func() {
+			configMap.Data[inboundExternalAuthKey] = "enable: true\naddress: 10.0.0.10\nport: 9191\nstat_prefix: opa\ntimeout: -1s\n"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			externalAuth := cfg.GetInboundExternalAuthConfig()
+			Expect(externalAuth).ToNot(BeNil())
+			Expect(externalAuth.StatPrefix).To(Equal("opa"))
+			Expect(externalAuth.Timeout).To(Equal(defaultConfig.InboundExternalAuth.Timeout))
+		})
+
+		It("correctly disables external authorization when the address or the port is invalid", func() {
+			for _, externalAuth := range []string{
+				"enable: true\naddress: grpc://opa\nport: 9191\n",
+				"enable: true\nport: 9191\n",
+				"enable: true\naddress: opa\n",
+			} {
+				configMap.Data[inboundExternalAuthKey] = externalAuth
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetInboundExternalAuthConfig()).To(BeNil(), "external authorization %q", externalAuth)
+			}
+		})
+	})
+
+	Context("create OSM config for multicluster", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+		gateway := MulticlusterGateway{
+			Host: "osm-multicluster-gateway.osm-system.svc.cluster.local",
+			Port: 14080,
+		}
+
+		It("correctly defaults to disabling multicluster", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsMulticlusterEnabled()).To(BeFalse())
+			Expect(cfg.GetMulticlusterGateway()).To(Equal(MulticlusterGateway{}))
+		})
+
+		It("correctly returns the gateway only while multicluster is enabled", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, multicluster := range []struct {
+				enabled         string
+				gateway         string
+				expectedEnabled bool
+				expectedGateway MulticlusterGateway
+			}{
+				{"false", "host: osm-multicluster-gateway.osm-system.svc.cluster.local\nport: 14080\n", false, MulticlusterGateway{}},
+				{"true", "host: osm-multicluster-gateway.osm-system.svc.cluster.local\nport: 14080\n", true, gateway},
+				{"false", "host: osm-multicluster-gateway.osm-system.svc.cluster.local\nport: 14080\n", false, MulticlusterGateway{}},
+			} {
+				configMap.Data[multiclusterEnabledKey] = multicluster.enabled
+				configMap.Data[multiclusterGatewayKey] = multicluster.gateway
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.IsMulticlusterEnabled()).To(Equal(multicluster.expectedEnabled), "multicluster enabled %q", multicluster.enabled)
+				Expect(cfg.GetMulticlusterGateway()).To(Equal(multicluster.expectedGateway), "multicluster enabled %q", multicluster.enabled)
+			}
+		})
+
+		It("correctly ignores the gateway when its host or port is invalid", func() {
+			configMap.Data[multiclusterEnabledKey] = "true"
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, gateway := range []string{
+				"host: gateway:14080\nport: 14080\n",
+				"port: 14080\n",
+				"host: osm-multicluster-gateway.osm-system.svc.cluster.local\n",
+				"host: osm-multicluster-gateway.osm-system.svc.cluster.local\nport: 100000\n",
+			} {
+				configMap.Data[multiclusterGatewayKey] = gateway
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.IsMulticlusterEnabled()).To(BeTrue())
+				Expect(cfg.GetMulticlusterGateway()).To(Equal(MulticlusterGateway{}), "multicluster gateway %q", gateway)
+			}
+		})
+	})
+
+	Context("create OSM config for the stats sink", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to disabling the stats sink", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetStatsSink()).To(BeNil())
+		})
+
+		It("correctly returns the stats sink of each type", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, statsSink := range []struct {
+				value    string
+				expected *StatsSink
+			}{
+				{"type: statsd\naddress: 10.0.0.10\nport: 8125\n", &StatsSink{Type: StatsSinkTypeStatsd, Address: "10.0.0.10", Port: 8125}},
+				{"type: dogstatsd\naddress: fd00::10\nport: 8125\nprefix: osm\n", &StatsSink{Type: StatsSinkTypeDogStatsd, Address: "fd00::10", Port: 8125, Prefix: "osm"}},
+				{"address: 10.0.0.10\nport: 8125\n", nil},
+			} {
+				configMap.Data[statsSinkKey] = statsSink.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetStatsSink()).To(Equal(statsSink.expected), "stats sink %q", statsSink.value)
+			}
+		})
+
+		It("correctly disables the stats sink when its type, address or port is invalid", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, statsSink := range []string{
+				"type: graphite\naddress: 10.0.0.10\nport: 8125\n",
+				"type: statsd\naddress: statsd.osm-system.svc.cluster.local\nport: 8125\n",
+				"type: statsd\nport: 8125\n",
+				"type: statsd\naddress: 10.0.0.10\n",
+				"type: statsd\naddress: 10.0.0.10\nport: 100000\n",
+			} {
+				configMap.Data[statsSinkKey] = statsSink
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetStatsSink()).To(BeNil(), "stats sink %q", statsSink)
+			}
+		})
+	})
+
+	Context("create OSM config for the egress DNS resolution", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to LOGICAL_DNS when it is unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEgressDNSResolution()).To(Equal(EgressDNSResolutionLogicalDNS))
+		})
+
+		It("correctly returns the configured egress DNS resolution, or falls back on LOGICAL_DNS when it is invalid", func() {
+			for _, dnsResolution := range []struct {
+				value    string
+				expected string
+			}{
+				{EgressDNSResolutionStrictDNS, EgressDNSResolutionStrictDNS},
+				{EgressDNSResolutionLogicalDNS, EgressDNSResolutionLogicalDNS},
+				{EgressDNSResolutionStatic, EgressDNSResolutionStatic},
+				{"strict_dns", EgressDNSResolutionLogicalDNS},
+			} {
+				configMap.Data[egressDNSResolutionKey] = dnsResolution.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetEgressDNSResolution()).To(Equal(dnsResolution.expected), "egress DNS resolution %q", dnsResolution.value)
+			}
+		})
+	})
+
+	Context("create OSM config for the egress CA bundle", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to no CA bundle when it is unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEgressCABundleRef()).To(BeNil())
+		})
+
+		It("correctly parses the CA bundle reference, defaulting the key to ca.crt, or ignores it when it is malformed", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, caBundle := range []struct {
+				value    string
+				expected *CABundleRef
+			}{
+				{"osm-system/egress-ca-bundle", &CABundleRef{Namespace: "osm-system", Name: "egress-ca-bundle", Key: "ca.crt"}},
+				{"osm-system/egress-ca-bundle/ca.pem", &CABundleRef{Namespace: "osm-system", Name: "egress-ca-bundle", Key: "ca.pem"}},
+				{"egress-ca-bundle", nil},
+				{"osm-system/egress-ca-bundle/ca.pem/extra", nil},
+				{"osm-system//ca.pem", nil},
+				{"OSM-System/egress-ca-bundle", nil},
+				{"osm-system/egress_ca_bundle", nil},
+				{"osm-system/egress-ca-bundle/ca pem", nil},
+			} {
+				configMap.Data[egressCABundleKey] = caBundle.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetEgressCABundleRef()).To(Equal(caBundle.expected), "CA bundle reference %q", caBundle.value)
+			}
+		})
+	})
+
+	Context("create OSM config for the disabled xDS types", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly disables no xDS type when it is unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetDisabledXDSTypes()).To(BeEmpty())
+		})
+
+		It("correctly returns the disabled xDS types, skipping the unknown ones", func() {
+			for _, xdsTypes := range []struct {
+				value    string
+				expected map[string]bool
+			}{
+				{"RDS", map[string]bool{"RDS": true}},
+				{"RDS, SDS", map[string]bool{"RDS": true, "SDS": true}},
+				{"CDS,EDS,LDS,RDS,SDS", map[string]bool{"CDS": true, "EDS": true, "LDS": true, "RDS": true, "SDS": true}},
+				{"RDS,ADS,rds", map[string]bool{"RDS": true}},
+				{"ADS", map[string]bool{}},
+			} {
+				configMap.Data[disabledXDSTypesKey] = xdsTypes.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetDisabledXDSTypes()).To(Equal(xdsTypes.expected), "disabled xDS types %q", xdsTypes.value)
+			}
+		})
+	})
+
+	Context("create OSM config for the trust domain", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		typedAnnouncements := cfg.GetTypedAnnouncementsChannel()
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to cluster.local when it is unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+			<-typedAnnouncements
+
+			Expect(cfg.GetTrustDomain()).To(Equal("cluster.local"))
+		})
+
+		It("correctly returns the configured trust domain and announces its change", func() {
+			configMap.Data[trustDomainKey] = "mesh.example.com"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+			event := <-typedAnnouncements
+
+			Expect(event.ChangedFields).To(ContainElement("TrustDomain"))
+			Expect(cfg.GetTrustDomain()).To(Equal("mesh.example.com"))
+		})
+
+		It("correctly falls back on cluster.local when the trust domain is invalid", func() {
+			for _, trustDomain := range []string{"Mesh.Example.com", "mesh.example.com.", "mesh_example", "*.example.com"} {
+				configMap.Data[trustDomainKey] = trustDomain
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+				<-typedAnnouncements
+
+				Expect(cfg.GetTrustDomain()).To(Equal("cluster.local"), "trust domain %q", trustDomain)
+			}
+		})
+	})
+
+	Context("create OSM config for the mesh TLS versions", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to TLS1_2 through TLS1_3 when the versions are unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			minVersion, maxVersion := cfg.GetMeshTLSVersions()
+			Expect(minVersion).To(Equal("TLS1_2"))
+			Expect(maxVersion).To(Equal("TLS1_3"))
+		})
+
+		It("correctly returns the configured version ranges, defaulting the invalid versions", func() {
+			for _, versions := range []struct {
+				minVersion         string
+				maxVersion         string
+				expectedMinVersion string
+				expectedMaxVersion string
+			}{
+				{"TLS1_0", "TLS1_3", "TLS1_0", "TLS1_3"},
+				{"TLS1_3", "TLS1_3", "TLS1_3", "TLS1_3"},
+				{"TLS1_1", "TLS1_2", "TLS1_1", "TLS1_2"},
+				{"TLS1_3", "", "TLS1_3", "TLS1_3"},
+				{"SSLv3", "TLS1_2", "TLS1_2", "TLS1_2"},
+				{"TLS1_1", "TLS2_0", "TLS1_1", "TLS1_3"},
+			} {
+				configMap.Data[meshTLSMinVersionKey] = versions.minVersion
+				configMap.Data[meshTLSMaxVersionKey] = versions.maxVersion
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				minVersion, maxVersion := cfg.GetMeshTLSVersions()
+				Expect(minVersion).To(Equal(versions.expectedMinVersion), "min version %q, max version %q", versions.minVersion, versions.maxVersion)
+				Expect(maxVersion).To(Equal(versions.expectedMaxVersion), "min version %q, max version %q", versions.minVersion, versions.maxVersion)
+			}
+		})
+
+		It("swaps a min version newer than the max version", func() {
+			for _, versions := range []struct {
+				minVersion         string
+				maxVersion         string
+				expectedMinVersion string
+				expectedMaxVersion string
+			}{
+				{"TLS1_3", "TLS1_1", "TLS1_1", "TLS1_3"},
+				{"", "TLS1_0", "TLS1_0", "TLS1_2"},
+			} {
+				configMap.Data[meshTLSMinVersionKey] = versions.minVersion
+				configMap.Data[meshTLSMaxVersionKey] = versions.maxVersion
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				minVersion, maxVersion := cfg.GetMeshTLSVersions()
+				Expect(minVersion).To(Equal(versions.expectedMinVersion), "min version %q, max version %q", versions.minVersion, versions.maxVersion)
+				Expect(maxVersion).To(Equal(versions.expectedMaxVersion), "min version %q, max version %q", versions.minVersion, versions.maxVersion)
+			}
+			Expect(errorCauses(cfg.ValidateConfig())).To(ConsistOf(errInvertedTLSVersions))
+		})
+	})
+
+	Context("create OSM config for the mesh cipher suites", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly leaves the cipher suites to Envoy when they are unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetMeshCipherSuites()).To(BeEmpty())
+		})
+
+		It("correctly returns the configured cipher suites in order", func() {
+			configMap.Data[meshCipherSuitesKey] = "ECDHE-RSA-AES256-GCM-SHA384, ECDHE-ECDSA-AES128-GCM-SHA256,ECDHE-RSA-AES256-GCM-SHA384"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetMeshCipherSuites()).To(Equal([]string{"ECDHE-RSA-AES256-GCM-SHA384", "ECDHE-ECDSA-AES128-GCM-SHA256"}))
+		})
+
+		It("skips the unknown cipher suites", func() {
+			configMap.Data[meshCipherSuitesKey] = "TLS_RSA_WITH_RC4_128_SHA,ECDHE-RSA-AES128-GCM-SHA256,ecdhe-rsa-aes256-gcm-sha384"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetMeshCipherSuites()).To(Equal([]string{"ECDHE-RSA-AES128-GCM-SHA256"}))
+			Expect(errorCauses(cfg.ValidateConfig())).To(ConsistOf(errInvalidEnumValue, errInvalidEnumValue))
+		})
+
+		It("leaves the cipher suites to Envoy when none is known", func() {
+			configMap.Data[meshCipherSuitesKey] = "RC4-SHA"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetMeshCipherSuites()).To(BeEmpty())
+		})
+	})
+
+	Context("create OSM config for the WASM extensions", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly returns no WASM extensions when they are unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetWASMExtensions()).To(BeEmpty())
+		})
+
+		It("correctly returns the valid WASM extensions in order, defaulting their insertion point", func() {
+			configMap.Data[wasmExtensionsKey] = `
+- name: headers
+  uri: file:///etc/envoy/wasm/headers.wasm
+  root_id: add_header
+  config: '{"header": "x-mesh"}'
+- name: audit
+  uri: /etc/envoy/wasm/audit.wasm
+  insertion_point: outbound
+`
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			wasmExtensions := cfg.GetWASMExtensions()
+			Expect(wasmExtensions).To(Equal([]WASMExtensionSpec{
+				{
+					Name:           "headers",
+					URI:            "file:///etc/envoy/wasm/headers.wasm",
+					RootID:         "add_header",
+					Config:         `{"header": "x-mesh"}`,
+					InsertionPoint: WASMInsertionPointInbound,
+				},
+				{
+					Name:           "audit",
+					URI:            "/etc/envoy/wasm/audit.wasm",
+					InsertionPoint: WASMInsertionPointOutbound,
+				},
+			}))
+
+			modulePath, ok := wasmExtensions[0].ModulePath()
+			Expect(ok).To(BeTrue())
+			Expect(modulePath).To(Equal("/etc/envoy/wasm/headers.wasm"))
+			Expect(cfg.ValidateConfig()).To(BeEmpty())
+		})
+
+		It("skips the invalid WASM extensions", func() {
+			configMap.Data[wasmExtensionsKey] = `
+- uri: /etc/envoy/wasm/unnamed.wasm
+- name: no-uri
+- name: remote
+  uri: https://example.com/remote.wasm
+- name: relative
+  uri: wasm/relative.wasm
+- name: sidecar
+  uri: /etc/envoy/wasm/sidecar.wasm
+  insertion_point: sidecar
+- name: headers
+  uri: /etc/envoy/wasm/headers.wasm
+- name: headers
+  uri: /etc/envoy/wasm/headers-v2.wasm
+`
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetWASMExtensions()).To(Equal([]WASMExtensionSpec{{
+				Name:           "headers",
+				URI:            "/etc/envoy/wasm/headers.wasm",
+				InsertionPoint: WASMInsertionPointInbound,
+			}}))
+			Expect(errorCauses(cfg.ValidateConfig())).To(ConsistOf(
+				errInvalidWASMExtension, // unnamed
+				errInvalidWASMExtension, // no URI
+				errInvalidWASMExtension, // remote URI
+				errInvalidWASMExtension, // relative URI
+				errInvalidEnumValue,     // insertion point
+				errInvalidWASMExtension, // duplicate name
+			))
+		})
+	})
+
+	Context("create OSM config for the locality-aware routing", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to no locality-aware routing and no locality override", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsLocalityAwareRoutingEnabled()).To(BeFalse())
+			Expect(cfg.GetLocality()).To(Equal(Locality{}))
+		})
+
+		It("correctly enables locality-aware routing with the locality derived from the node labels", func() {
+			configMap.Data[localityAwareRoutingKey] = "true"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsLocalityAwareRoutingEnabled()).To(BeTrue())
+			Expect(cfg.GetLocality()).To(Equal(Locality{}))
+		})
+
+		It("correctly returns the region and zone overrides", func() {
+			configMap.Data[localityRegionKey] = "westus2"
+			configMap.Data[localityZoneKey] = "westus2-1"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetLocality()).To(Equal(Locality{Region: "westus2", Zone: "westus2-1"}))
+		})
+
+		It("correctly ignores the overrides which are not valid label values", func() {
+			configMap.Data[localityZoneKey] = "westus2 zone 1"
+			configMap.Data[localityAwareRoutingKey] = "false"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsLocalityAwareRoutingEnabled()).To(BeFalse())
+			Expect(cfg.GetLocality()).To(Equal(Locality{Region: "westus2"}))
+		})
+	})
+
+	Context("create OSM config for the egress mode", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				egressKey: "true",
+			},
+		}
+
+		It("correctly follows the egress toggle when the egress mode is unset", func() {
+			Expect(cfg.GetEgressMode()).To(Equal(EgressModeDisabled))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEgressMode()).To(Equal(EgressModeGlobal))
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+		})
+
+		It("correctly retrieves the disabled egress mode", func() {
+			configMap.Data[egressModeKey] = "disabled"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEgressMode()).To(Equal(EgressModeDisabled))
+			Expect(cfg.IsEgressEnabled()).To(BeFalse())
+		})
+
+		It("correctly retrieves the global egress mode", func() {
+			configMap.Data[egressKey] = "false"
+			configMap.Data[egressModeKey] = "global"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEgressMode()).To(Equal(EgressModeGlobal))
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+		})
+
+		It("correctly retrieves the policy egress mode", func() {
+			configMap.Data[egressModeKey] = "policy"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEgressMode()).To(Equal(EgressModePolicy))
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+		})
+
+		It("correctly defaults an invalid egress mode to disabled", func() {
+			configMap.Data[egressKey] = "true"
+			configMap.Data[egressModeKey] = "allow-all"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEgressMode()).To(Equal(EgressModeDisabled))
+			Expect(cfg.IsEgressEnabled()).To(BeFalse())
+		})
+	})
+
+	Context("create OSM config for the default ingress backend protocol", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				useHTTPSIngressKey: "true",
+			},
+		}
+
+		It("correctly follows the HTTPS ingress toggle when the protocol is unset", func() {
+			Expect(cfg.GetDefaultIngressBackendProtocol()).To(Equal(IngressBackendProtocolHTTP))
+			Expect(cfg.UseHTTPSIngress()).To(BeFalse())
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetDefaultIngressBackendProtocol()).To(Equal(IngressBackendProtocolHTTPS))
+			Expect(cfg.UseHTTPSIngress()).To(BeTrue())
+		})
+
+		It("correctly retrieves http", func() {
+			configMap.Data[defaultIngressBackendProtocolKey] = "http"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetDefaultIngressBackendProtocol()).To(Equal(IngressBackendProtocolHTTP))
+			Expect(cfg.UseHTTPSIngress()).To(BeFalse())
+		})
+
+		It("correctly retrieves https regardless of its case", func() {
+			configMap.Data[useHTTPSIngressKey] = "false"
+			configMap.Data[defaultIngressBackendProtocolKey] = "HTTPS"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetDefaultIngressBackendProtocol()).To(Equal(IngressBackendProtocolHTTPS))
+			Expect(cfg.UseHTTPSIngress()).To(BeTrue())
+		})
+
+		It("correctly follows the HTTPS ingress toggle when the protocol is invalid", func() {
+			configMap.Data[defaultIngressBackendProtocolKey] = "grpc"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetDefaultIngressBackendProtocol()).To(Equal(IngressBackendProtocolHTTP))
+			Expect(cfg.UseHTTPSIngress()).To(BeFalse())
+		})
+
+		It("correctly follows the enabled HTTPS ingress toggle when the protocol is invalid", func() {
+			configMap.Data[useHTTPSIngressKey] = "true"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetDefaultIngressBackendProtocol()).To(Equal(IngressBackendProtocolHTTPS))
+			Expect(cfg.UseHTTPSIngress()).To(BeTrue())
+		})
+	})
+
+	Context("create OSM config for the allowed egress domains", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				egressAllowedDomainsKey: "api.stripe.com",
+			},
+		}
+
+		It("correctly retrieves a single domain", func() {
+			Expect(cfg.GetEgressAllowedDomains()).To(BeEmpty())
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEgressAllowedDomains()).To(Equal([]string{"api.stripe.com"}))
+		})
+
+		It("correctly normalizes the domains and skips the invalid ones", func() {
+			for value, expected := range map[string][]string{
+				"*.example.com":                          {"*.example.com"},
+				"api.stripe.com., github.com.":           {"api.stripe.com", "github.com"},
+				"API.Stripe.COM *.Example.com":           {"*.example.com", "api.stripe.com"},
+				"github.com,GitHub.com.,github.com":      {"github.com"},
+				"github.com http://github.com":           {"github.com"},
+				"github.com:443,github.com/login":        nil,
+				"*,*.,foo.*.com,*example.com":            nil,
+				"-github.com,api..stripe.com,git_hub.io": nil,
+			} {
+				configMap.Data[egressAllowedDomainsKey] = value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetEgressAllowedDomains()).To(Equal(expected), value)
+			}
+		})
+	})
+
+	Context("create OSM config for the Prometheus scrape port and path", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults the scrape port and path when they are unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetPrometheusScrapePort()).To(Equal(uint32(constants.EnvoyPrometheusInboundListenerPort)))
+			Expect(cfg.GetPrometheusScrapePath()).To(Equal(constants.PrometheusScrapePath))
+		})
+
+		It("correctly retrieves the scrape port and path", func() {
+			configMap.Data[prometheusScrapePortKey] = "9090"
+			configMap.Data[prometheusScrapePathKey] = "/metrics"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetPrometheusScrapePort()).To(Equal(uint32(9090)))
+			Expect(cfg.GetPrometheusScrapePath()).To(Equal("/metrics"))
+		})
+
+		It("correctly defaults an empty scrape path to /stats/prometheus", func() {
+			configMap.Data[prometheusScrapePathKey] = ""
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetPrometheusScrapePath()).To(Equal("/stats/prometheus"))
+		})
+
+		It("correctly falls back to the defaults when the scrape port and path are invalid", func() {
+			configMap.Data[prometheusScrapePortKey] = "70000"
+			configMap.Data[prometheusScrapePathKey] = "metrics"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetPrometheusScrapePort()).To(Equal(uint32(constants.EnvoyPrometheusInboundListenerPort)))
+			Expect(cfg.GetPrometheusScrapePath()).To(Equal(constants.PrometheusScrapePath))
+		})
+	})
+
+	Context("create OSM config for the Envoy access logs", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults the access log settings when they are unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsAccessLoggingEnabled()).To(BeFalse())
+			Expect(cfg.GetAccessLogFormat()).To(Equal(AccessLogFormatText))
+		})
+
+		It("correctly retrieves the access log settings", func() {
+			configMap.Data[enableAccessLoggingKey] = "true"
+			configMap.Data[accessLogFormatKey] = "json"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsAccessLoggingEnabled()).To(BeTrue())
+			Expect(cfg.GetAccessLogFormat()).To(Equal(AccessLogFormatJSON))
+		})
+
+		It("correctly falls back to the text access log format when the configured one is invalid", func() {
+			configMap.Data[accessLogFormatKey] = "yaml"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsAccessLoggingEnabled()).To(BeTrue())
+			Expect(cfg.GetAccessLogFormat()).To(Equal(AccessLogFormatText))
+		})
+	})
+
+	Context("create OSM config for the Envoy admin port", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults the admin port when it is unset", func() {
+			Expect(cfg.GetEnvoyAdminPort()).To(Equal(uint32(constants.EnvoyAdminPort)))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyAdminPort()).To(Equal(uint32(constants.EnvoyAdminPort)))
+		})
+
+		It("correctly retrieves the admin port", func() {
+			configMap.Data[envoyAdminPortKey] = "15100"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyAdminPort()).To(Equal(uint32(15100)))
+		})
+
+		It("correctly rejects an admin port above the valid port range", func() {
+			configMap.Data[envoyAdminPortKey] = "65536"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyAdminPort()).To(Equal(uint32(constants.EnvoyAdminPort)))
+		})
+
+		It("correctly rejects an admin port below the valid port range", func() {
+			configMap.Data[envoyAdminPortKey] = "-1"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyAdminPort()).To(Equal(uint32(constants.EnvoyAdminPort)))
+		})
+	})
+
+	Context("create OSM config for the maximum number of data plane connections", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to unlimited connections when it is unset", func() {
+			Expect(cfg.GetMaxDataPlaneConnections()).To(Equal(0))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetMaxDataPlaneConnections()).To(Equal(0))
+		})
+
+		It("correctly retrieves the maximum number of connections", func() {
+			configMap.Data[maxDataPlaneConnectionsKey] = "500"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetMaxDataPlaneConnections()).To(Equal(500))
+		})
+
+		It("correctly retrieves unlimited connections", func() {
+			configMap.Data[maxDataPlaneConnectionsKey] = "0"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetMaxDataPlaneConnections()).To(Equal(0))
+		})
+
+		It("correctly treats a negative maximum number of connections as unlimited", func() {
+			configMap.Data[maxDataPlaneConnectionsKey] = "-10"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetMaxDataPlaneConnections()).To(Equal(0))
+		})
+	})
+
+	Context("create OSM config for the maximum number of endpoints per cluster", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to unlimited endpoints when it is unset", func() {
+			Expect(cfg.GetMaxEndpointsPerCluster()).To(Equal(uint32(0)))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetMaxEndpointsPerCluster()).To(Equal(uint32(0)))
+		})
+
+		It("correctly retrieves the maximum number of endpoints", func() {
+			configMap.Data[maxEndpointsPerClusterKey] = "100"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetMaxEndpointsPerCluster()).To(Equal(uint32(100)))
+		})
+
+		It("correctly retrieves unlimited endpoints", func() {
+			configMap.Data[maxEndpointsPerClusterKey] = "0"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetMaxEndpointsPerCluster()).To(Equal(uint32(0)))
+		})
+
+		It("correctly treats a negative maximum number of endpoints as unlimited", func() {
+			configMap.Data[maxEndpointsPerClusterKey] = "-10"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetMaxEndpointsPerCluster()).To(Equal(uint32(0)))
+		})
+	})
+
+	Context("create OSM config for the leader election lease timings", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+		defaultLeaderElectionConfig := LeaderElectionConfig{
+			LeaseDuration: 15 * time.Second,
+			RenewDeadline: 10 * time.Second,
+			RetryPeriod:   2 * time.Second,
+		}
+
+		It("correctly defaults the lease timings when they are unset", func() {
+			Expect(cfg.GetLeaderElectionConfig()).To(Equal(defaultLeaderElectionConfig))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetLeaderElectionConfig()).To(Equal(defaultLeaderElectionConfig))
+		})
+
+		It("correctly retrieves the lease timings", func() {
+			configMap.Data[leaderElectionKey] = "lease_duration: 60s\nrenew_deadline: 40s\nretry_period: 5s"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetLeaderElectionConfig()).To(Equal(LeaderElectionConfig{
+				LeaseDuration: 60 * time.Second,
+				RenewDeadline: 40 * time.Second,
+				RetryPeriod:   5 * time.Second,
+			}))
+		})
+
+		It("correctly defaults the lease timings which are unset", func() {
+			configMap.Data[leaderElectionKey] = "lease_duration: 30s"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetLeaderElectionConfig()).To(Equal(LeaderElectionConfig{
+				LeaseDuration: 30 * time.Second,
+				RenewDeadline: defaultLeaderElectionConfig.RenewDeadline,
+				RetryPeriod:   defaultLeaderElectionConfig.RetryPeriod,
+			}))
+		})
+
+		It("correctly defaults the lease timings which are invalid or not positive", func() {
+			configMap.Data[leaderElectionKey] = "lease_duration: 30s\nrenew_deadline: soon\nretry_period: -1s"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetLeaderElectionConfig()).To(Equal(LeaderElectionConfig{
+				LeaseDuration: 30 * time.Second,
+				RenewDeadline: defaultLeaderElectionConfig.RenewDeadline,
+				RetryPeriod:   defaultLeaderElectionConfig.RetryPeriod,
+			}))
+		})
+
+		It("correctly falls back to the default lease timings when they are not in decreasing order", func() {
+			configMap.Data[leaderElectionKey] = "lease_duration: 10s\nrenew_deadline: 20s"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetLeaderElectionConfig()).To(Equal(defaultLeaderElectionConfig))
+		})
+	})
+
+	Context("create OSM config for the service certificate validity duration", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults the validity duration when it is unset", func() {
+			Expect(cfg.GetServiceCertValidityDuration()).To(Equal(constants.DefaultServiceCertValidityDuration))
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetServiceCertValidityDuration()).To(Equal(constants.DefaultServiceCertValidityDuration))
+		})
+
+		It("correctly retrieves the validity duration", func() {
+			configMap.Data[serviceCertValidityDurationKey] = "12h"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetServiceCertValidityDuration()).To(Equal(12 * time.Hour))
+		})
+
+		It("correctly clamps a validity duration shorter than the minimum", func() {
+			configMap.Data[serviceCertValidityDurationKey] = "1m"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetServiceCertValidityDuration()).To(Equal(constants.MinServiceCertValidityDuration))
+		})
+
+		It("correctly retrieves a validity duration equal to the minimum", func() {
+			configMap.Data[serviceCertValidityDurationKey] = "5m"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetServiceCertValidityDuration()).To(Equal(5 * time.Minute))
+		})
+
+		It("correctly falls back to the default validity duration when the configured one cannot be parsed", func() {
+			configMap.Data[serviceCertValidityDurationKey] = "one day"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetServiceCertValidityDuration()).To(Equal(constants.DefaultServiceCertValidityDuration))
+		})
+	})
+
+	Context("create OSM config with a default service certificate validity duration", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, WithDefaultServiceCertValidityDuration(time.Hour))
+
+		It("correctly uses the given default when the validity duration is unset", func() {
+			Expect(cfg.GetServiceCertValidityDuration()).To(Equal(time.Hour))
+		})
+	})
+
+	Context("create OSM config for the Envoy proxy log level", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		testInfoEnvoyLogLevel := "info"
+		testErrorEnvoyLogLevel := "error"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("correctly identifies that the Envoy log level is debug", func() {
+			Expect(cfg.GetEnvoyLogLevel()).To(Equal(testDebugEnvoyLogLevel))
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: defaultConfigMap,
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetOSMNamespace()).To(Equal(osmNamespace))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cfg.GetEnvoyLogLevel()).To(Equal(testDebugEnvoyLogLevel))
+		})
+
+		It("correctly identifies that Envoy log level is info", func() {
+			defaultConfigMap[envoyLogLevel] = testInfoEnvoyLogLevel
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: defaultConfigMap,
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetOSMNamespace()).To(Equal(osmNamespace))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cfg.GetEnvoyLogLevel()).To(Equal(testInfoEnvoyLogLevel))
+		})
+
+		It("correctly identifies that Envoy log level is error", func() {
+			defaultConfigMap[envoyLogLevel] = testErrorEnvoyLogLevel
 			configMap := v1.ConfigMap{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: osmNamespace,
@@ -383,5 +4285,308 @@ var _ = Describe("Test Envoy configuration creation", func() {
 
 			Expect(cfg.GetEnvoyLogLevel()).To(Equal(testErrorEnvoyLogLevel))
 		})
+
+		It("correctly falls back to the default Envoy log level when the configured one is invalid", func() {
+			defaultConfigMap[envoyLogLevel] = "debg"
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: defaultConfigMap,
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyLogLevel()).To(Equal(constants.DefaultEnvoyLogLevel))
+		})
+
+		It("correctly falls back to the default Envoy log level when the configured one is empty", func() {
+			defaultConfigMap[envoyLogLevel] = ""
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: defaultConfigMap,
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyLogLevel()).To(Equal(constants.DefaultEnvoyLogLevel))
+		})
+
+		It("correctly accepts an Envoy log level regardless of case", func() {
+			defaultConfigMap[envoyLogLevel] = "WARNING"
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: defaultConfigMap,
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyLogLevel()).To(Equal("warning"))
+			defaultConfigMap[envoyLogLevel] = testDebugEnvoyLogLevel
+		})
+
+		It("correctly validates Envoy log levels", func() {
+			for _, logLevel := range []string{"trace", "debug", "info", "warning", "error", "critical", "off", "Info", "ERROR"} {
+				Expect(isValidEnvoyLogLevel(logLevel)).To(BeTrue(), logLevel)
+			}
+			for _, logLevel := range []string{"", "debg", "warn", "fatal", " info"} {
+				Expect(isValidEnvoyLogLevel(logLevel)).To(BeFalse(), logLevel)
+			}
+		})
+	})
+
+	Context("create OSM config for the Envoy log format", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+
+		It("correctly defaults to text when it is unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyLogFormat()).To(Equal(EnvoyLogFormatText))
+		})
+
+		It("correctly returns the configured format, or falls back on text when it is invalid", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, logFormat := range []struct {
+				value    string
+				expected string
+			}{
+				{EnvoyLogFormatJSON, EnvoyLogFormatJSON},
+				{"logfmt", EnvoyLogFormatText},
+				{"JSON", EnvoyLogFormatText},
+			} {
+				configMap.Data[envoyLogFormatKey] = logFormat.value
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetEnvoyLogFormat()).To(Equal(logFormat.expected), "Envoy log format %q", logFormat.value)
+			}
+			Expect(errorCauses(cfg.ValidateConfig())).To(ContainElement(errInvalidEnumValue))
+		})
+	})
+
+	Context("create OSM config for the certificate provider", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, WithAnnouncementDebounceWindow(0))
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{},
+		}
+		subscriber := cfg.Subscribe("CertificateProvider", "Vault", "CertManager")
+
+		It("correctly returns no provider when it is unset", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetCertificateProvider()).To(Equal(""))
+			Expect(cfg.GetVaultConfig()).To(BeNil())
+			Expect(cfg.GetCertManagerConfig()).To(BeNil())
+		})
+
+		It("correctly returns the configured provider, announcing its change", func() {
+			configMap.Data[certificateProviderKey] = CertificateProviderCertManager
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetCertificateProvider()).To(Equal(CertificateProviderCertManager))
+			var event ConfigChangeEvent
+			Expect(subscriber).To(Receive(&event))
+			Expect(event.ChangedFields).To(Equal([]string{"CertificateProvider"}))
+		})
+
+		It("correctly returns no provider when it is invalid", func() {
+			configMap.Data[certificateProviderKey] = "keyvault"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetCertificateProvider()).To(Equal(""))
+			Expect(errorCauses(cfg.ValidateConfig())).To(ContainElement(errInvalidEnumValue))
+		})
+	})
+
+	Context("create OSM config for the Vault certificate provider", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				certificateProviderKey: CertificateProviderVault,
+				vaultKey:               "host: vault.osm-system.svc.cluster.local\ntoken_secret_ref: osm-system/osm-vault-token/token",
+			},
+		}
+
+		It("correctly defaults the protocol, port and role", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetCertificateProvider()).To(Equal(CertificateProviderVault))
+			Expect(cfg.GetVaultConfig()).To(Equal(&VaultConfig{
+				Protocol:       defaultVaultProtocol,
+				Host:           "vault.osm-system.svc.cluster.local",
+				Port:           defaultVaultPort,
+				TokenSecretRef: "osm-system/osm-vault-token/token",
+				Role:           defaultVaultRole,
+			}))
+		})
+
+		It("correctly returns the configured protocol, port and role", func() {
+			configMap.Data[vaultKey] = "protocol: https\nhost: vault.osm-system.svc.cluster.local\nport: 8443\ntoken_secret_ref: osm-system/osm-vault-token/token\nrole: mesh"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetVaultConfig()).To(Equal(&VaultConfig{
+				Protocol:       "https",
+				Host:           "vault.osm-system.svc.cluster.local",
+				Port:           8443,
+				TokenSecretRef: "osm-system/osm-vault-token/token",
+				Role:           "mesh",
+			}))
+		})
+
+		It("correctly ignores the config missing its required fields", func() {
+			// Every update changes the config, since the updates leaving it unchanged are not announced
+			for _, vaultConfig := range []string{
+				"host: vault.osm-system.svc.cluster.local",
+				"token_secret_ref: osm-system/osm-vault-token/token",
+				"host: vault.osm-system.svc.cluster.local\ntoken_secret_ref: osm-vault-token",
+				"protocol: ftp\nhost: vault.osm-system.svc.cluster.local\ntoken_secret_ref: osm-system/osm-vault-token/token",
+			} {
+				configMap.Data[vaultKey] = vaultConfig
+				_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				// Wait for the config map change to propagate to the cache.
+				log.Info().Msg("Waiting for announcement")
+				<-cfg.GetAnnouncementsChannel()
+
+				Expect(cfg.GetVaultConfig()).To(BeNil(), "Vault config %q", vaultConfig)
+			}
+		})
+
+		It("correctly parses the reference to the Secret key holding the token", func() {
+			Expect(VaultConfig{TokenSecretRef: "osm-system/osm-vault-token/token"}.GetTokenSecretRef()).To(Equal(&SecretKeyRef{
+				Namespace: "osm-system",
+				Name:      "osm-vault-token",
+				Key:       "token",
+			}))
+			Expect(VaultConfig{}.GetTokenSecretRef()).To(BeNil())
+			Expect(VaultConfig{TokenSecretRef: "osm-system/osm-vault-token"}.GetTokenSecretRef()).To(BeNil())
+		})
+	})
+
+	Context("create OSM config for the cert-manager certificate provider", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				certificateProviderKey: CertificateProviderCertManager,
+				certManagerKey:         "issuer_name: osm-ca",
+			},
+		}
+
+		It("correctly defaults the issuer kind and group", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetCertManagerConfig()).To(Equal(&CertManagerConfig{
+				IssuerName:  "osm-ca",
+				IssuerKind:  defaultCertManagerIssuerKind,
+				IssuerGroup: defaultCertManagerIssuerGroup,
+			}))
+		})
+
+		It("correctly ignores the config missing its issuer name", func() {
+			configMap.Data[certManagerKey] = "issuer_kind: ClusterIssuer"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetCertManagerConfig()).To(BeNil())
+			Expect(errorCauses(cfg.ValidateConfig())).To(ContainElement(errMissingField))
+		})
 	})
 })
@@ -0,0 +1,115 @@
+package configurator
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/openservicemesh/osm/pkg/metricsstore"
+)
+
+// configMetrics is the set of gauges describing the active OSM config, and of metrics tracking its reloads
+type configMetrics struct {
+	permissiveMode prometheus.Gauge
+	egressEnabled  prometheus.Gauge
+	tracingEnabled prometheus.Gauge
+	envoyLogLevel  *prometheus.GaugeVec
+
+	reloads        prometheus.Counter
+	reloadErrors   prometheus.Counter
+	lastReloadTime prometheus.Gauge
+	rejections     prometheus.Counter
+}
+
+func newConfigMetrics() *configMetrics {
+	return &configMetrics{
+		permissiveMode: newConfigGauge("config_permissive_mode", "Whether permissive traffic policy mode is enabled (1) or not (0)"),
+		egressEnabled:  newConfigGauge("config_egress_enabled", "Whether egress is enabled (1) or not (0)"),
+		tracingEnabled: newConfigGauge("config_tracing_enabled", "Whether tracing is enabled (1) or not (0)"),
+		envoyLogLevel: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsstore.PrometheusNamespace,
+			Name:      "config_envoy_log_level",
+			Help:      "Whether the Envoy log level given by the level label is in use (1) or not (0)",
+		}, []string{"level"}),
+		reloads: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsstore.PrometheusNamespace,
+			Name:      "config_reload_total",
+			Help:      "Number of reloads of the OSM config, by the informer events or on demand, including the failed ones",
+		}),
+		reloadErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsstore.PrometheusNamespace,
+			Name:      "config_reload_errors_total",
+			Help:      "Number of reloads of the OSM config which failed to read the config, keeping the previous one",
+		}),
+		lastReloadTime: newConfigGauge("config_last_reload_timestamp_seconds", "Unix time of the last successful reload of the OSM config"),
+		rejections: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsstore.PrometheusNamespace,
+			Name:      "config_rejected_total",
+			Help:      "Number of revisions of the OSM ConfigMap rejected rather than applied, such as the oversized ones",
+		}),
+	}
+}
+
+func newConfigGauge(name, help string) prometheus.Gauge {
+	return prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsstore.PrometheusNamespace,
+		Name:      name,
+		Help:      help,
+	})
+}
+
+// update sets the gauges to the values of the given config
+func (m *configMetrics) update(config *MeshConfig, envoyLogLevel string) {
+	m.permissiveMode.Set(boolToFloat(config.PermissiveTrafficPolicyMode))
+	m.egressEnabled.Set(boolToFloat(config.getEgressMode() != EgressModeDisabled))
+	m.tracingEnabled.Set(boolToFloat(config.TracingEnable))
+	for level := range validEnvoyLogLevels {
+		m.envoyLogLevel.WithLabelValues(level).Set(boolToFloat(level == envoyLogLevel))
+	}
+}
+
+// recordReload counts a reload of the config, which failed when the given error is not nil; the last reload time is
+// only set by the successful reloads
+func (m *configMetrics) recordReload(err error) {
+	m.reloads.Inc()
+	if err != nil {
+		m.reloadErrors.Inc()
+		return
+	}
+	m.lastReloadTime.SetToCurrentTime()
+}
+
+// recordRejection counts a ConfigMap revision rejected for the given error, as a failed reload of the config
+func (m *configMetrics) recordRejection(err error) {
+	m.rejections.Inc()
+	m.recordReload(err)
+}
+
+// Describe implements prometheus.Collector
+func (m *configMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.permissiveMode.Describe(ch)
+	m.egressEnabled.Describe(ch)
+	m.tracingEnabled.Describe(ch)
+	m.envoyLogLevel.Describe(ch)
+	m.reloads.Describe(ch)
+	m.reloadErrors.Describe(ch)
+	m.lastReloadTime.Describe(ch)
+	m.rejections.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (m *configMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.permissiveMode.Collect(ch)
+	m.egressEnabled.Collect(ch)
+	m.tracingEnabled.Collect(ch)
+	m.envoyLogLevel.Collect(ch)
+	m.reloads.Collect(ch)
+	m.reloadErrors.Collect(ch)
+	m.lastReloadTime.Collect(ch)
+	m.rejections.Collect(ch)
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
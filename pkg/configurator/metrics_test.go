@@ -0,0 +1,144 @@
+package configurator
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ = Describe("Test OSM config metrics", func() {
+	Context("create OSM config and update the ConfigMap", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				permissiveTrafficPolicyModeKey: "true",
+				egressKey:                      "true",
+				envoyLogLevel:                  "warning",
+			},
+		}
+
+		It("reports the default config before the ConfigMap exists", func() {
+			Expect(testutil.ToFloat64(cfg.metrics.permissiveMode)).To(Equal(0.0))
+			Expect(testutil.ToFloat64(cfg.metrics.egressEnabled)).To(Equal(0.0))
+			Expect(testutil.ToFloat64(cfg.metrics.tracingEnabled)).To(Equal(0.0))
+			Expect(testutil.ToFloat64(cfg.metrics.envoyLogLevel.WithLabelValues("debug"))).To(Equal(1.0))
+		})
+
+		It("tracks the fields of the created ConfigMap", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(testutil.ToFloat64(cfg.metrics.permissiveMode)).To(Equal(1.0))
+			Expect(testutil.ToFloat64(cfg.metrics.egressEnabled)).To(Equal(1.0))
+			Expect(testutil.ToFloat64(cfg.metrics.tracingEnabled)).To(Equal(0.0))
+			Expect(testutil.ToFloat64(cfg.metrics.envoyLogLevel.WithLabelValues("warning"))).To(Equal(1.0))
+			Expect(testutil.ToFloat64(cfg.metrics.envoyLogLevel.WithLabelValues("debug"))).To(Equal(0.0))
+		})
+
+		It("tracks the fields flipped by a ConfigMap update", func() {
+			configMap.Data[permissiveTrafficPolicyModeKey] = "false"
+			configMap.Data[tracingEnableKey] = "true"
+			configMap.Data[envoyLogLevel] = "info"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), &configMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(testutil.ToFloat64(cfg.metrics.permissiveMode)).To(Equal(0.0))
+			Expect(testutil.ToFloat64(cfg.metrics.egressEnabled)).To(Equal(1.0))
+			Expect(testutil.ToFloat64(cfg.metrics.tracingEnabled)).To(Equal(1.0))
+			Expect(testutil.ToFloat64(cfg.metrics.envoyLogLevel.WithLabelValues("info"))).To(Equal(1.0))
+			Expect(testutil.ToFloat64(cfg.metrics.envoyLogLevel.WithLabelValues("warning"))).To(Equal(0.0))
+		})
+
+		It("counts a successful reload for each ConfigMap event", func() {
+			Expect(testutil.ToFloat64(cfg.metrics.reloads)).To(Equal(2.0))
+			Expect(testutil.ToFloat64(cfg.metrics.reloadErrors)).To(Equal(0.0))
+			Expect(testutil.ToFloat64(cfg.metrics.lastReloadTime)).To(BeNumerically(">", 0))
+		})
+
+		It("exposes the gauges through the metrics collector", func() {
+			registry := prometheus.NewRegistry()
+			Expect(registry.Register(cfg.GetMetricsCollector())).To(Succeed())
+
+			metricFamilies, err := registry.Gather()
+			Expect(err).ToNot(HaveOccurred())
+			var names []string
+			for _, metricFamily := range metricFamilies {
+				names = append(names, metricFamily.GetName())
+			}
+			Expect(names).To(ConsistOf(
+				"osm_config_permissive_mode",
+				"osm_config_egress_enabled",
+				"osm_config_tracing_enabled",
+				"osm_config_envoy_log_level",
+				"osm_config_reload_total",
+				"osm_config_reload_errors_total",
+				"osm_config_last_reload_timestamp_seconds",
+				"osm_config_rejected_total",
+			))
+		})
+	})
+
+	Context("reload the OSM config on demand", func() {
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		kubeClient := testclient.NewSimpleClientset(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				egressKey: "true",
+			},
+		})
+		cfg := newClient(osmNamespace, osmConfigMapName)
+		cfg.kubeClient = kubeClient
+		cfg.cache = cache.NewStore(cache.MetaNamespaceKeyFunc)
+
+		It("counts each successful reload", func() {
+			for i := 1; i <= 3; i++ {
+				Expect(cfg.ReloadNow()).To(Succeed())
+				Expect(testutil.ToFloat64(cfg.metrics.reloads)).To(Equal(float64(i)))
+			}
+			Expect(testutil.ToFloat64(cfg.metrics.reloadErrors)).To(Equal(0.0))
+			Expect(testutil.ToFloat64(cfg.metrics.lastReloadTime)).To(BeNumerically(">", 0))
+		})
+
+		It("counts each failed reload, without updating the last reload time", func() {
+			lastReloadTime := testutil.ToFloat64(cfg.metrics.lastReloadTime)
+			kubeClient.PrependReactor("get", "configmaps", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New("API server unavailable")
+			})
+
+			for i := 1; i <= 2; i++ {
+				Expect(cfg.ReloadNow()).ToNot(Succeed())
+				Expect(testutil.ToFloat64(cfg.metrics.reloads)).To(Equal(float64(3 + i)))
+				Expect(testutil.ToFloat64(cfg.metrics.reloadErrors)).To(Equal(float64(i)))
+			}
+			Expect(testutil.ToFloat64(cfg.metrics.lastReloadTime)).To(Equal(lastReloadTime))
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+		})
+	})
+})
@@ -5,9 +5,15 @@
 package configurator
 
 import (
+	context "context"
+	io "io"
+	net "net"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
+	prometheus "github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
 )
 
 // MockConfigurator is a mock of Configurator interface
@@ -33,6 +39,34 @@ func (m *MockConfigurator) EXPECT() *MockConfiguratorMockRecorder {
 	return m.recorder
 }
 
+// Close mocks base method
+func (m *MockConfigurator) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close
+func (mr *MockConfiguratorMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockConfigurator)(nil).Close))
+}
+
+// GetAccessLogFormat mocks base method
+func (m *MockConfigurator) GetAccessLogFormat() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccessLogFormat")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetAccessLogFormat indicates an expected call of GetAccessLogFormat
+func (mr *MockConfiguratorMockRecorder) GetAccessLogFormat() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessLogFormat", reflect.TypeOf((*MockConfigurator)(nil).GetAccessLogFormat))
+}
+
 // GetAnnouncementsChannel mocks base method
 func (m *MockConfigurator) GetAnnouncementsChannel() <-chan interface{} {
 	m.ctrl.T.Helper()
@@ -41,67 +75,955 @@ func (m *MockConfigurator) GetAnnouncementsChannel() <-chan interface{} {
 	return ret0
 }
 
-// GetAnnouncementsChannel indicates an expected call of GetAnnouncementsChannel
-func (mr *MockConfiguratorMockRecorder) GetAnnouncementsChannel() *gomock.Call {
+// GetAnnouncementsChannel indicates an expected call of GetAnnouncementsChannel
+func (mr *MockConfiguratorMockRecorder) GetAnnouncementsChannel() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnnouncementsChannel", reflect.TypeOf((*MockConfigurator)(nil).GetAnnouncementsChannel))
+}
+
+// GetCertManagerConfig mocks base method
+func (m *MockConfigurator) GetCertManagerConfig() *CertManagerConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertManagerConfig")
+	ret0, _ := ret[0].(*CertManagerConfig)
+	return ret0
+}
+
+// GetCertManagerConfig indicates an expected call of GetCertManagerConfig
+func (mr *MockConfiguratorMockRecorder) GetCertManagerConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertManagerConfig", reflect.TypeOf((*MockConfigurator)(nil).GetCertManagerConfig))
+}
+
+// GetCertificateProvider mocks base method
+func (m *MockConfigurator) GetCertificateProvider() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCertificateProvider")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetCertificateProvider indicates an expected call of GetCertificateProvider
+func (mr *MockConfiguratorMockRecorder) GetCertificateProvider() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCertificateProvider", reflect.TypeOf((*MockConfigurator)(nil).GetCertificateProvider))
+}
+
+// GetConfigHash mocks base method
+func (m *MockConfigurator) GetConfigHash() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigHash")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetConfigHash indicates an expected call of GetConfigHash
+func (mr *MockConfiguratorMockRecorder) GetConfigHash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigHash", reflect.TypeOf((*MockConfigurator)(nil).GetConfigHash))
+}
+
+// GetConfigMap mocks base method
+func (m *MockConfigurator) GetConfigMap() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigMap")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigMap indicates an expected call of GetConfigMap
+func (mr *MockConfiguratorMockRecorder) GetConfigMap() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMap", reflect.TypeOf((*MockConfigurator)(nil).GetConfigMap))
+}
+
+// GetConfigMapName mocks base method
+func (m *MockConfigurator) GetConfigMapName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigMapName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetConfigMapName indicates an expected call of GetConfigMapName
+func (mr *MockConfiguratorMockRecorder) GetConfigMapName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMapName", reflect.TypeOf((*MockConfigurator)(nil).GetConfigMapName))
+}
+
+// GetConfigMapNamespace mocks base method
+func (m *MockConfigurator) GetConfigMapNamespace() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigMapNamespace")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetConfigMapNamespace indicates an expected call of GetConfigMapNamespace
+func (mr *MockConfiguratorMockRecorder) GetConfigMapNamespace() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMapNamespace", reflect.TypeOf((*MockConfigurator)(nil).GetConfigMapNamespace))
+}
+
+// GetConfigMapYAML mocks base method
+func (m *MockConfigurator) GetConfigMapYAML() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigMapYAML")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConfigMapYAML indicates an expected call of GetConfigMapYAML
+func (mr *MockConfiguratorMockRecorder) GetConfigMapYAML() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMapYAML", reflect.TypeOf((*MockConfigurator)(nil).GetConfigMapYAML))
+}
+
+// GetConfigProvenance mocks base method
+func (m *MockConfigurator) GetConfigProvenance() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigProvenance")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// GetConfigProvenance indicates an expected call of GetConfigProvenance
+func (mr *MockConfiguratorMockRecorder) GetConfigProvenance() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigProvenance", reflect.TypeOf((*MockConfigurator)(nil).GetConfigProvenance))
+}
+
+// GetConfigResourceVersion mocks base method
+func (m *MockConfigurator) GetConfigResourceVersion() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigResourceVersion")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetConfigResourceVersion indicates an expected call of GetConfigResourceVersion
+func (mr *MockConfiguratorMockRecorder) GetConfigResourceVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigResourceVersion", reflect.TypeOf((*MockConfigurator)(nil).GetConfigResourceVersion))
+}
+
+// GetDefaultCircuitBreaking mocks base method
+func (m *MockConfigurator) GetDefaultCircuitBreaking() CircuitBreaking {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultCircuitBreaking")
+	ret0, _ := ret[0].(CircuitBreaking)
+	return ret0
+}
+
+// GetDefaultCircuitBreaking indicates an expected call of GetDefaultCircuitBreaking
+func (mr *MockConfiguratorMockRecorder) GetDefaultCircuitBreaking() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultCircuitBreaking", reflect.TypeOf((*MockConfigurator)(nil).GetDefaultCircuitBreaking))
+}
+
+// GetDefaultIngressBackendProtocol mocks base method
+func (m *MockConfigurator) GetDefaultIngressBackendProtocol() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultIngressBackendProtocol")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetDefaultIngressBackendProtocol indicates an expected call of GetDefaultIngressBackendProtocol
+func (mr *MockConfiguratorMockRecorder) GetDefaultIngressBackendProtocol() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultIngressBackendProtocol", reflect.TypeOf((*MockConfigurator)(nil).GetDefaultIngressBackendProtocol))
+}
+
+// GetDefaultLBAlgorithm mocks base method
+func (m *MockConfigurator) GetDefaultLBAlgorithm() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultLBAlgorithm")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetDefaultLBAlgorithm indicates an expected call of GetDefaultLBAlgorithm
+func (mr *MockConfiguratorMockRecorder) GetDefaultLBAlgorithm() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultLBAlgorithm", reflect.TypeOf((*MockConfigurator)(nil).GetDefaultLBAlgorithm))
+}
+
+// GetDefaultRetryPolicy mocks base method
+func (m *MockConfigurator) GetDefaultRetryPolicy() *RetryPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultRetryPolicy")
+	ret0, _ := ret[0].(*RetryPolicy)
+	return ret0
+}
+
+// GetDefaultRetryPolicy indicates an expected call of GetDefaultRetryPolicy
+func (mr *MockConfiguratorMockRecorder) GetDefaultRetryPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultRetryPolicy", reflect.TypeOf((*MockConfigurator)(nil).GetDefaultRetryPolicy))
+}
+
+// GetDisabledXDSTypes mocks base method
+func (m *MockConfigurator) GetDisabledXDSTypes() map[string]bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDisabledXDSTypes")
+	ret0, _ := ret[0].(map[string]bool)
+	return ret0
+}
+
+// GetDisabledXDSTypes indicates an expected call of GetDisabledXDSTypes
+func (mr *MockConfiguratorMockRecorder) GetDisabledXDSTypes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisabledXDSTypes", reflect.TypeOf((*MockConfigurator)(nil).GetDisabledXDSTypes))
+}
+
+// GetEgressAllowedDomains mocks base method
+func (m *MockConfigurator) GetEgressAllowedDomains() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEgressAllowedDomains")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetEgressAllowedDomains indicates an expected call of GetEgressAllowedDomains
+func (mr *MockConfiguratorMockRecorder) GetEgressAllowedDomains() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEgressAllowedDomains", reflect.TypeOf((*MockConfigurator)(nil).GetEgressAllowedDomains))
+}
+
+// GetEgressCABundleRef mocks base method
+func (m *MockConfigurator) GetEgressCABundleRef() *CABundleRef {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEgressCABundleRef")
+	ret0, _ := ret[0].(*CABundleRef)
+	return ret0
+}
+
+// GetEgressCABundleRef indicates an expected call of GetEgressCABundleRef
+func (mr *MockConfiguratorMockRecorder) GetEgressCABundleRef() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEgressCABundleRef", reflect.TypeOf((*MockConfigurator)(nil).GetEgressCABundleRef))
+}
+
+// GetEgressDNSResolution mocks base method
+func (m *MockConfigurator) GetEgressDNSResolution() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEgressDNSResolution")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetEgressDNSResolution indicates an expected call of GetEgressDNSResolution
+func (mr *MockConfiguratorMockRecorder) GetEgressDNSResolution() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEgressDNSResolution", reflect.TypeOf((*MockConfigurator)(nil).GetEgressDNSResolution))
+}
+
+// GetEgressMode mocks base method
+func (m *MockConfigurator) GetEgressMode() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEgressMode")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetEgressMode indicates an expected call of GetEgressMode
+func (mr *MockConfiguratorMockRecorder) GetEgressMode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEgressMode", reflect.TypeOf((*MockConfigurator)(nil).GetEgressMode))
+}
+
+// GetEgressModeForChannel mocks base method
+func (m *MockConfigurator) GetEgressModeForChannel(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEgressModeForChannel", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetEgressModeForChannel indicates an expected call of GetEgressModeForChannel
+func (mr *MockConfiguratorMockRecorder) GetEgressModeForChannel(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEgressModeForChannel", reflect.TypeOf((*MockConfigurator)(nil).GetEgressModeForChannel), arg0)
+}
+
+// GetEnvoyAdminPort mocks base method
+func (m *MockConfigurator) GetEnvoyAdminPort() uint32 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyAdminPort")
+	ret0, _ := ret[0].(uint32)
+	return ret0
+}
+
+// GetEnvoyAdminPort indicates an expected call of GetEnvoyAdminPort
+func (mr *MockConfiguratorMockRecorder) GetEnvoyAdminPort() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyAdminPort", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyAdminPort))
+}
+
+// GetEnvoyConcurrency mocks base method
+func (m *MockConfigurator) GetEnvoyConcurrency() uint32 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyConcurrency")
+	ret0, _ := ret[0].(uint32)
+	return ret0
+}
+
+// GetEnvoyConcurrency indicates an expected call of GetEnvoyConcurrency
+func (mr *MockConfiguratorMockRecorder) GetEnvoyConcurrency() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyConcurrency", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyConcurrency))
+}
+
+// GetEnvoyConnectionIdleTimeout mocks base method
+func (m *MockConfigurator) GetEnvoyConnectionIdleTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyConnectionIdleTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetEnvoyConnectionIdleTimeout indicates an expected call of GetEnvoyConnectionIdleTimeout
+func (mr *MockConfiguratorMockRecorder) GetEnvoyConnectionIdleTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyConnectionIdleTimeout", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyConnectionIdleTimeout))
+}
+
+// GetEnvoyImage mocks base method
+func (m *MockConfigurator) GetEnvoyImage() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyImage")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetEnvoyImage indicates an expected call of GetEnvoyImage
+func (mr *MockConfiguratorMockRecorder) GetEnvoyImage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyImage", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyImage))
+}
+
+// GetEnvoyLogFormat mocks base method
+func (m *MockConfigurator) GetEnvoyLogFormat() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyLogFormat")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetEnvoyLogFormat indicates an expected call of GetEnvoyLogFormat
+func (mr *MockConfiguratorMockRecorder) GetEnvoyLogFormat() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyLogFormat", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyLogFormat))
+}
+
+// GetEnvoyLogLevel mocks base method
+func (m *MockConfigurator) GetEnvoyLogLevel() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyLogLevel")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetEnvoyLogLevel indicates an expected call of GetEnvoyLogLevel
+func (mr *MockConfiguratorMockRecorder) GetEnvoyLogLevel() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyLogLevel", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyLogLevel))
+}
+
+// GetEnvoyLogLevelForChannel mocks base method
+func (m *MockConfigurator) GetEnvoyLogLevelForChannel(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyLogLevelForChannel", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetEnvoyLogLevelForChannel indicates an expected call of GetEnvoyLogLevelForChannel
+func (mr *MockConfiguratorMockRecorder) GetEnvoyLogLevelForChannel(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyLogLevelForChannel", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyLogLevelForChannel), arg0)
+}
+
+// GetEnvoyLogLevelForNamespace mocks base method
+func (m *MockConfigurator) GetEnvoyLogLevelForNamespace(arg0 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyLogLevelForNamespace", arg0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetEnvoyLogLevelForNamespace indicates an expected call of GetEnvoyLogLevelForNamespace
+func (mr *MockConfiguratorMockRecorder) GetEnvoyLogLevelForNamespace(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyLogLevelForNamespace", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyLogLevelForNamespace), arg0)
+}
+
+// GetEnvoyRequestTimeout mocks base method
+func (m *MockConfigurator) GetEnvoyRequestTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnvoyRequestTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetEnvoyRequestTimeout indicates an expected call of GetEnvoyRequestTimeout
+func (mr *MockConfiguratorMockRecorder) GetEnvoyRequestTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyRequestTimeout", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyRequestTimeout))
+}
+
+// GetExcludedNamespaces mocks base method
+func (m *MockConfigurator) GetExcludedNamespaces() map[string]bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExcludedNamespaces")
+	ret0, _ := ret[0].(map[string]bool)
+	return ret0
+}
+
+// GetExcludedNamespaces indicates an expected call of GetExcludedNamespaces
+func (mr *MockConfiguratorMockRecorder) GetExcludedNamespaces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExcludedNamespaces", reflect.TypeOf((*MockConfigurator)(nil).GetExcludedNamespaces))
+}
+
+// GetFeatureFlags mocks base method
+func (m *MockConfigurator) GetFeatureFlags() map[string]bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeatureFlags")
+	ret0, _ := ret[0].(map[string]bool)
+	return ret0
+}
+
+// GetFeatureFlags indicates an expected call of GetFeatureFlags
+func (mr *MockConfiguratorMockRecorder) GetFeatureFlags() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeatureFlags", reflect.TypeOf((*MockConfigurator)(nil).GetFeatureFlags))
+}
+
+// GetInboundExternalAuthConfig mocks base method
+func (m *MockConfigurator) GetInboundExternalAuthConfig() *InboundExternalAuth {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboundExternalAuthConfig")
+	ret0, _ := ret[0].(*InboundExternalAuth)
+	return ret0
+}
+
+// GetInboundExternalAuthConfig indicates an expected call of GetInboundExternalAuthConfig
+func (mr *MockConfiguratorMockRecorder) GetInboundExternalAuthConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboundExternalAuthConfig", reflect.TypeOf((*MockConfigurator)(nil).GetInboundExternalAuthConfig))
+}
+
+// GetInboundPortExclusionList mocks base method
+func (m *MockConfigurator) GetInboundPortExclusionList() []int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInboundPortExclusionList")
+	ret0, _ := ret[0].([]int)
+	return ret0
+}
+
+// GetInboundPortExclusionList indicates an expected call of GetInboundPortExclusionList
+func (mr *MockConfiguratorMockRecorder) GetInboundPortExclusionList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInboundPortExclusionList", reflect.TypeOf((*MockConfigurator)(nil).GetInboundPortExclusionList))
+}
+
+// GetInitContainerImage mocks base method
+func (m *MockConfigurator) GetInitContainerImage() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInitContainerImage")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetInitContainerImage indicates an expected call of GetInitContainerImage
+func (mr *MockConfiguratorMockRecorder) GetInitContainerImage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInitContainerImage", reflect.TypeOf((*MockConfigurator)(nil).GetInitContainerImage))
+}
+
+// GetLastConfigError mocks base method
+func (m *MockConfigurator) GetLastConfigError() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastConfigError")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetLastConfigError indicates an expected call of GetLastConfigError
+func (mr *MockConfiguratorMockRecorder) GetLastConfigError() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastConfigError", reflect.TypeOf((*MockConfigurator)(nil).GetLastConfigError))
+}
+
+// GetLeaderElectionConfig mocks base method
+func (m *MockConfigurator) GetLeaderElectionConfig() LeaderElectionConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLeaderElectionConfig")
+	ret0, _ := ret[0].(LeaderElectionConfig)
+	return ret0
+}
+
+// GetLeaderElectionConfig indicates an expected call of GetLeaderElectionConfig
+func (mr *MockConfiguratorMockRecorder) GetLeaderElectionConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLeaderElectionConfig", reflect.TypeOf((*MockConfigurator)(nil).GetLeaderElectionConfig))
+}
+
+// GetLocality mocks base method
+func (m *MockConfigurator) GetLocality() Locality {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocality")
+	ret0, _ := ret[0].(Locality)
+	return ret0
+}
+
+// GetLocality indicates an expected call of GetLocality
+func (mr *MockConfiguratorMockRecorder) GetLocality() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocality", reflect.TypeOf((*MockConfigurator)(nil).GetLocality))
+}
+
+// GetMTLSExemptSourceCIDRs mocks base method
+func (m *MockConfigurator) GetMTLSExemptSourceCIDRs() []*net.IPNet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMTLSExemptSourceCIDRs")
+	ret0, _ := ret[0].([]*net.IPNet)
+	return ret0
+}
+
+// GetMTLSExemptSourceCIDRs indicates an expected call of GetMTLSExemptSourceCIDRs
+func (mr *MockConfiguratorMockRecorder) GetMTLSExemptSourceCIDRs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMTLSExemptSourceCIDRs", reflect.TypeOf((*MockConfigurator)(nil).GetMTLSExemptSourceCIDRs))
+}
+
+// GetMaxDataPlaneConnections mocks base method
+func (m *MockConfigurator) GetMaxDataPlaneConnections() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxDataPlaneConnections")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetMaxDataPlaneConnections indicates an expected call of GetMaxDataPlaneConnections
+func (mr *MockConfiguratorMockRecorder) GetMaxDataPlaneConnections() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxDataPlaneConnections", reflect.TypeOf((*MockConfigurator)(nil).GetMaxDataPlaneConnections))
+}
+
+// GetMaxEndpointsPerCluster mocks base method
+func (m *MockConfigurator) GetMaxEndpointsPerCluster() uint32 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMaxEndpointsPerCluster")
+	ret0, _ := ret[0].(uint32)
+	return ret0
+}
+
+// GetMaxEndpointsPerCluster indicates an expected call of GetMaxEndpointsPerCluster
+func (mr *MockConfiguratorMockRecorder) GetMaxEndpointsPerCluster() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMaxEndpointsPerCluster", reflect.TypeOf((*MockConfigurator)(nil).GetMaxEndpointsPerCluster))
+}
+
+// GetMeshCIDRRanges mocks base method
+func (m *MockConfigurator) GetMeshCIDRRanges() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMeshCIDRRanges")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetMeshCIDRRanges indicates an expected call of GetMeshCIDRRanges
+func (mr *MockConfiguratorMockRecorder) GetMeshCIDRRanges() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeshCIDRRanges", reflect.TypeOf((*MockConfigurator)(nil).GetMeshCIDRRanges))
+}
+
+// GetMeshCIDRRangesByFamily mocks base method
+func (m *MockConfigurator) GetMeshCIDRRangesByFamily() ([]string, []string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMeshCIDRRangesByFamily")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].([]string)
+	return ret0, ret1
+}
+
+// GetMeshCIDRRangesByFamily indicates an expected call of GetMeshCIDRRangesByFamily
+func (mr *MockConfiguratorMockRecorder) GetMeshCIDRRangesByFamily() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeshCIDRRangesByFamily", reflect.TypeOf((*MockConfigurator)(nil).GetMeshCIDRRangesByFamily))
+}
+
+// GetMeshCIDRRangesParsed mocks base method
+func (m *MockConfigurator) GetMeshCIDRRangesParsed() ([]*net.IPNet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMeshCIDRRangesParsed")
+	ret0, _ := ret[0].([]*net.IPNet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMeshCIDRRangesParsed indicates an expected call of GetMeshCIDRRangesParsed
+func (mr *MockConfiguratorMockRecorder) GetMeshCIDRRangesParsed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeshCIDRRangesParsed", reflect.TypeOf((*MockConfigurator)(nil).GetMeshCIDRRangesParsed))
+}
+
+// GetMeshCipherSuites mocks base method
+func (m *MockConfigurator) GetMeshCipherSuites() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMeshCipherSuites")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetMeshCipherSuites indicates an expected call of GetMeshCipherSuites
+func (mr *MockConfiguratorMockRecorder) GetMeshCipherSuites() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeshCipherSuites", reflect.TypeOf((*MockConfigurator)(nil).GetMeshCipherSuites))
+}
+
+// GetMeshConfig mocks base method
+func (m *MockConfigurator) GetMeshConfig() MeshConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMeshConfig")
+	ret0, _ := ret[0].(MeshConfig)
+	return ret0
+}
+
+// GetMeshConfig indicates an expected call of GetMeshConfig
+func (mr *MockConfiguratorMockRecorder) GetMeshConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeshConfig", reflect.TypeOf((*MockConfigurator)(nil).GetMeshConfig))
+}
+
+// GetMeshTLSVersions mocks base method
+func (m *MockConfigurator) GetMeshTLSVersions() (string, string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMeshTLSVersions")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	return ret0, ret1
+}
+
+// GetMeshTLSVersions indicates an expected call of GetMeshTLSVersions
+func (mr *MockConfiguratorMockRecorder) GetMeshTLSVersions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeshTLSVersions", reflect.TypeOf((*MockConfigurator)(nil).GetMeshTLSVersions))
+}
+
+// GetMetricsCollector mocks base method
+func (m *MockConfigurator) GetMetricsCollector() prometheus.Collector {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetricsCollector")
+	ret0, _ := ret[0].(prometheus.Collector)
+	return ret0
+}
+
+// GetMetricsCollector indicates an expected call of GetMetricsCollector
+func (mr *MockConfiguratorMockRecorder) GetMetricsCollector() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetricsCollector", reflect.TypeOf((*MockConfigurator)(nil).GetMetricsCollector))
+}
+
+// GetMulticlusterGateway mocks base method
+func (m *MockConfigurator) GetMulticlusterGateway() MulticlusterGateway {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMulticlusterGateway")
+	ret0, _ := ret[0].(MulticlusterGateway)
+	return ret0
+}
+
+// GetMulticlusterGateway indicates an expected call of GetMulticlusterGateway
+func (mr *MockConfiguratorMockRecorder) GetMulticlusterGateway() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMulticlusterGateway", reflect.TypeOf((*MockConfigurator)(nil).GetMulticlusterGateway))
+}
+
+// GetOSMNamespace mocks base method
+func (m *MockConfigurator) GetOSMNamespace() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOSMNamespace")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetOSMNamespace indicates an expected call of GetOSMNamespace
+func (mr *MockConfiguratorMockRecorder) GetOSMNamespace() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOSMNamespace", reflect.TypeOf((*MockConfigurator)(nil).GetOSMNamespace))
+}
+
+// GetOutboundPortExclusionList mocks base method
+func (m *MockConfigurator) GetOutboundPortExclusionList() []int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutboundPortExclusionList")
+	ret0, _ := ret[0].([]int)
+	return ret0
+}
+
+// GetOutboundPortExclusionList indicates an expected call of GetOutboundPortExclusionList
+func (mr *MockConfiguratorMockRecorder) GetOutboundPortExclusionList() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutboundPortExclusionList", reflect.TypeOf((*MockConfigurator)(nil).GetOutboundPortExclusionList))
+}
+
+// GetOutlierDetection mocks base method
+func (m *MockConfigurator) GetOutlierDetection() *OutlierDetection {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOutlierDetection")
+	ret0, _ := ret[0].(*OutlierDetection)
+	return ret0
+}
+
+// GetOutlierDetection indicates an expected call of GetOutlierDetection
+func (mr *MockConfiguratorMockRecorder) GetOutlierDetection() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOutlierDetection", reflect.TypeOf((*MockConfigurator)(nil).GetOutlierDetection))
+}
+
+// GetOverloadManager mocks base method
+func (m *MockConfigurator) GetOverloadManager() *OverloadManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverloadManager")
+	ret0, _ := ret[0].(*OverloadManager)
+	return ret0
+}
+
+// GetOverloadManager indicates an expected call of GetOverloadManager
+func (mr *MockConfiguratorMockRecorder) GetOverloadManager() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverloadManager", reflect.TypeOf((*MockConfigurator)(nil).GetOverloadManager))
+}
+
+// GetPrometheusScrapePath mocks base method
+func (m *MockConfigurator) GetPrometheusScrapePath() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrometheusScrapePath")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetPrometheusScrapePath indicates an expected call of GetPrometheusScrapePath
+func (mr *MockConfiguratorMockRecorder) GetPrometheusScrapePath() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrometheusScrapePath", reflect.TypeOf((*MockConfigurator)(nil).GetPrometheusScrapePath))
+}
+
+// GetPrometheusScrapePort mocks base method
+func (m *MockConfigurator) GetPrometheusScrapePort() uint32 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrometheusScrapePort")
+	ret0, _ := ret[0].(uint32)
+	return ret0
+}
+
+// GetPrometheusScrapePort indicates an expected call of GetPrometheusScrapePort
+func (mr *MockConfiguratorMockRecorder) GetPrometheusScrapePort() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrometheusScrapePort", reflect.TypeOf((*MockConfigurator)(nil).GetPrometheusScrapePort))
+}
+
+// GetProxyBindAddress mocks base method
+func (m *MockConfigurator) GetProxyBindAddress() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProxyBindAddress")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetProxyBindAddress indicates an expected call of GetProxyBindAddress
+func (mr *MockConfiguratorMockRecorder) GetProxyBindAddress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProxyBindAddress", reflect.TypeOf((*MockConfigurator)(nil).GetProxyBindAddress))
+}
+
+// GetProxyBootstrapOverride mocks base method
+func (m *MockConfigurator) GetProxyBootstrapOverride() (map[string]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProxyBootstrapOverride")
+	ret0, _ := ret[0].(map[string]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProxyBootstrapOverride indicates an expected call of GetProxyBootstrapOverride
+func (mr *MockConfiguratorMockRecorder) GetProxyBootstrapOverride() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProxyBootstrapOverride", reflect.TypeOf((*MockConfigurator)(nil).GetProxyBootstrapOverride))
+}
+
+// GetProxyDrainTime mocks base method
+func (m *MockConfigurator) GetProxyDrainTime() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProxyDrainTime")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetProxyDrainTime indicates an expected call of GetProxyDrainTime
+func (mr *MockConfiguratorMockRecorder) GetProxyDrainTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProxyDrainTime", reflect.TypeOf((*MockConfigurator)(nil).GetProxyDrainTime))
+}
+
+// GetProxyEnvVars mocks base method
+func (m *MockConfigurator) GetProxyEnvVars() []v1.EnvVar {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProxyEnvVars")
+	ret0, _ := ret[0].([]v1.EnvVar)
+	return ret0
+}
+
+// GetProxyEnvVars indicates an expected call of GetProxyEnvVars
+func (mr *MockConfiguratorMockRecorder) GetProxyEnvVars() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProxyEnvVars", reflect.TypeOf((*MockConfigurator)(nil).GetProxyEnvVars))
+}
+
+// GetProxyParentShutdownTime mocks base method
+func (m *MockConfigurator) GetProxyParentShutdownTime() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProxyParentShutdownTime")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetProxyParentShutdownTime indicates an expected call of GetProxyParentShutdownTime
+func (mr *MockConfiguratorMockRecorder) GetProxyParentShutdownTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProxyParentShutdownTime", reflect.TypeOf((*MockConfigurator)(nil).GetProxyParentShutdownTime))
+}
+
+// GetProxyProbeSpec mocks base method
+func (m *MockConfigurator) GetProxyProbeSpec() v1.Probe {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProxyProbeSpec")
+	ret0, _ := ret[0].(v1.Probe)
+	return ret0
+}
+
+// GetProxyProbeSpec indicates an expected call of GetProxyProbeSpec
+func (mr *MockConfiguratorMockRecorder) GetProxyProbeSpec() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProxyProbeSpec", reflect.TypeOf((*MockConfigurator)(nil).GetProxyProbeSpec))
+}
+
+// GetRedactedConfigMap mocks base method
+func (m *MockConfigurator) GetRedactedConfigMap() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRedactedConfigMap")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRedactedConfigMap indicates an expected call of GetRedactedConfigMap
+func (mr *MockConfiguratorMockRecorder) GetRedactedConfigMap() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRedactedConfigMap", reflect.TypeOf((*MockConfigurator)(nil).GetRedactedConfigMap))
+}
+
+// GetSMISpecVersion mocks base method
+func (m *MockConfigurator) GetSMISpecVersion() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSMISpecVersion")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetSMISpecVersion indicates an expected call of GetSMISpecVersion
+func (mr *MockConfiguratorMockRecorder) GetSMISpecVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSMISpecVersion", reflect.TypeOf((*MockConfigurator)(nil).GetSMISpecVersion))
+}
+
+// GetServiceCertValidityDuration mocks base method
+func (m *MockConfigurator) GetServiceCertValidityDuration() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceCertValidityDuration")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetServiceCertValidityDuration indicates an expected call of GetServiceCertValidityDuration
+func (mr *MockConfiguratorMockRecorder) GetServiceCertValidityDuration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceCertValidityDuration", reflect.TypeOf((*MockConfigurator)(nil).GetServiceCertValidityDuration))
+}
+
+// GetSidecarResources mocks base method
+func (m *MockConfigurator) GetSidecarResources() v1.ResourceRequirements {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSidecarResources")
+	ret0, _ := ret[0].(v1.ResourceRequirements)
+	return ret0
+}
+
+// GetSidecarResources indicates an expected call of GetSidecarResources
+func (mr *MockConfiguratorMockRecorder) GetSidecarResources() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnnouncementsChannel", reflect.TypeOf((*MockConfigurator)(nil).GetAnnouncementsChannel))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSidecarResources", reflect.TypeOf((*MockConfigurator)(nil).GetSidecarResources))
 }
 
-// GetConfigMap mocks base method
-func (m *MockConfigurator) GetConfigMap() ([]byte, error) {
+// GetStatsPrefix mocks base method
+func (m *MockConfigurator) GetStatsPrefix() string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetConfigMap")
-	ret0, _ := ret[0].([]byte)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "GetStatsPrefix")
+	ret0, _ := ret[0].(string)
+	return ret0
 }
 
-// GetConfigMap indicates an expected call of GetConfigMap
-func (mr *MockConfiguratorMockRecorder) GetConfigMap() *gomock.Call {
+// GetStatsPrefix indicates an expected call of GetStatsPrefix
+func (mr *MockConfiguratorMockRecorder) GetStatsPrefix() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigMap", reflect.TypeOf((*MockConfigurator)(nil).GetConfigMap))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatsPrefix", reflect.TypeOf((*MockConfigurator)(nil).GetStatsPrefix))
 }
 
-// GetEnvoyLogLevel mocks base method
-func (m *MockConfigurator) GetEnvoyLogLevel() string {
+// GetStatsSink mocks base method
+func (m *MockConfigurator) GetStatsSink() *StatsSink {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEnvoyLogLevel")
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "GetStatsSink")
+	ret0, _ := ret[0].(*StatsSink)
 	return ret0
 }
 
-// GetEnvoyLogLevel indicates an expected call of GetEnvoyLogLevel
-func (mr *MockConfiguratorMockRecorder) GetEnvoyLogLevel() *gomock.Call {
+// GetStatsSink indicates an expected call of GetStatsSink
+func (mr *MockConfiguratorMockRecorder) GetStatsSink() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnvoyLogLevel", reflect.TypeOf((*MockConfigurator)(nil).GetEnvoyLogLevel))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatsSink", reflect.TypeOf((*MockConfigurator)(nil).GetStatsSink))
 }
 
-// GetMeshCIDRRanges mocks base method
-func (m *MockConfigurator) GetMeshCIDRRanges() []string {
+// GetStatsTags mocks base method
+func (m *MockConfigurator) GetStatsTags() map[string]string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMeshCIDRRanges")
-	ret0, _ := ret[0].([]string)
+	ret := m.ctrl.Call(m, "GetStatsTags")
+	ret0, _ := ret[0].(map[string]string)
 	return ret0
 }
 
-// GetMeshCIDRRanges indicates an expected call of GetMeshCIDRRanges
-func (mr *MockConfiguratorMockRecorder) GetMeshCIDRRanges() *gomock.Call {
+// GetStatsTags indicates an expected call of GetStatsTags
+func (mr *MockConfiguratorMockRecorder) GetStatsTags() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMeshCIDRRanges", reflect.TypeOf((*MockConfigurator)(nil).GetMeshCIDRRanges))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatsTags", reflect.TypeOf((*MockConfigurator)(nil).GetStatsTags))
 }
 
-// GetOSMNamespace mocks base method
-func (m *MockConfigurator) GetOSMNamespace() string {
+// GetTracingBackend mocks base method
+func (m *MockConfigurator) GetTracingBackend() string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOSMNamespace")
+	ret := m.ctrl.Call(m, "GetTracingBackend")
 	ret0, _ := ret[0].(string)
 	return ret0
 }
 
-// GetOSMNamespace indicates an expected call of GetOSMNamespace
-func (mr *MockConfiguratorMockRecorder) GetOSMNamespace() *gomock.Call {
+// GetTracingBackend indicates an expected call of GetTracingBackend
+func (mr *MockConfiguratorMockRecorder) GetTracingBackend() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOSMNamespace", reflect.TypeOf((*MockConfigurator)(nil).GetOSMNamespace))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracingBackend", reflect.TypeOf((*MockConfigurator)(nil).GetTracingBackend))
 }
 
 // GetTracingEndpoint mocks base method
@@ -132,6 +1054,20 @@ func (mr *MockConfiguratorMockRecorder) GetTracingHost() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracingHost", reflect.TypeOf((*MockConfigurator)(nil).GetTracingHost))
 }
 
+// GetTracingHosts mocks base method
+func (m *MockConfigurator) GetTracingHosts() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracingHosts")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetTracingHosts indicates an expected call of GetTracingHosts
+func (mr *MockConfiguratorMockRecorder) GetTracingHosts() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracingHosts", reflect.TypeOf((*MockConfigurator)(nil).GetTracingHosts))
+}
+
 // GetTracingPort mocks base method
 func (m *MockConfigurator) GetTracingPort() uint32 {
 	m.ctrl.T.Helper()
@@ -146,6 +1082,160 @@ func (mr *MockConfiguratorMockRecorder) GetTracingPort() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracingPort", reflect.TypeOf((*MockConfigurator)(nil).GetTracingPort))
 }
 
+// GetTracingSamplingRate mocks base method
+func (m *MockConfigurator) GetTracingSamplingRate() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracingSamplingRate")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetTracingSamplingRate indicates an expected call of GetTracingSamplingRate
+func (mr *MockConfiguratorMockRecorder) GetTracingSamplingRate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracingSamplingRate", reflect.TypeOf((*MockConfigurator)(nil).GetTracingSamplingRate))
+}
+
+// GetTrustDomain mocks base method
+func (m *MockConfigurator) GetTrustDomain() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrustDomain")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetTrustDomain indicates an expected call of GetTrustDomain
+func (mr *MockConfiguratorMockRecorder) GetTrustDomain() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrustDomain", reflect.TypeOf((*MockConfigurator)(nil).GetTrustDomain))
+}
+
+// GetTypedAnnouncementsChannel mocks base method
+func (m *MockConfigurator) GetTypedAnnouncementsChannel() <-chan ConfigChangeEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTypedAnnouncementsChannel")
+	ret0, _ := ret[0].(<-chan ConfigChangeEvent)
+	return ret0
+}
+
+// GetTypedAnnouncementsChannel indicates an expected call of GetTypedAnnouncementsChannel
+func (mr *MockConfiguratorMockRecorder) GetTypedAnnouncementsChannel() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTypedAnnouncementsChannel", reflect.TypeOf((*MockConfigurator)(nil).GetTypedAnnouncementsChannel))
+}
+
+// GetVaultConfig mocks base method
+func (m *MockConfigurator) GetVaultConfig() *VaultConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVaultConfig")
+	ret0, _ := ret[0].(*VaultConfig)
+	return ret0
+}
+
+// GetVaultConfig indicates an expected call of GetVaultConfig
+func (mr *MockConfiguratorMockRecorder) GetVaultConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVaultConfig", reflect.TypeOf((*MockConfigurator)(nil).GetVaultConfig))
+}
+
+// GetWASMExtensions mocks base method
+func (m *MockConfigurator) GetWASMExtensions() []WASMExtensionSpec {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWASMExtensions")
+	ret0, _ := ret[0].([]WASMExtensionSpec)
+	return ret0
+}
+
+// GetWASMExtensions indicates an expected call of GetWASMExtensions
+func (mr *MockConfiguratorMockRecorder) GetWASMExtensions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWASMExtensions", reflect.TypeOf((*MockConfigurator)(nil).GetWASMExtensions))
+}
+
+// GetXDSKeepaliveTime mocks base method
+func (m *MockConfigurator) GetXDSKeepaliveTime() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetXDSKeepaliveTime")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetXDSKeepaliveTime indicates an expected call of GetXDSKeepaliveTime
+func (mr *MockConfiguratorMockRecorder) GetXDSKeepaliveTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXDSKeepaliveTime", reflect.TypeOf((*MockConfigurator)(nil).GetXDSKeepaliveTime))
+}
+
+// GetXDSKeepaliveTimeout mocks base method
+func (m *MockConfigurator) GetXDSKeepaliveTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetXDSKeepaliveTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetXDSKeepaliveTimeout indicates an expected call of GetXDSKeepaliveTimeout
+func (mr *MockConfiguratorMockRecorder) GetXDSKeepaliveTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXDSKeepaliveTimeout", reflect.TypeOf((*MockConfigurator)(nil).GetXDSKeepaliveTimeout))
+}
+
+// GetXDSServerResponseTimeout mocks base method
+func (m *MockConfigurator) GetXDSServerResponseTimeout() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetXDSServerResponseTimeout")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetXDSServerResponseTimeout indicates an expected call of GetXDSServerResponseTimeout
+func (mr *MockConfiguratorMockRecorder) GetXDSServerResponseTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetXDSServerResponseTimeout", reflect.TypeOf((*MockConfigurator)(nil).GetXDSServerResponseTimeout))
+}
+
+// IsAccessLoggingEnabled mocks base method
+func (m *MockConfigurator) IsAccessLoggingEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAccessLoggingEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsAccessLoggingEnabled indicates an expected call of IsAccessLoggingEnabled
+func (mr *MockConfiguratorMockRecorder) IsAccessLoggingEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAccessLoggingEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsAccessLoggingEnabled))
+}
+
+// IsConfigReady mocks base method
+func (m *MockConfigurator) IsConfigReady() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsConfigReady")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsConfigReady indicates an expected call of IsConfigReady
+func (mr *MockConfiguratorMockRecorder) IsConfigReady() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsConfigReady", reflect.TypeOf((*MockConfigurator)(nil).IsConfigReady))
+}
+
+// IsDebugServerEnabled mocks base method
+func (m *MockConfigurator) IsDebugServerEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDebugServerEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsDebugServerEnabled indicates an expected call of IsDebugServerEnabled
+func (mr *MockConfiguratorMockRecorder) IsDebugServerEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDebugServerEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsDebugServerEnabled))
+}
+
 // IsEgressEnabled mocks base method
 func (m *MockConfigurator) IsEgressEnabled() bool {
 	m.ctrl.T.Helper()
@@ -160,6 +1250,90 @@ func (mr *MockConfiguratorMockRecorder) IsEgressEnabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEgressEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsEgressEnabled))
 }
 
+// IsEgressEnabledForChannel mocks base method
+func (m *MockConfigurator) IsEgressEnabledForChannel(arg0 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEgressEnabledForChannel", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsEgressEnabledForChannel indicates an expected call of IsEgressEnabledForChannel
+func (mr *MockConfiguratorMockRecorder) IsEgressEnabledForChannel(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEgressEnabledForChannel", reflect.TypeOf((*MockConfigurator)(nil).IsEgressEnabledForChannel), arg0)
+}
+
+// IsEgressEnabledForNamespace mocks base method
+func (m *MockConfigurator) IsEgressEnabledForNamespace(arg0 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEgressEnabledForNamespace", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsEgressEnabledForNamespace indicates an expected call of IsEgressEnabledForNamespace
+func (mr *MockConfiguratorMockRecorder) IsEgressEnabledForNamespace(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEgressEnabledForNamespace", reflect.TypeOf((*MockConfigurator)(nil).IsEgressEnabledForNamespace), arg0)
+}
+
+// IsFeatureEnabled mocks base method
+func (m *MockConfigurator) IsFeatureEnabled(arg0 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsFeatureEnabled", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsFeatureEnabled indicates an expected call of IsFeatureEnabled
+func (mr *MockConfiguratorMockRecorder) IsFeatureEnabled(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFeatureEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsFeatureEnabled), arg0)
+}
+
+// IsLocalityAwareRoutingEnabled mocks base method
+func (m *MockConfigurator) IsLocalityAwareRoutingEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsLocalityAwareRoutingEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsLocalityAwareRoutingEnabled indicates an expected call of IsLocalityAwareRoutingEnabled
+func (mr *MockConfiguratorMockRecorder) IsLocalityAwareRoutingEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsLocalityAwareRoutingEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsLocalityAwareRoutingEnabled))
+}
+
+// IsMulticlusterEnabled mocks base method
+func (m *MockConfigurator) IsMulticlusterEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsMulticlusterEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsMulticlusterEnabled indicates an expected call of IsMulticlusterEnabled
+func (mr *MockConfiguratorMockRecorder) IsMulticlusterEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMulticlusterEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsMulticlusterEnabled))
+}
+
+// IsPermissiveAuditLoggingEnabled mocks base method
+func (m *MockConfigurator) IsPermissiveAuditLoggingEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPermissiveAuditLoggingEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsPermissiveAuditLoggingEnabled indicates an expected call of IsPermissiveAuditLoggingEnabled
+func (mr *MockConfiguratorMockRecorder) IsPermissiveAuditLoggingEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPermissiveAuditLoggingEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsPermissiveAuditLoggingEnabled))
+}
+
 // IsPermissiveTrafficPolicyMode mocks base method
 func (m *MockConfigurator) IsPermissiveTrafficPolicyMode() bool {
 	m.ctrl.T.Helper()
@@ -188,6 +1362,20 @@ func (mr *MockConfiguratorMockRecorder) IsPrometheusScrapingEnabled() *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPrometheusScrapingEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsPrometheusScrapingEnabled))
 }
 
+// IsSidecarInjectionEnabled mocks base method
+func (m *MockConfigurator) IsSidecarInjectionEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSidecarInjectionEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsSidecarInjectionEnabled indicates an expected call of IsSidecarInjectionEnabled
+func (mr *MockConfiguratorMockRecorder) IsSidecarInjectionEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSidecarInjectionEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsSidecarInjectionEnabled))
+}
+
 // IsTracingEnabled mocks base method
 func (m *MockConfigurator) IsTracingEnabled() bool {
 	m.ctrl.T.Helper()
@@ -202,6 +1390,119 @@ func (mr *MockConfiguratorMockRecorder) IsTracingEnabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTracingEnabled", reflect.TypeOf((*MockConfigurator)(nil).IsTracingEnabled))
 }
 
+// OnConfigChange mocks base method
+func (m *MockConfigurator) OnConfigChange(arg0 func(ConfigChangeEvent)) func() {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OnConfigChange", arg0)
+	ret0, _ := ret[0].(func())
+	return ret0
+}
+
+// OnConfigChange indicates an expected call of OnConfigChange
+func (mr *MockConfiguratorMockRecorder) OnConfigChange(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnConfigChange", reflect.TypeOf((*MockConfigurator)(nil).OnConfigChange), arg0)
+}
+
+// RegisterValidator mocks base method
+func (m *MockConfigurator) RegisterValidator(arg0 ConfigValidator) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterValidator", arg0)
+}
+
+// RegisterValidator indicates an expected call of RegisterValidator
+func (mr *MockConfiguratorMockRecorder) RegisterValidator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterValidator", reflect.TypeOf((*MockConfigurator)(nil).RegisterValidator), arg0)
+}
+
+// ReloadNow mocks base method
+func (m *MockConfigurator) ReloadNow() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadNow")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReloadNow indicates an expected call of ReloadNow
+func (mr *MockConfiguratorMockRecorder) ReloadNow() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadNow", reflect.TypeOf((*MockConfigurator)(nil).ReloadNow))
+}
+
+// SetConfigField mocks base method
+func (m *MockConfigurator) SetConfigField(arg0 string, arg1 interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetConfigField", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetConfigField indicates an expected call of SetConfigField
+func (mr *MockConfiguratorMockRecorder) SetConfigField(arg0 interface{}, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConfigField", reflect.TypeOf((*MockConfigurator)(nil).SetConfigField), arg0, arg1)
+}
+
+// Snapshot mocks base method
+func (m *MockConfigurator) Snapshot() ConfigSnapshot {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot")
+	ret0, _ := ret[0].(ConfigSnapshot)
+	return ret0
+}
+
+// Snapshot indicates an expected call of Snapshot
+func (mr *MockConfiguratorMockRecorder) Snapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockConfigurator)(nil).Snapshot))
+}
+
+// StripForwardedHeaders mocks base method
+func (m *MockConfigurator) StripForwardedHeaders() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StripForwardedHeaders")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// StripForwardedHeaders indicates an expected call of StripForwardedHeaders
+func (mr *MockConfiguratorMockRecorder) StripForwardedHeaders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StripForwardedHeaders", reflect.TypeOf((*MockConfigurator)(nil).StripForwardedHeaders))
+}
+
+// Subscribe mocks base method
+func (m *MockConfigurator) Subscribe(arg0 ...string) <-chan ConfigChangeEvent {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range arg0 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Subscribe", varargs...)
+	ret0, _ := ret[0].(<-chan ConfigChangeEvent)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe
+func (mr *MockConfiguratorMockRecorder) Subscribe(arg0 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{}, arg0...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockConfigurator)(nil).Subscribe), varargs...)
+}
+
+// Unsubscribe mocks base method
+func (m *MockConfigurator) Unsubscribe(arg0 <-chan ConfigChangeEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unsubscribe", arg0)
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe
+func (mr *MockConfiguratorMockRecorder) Unsubscribe(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockConfigurator)(nil).Unsubscribe), arg0)
+}
+
 // UseHTTPSIngress mocks base method
 func (m *MockConfigurator) UseHTTPSIngress() bool {
 	m.ctrl.T.Helper()
@@ -215,3 +1516,45 @@ func (mr *MockConfiguratorMockRecorder) UseHTTPSIngress() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseHTTPSIngress", reflect.TypeOf((*MockConfigurator)(nil).UseHTTPSIngress))
 }
+
+// ValidateConfig mocks base method
+func (m *MockConfigurator) ValidateConfig() []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateConfig")
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// ValidateConfig indicates an expected call of ValidateConfig
+func (mr *MockConfiguratorMockRecorder) ValidateConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateConfig", reflect.TypeOf((*MockConfigurator)(nil).ValidateConfig))
+}
+
+// WatchConfig mocks base method
+func (m *MockConfigurator) WatchConfig(arg0 context.Context) <-chan ConfigChangeEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchConfig", arg0)
+	ret0, _ := ret[0].(<-chan ConfigChangeEvent)
+	return ret0
+}
+
+// WatchConfig indicates an expected call of WatchConfig
+func (mr *MockConfiguratorMockRecorder) WatchConfig(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchConfig", reflect.TypeOf((*MockConfigurator)(nil).WatchConfig), arg0)
+}
+
+// WriteConfigMap mocks base method
+func (m *MockConfigurator) WriteConfigMap(arg0 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteConfigMap", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteConfigMap indicates an expected call of WriteConfigMap
+func (mr *MockConfiguratorMockRecorder) WriteConfigMap(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteConfigMap", reflect.TypeOf((*MockConfigurator)(nil).WriteConfigMap), arg0)
+}
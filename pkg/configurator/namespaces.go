@@ -0,0 +1,66 @@
+package configurator
+
+import (
+	"strings"
+
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// WithNamespaceLister makes the configurator consult the annotations of the namespaces, listed with the given lister,
+// which override the global config for the pods of a namespace
+func WithNamespaceLister(namespaceLister corev1listers.NamespaceLister) Option {
+	return func(c *Client) {
+		c.namespaceLister = namespaceLister
+	}
+}
+
+// IsEgressEnabledForNamespace determines whether egress is enabled for the pods of the given namespace. The
+// openservicemesh.io/egress annotation of the namespace, one of enabled/yes/true or disabled/no/false, overrides the
+// global config; egress follows the global config when the annotation is missing or invalid.
+func (c *Client) IsEgressEnabledForNamespace(namespace string) bool {
+	annotation, ok := c.getNamespaceAnnotation(namespace, constants.EgressAnnotation)
+	if !ok {
+		return c.IsEgressEnabled()
+	}
+	switch strings.ToLower(annotation) {
+	case "enabled", "yes", "true":
+		return true
+	case "disabled", "no", "false":
+		return false
+	default:
+		log.Warn().Msgf("Invalid value %q for annotation %s of namespace %s; Using the global config", annotation, constants.EgressAnnotation, namespace)
+		return c.IsEgressEnabled()
+	}
+}
+
+// GetEnvoyLogLevelForNamespace returns the Envoy log level for the pods of the given namespace. The
+// openservicemesh.io/envoy-log-level annotation of the namespace overrides the global config; the log level follows
+// the global config when the annotation is missing or is not a valid log level.
+func (c *Client) GetEnvoyLogLevelForNamespace(namespace string) string {
+	logLevel, ok := c.getNamespaceAnnotation(namespace, constants.EnvoyLogLevelAnnotation)
+	if !ok {
+		return c.GetEnvoyLogLevel()
+	}
+	if !isValidEnvoyLogLevel(logLevel) {
+		log.Warn().Msgf("Invalid Envoy log level %q for annotation %s of namespace %s; Using the global config", logLevel, constants.EnvoyLogLevelAnnotation, namespace)
+		return c.GetEnvoyLogLevel()
+	}
+	return strings.ToLower(logLevel)
+}
+
+// getNamespaceAnnotation returns the value of the given annotation of the namespace, and whether the annotation is set;
+// annotations are never set when the configurator has no namespace lister or the namespace cannot be listed
+func (c *Client) getNamespaceAnnotation(namespace, annotation string) (string, bool) {
+	if c.namespaceLister == nil {
+		return "", false
+	}
+	ns, err := c.namespaceLister.Get(namespace)
+	if err != nil {
+		log.Debug().Err(err).Msgf("Error getting namespace %s; Using the global config", namespace)
+		return "", false
+	}
+	value, ok := ns.Annotations[annotation]
+	return value, ok
+}
@@ -0,0 +1,96 @@
+package configurator
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+var _ = Describe("Test the per-namespace config overrides", func() {
+	osmNamespace := "-test-osm-namespace-"
+	osmConfigMapName := "-test-osm-config-map-"
+
+	Context("create OSM config with the namespace annotations", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		for name, annotations := range map[string]map[string]string{
+			"no-annotations": nil,
+			"overrides": {
+				constants.EgressAnnotation:        "enabled",
+				constants.EnvoyLogLevelAnnotation: "Trace",
+			},
+			"disables-egress": {
+				constants.EgressAnnotation: "no",
+			},
+			"invalid-annotations": {
+				constants.EgressAnnotation:        "sometimes",
+				constants.EnvoyLogLevelAnnotation: "verbose",
+			},
+		} {
+			Expect(indexer.Add(&v1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        name,
+					Annotations: annotations,
+				},
+			})).To(Succeed())
+		}
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, WithNamespaceLister(corev1listers.NewNamespaceLister(indexer)))
+
+		It("uses the global config for the namespaces without annotations", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					egressKey:         "true",
+					meshCIDRRangesKey: "10.0.0.0/16",
+					envoyLogLevel:     "warning",
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			for _, namespace := range []string{"no-annotations", "not-found"} {
+				Expect(cfg.IsEgressEnabledForNamespace(namespace)).To(BeTrue(), namespace)
+				Expect(cfg.GetEnvoyLogLevelForNamespace(namespace)).To(Equal("warning"), namespace)
+			}
+		})
+
+		It("uses the annotations of the namespaces overriding the global config", func() {
+			Expect(cfg.IsEgressEnabledForNamespace("overrides")).To(BeTrue())
+			Expect(cfg.GetEnvoyLogLevelForNamespace("overrides")).To(Equal("trace"))
+			Expect(cfg.IsEgressEnabledForNamespace("disables-egress")).To(BeFalse())
+			Expect(cfg.GetEnvoyLogLevelForNamespace("disables-egress")).To(Equal("warning"))
+		})
+
+		It("uses the global config in place of the invalid annotations", func() {
+			Expect(cfg.IsEgressEnabledForNamespace("invalid-annotations")).To(BeTrue())
+			Expect(cfg.GetEnvoyLogLevelForNamespace("invalid-annotations")).To(Equal("warning"))
+		})
+	})
+
+	Context("create OSM config without a namespace lister", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		cfg := NewConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("uses the global config", func() {
+			Expect(cfg.IsEgressEnabledForNamespace("overrides")).To(BeFalse())
+			Expect(cfg.GetEnvoyLogLevelForNamespace("overrides")).To(Equal("debug"))
+		})
+	})
+})
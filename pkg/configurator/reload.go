@@ -0,0 +1,138 @@
+package configurator
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+// configMapReload is the ConfigMap read by ReloadNow, which the config is parsed from in place of the ConfigMap in the
+// informer cache as long as the informer cache holds an older revision of it
+type configMapReload struct {
+	// configMap is the ConfigMap read from the API server, or nil when it was not found
+	configMap *v1.ConfigMap
+
+	// deletedConfigMap is the ConfigMap in the informer cache when the ConfigMap was not found
+	deletedConfigMap *v1.ConfigMap
+}
+
+// isAheadOf returns whether the reloaded ConfigMap is more recent than the given ConfigMap from the informer cache
+func (r *configMapReload) isAheadOf(configMap *v1.ConfigMap) bool {
+	if r.configMap != nil {
+		return configMap == nil || isOlderRevision(configMap, r.configMap)
+	}
+	return configMap != nil && r.deletedConfigMap != nil && !isOlderRevision(r.deletedConfigMap, configMap)
+}
+
+// isOlderRevision returns whether the ConfigMap is an older revision than the other one. The resourceVersions are
+// opaque, so when they are not the integers the API server assigns, the ConfigMap is only known to be as recent as the
+// other one once it holds the same data.
+func isOlderRevision(configMap, other *v1.ConfigMap) bool {
+	if isNumericResourceVersion(configMap.ResourceVersion) && isNumericResourceVersion(other.ResourceVersion) {
+		return isOlderResourceVersion(configMap.ResourceVersion, other.ResourceVersion)
+	}
+	return !reflect.DeepEqual(configMap.Data, other.Data)
+}
+
+// isNumericResourceVersion returns whether the resourceVersion is an integer, as assigned by the API server
+func isNumericResourceVersion(resourceVersion string) bool {
+	_, err := strconv.ParseUint(resourceVersion, 10, 64)
+	return err == nil
+}
+
+// isOlderResourceVersion returns whether the resourceVersion is older than the other one; resourceVersions are opaque,
+// so those which are not integers are never considered older
+func isOlderResourceVersion(resourceVersion, other string) bool {
+	version, err := strconv.ParseUint(resourceVersion, 10, 64)
+	if err != nil {
+		return false
+	}
+	otherVersion, err := strconv.ParseUint(other, 10, 64)
+	if err != nil {
+		return false
+	}
+	return version < otherVersion
+}
+
+// ReloadNow reads the OSM ConfigMap from the Kubernetes API server, bypassing the informer cache suspected to be stale,
+// and caches the config parsed from it, which is announced like an informer event when it differs from the cached config.
+// The informer cache is left untouched: the ConfigMap read takes precedence over it until the informer observes the same
+// or a newer revision of the ConfigMap, and a read older than the informer cache is not applied.
+// Each reload is counted by the reload metrics, like the reloads of the informer events. It fails once the client is closed.
+func (c *Client) ReloadNow() error {
+	if c.isClosed() {
+		return errors.Wrapf(errClientClosed, "ConfigMap %s not reloaded", c.getConfigMapCacheKey())
+	}
+	if c.kubeClient == nil {
+		return errors.Wrapf(errConfigNotReloadable, "%s is not a Kubernetes ConfigMap", c.getConfigMapCacheKey())
+	}
+	if c.meshConfigCache != nil && c.getMeshConfigFromInformerCache() != nil {
+		return errors.Wrapf(errConfigNotReloadable, "MeshConfig %s takes precedence over ConfigMap %s", c.getMeshConfigCacheKey(), c.getConfigMapCacheKey())
+	}
+
+	configMap, err := c.kubeClient.CoreV1().ConfigMaps(c.osmNamespace).Get(context.TODO(), c.osmConfigMapName, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		c.metrics.recordReload(err)
+		return errors.Wrapf(err, "Error getting ConfigMap %s", c.getConfigMapCacheKey())
+	}
+	if apierrors.IsNotFound(err) {
+		configMap = nil
+	}
+
+	log.Info().Msgf("Reloaded ConfigMap %s from the API server", c.getConfigMapCacheKey())
+	c.applyConfigMapFromAPIServer(configMap)
+	return nil
+}
+
+// applyConfigMapFromAPIServer caches and announces the config of the ConfigMap read from the API server, or nil when it
+// was not found, in place of the ConfigMap in the informer cache
+func (c *Client) applyConfigMapFromAPIServer(configMap *v1.ConfigMap) {
+	c.eventLock.Lock()
+	cachedConfigMap := c.getConfigMapFromInformerCache()
+	reload := &configMapReload{configMap: configMap}
+	event := k8s.Event{
+		Type:  k8s.UpdateEvent,
+		Value: configMap,
+	}
+	if configMap == nil {
+		reload.deletedConfigMap = cachedConfigMap
+		event = k8s.Event{
+			Type:  k8s.DeleteEvent,
+			Value: cachedConfigMap,
+		}
+	} else if cachedConfigMap != nil && isOlderResourceVersion(configMap.ResourceVersion, cachedConfigMap.ResourceVersion) {
+		c.eventLock.Unlock()
+		log.Info().Msgf("ConfigMap %s read at resourceVersion %q is older than the informer cache at resourceVersion %q; Keeping the current config",
+			c.getConfigMapCacheKey(), configMap.ResourceVersion, cachedConfigMap.ResourceVersion)
+		c.metrics.recordReload(nil)
+		return
+	}
+	c.reload = reload
+	typedEvent := c.applyConfigMap(configMap)
+	c.eventLock.Unlock()
+
+	c.announce(event, typedEvent)
+}
+
+// getEventConfigMap returns the ConfigMap the config is parsed from on an informer event: the ConfigMap in effect in
+// the informer caches, unless the informer has yet to catch up with the ConfigMap read by ReloadNow.
+// It must be called with eventLock held.
+func (c *Client) getEventConfigMap() *v1.ConfigMap {
+	configMap := c.getEffectiveConfigMap()
+	if c.reload == nil {
+		return configMap
+	}
+	if (c.meshConfigCache == nil || c.getMeshConfigFromInformerCache() == nil) && c.reload.isAheadOf(configMap) {
+		log.Debug().Msgf("Informer cache of ConfigMap %s is older than the ConfigMap reloaded from the API server; Keeping the reloaded ConfigMap", c.getConfigMapCacheKey())
+		return c.reload.configMap
+	}
+	c.reload = nil
+	return configMap
+}
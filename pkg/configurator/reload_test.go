@@ -0,0 +1,204 @@
+package configurator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test reloading the OSM config", func() {
+	Context("reload the OSM config from the API server", func() {
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				egressKey:      "true",
+				tracingPortKey: "9411",
+			},
+		}
+		kubeClient := testclient.NewSimpleClientset(&configMap)
+
+		// The informer never runs, so its cache is stale: the ConfigMap changes are only observed by ReloadNow
+		cfg := newClient(osmNamespace, osmConfigMapName)
+		cfg.kubeClient = kubeClient
+		cfg.cache = cache.NewStore(cache.MetaNamespaceKeyFunc)
+
+		It("picks up the ConfigMap missing from the informer cache", func() {
+			Expect(cfg.IsEgressEnabled()).To(BeFalse())
+
+			Expect(cfg.ReloadNow()).To(Succeed())
+			Eventually(cfg.GetAnnouncementsChannel()).Should(Receive())
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(9411)))
+
+			Expect(cfg.getConfigMapFromInformerCache()).To(BeNil())
+		})
+
+		It("picks up the ConfigMap mutated out-of-band", func() {
+			mutatedConfigMap := configMap.DeepCopy()
+			mutatedConfigMap.Data[tracingPortKey] = "14268"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), mutatedConfigMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(9411)))
+
+			Expect(cfg.ReloadNow()).To(Succeed())
+			Eventually(cfg.GetAnnouncementsChannel()).Should(Receive())
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(14268)))
+		})
+
+		It("does not announce the reloads leaving the config unchanged", func() {
+			Expect(cfg.ReloadNow()).To(Succeed())
+			Expect(cfg.GetAnnouncementsChannel()).ToNot(Receive())
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(14268)))
+		})
+
+		It("falls back on the default config once the ConfigMap is deleted", func() {
+			err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Delete(context.TODO(), osmConfigMapName, metav1.DeleteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(cfg.ReloadNow()).To(Succeed())
+			Eventually(cfg.GetAnnouncementsChannel()).Should(Receive())
+			Expect(cfg.IsEgressEnabled()).To(BeFalse())
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(mergeOverDefaultConfig(nil).TracingPort)))
+		})
+	})
+
+	Context("reload the ConfigMap after the client is closed", func() {
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				tracingPortKey: "9411",
+			},
+		}
+
+		It("returns an error without announcing the mutated ConfigMap", func() {
+			kubeClient := testclient.NewSimpleClientset(&configMap)
+			stop := make(chan struct{})
+			defer close(stop)
+			cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, WithAnnouncementDebounceWindow(0))
+			typedAnnouncements := cfg.GetTypedAnnouncementsChannel()
+			Expect(cfg.Close()).To(Succeed())
+
+			mutatedConfigMap := configMap.DeepCopy()
+			mutatedConfigMap.Data[tracingPortKey] = "14268"
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), mutatedConfigMap, metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(func() { err = cfg.ReloadNow() }).ToNot(Panic())
+			Expect(errors.Is(err, errClientClosed)).To(BeTrue())
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(9411)))
+			Expect(typedAnnouncements).To(BeClosed())
+
+			// A reload racing with Close does not send on the closed typed announcements channel either
+			Expect(func() { cfg.applyConfigMapFromAPIServer(mutatedConfigMap) }).ToNot(Panic())
+		})
+	})
+
+	Context("keep the reloaded ConfigMap until the informer catches up with it", func() {
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		newConfigMap := func(resourceVersion, tracingPort string) *v1.ConfigMap {
+			return &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       osmNamespace,
+					Name:            osmConfigMapName,
+					ResourceVersion: resourceVersion,
+				},
+				Data: map[string]string{
+					tracingPortKey: tracingPort,
+				},
+			}
+		}
+
+		It("ignores the informer events older than the reloaded ConfigMap", func() {
+			cfg := newClient(osmNamespace, osmConfigMapName)
+			cfg.kubeClient = testclient.NewSimpleClientset(newConfigMap("10", "14268"))
+			cfg.cache = cache.NewStore(cache.MetaNamespaceKeyFunc)
+			close(cfg.cacheSynced)
+			Expect(cfg.cache.Add(newConfigMap("8", "9411"))).To(Succeed())
+			cfg.handleConfigMapEvent(nil)
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(9411)))
+
+			Expect(cfg.ReloadNow()).To(Succeed())
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(14268)))
+			Expect(cfg.getConfigMapFromInformerCache().ResourceVersion).To(Equal("8"))
+
+			// The informer observes a revision older than the reloaded one
+			Expect(cfg.cache.Update(newConfigMap("9", "9091"))).To(Succeed())
+			cfg.handleConfigMapEvent(nil)
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(14268)))
+			Expect(cfg.GetConfigResourceVersion()).To(Equal("10"))
+
+			// The informer catches up, and its events are applied from then on
+			Expect(cfg.cache.Update(newConfigMap("10", "14268"))).To(Succeed())
+			cfg.handleConfigMapEvent(nil)
+			Expect(cfg.reload).To(BeNil())
+			Expect(cfg.cache.Update(newConfigMap("11", "9092"))).To(Succeed())
+			cfg.handleConfigMapEvent(nil)
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(9092)))
+		})
+
+		It("ignores the informer events of the ConfigMap reloaded as deleted", func() {
+			cfg := newClient(osmNamespace, osmConfigMapName)
+			cfg.kubeClient = testclient.NewSimpleClientset()
+			cfg.cache = cache.NewStore(cache.MetaNamespaceKeyFunc)
+			close(cfg.cacheSynced)
+			Expect(cfg.cache.Add(newConfigMap("8", "9411"))).To(Succeed())
+			cfg.handleConfigMapEvent(nil)
+
+			Expect(cfg.ReloadNow()).To(Succeed())
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(mergeOverDefaultConfig(nil).TracingPort)))
+
+			// A resync of the informer redelivers the deleted ConfigMap
+			cfg.handleConfigMapEvent(nil)
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(mergeOverDefaultConfig(nil).TracingPort)))
+
+			// The ConfigMap is created again
+			Expect(cfg.cache.Update(newConfigMap("12", "9092"))).To(Succeed())
+			cfg.handleConfigMapEvent(nil)
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(9092)))
+		})
+
+		It("does not apply a reloaded ConfigMap older than the informer cache", func() {
+			cfg := newClient(osmNamespace, osmConfigMapName)
+			cfg.kubeClient = testclient.NewSimpleClientset(newConfigMap("10", "14268"))
+			cfg.cache = cache.NewStore(cache.MetaNamespaceKeyFunc)
+			close(cfg.cacheSynced)
+			Expect(cfg.cache.Add(newConfigMap("12", "9411"))).To(Succeed())
+			cfg.handleConfigMapEvent(nil)
+
+			Expect(cfg.ReloadNow()).To(Succeed())
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(9411)))
+			Expect(cfg.GetConfigResourceVersion()).To(Equal("12"))
+		})
+	})
+
+	Context("reload the OSM config read from a file", func() {
+		It("fails since the file is not a ConfigMap", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+			cfg := newFileConfigurator(filepath.Join(os.TempDir(), "-missing-osm-config-.json"), stop, make(chan os.Signal, 1))
+
+			err := cfg.ReloadNow()
+			Expect(errors.Is(err, errConfigNotReloadable)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,47 @@
+package configurator
+
+import (
+	// Imported for go:embed
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// schemaJSON holds the JSON Schema of the OSM config, as returned by GetConfigMap
+//
+//go:embed schema.json
+var schemaJSON []byte
+
+// configSchema is the compiled schema the OSM config documents are validated against
+var configSchema = mustLoadConfigSchema(schemaJSON)
+
+// mustLoadConfigSchema compiles the embedded schema; the schema is part of the build, so a problem with it is a programming error
+func mustLoadConfigSchema(schema []byte) *gojsonschema.Schema {
+	compiledSchema, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schema))
+	if err != nil {
+		panic(fmt.Sprintf("Error compiling the embedded OSM config schema: %v", err))
+	}
+	return compiledSchema
+}
+
+// ValidateAgainstSchema returns an error describing every place the given OSM config document, in the JSON format
+// returned by GetConfigMap, does not match the embedded schema, or nil when the document is valid.
+// Unlike the getters, which fall back to the defaults, this reports out of range values and unknown fields precisely.
+func ValidateAgainstSchema(data []byte) error {
+	result, err := configSchema.Validate(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return errors.Wrap(errInvalidConfigJSON, err.Error())
+	}
+	if result.Valid() {
+		return nil
+	}
+
+	var problems []string
+	for _, resultErr := range result.Errors() {
+		problems = append(problems, resultErr.String())
+	}
+	return errors.Wrapf(errSchemaViolation, "%d problem(s): %s", len(problems), strings.Join(problems, "; "))
+}
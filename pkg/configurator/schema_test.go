@@ -0,0 +1,75 @@
+package configurator
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+)
+
+var _ = Describe("Test the OSM config schema", func() {
+	Context("validate a config document against the schema", func() {
+		It("accepts the default config", func() {
+			document, err := marshalConfigToJSON(&defaultConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ValidateAgainstSchema(document)).To(Succeed())
+		})
+
+		It("accepts a valid config", func() {
+			document := []byte(`{
+				"Egress": true,
+				"EgressMode": "policy",
+				"MeshCIDRRanges": "10.0.0.0/16",
+				"EnvoyLogLevel": "Warning",
+				"TracingEnable": true,
+				"TracingPort": 9411,
+				"TracingSamplingRate": 0.5,
+				"TracingBackend": "jaeger",
+				"EnvoyRequestTimeout": "1m30s",
+				"RetryPolicy": {"NumRetries": 3, "PerTryTimeout": "500ms", "RetryOn": "5xx"},
+				"SidecarResources": {"CPURequest": "0.5", "MemoryLimit": "1Gi"},
+				"OutboundPortExclusionList": "6379, 3306",
+				"FeatureFlags": {"feature-a": true}
+			}`)
+			Expect(ValidateAgainstSchema(document)).To(Succeed())
+		})
+
+		It("accepts an empty config", func() {
+			Expect(ValidateAgainstSchema([]byte(`{}`))).To(Succeed())
+		})
+
+		It("rejects the invalid configs with descriptive errors", func() {
+			for document, expectedProblem := range map[string]string{
+				`{"TracingPort": 65536}`:                       "TracingPort: Must be less than or equal to",
+				`{"EnvoyAdminPort": -1}`:                       "EnvoyAdminPort: Must be greater than or equal to",
+				`{"TracingSamplingRate": 1.5}`:                 "TracingSamplingRate: Must be less than or equal to",
+				`{"MaxDataPlaneConnections": -1}`:              "MaxDataPlaneConnections: Must be greater than or equal to",
+				`{"Egress": "yes"}`:                            "Egress: Invalid type. Expected: boolean, given: string",
+				`{"EgressMode": "everywhere"}`:                 "EgressMode: EgressMode must be one of the following",
+				`{"EnvoyLogLevel": "verbose"}`:                 "EnvoyLogLevel: Does not match pattern",
+				`{"PrometheusScrapePath": "metrics"}`:          "PrometheusScrapePath: Does not match pattern",
+				`{"RetryPolicy": {"PerTryTimeout": "soon"}}`:   "RetryPolicy.PerTryTimeout: Does not match pattern",
+				`{"SidecarResources": {"CPULimit": "lots"}}`:   "SidecarResources.CPULimit: Does not match pattern",
+				`{"FeatureFlags": {"feature-a": "on"}}`:        "FeatureFlags.feature-a: Invalid type. Expected: boolean, given: string",
+				`{"TracingPrt": 9411}`:                         "(root): Additional property TracingPrt is not allowed",
+				`{"CircuitBreaking": {"MaxConnection": 1024}}`: "CircuitBreaking: Additional property MaxConnection is not allowed",
+			} {
+				err := ValidateAgainstSchema([]byte(document))
+				Expect(errors.Cause(err)).To(Equal(errSchemaViolation), document)
+				Expect(err.Error()).To(ContainSubstring(expectedProblem), document)
+			}
+		})
+
+		It("reports every problem of a document", func() {
+			err := ValidateAgainstSchema([]byte(`{"TracingPort": 65536, "EnvoyAdminPort": 0.5, "AccessLogFormat": "yaml"}`))
+			Expect(errors.Cause(err)).To(Equal(errSchemaViolation))
+			Expect(err.Error()).To(ContainSubstring("3 problem(s)"))
+			Expect(err.Error()).To(ContainSubstring("TracingPort: Must be less than or equal to"))
+			Expect(err.Error()).To(ContainSubstring("EnvoyAdminPort: Invalid type. Expected: integer, given: number"))
+			Expect(err.Error()).To(ContainSubstring("AccessLogFormat: AccessLogFormat must be one of the following"))
+		})
+
+		It("rejects a document which is not JSON", func() {
+			Expect(errors.Cause(ValidateAgainstSchema([]byte(`TracingPort: 9411`)))).To(Equal(errInvalidConfigJSON))
+		})
+	})
+})
@@ -0,0 +1,55 @@
+package configurator
+
+import (
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+)
+
+// defaultMaxConfigMapSize is the default maximum size of the data of the OSM ConfigMap, the 1MiB limit of the
+// Kubernetes ConfigMaps
+const defaultMaxConfigMapSize = 1024 * 1024
+
+// configErrorHolder holds the error of the last ConfigMap revision, since an atomic.Value cannot hold a nil error
+type configErrorHolder struct {
+	err error
+}
+
+// WithMaxConfigMapSize sets the maximum size, in bytes, of the data of the OSM ConfigMap, in place of the default 1MiB;
+// the ConfigMap revisions whose keys and values add up to more than this are rejected, keeping the previous config
+func WithMaxConfigMapSize(size int) Option {
+	return func(c *Client) {
+		c.maxConfigMapSize = size
+	}
+}
+
+// checkConfigMapSize returns an error when the keys and values of the data of the given ConfigMap add up to more than
+// the maximum size, the way the Kubernetes API server measures the size of a ConfigMap
+func (c *Client) checkConfigMapSize(configMap *v1.ConfigMap) error {
+	if configMap == nil {
+		return nil
+	}
+
+	size := 0
+	for key, value := range configMap.Data {
+		size += len(key) + len(value)
+	}
+	for key, value := range configMap.BinaryData {
+		size += len(key) + len(value)
+	}
+	if size > c.maxConfigMapSize {
+		return errors.Wrapf(errConfigMapTooLarge, "ConfigMap %s at resourceVersion %q is %d bytes, above the maximum of %d bytes",
+			c.getConfigMapCacheKey(), configMap.ResourceVersion, size, c.maxConfigMapSize)
+	}
+	return nil
+}
+
+// GetLastConfigError returns the error for which the last ConfigMap revision was rejected, keeping the previous config,
+// or nil when the last revision was applied
+func (c *Client) GetLastConfigError() error {
+	holder, _ := c.lastConfigError.Load().(configErrorHolder)
+	return holder.err
+}
+
+func (c *Client) setLastConfigError(err error) {
+	c.lastConfigError.Store(configErrorHolder{err: err})
+}
@@ -0,0 +1,92 @@
+package configurator
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+var _ = Describe("Test the maximum size of the OSM ConfigMap", func() {
+	Context("reject the oversized ConfigMap revisions", func() {
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       osmNamespace,
+				Name:            osmConfigMapName,
+				ResourceVersion: "1",
+			},
+			Data: map[string]string{
+				egressKey: "true",
+			},
+		}
+
+		// The informer never runs: the ConfigMap revisions are stored in the cache and handled by the test
+		cfg := newClient(osmNamespace, osmConfigMapName)
+		cfg.cache = cache.NewStore(cache.MetaNamespaceKeyFunc)
+		close(cfg.cacheSynced)
+
+		It("applies the ConfigMap within the maximum size", func() {
+			Expect(cfg.cache.Add(&configMap)).To(Succeed())
+			cfg.handleConfigMapEvent(k8s.Event{Type: k8s.CreateEvent, Value: &configMap})
+
+			Eventually(cfg.GetAnnouncementsChannel()).Should(Receive())
+			Expect(cfg.GetLastConfigError()).ToNot(HaveOccurred())
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+		})
+
+		It("rejects the oversized ConfigMap, keeping the previous config", func() {
+			oversizedConfigMap := configMap.DeepCopy()
+			oversizedConfigMap.ResourceVersion = "2"
+			oversizedConfigMap.Data[egressKey] = "false"
+			oversizedConfigMap.Data[proxyBootstrapOverrideKey] = "stats_flush_interval: " + strings.Repeat("1", defaultMaxConfigMapSize)
+			Expect(cfg.cache.Update(oversizedConfigMap)).To(Succeed())
+			cfg.handleConfigMapEvent(k8s.Event{Type: k8s.UpdateEvent, Value: oversizedConfigMap})
+
+			Expect(cfg.GetAnnouncementsChannel()).ToNot(Receive())
+			Expect(errors.Is(cfg.GetLastConfigError(), errConfigMapTooLarge)).To(BeTrue())
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+			Expect(cfg.GetConfigResourceVersion()).To(Equal("1"))
+			Expect(testutil.ToFloat64(cfg.metrics.rejections)).To(Equal(1.0))
+			Expect(testutil.ToFloat64(cfg.metrics.reloadErrors)).To(Equal(1.0))
+		})
+
+		It("clears the error once a ConfigMap within the maximum size is applied", func() {
+			fixedConfigMap := configMap.DeepCopy()
+			fixedConfigMap.ResourceVersion = "3"
+			fixedConfigMap.Data[egressKey] = "false"
+			Expect(cfg.cache.Update(fixedConfigMap)).To(Succeed())
+			cfg.handleConfigMapEvent(k8s.Event{Type: k8s.UpdateEvent, Value: fixedConfigMap})
+
+			Eventually(cfg.GetAnnouncementsChannel()).Should(Receive())
+			Expect(cfg.GetLastConfigError()).ToNot(HaveOccurred())
+			Expect(cfg.IsEgressEnabled()).To(BeFalse())
+			Expect(cfg.GetConfigResourceVersion()).To(Equal("3"))
+		})
+	})
+
+	Context("configure the maximum size", func() {
+		It("measures the keys and values of the data and binary data", func() {
+			cfg := newClient("-test-osm-namespace-", "-test-osm-config-map-")
+			WithMaxConfigMapSize(10)(cfg)
+
+			Expect(cfg.checkConfigMapSize(nil)).To(Succeed())
+			Expect(cfg.checkConfigMapSize(&v1.ConfigMap{Data: map[string]string{"egress": "true"}})).To(Succeed())
+
+			err := cfg.checkConfigMapSize(&v1.ConfigMap{
+				Data:       map[string]string{"egress": "true"},
+				BinaryData: map[string][]byte{"a": []byte("b")},
+			})
+			Expect(errors.Is(err, errConfigMapTooLarge)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,88 @@
+package configurator
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ConfigChannelLabel is the label of the pods selecting the config channel their proxies are configured from
+	ConfigChannelLabel = "osm.io/config-channel"
+
+	// PrimaryConfigChannel is the config channel of the pods without a config channel label, configured from the OSM ConfigMap
+	PrimaryConfigChannel = ""
+
+	// StagingConfigChannel is the config channel configured from the staging ConfigMap overlaid on the OSM ConfigMap
+	StagingConfigChannel = "staging"
+
+	// stagingConfigMapSuffix is appended to the name of the OSM ConfigMap to name the staging ConfigMap
+	stagingConfigMapSuffix = "-staging"
+)
+
+// WithStagingConfigMap makes the configurator also watch the staging ConfigMap, named after the OSM ConfigMap with
+// the -staging suffix, whose keys override the keys of the OSM ConfigMap for the staging config channel
+func WithStagingConfigMap() Option {
+	return func(c *Client) {
+		c.stagingConfigMapName = c.osmConfigMapName + stagingConfigMapSuffix
+	}
+}
+
+// GetConfigChannel returns the config channel selected by the given pod labels, which is the primary config channel
+// for the pods without a config channel label
+func GetConfigChannel(podLabels map[string]string) string {
+	return podLabels[ConfigChannelLabel]
+}
+
+func (c *Client) getStagingConfigMapCacheKey() string {
+	return fmt.Sprintf("%s/%s", c.osmNamespace, c.stagingConfigMapName)
+}
+
+// setStagingConfig swaps the cached staging config for the config parsed from the staging ConfigMap overlaid on the
+// given ConfigMap, or for nil when the staging ConfigMap is not watched or does not exist
+func (c *Client) setStagingConfig(configMap *v1.ConfigMap) {
+	if c.stagingConfigMapName == "" {
+		return
+	}
+
+	item, exists, err := c.cache.GetByKey(c.getStagingConfigMapCacheKey())
+	if err != nil || !exists {
+		if err != nil {
+			log.Error().Err(err).Msgf("Error getting staging ConfigMap from cache with key %s", c.getStagingConfigMapCacheKey())
+		}
+		c.stagingConfig.Store((*MeshConfig)(nil))
+		return
+	}
+
+	overlay := &v1.ConfigMap{Data: make(map[string]string)}
+	if configMap != nil {
+		for key, value := range configMap.Data {
+			overlay.Data[key] = value
+		}
+	}
+	for key, value := range item.(*v1.ConfigMap).Data {
+		overlay.Data[key] = value
+	}
+	c.stagingConfig.Store(mergeOverDefaultConfig(overlay))
+	log.Debug().Msgf("Updated staging config from ConfigMap %s", c.getStagingConfigMapCacheKey())
+}
+
+// getConfigForChannel returns the cached config of the given config channel; the primary config is returned for the
+// unknown channels, and for the staging channel when the staging ConfigMap does not exist. The returned struct must not be modified.
+func (c *Client) getConfigForChannel(channel string) *MeshConfig {
+	if channel == StagingConfigChannel {
+		if config, ok := c.stagingConfig.Load().(*MeshConfig); ok && config != nil {
+			return config
+		}
+	}
+	return c.getConfigMap()
+}
+
+// getConfigMapCacheKeyForChannel returns the cache key of the ConfigMap the config of the given channel is parsed from,
+// to name it in the logs
+func (c *Client) getConfigMapCacheKeyForChannel(channel string) string {
+	if c.getConfigForChannel(channel) != c.getConfigMap() {
+		return c.getStagingConfigMapCacheKey()
+	}
+	return c.getConfigMapCacheKey()
+}
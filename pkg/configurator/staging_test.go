@@ -0,0 +1,130 @@
+package configurator
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Test the staging ConfigMap overlay", func() {
+	osmNamespace := "-test-osm-namespace-"
+	osmConfigMapName := "-test-osm-config-map-"
+	newConfigMap := func(name string, data map[string]string) *v1.ConfigMap {
+		return &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      name,
+			},
+			Data: data,
+		}
+	}
+
+	Context("create OSM config with a staging ConfigMap", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, WithStagingConfigMap())
+		stagingConfigMapName := osmConfigMapName + stagingConfigMapSuffix
+
+		It("falls back to the primary config when the staging ConfigMap does not exist", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), newConfigMap(osmConfigMapName, map[string]string{
+				egressKey:     "false",
+				envoyLogLevel: "info",
+			}), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsEgressEnabledForChannel(StagingConfigChannel)).To(BeFalse())
+			Expect(cfg.GetEnvoyLogLevelForChannel(StagingConfigChannel)).To(Equal("info"))
+		})
+
+		It("overrides the keys of the primary ConfigMap for the staging channel only", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), newConfigMap(stagingConfigMapName, map[string]string{
+				egressKey:         "true",
+				meshCIDRRangesKey: "10.0.0.0/16",
+			}), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsEgressEnabledForChannel(StagingConfigChannel)).To(BeTrue())
+			Expect(cfg.GetEgressModeForChannel(StagingConfigChannel)).To(Equal(EgressModeGlobal))
+			// The keys the staging ConfigMap does not set are taken from the primary ConfigMap
+			Expect(cfg.GetEnvoyLogLevelForChannel(StagingConfigChannel)).To(Equal("info"))
+
+			Expect(cfg.IsEgressEnabled()).To(BeFalse())
+			Expect(cfg.IsEgressEnabledForChannel(PrimaryConfigChannel)).To(BeFalse())
+			Expect(cfg.IsEgressEnabledForChannel("canary")).To(BeFalse())
+		})
+
+		It("applies the changes of the primary ConfigMap to the staging channel", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Update(context.TODO(), newConfigMap(osmConfigMapName, map[string]string{
+				egressKey:     "false",
+				envoyLogLevel: "error",
+			}), metav1.UpdateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.GetEnvoyLogLevelForChannel(StagingConfigChannel)).To(Equal("error"))
+			Expect(cfg.IsEgressEnabledForChannel(StagingConfigChannel)).To(BeTrue())
+		})
+
+		It("falls back to the primary config once the staging ConfigMap is deleted", func() {
+			err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Delete(context.TODO(), stagingConfigMapName, metav1.DeleteOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsEgressEnabledForChannel(StagingConfigChannel)).To(BeFalse())
+			Expect(cfg.GetEnvoyLogLevelForChannel(StagingConfigChannel)).To(Equal("error"))
+		})
+	})
+
+	Context("create OSM config without watching the staging ConfigMap", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("ignores the staging ConfigMap", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), newConfigMap(osmConfigMapName+stagingConfigMapSuffix, map[string]string{
+				egressKey: "true",
+			}), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), newConfigMap(osmConfigMapName, map[string]string{
+				egressKey: "false",
+			}), metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.IsEgressEnabledForChannel(StagingConfigChannel)).To(BeFalse())
+		})
+	})
+
+	Context("get the config channel of a pod", func() {
+		It("returns the config channel of the config channel label", func() {
+			Expect(GetConfigChannel(map[string]string{ConfigChannelLabel: StagingConfigChannel})).To(Equal(StagingConfigChannel))
+		})
+
+		It("returns the primary config channel for a pod without a config channel label", func() {
+			Expect(GetConfigChannel(map[string]string{"app": "bookstore"})).To(Equal(PrimaryConfigChannel))
+			Expect(GetConfigChannel(nil)).To(Equal(PrimaryConfigChannel))
+		})
+	})
+})
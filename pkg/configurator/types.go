@@ -1,15 +1,170 @@
 package configurator
 
 import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/openservicemesh/osm/pkg/logger"
+	"github.com/openservicemesh/osm/pkg/osm_client/clientset/versioned"
 )
 
 var (
 	log = logger.New("configurator")
 )
 
+const (
+	// TracingBackendZipkin is the tracing backend type for Zipkin collectors
+	TracingBackendZipkin = "zipkin"
+
+	// TracingBackendJaeger is the tracing backend type for Jaeger collectors
+	TracingBackendJaeger = "jaeger"
+
+	// TracingBackendOTLP is the tracing backend type for OpenTelemetry (OTLP) collectors
+	TracingBackendOTLP = "otlp"
+)
+
+const (
+	// EgressModeDisabled is the egress mode in which traffic to destinations outside of the mesh is denied
+	EgressModeDisabled = "disabled"
+
+	// EgressModeGlobal is the egress mode in which traffic to all destinations outside of the mesh is allowed
+	EgressModeGlobal = "global"
+
+	// EgressModePolicy is the egress mode in which traffic to destinations outside of the mesh is denied by default,
+	// except for the destinations allowed by egress policies
+	EgressModePolicy = "policy"
+)
+
+const (
+	// CertificateProviderTresor is the certificate provider in which the controller signs the certificates itself
+	CertificateProviderTresor = "tresor"
+
+	// CertificateProviderVault is the certificate provider in which Hashicorp Vault signs the certificates
+	CertificateProviderVault = "vault"
+
+	// CertificateProviderCertManager is the certificate provider in which cert-manager signs the certificates, requested
+	// as CertificateRequest resources
+	CertificateProviderCertManager = "cert-manager"
+)
+
+const (
+	// IngressBackendProtocolHTTP is the protocol of the traffic from ingress to plaintext backend pods
+	IngressBackendProtocolHTTP = "http"
+
+	// IngressBackendProtocolHTTPS is the protocol of the traffic from ingress to backend pods serving TLS
+	IngressBackendProtocolHTTPS = "https"
+)
+
+const (
+	// EgressDNSResolutionStrictDNS is the egress DNS resolution in which Envoy continuously resolves the external hosts,
+	// and load balances over all the addresses they resolve to
+	EgressDNSResolutionStrictDNS = "STRICT_DNS"
+
+	// EgressDNSResolutionLogicalDNS is the egress DNS resolution in which Envoy connects to the first address the
+	// external hosts resolve to, which suits the large hosts, such as the ones behind a CDN, rotating their addresses
+	EgressDNSResolutionLogicalDNS = "LOGICAL_DNS"
+
+	// EgressDNSResolutionStatic is the egress DNS resolution in which the external hosts are IP addresses, which are
+	// not resolved
+	EgressDNSResolutionStatic = "STATIC"
+)
+
+const (
+	// LBAlgorithmRoundRobin is the load balancing algorithm in which Envoy picks the endpoints in turn
+	LBAlgorithmRoundRobin = "ROUND_ROBIN"
+
+	// LBAlgorithmLeastRequest is the load balancing algorithm in which Envoy picks the endpoint with the fewest active
+	// requests out of two random endpoints
+	LBAlgorithmLeastRequest = "LEAST_REQUEST"
+
+	// LBAlgorithmRingHash is the load balancing algorithm in which Envoy consistently hashes the requests onto a ring of
+	// the endpoints, according to the hash policy of their route; the requests without a hash policy are hashed randomly
+	LBAlgorithmRingHash = "RING_HASH"
+
+	// LBAlgorithmRandom is the load balancing algorithm in which Envoy picks a random endpoint
+	LBAlgorithmRandom = "RANDOM"
+
+	// LBAlgorithmMaglev is the load balancing algorithm in which Envoy consistently hashes the requests onto a Maglev
+	// lookup table of the endpoints, according to the hash policy of their route
+	LBAlgorithmMaglev = "MAGLEV"
+)
+
+// The SMI spec versions are named after the version of the specs.smi-spec.io APIs the TrafficTarget and TrafficSplit
+// APIs are released with. The routes are served by specs.smi-spec.io/v1alpha3 in either version.
+const (
+	// SMISpecVersionV1alpha3 is the version of the SMI APIs in which the TrafficTargets are served by
+	// access.smi-spec.io/v1alpha2 and the TrafficSplits by split.smi-spec.io/v1alpha2
+	SMISpecVersionV1alpha3 = "v1alpha3"
+
+	// SMISpecVersionV1alpha4 is the version of the SMI APIs in which the TrafficTargets are served by
+	// access.smi-spec.io/v1alpha3 and the TrafficSplits by split.smi-spec.io/v1alpha4; it is the latest version the
+	// SMI client implements
+	SMISpecVersionV1alpha4 = "v1alpha4"
+)
+
+const (
+	// ProxyBindAddressPodIP is the proxy bind address binding the admin listener of the Envoy sidecars to the IP address
+	// of their pod, only known once the pod is scheduled
+	ProxyBindAddressPodIP = "pod-ip"
+)
+
+const (
+	// EnvoyLogFormatText is the format in which the Envoy processes write their logs as lines of text
+	EnvoyLogFormatText = "text"
+
+	// EnvoyLogFormatJSON is the format in which the Envoy processes write each log entry as a JSON object
+	EnvoyLogFormatJSON = "json"
+)
+
+const (
+	// AccessLogFormatText is the access log format in which Envoy writes each entry as a line of text
+	AccessLogFormatText = "text"
+
+	// AccessLogFormatJSON is the access log format in which Envoy writes each entry as a JSON object
+	AccessLogFormatJSON = "json"
+)
+
+const (
+	// StatsSinkTypeStatsd is the stats sink type in which Envoy pushes its stats over UDP in the statsd format
+	StatsSinkTypeStatsd = "statsd"
+
+	// StatsSinkTypeDogStatsd is the stats sink type in which Envoy pushes its stats over UDP in the DogStatsD format,
+	// which carries the tags of the stats
+	StatsSinkTypeDogStatsd = "dogstatsd"
+)
+
+const (
+	// WASMInsertionPointInbound is the insertion point of the WebAssembly extensions run on the inbound requests of the
+	// proxies, after their external authorization
+	WASMInsertionPointInbound = "inbound"
+
+	// WASMInsertionPointOutbound is the insertion point of the WebAssembly extensions run on the outbound requests of the proxies
+	WASMInsertionPointOutbound = "outbound"
+)
+
+const (
+	// ProvenanceConfigMap is the provenance of the config fields whose value is taken from the ConfigMap
+	ProvenanceConfigMap = "configmap"
+
+	// ProvenanceDefault is the provenance of the config fields whose value is taken from the default config,
+	// because the ConfigMap does not set them or sets them to a value which parses to nothing
+	ProvenanceDefault = "default"
+
+	// ProvenanceEnvironment is the provenance of the config fields whose value is taken from an environment variable,
+	// when the configurator is created WithEnvironmentOverlay
+	ProvenanceEnvironment = "environment"
+)
+
 // Client is the k8s client struct for the OSM Config.
 type Client struct {
 	osmNamespace     string
@@ -18,6 +173,404 @@ type Client struct {
 	informer         cache.SharedIndexInformer
 	cache            cache.Store
 	cacheSynced      chan interface{}
+
+	// closed is closed by Close, which closes the channels of the client once dispatcherStopped and forwarderStopped are closed
+	closed            chan struct{}
+	closeOnce         sync.Once
+	dispatcherStopped chan struct{}
+	forwarderStopped  chan struct{}
+
+	// configMapEvents receives the raw informer events, which are turned into announcements
+	configMapEvents chan interface{}
+
+	// pendingAnnouncements holds the announcements not yet received from the unbuffered announcements channel, in order;
+	// announcementQueued is signaled whenever one is queued
+	pendingAnnouncements     []interface{}
+	pendingAnnouncementsLock sync.Mutex
+	announcementQueued       chan struct{}
+
+	// typedAnnouncements is nil until GetTypedAnnouncementsChannel is first called, so no event is buffered for nobody
+	typedAnnouncements     chan ConfigChangeEvent
+	typedAnnouncementsLock sync.Mutex
+
+	// eventLock serializes the handling of the ConfigMap events with the seeding of the config on startup and the reloads
+	// of ReloadNow and SetConfigField; it is released before the resulting config change events are announced
+	eventLock sync.Mutex
+
+	// reload is the ConfigMap last read by ReloadNow, until the informer catches up with it; guarded by eventLock
+	reload *configMapReload
+
+	// announcementDebounceWindow is the window within which a burst of ConfigMap events is coalesced into a single announcement
+	announcementDebounceWindow time.Duration
+
+	// subscribers holds the channels returned by Subscribe, along with the fields each subscriber is interested in
+	subscribers     map[<-chan ConfigChangeEvent]*subscriber
+	subscribersLock sync.Mutex
+
+	// callbacks holds the callbacks registered with OnConfigChange, in registration order
+	callbacks     []*configChangeCallback
+	callbacksLock sync.Mutex
+
+	// validators holds the validators registered with RegisterValidator and WithValidators, in registration order
+	validators     []ConfigValidator
+	validatorsLock sync.Mutex
+
+	// config holds the *MeshConfig parsed from the ConfigMap; it is swapped atomically under configLock,
+	// so readers never take a lock
+	config     atomic.Value
+	configLock sync.Mutex
+
+	// resourceVersion holds the metadata.resourceVersion of the ConfigMap the cached config was parsed from
+	resourceVersion atomic.Value
+
+	// maxConfigMapSize is the maximum size of the data of the ConfigMap, above which its revisions are rejected
+	maxConfigMapSize int
+
+	// lastConfigError holds the configErrorHolder of the error for which the last ConfigMap revision was rejected
+	lastConfigError atomic.Value
+
+	// namespaceLister lists the namespaces whose annotations override the global config; it is nil when the
+	// annotations are not consulted
+	namespaceLister corev1listers.NamespaceLister
+
+	// stagingConfigMapName is the name of the staging ConfigMap; it is empty when the staging ConfigMap is not watched
+	stagingConfigMapName string
+
+	// stagingConfig holds the *MeshConfig parsed from the staging ConfigMap overlaid on the OSM ConfigMap,
+	// or a nil *MeshConfig when the staging ConfigMap does not exist
+	stagingConfig atomic.Value
+
+	// provenance holds the map[string]string returned by getConfigProvenance for the ConfigMap the cached config was merged from,
+	// with the fields set by the environment variables marked as such
+	provenance atomic.Value
+
+	// kubeClient patches the OSM ConfigMap in SetConfigField; it is nil when the config is not read from Kubernetes
+	kubeClient kubernetes.Interface
+
+	// lookupEnv looks up the environment variables overriding the ConfigMap; it is nil when the environment
+	// overlay is disabled
+	lookupEnv func(key string) (string, bool)
+
+	// configExists holds whether the cached config was parsed from an existing ConfigMap, rather than being the default config
+	configExists atomic.Value
+
+	metrics *configMetrics
+
+	// defaultEnvoyImage is the image of the Envoy sidecars when the ConfigMap does not set a valid one
+	defaultEnvoyImage string
+
+	// defaultInitContainerImage is the image of the init containers when the ConfigMap does not set a valid one
+	defaultInitContainerImage string
+
+	// defaultServiceCertValidityDuration is the validity duration of the service certificates when the ConfigMap does not set one
+	defaultServiceCertValidityDuration time.Duration
+
+	// The MeshConfig custom resource is only watched when the configurator is created with the WithMeshConfig option
+	meshConfigClient   versioned.Interface
+	meshConfigName     string
+	meshConfigInformer cache.SharedIndexInformer
+	meshConfigCache    cache.Store
+}
+
+// CABundleRef is a reference to the key of the Secret holding a bundle of PEM encoded CA certificates
+type CABundleRef struct {
+	// Namespace is the namespace of the Secret
+	Namespace string
+
+	// Name is the name of the Secret
+	Name string
+
+	// Key is the key of the Secret data holding the CA bundle
+	Key string
+}
+
+// SecretKeyRef is a reference to the key of a Secret
+type SecretKeyRef struct {
+	// Namespace is the namespace of the Secret
+	Namespace string
+
+	// Name is the name of the Secret
+	Name string
+
+	// Key is the key of the Secret data
+	Key string
+}
+
+// RetryPolicy is the retry policy applied by Envoy to the routes which have no retry policy of their own
+type RetryPolicy struct {
+	// NumRetries is the number of times a request is retried
+	NumRetries uint32 `yaml:"num_retries"`
+
+	// PerTryTimeout is the timeout, as a Go duration string, of each try; the request timeout applies when empty
+	PerTryTimeout string `yaml:"per_try_timeout"`
+
+	// RetryOn is the comma separated list of conditions, as understood by Envoy's x-envoy-retry-on header, on which requests are retried
+	RetryOn string `yaml:"retry_on"`
+}
+
+// CircuitBreaking is the set of circuit breaking thresholds applied by Envoy to each upstream cluster
+type CircuitBreaking struct {
+	// MaxConnections is the maximum number of connections to the cluster
+	MaxConnections uint32 `yaml:"max_connections"`
+
+	// MaxPendingRequests is the maximum number of requests to the cluster waiting for a connection
+	MaxPendingRequests uint32 `yaml:"max_pending_requests"`
+
+	// MaxRequests is the maximum number of parallel requests to the cluster
+	MaxRequests uint32 `yaml:"max_requests"`
+
+	// MaxRetries is the maximum number of parallel retries to the cluster
+	MaxRetries uint32 `yaml:"max_retries"`
+}
+
+// OutlierDetection is the config of Envoy's outlier detection, which ejects the hosts of each upstream cluster failing
+// consecutively from the load balancing of the cluster, for longer and longer each time they are ejected
+type OutlierDetection struct {
+	// Enable toggles the outlier detection of the upstream clusters
+	Enable bool `yaml:"enable"`
+
+	// Consecutive5xx is the number of consecutive 5xx responses, or connection failures, after which a host is ejected
+	Consecutive5xx uint32 `yaml:"consecutive_5xx"`
+
+	// Interval is the interval, as a Go duration string, between two sweeps of the hosts for ejection
+	Interval string `yaml:"interval"`
+
+	// BaseEjectionTime is the duration, as a Go duration string, a host is ejected for, multiplied by the number of
+	// times it has been ejected
+	BaseEjectionTime string `yaml:"base_ejection_time"`
+
+	// MaxEjectionPercent is the maximum percentage of the hosts of a cluster which can be ejected at the same time
+	MaxEjectionPercent uint32 `yaml:"max_ejection_percent"`
+}
+
+// InboundExternalAuth is the config of the external authorization service, such as OPA, which Envoy's ext_authz filter
+// asks over gRPC whether to let each inbound request through
+type InboundExternalAuth struct {
+	// Enable toggles the authorization of the inbound requests by the external authorization service
+	Enable bool `yaml:"enable"`
+
+	// Address is the IP address or DNS name of the external authorization service
+	Address string `yaml:"address"`
+
+	// Port is the port of the external authorization service
+	Port uint32 `yaml:"port"`
+
+	// StatPrefix is the name the stats of the external authorization service cluster are emitted under
+	StatPrefix string `yaml:"stat_prefix"`
+
+	// Timeout is the timeout, as a Go duration string, of each authorization request
+	Timeout string `yaml:"timeout"`
+
+	// FailureModeAllow lets the requests through when the external authorization service cannot be reached,
+	// instead of rejecting them
+	FailureModeAllow bool `yaml:"failure_mode_allow"`
+}
+
+// MulticlusterGateway is the gateway, in the cluster of the proxies, the traffic to the other clusters of the mesh goes through
+type MulticlusterGateway struct {
+	// Host is the IP address or DNS name of the gateway
+	Host string `yaml:"host"`
+
+	// Port is the port of the gateway
+	Port uint32 `yaml:"port"`
+}
+
+// StatsSink is the statsd sink the Envoy proxies push their stats to; it is disabled when its type is empty
+type StatsSink struct {
+	// Type is the protocol of the sink: statsd or dogstatsd
+	Type string `yaml:"type"`
+
+	// Address is the IP address of the sink, since Envoy sends the stats over UDP without resolving DNS names
+	Address string `yaml:"address"`
+
+	// Port is the UDP port of the sink
+	Port uint32 `yaml:"port"`
+
+	// Prefix is the prefix of the stats pushed to the sink; Envoy prefixes them with envoy when it is empty
+	Prefix string `yaml:"prefix"`
+}
+
+// VaultConfig is the config of the Hashicorp Vault certificate provider
+type VaultConfig struct {
+	// Protocol is the protocol of the Vault API: http or https
+	Protocol string `yaml:"protocol"`
+
+	// Host is the host name or IP address of Vault
+	Host string `yaml:"host"`
+
+	// Port is the port of the Vault API
+	Port uint32 `yaml:"port"`
+
+	// TokenSecretRef is the reference, of the form <namespace>/<name>/<key>, to the Secret key holding the token the
+	// controller authenticates to Vault with, so the token itself is never part of the config
+	TokenSecretRef string `yaml:"token_secret_ref"`
+
+	// Role is the name of the Vault role dedicated to OSM
+	Role string `yaml:"role"`
+}
+
+// CertManagerConfig is the config of the cert-manager certificate provider
+type CertManagerConfig struct {
+	// IssuerName is the name of the cert-manager issuer signing the certificates
+	IssuerName string `yaml:"issuer_name"`
+
+	// IssuerKind is the kind of the issuer, such as Issuer or ClusterIssuer
+	IssuerKind string `yaml:"issuer_kind"`
+
+	// IssuerGroup is the API group of the issuer
+	IssuerGroup string `yaml:"issuer_group"`
+}
+
+// OverloadManager is the heap limit of the Envoy sidecars, approaching which they shed load instead of running out of
+// memory; it is disabled when MaxHeapBytes is 0
+type OverloadManager struct {
+	// MaxHeapBytes is the maximum heap size, in bytes, of the Envoy sidecars
+	MaxHeapBytes uint64 `yaml:"max_heap_bytes"`
+
+	// ShrinkHeapThreshold is the fraction, between 0 and 1, of the maximum heap size above which the Envoy sidecars
+	// release the free memory of their heap to the system; 0 defaults to 0.95
+	ShrinkHeapThreshold float64 `yaml:"shrink_heap_threshold"`
+
+	// StopAcceptingThreshold is the fraction, between 0 and 1, of the maximum heap size above which the Envoy sidecars
+	// stop accepting requests; 0 defaults to 0.98
+	StopAcceptingThreshold float64 `yaml:"stop_accepting_threshold"`
+}
+
+// Locality is the region and zone the proxies run in, which locality-aware routing prefers the endpoints of; an empty
+// region or zone is derived at runtime from the topology labels of the node of each proxy
+type Locality struct {
+	// Region is the region of the proxies, such as westus2
+	Region string
+
+	// Zone is the zone of the proxies, such as westus2-1
+	Zone string
+}
+
+// WASMExtensionSpec is a WebAssembly extension run by Envoy's wasm HTTP filter on the inbound or outbound requests of the proxies
+type WASMExtensionSpec struct {
+	// Name is the unique name of the extension, which is also the ID of the VM it runs in
+	Name string `yaml:"name"`
+
+	// URI is the location of the WebAssembly module in the proxy container: an absolute path, or a file:// URI
+	URI string `yaml:"uri"`
+
+	// RootID is the ID of the root context of the module the extension runs; empty selects the default root context
+	RootID string `yaml:"root_id"`
+
+	// Config is the configuration passed as is to the extension when it starts
+	Config string `yaml:"config"`
+
+	// InsertionPoint is whether the extension runs on the inbound or the outbound requests; it defaults to inbound
+	InsertionPoint string `yaml:"insertion_point"`
+}
+
+// SidecarResources is the resource requests and limits, as Kubernetes quantity strings, of the injected Envoy sidecars
+type SidecarResources struct {
+	// CPURequest is the CPU requested by the sidecar, e.g. 100m
+	CPURequest string `yaml:"cpu_request"`
+
+	// CPULimit is the maximum CPU used by the sidecar, e.g. 1
+	CPULimit string `yaml:"cpu_limit"`
+
+	// MemoryRequest is the memory requested by the sidecar, e.g. 128Mi
+	MemoryRequest string `yaml:"memory_request"`
+
+	// MemoryLimit is the maximum memory used by the sidecar, e.g. 512Mi
+	MemoryLimit string `yaml:"memory_limit"`
+}
+
+// ProbeSpec is the timings, in seconds or numbers of probes, of the probes of the injected Envoy sidecars
+type ProbeSpec struct {
+	// InitialDelaySeconds is the delay after the sidecar starts before it is first probed
+	InitialDelaySeconds int32 `yaml:"initial_delay_seconds"`
+
+	// PeriodSeconds is the interval between two probes
+	PeriodSeconds int32 `yaml:"period_seconds"`
+
+	// TimeoutSeconds is the duration after which a probe times out
+	TimeoutSeconds int32 `yaml:"timeout_seconds"`
+
+	// FailureThreshold is the number of consecutive failed probes after which the probe fails
+	FailureThreshold int32 `yaml:"failure_threshold"`
+}
+
+// LeaderElection is the lease timings, as Go duration strings, of the leader election of the controllers
+type LeaderElection struct {
+	// LeaseDuration is the duration the candidates wait, after the last renewal of the leadership, before acquiring it
+	LeaseDuration string `yaml:"lease_duration"`
+
+	// RenewDeadline is the duration the leader keeps retrying to renew its leadership before giving it up
+	RenewDeadline string `yaml:"renew_deadline"`
+
+	// RetryPeriod is the interval between two attempts of the candidates to acquire or renew the leadership
+	RetryPeriod string `yaml:"retry_period"`
+}
+
+// LeaderElectionConfig is the validated lease timings of the leader election of the controllers, which are always in
+// the decreasing order of the lease duration, the renew deadline and the retry period
+type LeaderElectionConfig struct {
+	// LeaseDuration is the duration the candidates wait, after the last renewal of the leadership, before acquiring it
+	LeaseDuration time.Duration
+
+	// RenewDeadline is the duration the leader keeps retrying to renew its leadership before giving it up
+	RenewDeadline time.Duration
+
+	// RetryPeriod is the interval between two attempts of the candidates to acquire or renew the leadership
+	RetryPeriod time.Duration
+}
+
+// ConfigSnapshot is the effective OSM config along with its metadata, all taken from the same ConfigMap revision,
+// as returned by Snapshot
+type ConfigSnapshot struct {
+	// Config is a copy of the effective config, merged over the default config, with its sensitive fields masked
+	Config MeshConfig
+
+	// Hash is the SHA256 hex digest of the config, as returned by GetConfigHash
+	Hash string
+
+	// ResourceVersion is the metadata.resourceVersion of the ConfigMap the config was parsed from
+	ResourceVersion string
+
+	// Provenance tells whether each config field is taken from an environment variable, the ConfigMap or the default
+	// config, keyed by the field name
+	Provenance map[string]string
+
+	// ValidationErrors is the list of the messages of the problems found in the config
+	ValidationErrors []string
+}
+
+// FieldChange is a MeshConfig field whose effective value differs between two OSM ConfigMaps, as returned by DiffConfig
+type FieldChange struct {
+	// Field is the name of the MeshConfig field
+	Field string
+
+	// Key is the ConfigMap key of the field
+	Key string
+
+	// Old is the effective value of the field in the old ConfigMap, masked when the field is sensitive
+	Old interface{}
+
+	// New is the effective value of the field in the new ConfigMap, masked when the field is sensitive
+	New interface{}
+}
+
+// ConfigChangeEvent is announced whenever the OSM ConfigMap changes.
+type ConfigChangeEvent struct {
+	// ChangedFields is the list of MeshConfig field names whose values changed
+	ChangedFields []string
+
+	// Old is a snapshot of the config prior to the change, with its sensitive fields masked
+	Old MeshConfig
+
+	// New is a snapshot of the config after the change, with its sensitive fields masked
+	New MeshConfig
+
+	// ResourceVersion is the metadata.resourceVersion of the ConfigMap the new config was parsed from
+	ResourceVersion string
+
+	// Source is the client which made the change, such as kubectl-edit or a controller, as told by the managed fields and
+	// the last-applied-configuration annotation of the ConfigMap; it is empty when the ConfigMap does not tell
+	Source string
 }
 
 // Configurator is the controller interface for K8s namespaces
@@ -25,39 +578,338 @@ type Configurator interface {
 	// GetOSMNamespace returns the namespace in which OSM controller pod resides
 	GetOSMNamespace() string
 
+	// GetConfigMapName returns the name of the OSM ConfigMap
+	GetConfigMapName() string
+
+	// GetConfigMapNamespace returns the namespace of the OSM ConfigMap
+	GetConfigMapNamespace() string
+
 	// GetConfigMap returns the ConfigMap in pretty JSON (human readable)
 	GetConfigMap() ([]byte, error)
 
+	// GetConfigMapYAML returns the ConfigMap in YAML, keyed like the data of the ConfigMap
+	GetConfigMapYAML() ([]byte, error)
+
+	// WriteConfigMap writes the ConfigMap in pretty JSON (human readable), followed by a newline, to the given writer
+	WriteConfigMap(w io.Writer) error
+
+	// GetRedactedConfigMap returns the ConfigMap in pretty JSON (human readable), with the sensitive fields masked
+	GetRedactedConfigMap() ([]byte, error)
+
+	// GetConfigProvenance returns whether each config field is taken from an environment variable, the ConfigMap or the default config, keyed by the field name
+	GetConfigProvenance() map[string]string
+
+	// GetConfigHash returns the SHA256 hex digest of the effective config, stable across restarts for identical configs
+	GetConfigHash() string
+
+	// ValidateConfig returns all the problems found in the current OSM config, without modifying it
+	ValidateConfig() []error
+
+	// GetLastConfigError returns the error for which the last ConfigMap revision was rejected, or nil when it was applied
+	GetLastConfigError() error
+
+	// Snapshot returns the effective OSM config along with its hash, resourceVersion, provenance and validation errors
+	Snapshot() ConfigSnapshot
+
+	// ReloadNow reads the OSM ConfigMap from the Kubernetes API server, bypassing the informer cache, and caches the config parsed from it
+	ReloadNow() error
+
+	// SetConfigField patches the value of the given MeshConfig field into the OSM ConfigMap, and returns once the getters return it
+	SetConfigField(field string, value interface{}) error
+
+	// Close stops the informers and closes the announcements channels; it is safe to call more than once
+	Close() error
+
+	// IsConfigReady returns whether the OSM config has been synced and parsed from an existing ConfigMap
+	IsConfigReady() bool
+
+	// GetConfigResourceVersion returns the resourceVersion of the ConfigMap the current OSM config was parsed from
+	GetConfigResourceVersion() string
+
+	// GetMeshConfig returns a snapshot of the current OSM config
+	GetMeshConfig() MeshConfig
+
 	// IsPermissiveTrafficPolicyMode determines whether we are in "allow-all" mode or SMI policy (block by default) mode
 	IsPermissiveTrafficPolicyMode() bool
 
-	// IsEgressEnabled determines whether egress is globally enabled in the mesh or not
+	// IsPermissiveAuditLoggingEnabled returns whether the proxies log the requests the SMI policies would deny while in
+	// permissive traffic policy mode
+	IsPermissiveAuditLoggingEnabled() bool
+
+	// GetSMISpecVersion returns the supported version of the SMI APIs the SMI policies are honored in
+	GetSMISpecVersion() string
+
+	// IsEgressEnabled determines whether egress is enabled in the mesh or not, either globally or based on policies
 	IsEgressEnabled() bool
 
+	// GetEgressMode returns the egress mode of the mesh: disabled, global or policy
+	GetEgressMode() string
+
+	// IsEgressEnabledForChannel determines whether egress is enabled for the pods of the given config channel
+	IsEgressEnabledForChannel(channel string) bool
+
+	// GetEgressModeForChannel returns the egress mode for the pods of the given config channel
+	GetEgressModeForChannel(channel string) string
+
+	// GetEgressAllowedDomains returns the list of external domains egress is allowed to, matched against the SNI of the egress TLS connections
+	GetEgressAllowedDomains() []string
+
+	// GetEgressDNSResolution returns how Envoy resolves the addresses of the egress clusters: STRICT_DNS, LOGICAL_DNS or STATIC
+	GetEgressDNSResolution() string
+
+	// GetEgressCABundleRef returns the reference to the Secret key holding the CA bundle the egress TLS connections are verified with
+	GetEgressCABundleRef() *CABundleRef
+
 	// IsPrometheusScrapingEnabled determines whether Prometheus is enabled for scraping metrics
 	IsPrometheusScrapingEnabled() bool
 
+	// GetPrometheusScrapePort returns the port on which Prometheus scrapes the metrics of the proxies
+	GetPrometheusScrapePort() uint32
+
+	// GetPrometheusScrapePath returns the path on which Prometheus scrapes the metrics of the proxies
+	GetPrometheusScrapePath() string
+
 	// IsTracingEnabled returns whether tracing is enabled
 	IsTracingEnabled() bool
 
-	// GetTracingHost is the host to which we send tracing spans
+	// GetTracingHost is the host to which we send tracing spans, or the default host when the address is not a valid host
 	GetTracingHost() string
 
+	// GetTracingHosts returns the hosts, in order of preference, to which we send tracing spans, or the default host when
+	// none of the comma separated addresses is a valid host
+	GetTracingHosts() []string
+
 	// GetTracingPort returns the tracing listener port
 	GetTracingPort() uint32
 
 	// GetTracingEndpoint returns the collector endpoint
 	GetTracingEndpoint() string
 
+	// GetTracingSamplingRate returns the fraction of requests for which traces are sampled
+	GetTracingSamplingRate() float64
+
+	// GetTracingBackend returns the type of the tracing collector
+	GetTracingBackend() string
+
 	// GetMeshCIDRRanges returns a list of mesh CIDR ranges
 	GetMeshCIDRRanges() []string
 
+	// GetMeshCIDRRangesParsed returns a list of parsed mesh CIDR ranges
+	GetMeshCIDRRangesParsed() ([]*net.IPNet, error)
+
+	// GetMeshCIDRRangesByFamily returns the list of IPv4 mesh CIDR ranges and the list of IPv6 mesh CIDR ranges
+	GetMeshCIDRRangesByFamily() ([]string, []string)
+
 	// UseHTTPSIngress determines whether protocol used for traffic from ingress to backend pods should be HTTPS.
 	UseHTTPSIngress() bool
 
+	// GetDefaultIngressBackendProtocol returns the default protocol, http or https, of the traffic from ingress to backend pods
+	GetDefaultIngressBackendProtocol() string
+
+	// StripForwardedHeaders returns whether the proxies strip the X-Forwarded-* headers of the requests they receive
+	StripForwardedHeaders() bool
+
 	// GetEnvoyLogLevel returns the envoy log level
 	GetEnvoyLogLevel() string
 
+	// GetEnvoyLogLevelForChannel returns the envoy log level for the pods of the given config channel
+	GetEnvoyLogLevelForChannel(channel string) string
+
+	// GetEnvoyLogFormat returns the format of the logs of the Envoy processes: text or json
+	GetEnvoyLogFormat() string
+
+	// IsAccessLoggingEnabled returns whether the Envoy proxies write access logs
+	IsAccessLoggingEnabled() bool
+
+	// GetAccessLogFormat returns the format of the Envoy access logs: text or json
+	GetAccessLogFormat() string
+
+	// GetEnvoyAdminPort returns the port Envoy's admin interface listens on
+	GetEnvoyAdminPort() uint32
+
+	// GetEnvoyConnectionIdleTimeout returns the duration after which Envoy closes idle connections
+	GetEnvoyConnectionIdleTimeout() time.Duration
+
+	// GetEnvoyRequestTimeout returns the duration after which Envoy times out requests; 0 means no timeout
+	GetEnvoyRequestTimeout() time.Duration
+
+	// GetXDSServerResponseTimeout returns the duration within which an xDS response must be sent to an Envoy proxy
+	GetXDSServerResponseTimeout() time.Duration
+
+	// GetXDSKeepaliveTime returns the duration of inactivity of an xDS connection after which a keepalive ping is sent
+	GetXDSKeepaliveTime() time.Duration
+
+	// GetXDSKeepaliveTimeout returns the duration after which an xDS connection whose keepalive ping is not acknowledged is closed
+	GetXDSKeepaliveTimeout() time.Duration
+
+	// GetDisabledXDSTypes returns the set of the short names, such as RDS, of the xDS resource types not sent to the proxies
+	GetDisabledXDSTypes() map[string]bool
+
+	// GetDefaultRetryPolicy returns the validated default retry policy of the routes, or nil when there are no default retries
+	GetDefaultRetryPolicy() *RetryPolicy
+
+	// GetDefaultCircuitBreaking returns the circuit breaking thresholds of the upstream clusters, with Envoy's defaults in place of the unset ones
+	GetDefaultCircuitBreaking() CircuitBreaking
+
+	// GetDefaultLBAlgorithm returns the load balancing algorithm of the upstream clusters, as the name of Envoy's LB policy
+	GetDefaultLBAlgorithm() string
+
+	// GetOutlierDetection returns the validated outlier detection of the upstream clusters, or nil when it is disabled
+	GetOutlierDetection() *OutlierDetection
+
+	// GetInboundExternalAuthConfig returns the validated config of the external authorization of the inbound requests, or nil when it is disabled
+	GetInboundExternalAuthConfig() *InboundExternalAuth
+
+	// IsMulticlusterEnabled returns whether the mesh is federated with the meshes of other clusters
+	IsMulticlusterEnabled() bool
+
+	// GetMulticlusterGateway returns the validated gateway the traffic to the other clusters goes through, or the zero value when multicluster is disabled
+	GetMulticlusterGateway() MulticlusterGateway
+
+	// GetSidecarResources returns the resource requests and limits of the injected Envoy sidecars
+	GetSidecarResources() v1.ResourceRequirements
+
+	// GetEnvoyImage returns the image reference of the injected Envoy sidecars
+	GetEnvoyImage() string
+
+	// GetInitContainerImage returns the image reference of the injected init containers
+	GetInitContainerImage() string
+
+	// IsSidecarInjectionEnabled returns whether the Envoy sidecars are injected into the new pods of the mesh
+	IsSidecarInjectionEnabled() bool
+
+	// GetExcludedNamespaces returns the set of the namespaces whose pods are never meshed
+	GetExcludedNamespaces() map[string]bool
+
+	// GetProxyProbeSpec returns the timings, without a handler, of the probes of the injected Envoy sidecars
+	GetProxyProbeSpec() v1.Probe
+
+	// GetProxyBootstrapOverride returns the decoded YAML fragment deep-merged over the bootstrap config of the Envoy sidecars
+	GetProxyBootstrapOverride() (map[string]interface{}, error)
+
+	// GetProxyBindAddress returns the IP address the admin listener of the Envoy sidecars binds to, or pod-ip
+	GetProxyBindAddress() string
+
+	// GetEnvoyConcurrency returns the number of worker threads of the Envoy sidecars; 0 lets Envoy decide
+	GetEnvoyConcurrency() uint32
+
+	// GetProxyEnvVars returns the extra environment variables of the Envoy sidecars, sorted by name
+	GetProxyEnvVars() []v1.EnvVar
+
+	// GetProxyDrainTime returns the duration during which the Envoy sidecars drain their connections on a hot restart or shutdown
+	GetProxyDrainTime() time.Duration
+
+	// GetProxyParentShutdownTime returns the duration after which the parent Envoy process is shut down on a hot restart;
+	// it is never shorter than the drain time
+	GetProxyParentShutdownTime() time.Duration
+
+	// GetOverloadManager returns the validated heap limit of the Envoy sidecars, with its thresholds defaulted, or nil
+	// when it is disabled
+	GetOverloadManager() *OverloadManager
+
+	// GetServiceCertValidityDuration returns the validity duration of the service certificates
+	GetServiceCertValidityDuration() time.Duration
+
+	// GetTrustDomain returns the trust domain of the mesh, which the common names of the service certificates end with
+	GetTrustDomain() string
+
+	// GetMeshTLSVersions returns the oldest and the newest TLS protocol versions, from TLS1_0 to TLS1_3, of the mesh-internal connections
+	GetMeshTLSVersions() (string, string)
+
+	// GetMeshCipherSuites returns the TLS cipher suites, by order of preference, of the mesh-internal connections; nil leaves them to Envoy
+	GetMeshCipherSuites() []string
+
+	// GetMTLSExemptSourceCIDRs returns the sorted list of source CIDR ranges whose plaintext connections the inbound
+	// listeners accept without mTLS; it is empty unless it is configured
+	GetMTLSExemptSourceCIDRs() []*net.IPNet
+
+	// GetCertificateProvider returns the certificate provider, tresor, vault or cert-manager, issuing the certificates of
+	// the mesh; it is empty unless a valid provider is configured. osm-controller only reads it, and the configs of the
+	// providers, on startup
+	GetCertificateProvider() string
+
+	// GetVaultConfig returns the validated config of the Hashicorp Vault certificate provider, or nil when it is incomplete or invalid
+	GetVaultConfig() *VaultConfig
+
+	// GetCertManagerConfig returns the validated config of the cert-manager certificate provider, or nil when it is incomplete or invalid
+	GetCertManagerConfig() *CertManagerConfig
+
+	// GetMaxDataPlaneConnections returns the maximum number of Envoy proxies connected to the controller; 0 means unlimited
+	GetMaxDataPlaneConnections() int
+
+	// GetMaxEndpointsPerCluster returns the maximum number of endpoints of each cluster sent to the proxies over EDS; 0 means unlimited
+	GetMaxEndpointsPerCluster() uint32
+
+	// GetLeaderElectionConfig returns the validated lease timings of the leader election of the controllers
+	GetLeaderElectionConfig() LeaderElectionConfig
+
+	// IsDebugServerEnabled returns whether the debug server of the controller is enabled
+	IsDebugServerEnabled() bool
+
+	// GetOutboundPortExclusionList returns the list of ports for which outbound traffic bypasses the proxy
+	GetOutboundPortExclusionList() []int
+
+	// GetInboundPortExclusionList returns the list of ports for which inbound traffic bypasses the proxy
+	GetInboundPortExclusionList() []int
+
+	// IsLocalityAwareRoutingEnabled returns whether the proxies prefer the endpoints in their own locality
+	IsLocalityAwareRoutingEnabled() bool
+
+	// GetLocality returns the region and zone overrides of the proxies; empty values are derived from the node labels
+	GetLocality() Locality
+
+	// IsFeatureEnabled returns whether the feature flag with the given name is enabled
+	IsFeatureEnabled(name string) bool
+
+	// GetStatsPrefix returns the prefix of the names of the HTTP stats of the Envoy proxies
+	GetStatsPrefix() string
+
+	// GetStatsTags returns a copy of the tags, keyed by the tag name, added to all the stats of the Envoy proxies
+	GetStatsTags() map[string]string
+
+	// GetStatsSink returns the validated statsd sink the Envoy proxies push their stats to, or nil when it is disabled
+	GetStatsSink() *StatsSink
+
+	// GetWASMExtensions returns the valid WebAssembly extensions run by the Envoy proxies, in order, with their insertion point set
+	GetWASMExtensions() []WASMExtensionSpec
+
+	// GetFeatureFlags returns a copy of the feature flags, keyed by the feature name
+	GetFeatureFlags() map[string]bool
+
+	// GetMetricsCollector returns the Prometheus collector exposing the active OSM config as gauges
+	GetMetricsCollector() prometheus.Collector
+
 	// GetAnnouncementsChannel returns a channel, which is used to announce when changes have been made to the OSM ConfigMap
 	GetAnnouncementsChannel() <-chan interface{}
+
+	// GetTypedAnnouncementsChannel returns a channel, which is used to announce which fields of the OSM ConfigMap changed
+	GetTypedAnnouncementsChannel() <-chan ConfigChangeEvent
+
+	// Subscribe returns a channel receiving the config change events in which one of the given MeshConfig fields changed,
+	// or in which any field changed when no field is given
+	Subscribe(fields ...string) <-chan ConfigChangeEvent
+
+	// Unsubscribe stops the config change events to the given channel returned by Subscribe, and closes it
+	Unsubscribe(ch <-chan ConfigChangeEvent)
+
+	// WatchConfig returns a channel receiving the config change events in which any field changed, closed once the context is done
+	WatchConfig(ctx context.Context) <-chan ConfigChangeEvent
+
+	// IsEgressEnabledForNamespace determines whether egress is enabled for the pods of the given namespace, as per its annotations and the global config
+	IsEgressEnabledForNamespace(namespace string) bool
+
+	// GetEnvoyLogLevelForNamespace returns the Envoy log level for the pods of the given namespace, as per its annotations and the global config
+	GetEnvoyLogLevelForNamespace(namespace string) string
+
+	// OnConfigChange registers a callback invoked with each config change event, and returns a function unregistering it
+	OnConfigChange(cb func(ConfigChangeEvent)) (unregister func())
+
+	// RegisterValidator registers a validator run on the config of every ConfigMap revision, which is not applied when rejected
+	RegisterValidator(validator ConfigValidator)
 }
+
+// The client and its mock implement the whole Configurator interface, so that no package depends on the concrete client
+var (
+	_ Configurator = (*Client)(nil)
+	_ Configurator = (*MockConfigurator)(nil)
+)
@@ -0,0 +1,130 @@
+package configurator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// setConfigFieldTimeout is how long SetConfigField waits for the getters to return the patched value
+	setConfigFieldTimeout = 10 * time.Second
+
+	// setConfigFieldPollInterval is how often SetConfigField checks whether the getters return the patched value
+	setConfigFieldPollInterval = 10 * time.Millisecond
+)
+
+// SetConfigField patches the value of the given MeshConfig field, such as "Egress", into the OSM ConfigMap, and returns
+// once the getters return the patched value, or an error when they do not in time, e.g. since a validator rejects it.
+// Strings, booleans and numbers are stored as is, lists of strings are joined with commas, and the nested objects,
+// such as the retry policy, are stored as YAML; a nil value removes the key, so the field falls back on its default.
+// It fails once the client is closed, including while waiting for the getters, in which case the patch may be stored.
+func (c *Client) SetConfigField(field string, value interface{}) error {
+	structField, ok := reflect.TypeOf(MeshConfig{}).FieldByName(field)
+	if !ok {
+		return errors.Wrapf(errUnknownConfigField, "%q", field)
+	}
+	if c.isClosed() {
+		return errors.Wrapf(errClientClosed, "field %s of ConfigMap %s not set", field, c.getConfigMapCacheKey())
+	}
+	if c.kubeClient == nil {
+		return errors.Wrapf(errConfigMapNotWritable, "%s is not a Kubernetes ConfigMap", c.getConfigMapCacheKey())
+	}
+	if c.meshConfigCache != nil && c.getMeshConfigFromInformerCache() != nil {
+		return errors.Wrapf(errConfigMapNotWritable, "MeshConfig %s takes precedence over ConfigMap %s", c.getMeshConfigCacheKey(), c.getConfigMapCacheKey())
+	}
+
+	key := strings.Split(structField.Tag.Get("yaml"), ",")[0]
+	var patchValue *string
+	if value != nil {
+		formattedValue, err := formatConfigMapValue(value)
+		if err != nil {
+			return errors.Wrapf(errInvalidValueType, "%s: %s", key, err)
+		}
+		if errs := validateConfigMapValueTypes(&v1.ConfigMap{Data: map[string]string{key: formattedValue}}); len(errs) > 0 {
+			return errs[0]
+		}
+		patchValue = &formattedValue
+	}
+
+	// A merge patch only changes the given key, so concurrent updates of the other keys are kept
+	patch, err := json.Marshal(map[string]interface{}{
+		"data": map[string]*string{key: patchValue},
+	})
+	if err != nil {
+		return err
+	}
+	configMap, err := c.kubeClient.CoreV1().ConfigMaps(c.osmNamespace).Patch(context.TODO(), c.osmConfigMapName, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "Error patching key %s of ConfigMap %s", key, c.getConfigMapCacheKey())
+	}
+
+	// The patched ConfigMap is applied right away, like a reload, rather than waiting for the informer, which cannot
+	// deliver the patch while a config change callback calling SetConfigField is being invoked
+	c.applyConfigMapFromAPIServer(configMap)
+
+	// The value returned by the getters is the value parsed from the patched ConfigMap, which the environment may override
+	overlaidConfigMap, _ := c.overlayEnvironment(configMap)
+	expectedValue := reflect.ValueOf(*mergeOverDefaultConfig(overlaidConfigMap)).FieldByName(field).Interface()
+	err = wait.PollImmediate(setConfigFieldPollInterval, setConfigFieldTimeout, func() (bool, error) {
+		if c.isClosed() {
+			return false, errClientClosed
+		}
+		return reflect.DeepEqual(reflect.ValueOf(*c.getConfigMap()).FieldByName(field).Interface(), expectedValue), nil
+	})
+	if errors.Is(err, errClientClosed) {
+		return errors.Wrapf(err, "key %s of ConfigMap %s patched but not applied", key, c.getConfigMapCacheKey())
+	}
+	if err != nil {
+		return errors.Wrapf(errConfigUpdateTimeout, "key %s of ConfigMap %s not updated within %s", key, c.getConfigMapCacheKey(), setConfigFieldTimeout)
+	}
+	return nil
+}
+
+// formatConfigMapValue returns the ConfigMap value of the given field value; the lists, such as the mesh CIDR ranges,
+// are comma separated
+func formatConfigMapValue(value interface{}) (string, error) {
+	reflectValue := reflect.ValueOf(value)
+	if reflectValue.Kind() == reflect.Ptr && !reflectValue.IsNil() {
+		reflectValue = reflectValue.Elem()
+	}
+
+	if isScalarKind(reflectValue.Kind()) {
+		return fmt.Sprint(reflectValue.Interface()), nil
+	}
+	if reflectValue.Kind() == reflect.Slice && isScalarKind(reflectValue.Type().Elem().Kind()) {
+		var values []string
+		for i := 0; i < reflectValue.Len(); i++ {
+			values = append(values, fmt.Sprint(reflectValue.Index(i).Interface()))
+		}
+		return strings.Join(values, ","), nil
+	}
+
+	encoded, err := yaml.Marshal(reflectValue.Interface())
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// isScalarKind returns whether the values of the given kind are stored in the ConfigMap as formatted by fmt
+func isScalarKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
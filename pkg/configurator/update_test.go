@@ -0,0 +1,177 @@
+package configurator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Test updating the OSM config", func() {
+	Context("set a field of the OSM config", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				meshCIDRRangesKey: "10.0.0.0/16",
+				tracingPortKey:    "9411",
+			},
+		}
+
+		It("fails when the ConfigMap does not exist", func() {
+			Expect(cfg.SetConfigField("Egress", true)).ToNot(Succeed())
+		})
+
+		It("reads the field back right after setting it", func() {
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			Expect(cfg.SetConfigField("Egress", true)).To(Succeed())
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+
+			Expect(cfg.SetConfigField("TracingPort", 14268)).To(Succeed())
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(14268)))
+
+			Expect(cfg.SetConfigField("EgressAllowedDomains", []string{"api.stripe.com", "*.example.com"})).To(Succeed())
+			Expect(cfg.GetEgressAllowedDomains()).To(Equal([]string{"*.example.com", "api.stripe.com"}))
+
+			Expect(cfg.SetConfigField("RetryPolicy", RetryPolicy{NumRetries: 3, RetryOn: "5xx"})).To(Succeed())
+			Expect(cfg.GetDefaultRetryPolicy().NumRetries).To(Equal(uint32(3)))
+		})
+
+		It("keeps the other keys of the ConfigMap", func() {
+			configMap, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Get(context.TODO(), osmConfigMapName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(configMap.Data).To(HaveKeyWithValue(meshCIDRRangesKey, "10.0.0.0/16"))
+			Expect(configMap.Data).To(HaveKeyWithValue(egressKey, "true"))
+		})
+
+		It("removes the key when the value is nil", func() {
+			Expect(cfg.SetConfigField("TracingPort", nil)).To(Succeed())
+			Expect(cfg.GetTracingPort()).To(Equal(uint32(mergeOverDefaultConfig(nil).TracingPort)))
+
+			configMap, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Get(context.TODO(), osmConfigMapName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(configMap.Data).ToNot(HaveKey(tracingPortKey))
+		})
+
+		It("rejects unknown fields and values of the wrong type", func() {
+			err := cfg.SetConfigField("NoSuchField", true)
+			Expect(errors.Is(err, errUnknownConfigField)).To(BeTrue())
+
+			err = cfg.SetConfigField("Egress", 42)
+			Expect(errors.Is(err, errInvalidValueType)).To(BeTrue())
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+
+			close(stop)
+		})
+	})
+
+	Context("set a field of the OSM config after the client is closed", func() {
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: osmNamespace,
+				Name:      osmConfigMapName,
+			},
+			Data: map[string]string{
+				tracingPortKey: "9411",
+			},
+		}
+
+		It("fails right away without patching the ConfigMap once the client is closed", func() {
+			kubeClient := testclient.NewSimpleClientset(&configMap)
+			stop := make(chan struct{})
+			defer close(stop)
+			cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+			cfg.GetTypedAnnouncementsChannel()
+			Expect(cfg.Close()).To(Succeed())
+
+			var err error
+			Expect(func() { err = cfg.SetConfigField("TracingPort", 14268) }).ToNot(Panic())
+			Expect(errors.Is(err, errClientClosed)).To(BeTrue())
+
+			actual, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Get(context.TODO(), osmConfigMapName, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual.Data).To(HaveKeyWithValue(tracingPortKey, "9411"))
+		})
+
+		It("stops waiting for the getters once the client is closed", func() {
+			kubeClient := testclient.NewSimpleClientset(&configMap)
+			stop := make(chan struct{})
+			defer close(stop)
+			// The validator rejects the patched value, so SetConfigField waits for the getters until the client is closed
+			cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName, WithValidators(func(config MeshConfig) error {
+				if config.TracingPort == 14268 {
+					return errors.New("tracing port 14268 is forbidden")
+				}
+				return nil
+			}))
+
+			errs := make(chan error, 1)
+			go func() {
+				errs <- cfg.SetConfigField("TracingPort", 14268)
+			}()
+			Consistently(errs, 200*time.Millisecond).ShouldNot(Receive())
+			Expect(cfg.Close()).To(Succeed())
+
+			var err error
+			Eventually(errs, time.Second).Should(Receive(&err))
+			Expect(errors.Is(err, errClientClosed)).To(BeTrue())
+		})
+	})
+
+	Context("set a field of the OSM config read from a file", func() {
+		It("fails since the file is not a ConfigMap", func() {
+			stop := make(chan struct{})
+			defer close(stop)
+			cfg := newFileConfigurator(filepath.Join(os.TempDir(), "-missing-osm-config-.json"), stop, make(chan os.Signal, 1))
+
+			err := cfg.SetConfigField("Egress", true)
+			Expect(errors.Is(err, errConfigMapNotWritable)).To(BeTrue())
+		})
+	})
+
+	Context("format the ConfigMap values", func() {
+		It("formats the values like the ConfigMap stores them", func() {
+			samplingRate := 0.5
+			for _, value := range []struct {
+				value    interface{}
+				expected string
+			}{
+				{"debug", "debug"},
+				{true, "true"},
+				{9411, "9411"},
+				{uint32(3), "3"},
+				{&samplingRate, "0.5"},
+				{[]string{"10.0.0.0/16", "fd00::/64"}, "10.0.0.0/16,fd00::/64"},
+				{[]int{6379, 3306}, "6379,3306"},
+				{map[string]bool{"feature-a": true}, "feature-a: true\n"},
+			} {
+				formattedValue, err := formatConfigMapValue(value.value)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(formattedValue).To(Equal(value.expected), "value %v", value.value)
+			}
+		})
+	})
+})
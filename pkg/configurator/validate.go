@@ -0,0 +1,576 @@
+package configurator
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+// ValidateConfig returns all the problems found in the current OSM config, without modifying it.
+// An empty result means the getters use every configured value as is, rather than defaulting or adjusting it.
+func (c *Client) ValidateConfig() []error {
+	return c.getConfigMap().validate()
+}
+
+// ValidateConfigMapUpdate returns an error describing all the problems found in the updated OSM ConfigMap, or nil when
+// the update is valid, so a validating admission webhook can reject a bad config before it reaches the controllers.
+// The old ConfigMap is nil when the ConfigMap is created; the update is validated on its own, so an update fixing
+// some of the problems of the old ConfigMap is still rejected while problems remain.
+func ValidateConfigMapUpdate(oldConfigMap, newConfigMap *v1.ConfigMap) error {
+	if newConfigMap == nil {
+		return errors.Wrap(errInvalidConfigMap, "missing ConfigMap")
+	}
+
+	errs := validateConfigMapValueTypes(newConfigMap)
+	errs = append(errs, parseOSMConfigMap(newConfigMap).validate()...)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	var problems []string
+	for _, err := range errs {
+		problems = append(problems, err.Error())
+	}
+	return errors.Wrapf(errInvalidConfigMap, "%s/%s has %d problem(s): %s", newConfigMap.Namespace, newConfigMap.Name, len(errs), strings.Join(problems, "; "))
+}
+
+// validateConfigMapValueTypes returns an error for each ConfigMap value which cannot be parsed into the type of its
+// MeshConfig field; the parsing of the ConfigMap ignores these values, so they would otherwise go unnoticed.
+func validateConfigMapValueTypes(configMap *v1.ConfigMap) []error {
+	var errs []error
+	configType := reflect.TypeOf(MeshConfig{})
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		value, ok := configMap.Data[key]
+		if !ok {
+			continue
+		}
+
+		var err error
+		switch field.Type.Kind() {
+		case reflect.Bool:
+			_, err = strconv.ParseBool(value)
+		case reflect.Int:
+			_, err = strconv.ParseInt(value, 10, 32)
+		case reflect.Ptr:
+			// The optional numbers, such as the tracing sampling rate, are pointers to floats
+			_, err = strconv.ParseFloat(value, 64)
+		case reflect.Map, reflect.Slice, reflect.Struct:
+			err = yaml.Unmarshal([]byte(value), reflect.New(field.Type).Interface())
+		}
+		if err != nil {
+			errs = append(errs, errors.Wrapf(errInvalidValueType, "%s=%q", key, value))
+		}
+	}
+	return errs
+}
+
+// validate returns all the problems found in the config
+func (config *MeshConfig) validate() []error {
+	var errs []error
+
+	if config.EnvoyLogLevel != "" && !isValidEnvoyLogLevel(config.EnvoyLogLevel) {
+		errs = append(errs, errors.Wrapf(errInvalidLogLevel, "%s=%q", envoyLogLevel, config.EnvoyLogLevel))
+	}
+
+	errs = append(errs, validateEnumValue(egressModeKey, config.EgressMode, validEgressModes)...)
+	errs = append(errs, validateEnumValue(defaultIngressBackendProtocolKey, strings.ToLower(config.DefaultIngressBackendProtocol), validIngressBackendProtocols)...)
+	errs = append(errs, validateEnumValue(smiSpecVersionKey, config.SMISpecVersion, validSMISpecVersions)...)
+	errs = append(errs, validateEnumValue(egressDNSResolutionKey, config.EgressDNSResolution, validEgressDNSResolutions)...)
+	errs = append(errs, validateEnumValue(defaultLBAlgorithmKey, config.DefaultLBAlgorithm, validLBAlgorithms)...)
+	errs = append(errs, validateEnumValue(tracingBackendKey, config.TracingBackend, validTracingBackends)...)
+	errs = append(errs, validateEnumValue(envoyLogFormatKey, config.EnvoyLogFormat, validEnvoyLogFormats)...)
+	errs = append(errs, validateEnumValue(accessLogFormatKey, config.AccessLogFormat, validAccessLogFormats)...)
+	for _, xdsType := range parseDelimitedList(config.DisabledXDSTypes) {
+		errs = append(errs, validateEnumValue(disabledXDSTypesKey, xdsType, validXDSTypes)...)
+	}
+
+	errs = append(errs, validatePort(prometheusScrapePortKey, config.PrometheusScrapePort)...)
+	errs = append(errs, validatePort(tracingPortKey, config.TracingPort)...)
+	errs = append(errs, validatePort(envoyAdminPortKey, config.EnvoyAdminPort)...)
+	errs = append(errs, validatePortList(outboundPortExclusionListKey, config.OutboundPortExclusionList)...)
+	errs = append(errs, validatePortList(inboundPortExclusionListKey, config.InboundPortExclusionList)...)
+
+	for key, value := range map[string]string{localityRegionKey: config.LocalityRegion, localityZoneKey: config.LocalityZone} {
+		if value != "" && len(validation.IsValidLabelValue(value)) > 0 {
+			errs = append(errs, errors.Wrapf(errInvalidLabelValue, "%s=%q", key, value))
+		}
+	}
+
+	if samplingRate := config.TracingSamplingRate; samplingRate != nil && (math.IsNaN(*samplingRate) || *samplingRate < 0 || *samplingRate > 1) {
+		errs = append(errs, errors.Wrapf(errInvalidSamplingRate, "%s=%v", tracingSamplingRateKey, *samplingRate))
+	}
+
+	for _, host := range parseCommaSeparatedList(config.TracingAddress) {
+		if !isValidHost(host) {
+			errs = append(errs, errors.Wrapf(errInvalidHost, "%s=%q", tracingAddressKey, host))
+		}
+	}
+
+	if config.TracingEndpoint != "" {
+		if normalizedEndpoint, ok := normalizeTracingEndpoint(config.TracingEndpoint); !ok || normalizedEndpoint != config.TracingEndpoint {
+			errs = append(errs, errors.Wrapf(errInvalidPath, "%s=%q", tracingEndpointKey, config.TracingEndpoint))
+		}
+	}
+
+	if config.EnvoyImage != "" && !isValidImageReference(config.EnvoyImage) {
+		errs = append(errs, errors.Wrapf(errInvalidImage, "%s=%q", envoyImageKey, config.EnvoyImage))
+	}
+
+	if config.InitContainerImage != "" && !isValidImageReference(config.InitContainerImage) {
+		errs = append(errs, errors.Wrapf(errInvalidImage, "%s=%q", initContainerImageKey, config.InitContainerImage))
+	}
+
+	if config.PrometheusScrapePath != "" && !strings.HasPrefix(config.PrometheusScrapePath, "/") {
+		errs = append(errs, errors.Wrapf(errInvalidPath, "%s=%q", prometheusScrapePathKey, config.PrometheusScrapePath))
+	}
+
+	if config.EnvoyConcurrency < 0 {
+		errs = append(errs, errors.Wrapf(errNegativeValue, "%s=%d", envoyConcurrencyKey, config.EnvoyConcurrency))
+	} else if config.EnvoyConcurrency > maxEnvoyConcurrency {
+		errs = append(errs, errors.Wrapf(errValueTooLarge, "%s=%d is above %d", envoyConcurrencyKey, config.EnvoyConcurrency, maxEnvoyConcurrency))
+	}
+
+	for name := range config.ProxyEnvVars {
+		if len(validation.IsEnvVarName(name)) > 0 {
+			errs = append(errs, errors.Wrapf(errInvalidEnvVarName, "%s=%q", proxyEnvVarsKey, name))
+		} else if isReservedProxyEnvVar(name) {
+			errs = append(errs, errors.Wrapf(errReservedEnvVarName, "%s=%q", proxyEnvVarsKey, name))
+		}
+	}
+
+	errs = append(errs, config.validateProxyShutdownTimes()...)
+
+	if config.MaxDataPlaneConnections < 0 {
+		errs = append(errs, errors.Wrapf(errNegativeValue, "%s=%d", maxDataPlaneConnectionsKey, config.MaxDataPlaneConnections))
+	}
+	if config.MaxEndpointsPerCluster < 0 {
+		errs = append(errs, errors.Wrapf(errNegativeValue, "%s=%d", maxEndpointsPerClusterKey, config.MaxEndpointsPerCluster))
+	}
+	errs = append(errs, config.LeaderElection.validate()...)
+
+	errs = append(errs, config.validateCertificateProvider()...)
+
+	errs = append(errs, config.validateMeshCIDRRanges()...)
+	for _, cidr := range parseDelimitedList(config.MTLSExemptSourceCIDRs) {
+		if _, err := parseCIDR(mtlsExemptSourceCIDRsKey, cidr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, domain := range parseDelimitedList(config.EgressAllowedDomains) {
+		if !isValidDomain(normalizeDomain(domain)) {
+			errs = append(errs, errors.Wrapf(errInvalidDomain, "%s=%q", egressAllowedDomainsKey, domain))
+		}
+	}
+	if config.EgressCABundle != "" {
+		if _, err := parseCABundleRef(config.EgressCABundle); err != nil {
+			errs = append(errs, errors.Wrap(err, egressCABundleKey))
+		}
+	}
+	for _, namespace := range parseDelimitedList(config.ExcludedNamespaces) {
+		if !isValidNamespace(namespace) {
+			errs = append(errs, errors.Wrapf(errInvalidNamespace, "%s=%q", excludedNamespacesKey, namespace))
+		}
+	}
+	errs = append(errs, config.SidecarResources.validate()...)
+	errs = append(errs, config.InboundExternalAuth.validate()...)
+	errs = append(errs, config.StatsSink.validate()...)
+	errs = append(errs, config.OverloadManager.validate()...)
+	if config.MulticlusterEnabled {
+		errs = append(errs, config.MulticlusterGateway.validate()...)
+	}
+	errs = append(errs, config.OutlierDetection.validate()...)
+	errs = append(errs, config.ProxyProbe.validate()...)
+	errs = append(errs, config.validateWASMExtensions()...)
+	if _, err := parseYAMLMapping(proxyBootstrapOverrideKey, config.ProxyBootstrapConfigOverride); err != nil {
+		errs = append(errs, err)
+	}
+	if config.ProxyBindAddress != "" && !isValidBindAddress(config.ProxyBindAddress) {
+		errs = append(errs, errors.Wrapf(errInvalidBindAddress, "%s=%q", proxyBindAddressKey, config.ProxyBindAddress))
+	}
+
+	if config.StatsPrefix != "" && !prometheusMetricNamePattern.MatchString(config.StatsPrefix) {
+		errs = append(errs, errors.Wrapf(errInvalidStatsName, "%s=%q", statsPrefixKey, config.StatsPrefix))
+	}
+	for name := range config.StatsTags {
+		if !prometheusLabelNamePattern.MatchString(name) {
+			errs = append(errs, errors.Wrapf(errInvalidStatsName, "%s=%q", statsTagsKey, name))
+		}
+	}
+
+	errs = append(errs, validateDuration(envoyConnectionIdleTimeoutKey, config.EnvoyConnectionIdleTimeout, 0)...)
+	errs = append(errs, validateDuration(envoyRequestTimeoutKey, config.EnvoyRequestTimeout, 0)...)
+	errs = append(errs, validateDuration(xdsServerResponseTimeoutKey, config.XDSServerResponseTimeout, time.Nanosecond)...)
+	errs = append(errs, validateDuration(xdsKeepaliveTimeKey, config.XDSKeepaliveTime, time.Nanosecond)...)
+	errs = append(errs, validateDuration(xdsKeepaliveTimeoutKey, config.XDSKeepaliveTimeout, time.Nanosecond)...)
+	errs = append(errs, validateDuration(serviceCertValidityDurationKey, config.ServiceCertValidityDuration, constants.MinServiceCertValidityDuration)...)
+	if config.TrustDomain != "" && !isValidTrustDomain(config.TrustDomain) {
+		errs = append(errs, errors.Wrapf(errInvalidDomain, "%s=%q", trustDomainKey, config.TrustDomain))
+	}
+	errs = append(errs, config.validateMeshTLSVersions()...)
+	for _, cipherSuite := range parseDelimitedList(config.MeshCipherSuites) {
+		errs = append(errs, validateEnumValue(meshCipherSuitesKey, cipherSuite, validCipherSuites)...)
+	}
+	if config.RetryPolicy.NumRetries != 0 {
+		errs = append(errs, validateDuration(retryPolicyKey+".per_try_timeout", config.RetryPolicy.PerTryTimeout, time.Nanosecond)...)
+		for _, condition := range parseDelimitedList(config.RetryPolicy.RetryOn) {
+			errs = append(errs, validateEnumValue(retryPolicyKey+".retry_on", condition, validRetryOnConditions)...)
+		}
+	}
+
+	return errs
+}
+
+// validateMeshCIDRRanges returns an error for each malformed mesh CIDR range, and an error when egress requires
+// mesh CIDR ranges but none are valid
+func (config *MeshConfig) validateMeshCIDRRanges() []error {
+	var errs []error
+	validCIDRs := 0
+	for _, cidr := range parseDelimitedList(config.MeshCIDRRanges) {
+		if _, err := parseCIDR(meshCIDRRangesKey, cidr); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		validCIDRs++
+	}
+
+	if validCIDRs == 0 && config.getEgressMode() != EgressModeDisabled {
+		errs = append(errs, errNoValidMeshCIDRRanges)
+	}
+
+	return errs
+}
+
+// validateProxyShutdownTimes returns an error for each malformed drain or parent shutdown time of the proxies, and an
+// error when both are set and the parent shutdown time is shorter than the drain time
+func (config *MeshConfig) validateProxyShutdownTimes() []error {
+	errs := validateDuration(proxyDrainTimeKey, config.ProxyDrainTime, time.Second)
+	errs = append(errs, validateDuration(proxyParentShutdownTimeKey, config.ProxyParentShutdownTime, time.Second)...)
+	if len(errs) > 0 || config.ProxyDrainTime == "" || config.ProxyParentShutdownTime == "" {
+		return errs
+	}
+
+	drainTime, _ := time.ParseDuration(config.ProxyDrainTime)
+	parentShutdownTime, _ := time.ParseDuration(config.ProxyParentShutdownTime)
+	if parentShutdownTime < drainTime {
+		return []error{errors.Wrapf(errShutdownBeforeDrain, "%s=%s is shorter than %s=%s", proxyParentShutdownTimeKey, parentShutdownTime, proxyDrainTimeKey, drainTime)}
+	}
+	return nil
+}
+
+// validateMeshTLSVersions returns an error for each unsupported mesh TLS version, and an error when both are set and
+// the min version is newer than the max version
+func (config *MeshConfig) validateMeshTLSVersions() []error {
+	errs := validateEnumValue(meshTLSMinVersionKey, config.MeshTLSMinVersion, validTLSVersions)
+	errs = append(errs, validateEnumValue(meshTLSMaxVersionKey, config.MeshTLSMaxVersion, validTLSVersions)...)
+	if len(errs) > 0 || config.MeshTLSMinVersion == "" || config.MeshTLSMaxVersion == "" {
+		return errs
+	}
+
+	if config.MeshTLSMinVersion > config.MeshTLSMaxVersion {
+		return []error{errors.Wrapf(errInvertedTLSVersions, "%s=%s is newer than %s=%s", meshTLSMinVersionKey, config.MeshTLSMinVersion, meshTLSMaxVersionKey, config.MeshTLSMaxVersion)}
+	}
+	return nil
+}
+
+// validate returns an error for each malformed or negative quantity, and for each request above its limit
+func (resources SidecarResources) validate() []error {
+	var errs []error
+	for _, quantities := range []struct{ resource, request, limit string }{
+		{"cpu", resources.CPURequest, resources.CPULimit},
+		{"memory", resources.MemoryRequest, resources.MemoryLimit},
+	} {
+		requestKey := fmt.Sprintf("%s.%s_request", sidecarResourcesKey, quantities.resource)
+		limitKey := fmt.Sprintf("%s.%s_limit", sidecarResourcesKey, quantities.resource)
+		request, requestErrs := validateQuantity(requestKey, quantities.request)
+		limit, limitErrs := validateQuantity(limitKey, quantities.limit)
+		errs = append(errs, requestErrs...)
+		errs = append(errs, limitErrs...)
+		if request != nil && limit != nil && request.Cmp(*limit) > 0 {
+			errs = append(errs, errors.Wrapf(errInvalidQuantity, "%s=%q is above %s=%q", requestKey, quantities.request, limitKey, quantities.limit))
+		}
+	}
+	return errs
+}
+
+// validate returns an error for each problem preventing the inbound requests from being authorized externally
+// when external authorization is enabled
+func (externalAuth InboundExternalAuth) validate() []error {
+	if !externalAuth.Enable {
+		return nil
+	}
+
+	var errs []error
+	if !isValidHost(externalAuth.Address) {
+		errs = append(errs, errors.Wrapf(errInvalidHost, "%s.address=%q", inboundExternalAuthKey, externalAuth.Address))
+	}
+	if !isValidPort(int(externalAuth.Port)) {
+		errs = append(errs, errors.Wrapf(errInvalidPort, "%s.port=%d", inboundExternalAuthKey, externalAuth.Port))
+	}
+	errs = append(errs, validateDuration(inboundExternalAuthKey+".timeout", externalAuth.Timeout, time.Nanosecond)...)
+	return errs
+}
+
+// validate returns an error for each problem preventing the proxies from pushing their stats to the sink when it is enabled
+// validateCertificateProvider returns the errors of the certificate provider and of the configs of the providers; the
+// required fields of the config of a provider are only required when it is the certificate provider
+func (config *MeshConfig) validateCertificateProvider() []error {
+	errs := validateEnumValue(certificateProviderKey, config.CertificateProvider, validCertificateProviders)
+	errs = append(errs, config.Vault.validate(config.CertificateProvider == CertificateProviderVault)...)
+	errs = append(errs, config.CertManager.validate(config.CertificateProvider == CertificateProviderCertManager)...)
+	return errs
+}
+
+// validate returns the errors of the Vault config; its host and token Secret reference are only required when required is true
+func (vaultConfig VaultConfig) validate(required bool) []error {
+	errs := validateEnumValue(vaultKey+".protocol", vaultConfig.Protocol, validVaultProtocols)
+	if vaultConfig.Host == "" {
+		if required {
+			errs = append(errs, errors.Wrapf(errMissingField, "%s.host", vaultKey))
+		}
+	} else if !isValidHost(vaultConfig.Host) {
+		errs = append(errs, errors.Wrapf(errInvalidHost, "%s.host=%q", vaultKey, vaultConfig.Host))
+	}
+	errs = append(errs, validatePort(vaultKey+".port", int(vaultConfig.Port))...)
+	if vaultConfig.TokenSecretRef == "" {
+		if required {
+			errs = append(errs, errors.Wrapf(errMissingField, "%s.token_secret_ref", vaultKey))
+		}
+	} else if _, err := parseSecretKeyRef(vaultConfig.TokenSecretRef); err != nil {
+		errs = append(errs, errors.Wrapf(err, "%s.token_secret_ref", vaultKey))
+	}
+	return errs
+}
+
+// validate returns the errors of the cert-manager config; its issuer name is only required when required is true
+func (certManagerConfig CertManagerConfig) validate(required bool) []error {
+	if certManagerConfig.IssuerName == "" && required {
+		return []error{errors.Wrapf(errMissingField, "%s.issuer_name", certManagerKey)}
+	}
+	return nil
+}
+
+func (statsSink StatsSink) validate() []error {
+	if statsSink.Type == "" {
+		return nil
+	}
+
+	errs := validateEnumValue(statsSinkKey+".type", statsSink.Type, validStatsSinkTypes)
+	if net.ParseIP(statsSink.Address) == nil {
+		errs = append(errs, errors.Wrapf(errInvalidIPAddress, "%s.address=%q", statsSinkKey, statsSink.Address))
+	}
+	if !isValidPort(int(statsSink.Port)) {
+		errs = append(errs, errors.Wrapf(errInvalidPort, "%s.port=%d", statsSinkKey, statsSink.Port))
+	}
+	return errs
+}
+
+// validate returns an error for each threshold of the enabled overload manager outside of [0, 1], and an error when the
+// heap is shrunk above the threshold to stop accepting requests, once the unset thresholds are defaulted
+func (overloadManager OverloadManager) validate() []error {
+	if overloadManager.MaxHeapBytes == 0 {
+		return nil
+	}
+
+	var errs []error
+	if !isValidThreshold(overloadManager.ShrinkHeapThreshold) {
+		errs = append(errs, errors.Wrapf(errInvalidThreshold, "%s.shrink_heap_threshold=%v", overloadManagerKey, overloadManager.ShrinkHeapThreshold))
+	}
+	if !isValidThreshold(overloadManager.StopAcceptingThreshold) {
+		errs = append(errs, errors.Wrapf(errInvalidThreshold, "%s.stop_accepting_threshold=%v", overloadManagerKey, overloadManager.StopAcceptingThreshold))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	thresholds := overloadManager.withDefaultThresholds()
+	if thresholds.ShrinkHeapThreshold > thresholds.StopAcceptingThreshold {
+		return []error{errors.Wrapf(errInvertedThresholds, "%s.shrink_heap_threshold=%v is above %s.stop_accepting_threshold=%v", overloadManagerKey, thresholds.ShrinkHeapThreshold, overloadManagerKey, thresholds.StopAcceptingThreshold)}
+	}
+	return nil
+}
+
+// isValidThreshold returns whether the given fraction of the maximum heap size is within [0, 1]
+func isValidThreshold(threshold float64) bool {
+	return !math.IsNaN(threshold) && threshold >= 0 && threshold <= 1
+}
+
+// validate returns an error for each problem preventing the traffic to the other clusters from going through the gateway
+func (gateway MulticlusterGateway) validate() []error {
+	var errs []error
+	if !isValidHost(gateway.Host) {
+		errs = append(errs, errors.Wrapf(errInvalidHost, "%s.host=%q", multiclusterGatewayKey, gateway.Host))
+	}
+	if !isValidPort(int(gateway.Port)) {
+		errs = append(errs, errors.Wrapf(errInvalidPort, "%s.port=%d", multiclusterGatewayKey, gateway.Port))
+	}
+	return errs
+}
+
+// validate returns an error for each invalid duration or percentage of the outlier detection when it is enabled
+func (outlierDetection OutlierDetection) validate() []error {
+	if !outlierDetection.Enable {
+		return nil
+	}
+
+	errs := validateDuration(outlierDetectionKey+".interval", outlierDetection.Interval, time.Nanosecond)
+	errs = append(errs, validateDuration(outlierDetectionKey+".base_ejection_time", outlierDetection.BaseEjectionTime, time.Nanosecond)...)
+	if outlierDetection.MaxEjectionPercent > maxOutlierEjectionPercent {
+		errs = append(errs, errors.Wrapf(errValueTooLarge, "%s.max_ejection_percent=%d is above %d", outlierDetectionKey, outlierDetection.MaxEjectionPercent, maxOutlierEjectionPercent))
+	}
+	return errs
+}
+
+// validateWASMExtensions returns an error for each invalid WebAssembly extension, and for each extension named like a
+// previous one
+func (config *MeshConfig) validateWASMExtensions() []error {
+	var errs []error
+	names := make(map[string]bool)
+	for i, wasmExtension := range config.WASMExtensions {
+		errs = append(errs, wasmExtension.validate(i)...)
+		if wasmExtension.Name != "" && names[wasmExtension.Name] {
+			errs = append(errs, errors.Wrapf(errInvalidWASMExtension, "%s[%d].name=%q is not unique", wasmExtensionsKey, i, wasmExtension.Name))
+		}
+		names[wasmExtension.Name] = true
+	}
+	return errs
+}
+
+// validate returns an error for each problem preventing the WebAssembly extension at the given index from running
+func (wasmExtension WASMExtensionSpec) validate(index int) []error {
+	var errs []error
+	if wasmExtension.Name == "" {
+		errs = append(errs, errors.Wrapf(errInvalidWASMExtension, "%s[%d].name is empty", wasmExtensionsKey, index))
+	}
+	if _, ok := wasmExtension.ModulePath(); !ok {
+		errs = append(errs, errors.Wrapf(errInvalidWASMExtension, "%s[%d].uri=%q is not an absolute path or file:// URI", wasmExtensionsKey, index, wasmExtension.URI))
+	}
+	errs = append(errs, validateEnumValue(fmt.Sprintf("%s[%d].insertion_point", wasmExtensionsKey, index), wasmExtension.InsertionPoint, validWASMInsertionPoints)...)
+	return errs
+}
+
+// validate returns an error for each malformed or non-positive lease timing, and an error for each pair of timings
+// which are both set and not in the decreasing order of the lease duration, the renew deadline and the retry period
+func (leaderElection LeaderElection) validate() []error {
+	timings := []struct {
+		field string
+		value string
+	}{
+		{"lease_duration", leaderElection.LeaseDuration},
+		{"renew_deadline", leaderElection.RenewDeadline},
+		{"retry_period", leaderElection.RetryPeriod},
+	}
+
+	var errs []error
+	for _, timing := range timings {
+		errs = append(errs, validateDuration(leaderElectionKey+"."+timing.field, timing.value, time.Nanosecond)...)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	for i, longerTiming := range timings {
+		for _, shorterTiming := range timings[i+1:] {
+			if longerTiming.value == "" || shorterTiming.value == "" {
+				continue
+			}
+			// Both timings have been validated above
+			longerDuration, _ := time.ParseDuration(longerTiming.value)
+			shorterDuration, _ := time.ParseDuration(shorterTiming.value)
+			if longerDuration <= shorterDuration {
+				errs = append(errs, errors.Wrapf(errInvertedLeaseTimings, "%s.%s=%s is not longer than %s.%s=%s",
+					leaderElectionKey, longerTiming.field, longerTiming.value, leaderElectionKey, shorterTiming.field, shorterTiming.value))
+			}
+		}
+	}
+	return errs
+}
+
+// validate returns an error for each negative timing of the probe
+func (probeSpec ProbeSpec) validate() []error {
+	var errs []error
+	for _, timing := range []struct {
+		field string
+		value int32
+	}{
+		{"initial_delay_seconds", probeSpec.InitialDelaySeconds},
+		{"period_seconds", probeSpec.PeriodSeconds},
+		{"timeout_seconds", probeSpec.TimeoutSeconds},
+		{"failure_threshold", probeSpec.FailureThreshold},
+	} {
+		if timing.value < 0 {
+			errs = append(errs, errors.Wrapf(errNegativeValue, "%s.%s=%d", proxyProbeKey, timing.field, timing.value))
+		}
+	}
+	return errs
+}
+
+// validateQuantity returns the parsed quantity, or an error when the quantity is set and either cannot be parsed or is negative
+func validateQuantity(key, quantity string) (*resource.Quantity, []error) {
+	if quantity == "" {
+		return nil, nil
+	}
+	parsedQuantity, err := resource.ParseQuantity(quantity)
+	if err != nil || parsedQuantity.Sign() < 0 {
+		return nil, []error{errors.Wrapf(errInvalidQuantity, "%s=%q", key, quantity)}
+	}
+	return &parsedQuantity, nil
+}
+
+// validateEnumValue returns an error when the value is set and not one of the valid values
+func validateEnumValue(key, value string, validValues map[string]interface{}) []error {
+	if value == "" {
+		return nil
+	}
+	if _, ok := validValues[value]; !ok {
+		return []error{errors.Wrapf(errInvalidEnumValue, "%s=%q", key, value)}
+	}
+	return nil
+}
+
+// validatePort returns an error when the port is set and not a valid port number
+func validatePort(key string, port int) []error {
+	if port != 0 && !isValidPort(port) {
+		return []error{errors.Wrapf(errInvalidPort, "%s=%d", key, port)}
+	}
+	return nil
+}
+
+// validatePortList returns an error for each entry of the list of ports which is not a valid port number
+func validatePortList(key, portList string) []error {
+	var errs []error
+	for _, port := range parseDelimitedList(portList) {
+		if portNumber, err := strconv.Atoi(port); err != nil || !isValidPort(portNumber) {
+			errs = append(errs, errors.Wrapf(errInvalidPort, "%s=%q", key, port))
+		}
+	}
+	return errs
+}
+
+// validateDuration returns an error when the duration is set and either cannot be parsed or is shorter than the given minimum
+func validateDuration(key, duration string, minDuration time.Duration) []error {
+	if duration == "" {
+		return nil
+	}
+	parsedDuration, err := time.ParseDuration(duration)
+	if err != nil {
+		return []error{errors.Wrapf(errInvalidDuration, "%s=%q", key, duration)}
+	}
+	if parsedDuration < minDuration {
+		return []error{errors.Wrapf(errInvalidDuration, "%s=%q is shorter than %s", key, duration, minDuration)}
+	}
+	return nil
+}
@@ -0,0 +1,555 @@
+package configurator
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+// errorCauses returns the sentinel errors the given errors wrap
+func errorCauses(errs []error) []error {
+	var causes []error
+	for _, err := range errs {
+		causes = append(causes, errors.Cause(err))
+	}
+	return causes
+}
+
+var _ = Describe("Test OSM config validation", func() {
+	Context("validate the MeshConfig", func() {
+		It("reports no errors for an empty config", func() {
+			config := MeshConfig{}
+			Expect(config.validate()).To(BeEmpty())
+		})
+
+		It("reports no errors for a valid config", func() {
+			samplingRate := 0.5
+			config := MeshConfig{
+				Egress:                      true,
+				EgressMode:                  EgressModePolicy,
+				MeshCIDRRanges:              "10.0.0.0/16 fd00::/64",
+				EgressAllowedDomains:        "api.stripe.com, *.example.com, GitHub.com.",
+				SMISpecVersion:              SMISpecVersionV1alpha3,
+				EgressDNSResolution:         EgressDNSResolutionStatic,
+				EgressCABundle:              "osm-system/egress-ca-bundle",
+				PrometheusScrapePort:        9090,
+				PrometheusScrapePath:        "/metrics",
+				EnvoyLogLevel:               "Debug",
+				EnvoyLogFormat:              EnvoyLogFormatJSON,
+				TracingAddress:              "jaeger-0.osm-system.svc.cluster.local, jaeger-1.osm-system.svc.cluster.local",
+				TracingPort:                 9411,
+				TracingEndpoint:             "/api/v2/spans",
+				TracingSamplingRate:         &samplingRate,
+				TracingBackend:              TracingBackendZipkin,
+				DefaultLBAlgorithm:          LBAlgorithmRingHash,
+				AccessLogFormat:             AccessLogFormatJSON,
+				EnvoyAdminPort:              15000,
+				EnvoyConnectionIdleTimeout:  "1h",
+				EnvoyRequestTimeout:         "0s",
+				XDSServerResponseTimeout:    "10s",
+				XDSKeepaliveTime:            "5m",
+				XDSKeepaliveTimeout:         "10s",
+				DisabledXDSTypes:            "RDS, SDS",
+				ServiceCertValidityDuration: "24h",
+				TrustDomain:                 "mesh.example.com",
+				MeshTLSMinVersion:           "TLS1_2",
+				MeshTLSMaxVersion:           "TLS1_2",
+				MeshCipherSuites:            "ECDHE-ECDSA-AES256-GCM-SHA384, ECDHE-RSA-AES256-GCM-SHA384",
+				MTLSExemptSourceCIDRs:       "10.240.0.0/16, fd00:240::/64",
+				MaxDataPlaneConnections:     100,
+				MaxEndpointsPerCluster:      1000,
+				EnvoyImage:                  "registry.example.com:5000/envoyproxy/envoy-alpine:v1.15.0",
+				InitContainerImage:          "openservicemesh/init@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+				ExcludedNamespaces:          "kube-system, kube-public,monitoring",
+				SidecarResources: SidecarResources{
+					CPURequest:    "100m",
+					CPULimit:      "1",
+					MemoryRequest: "128Mi",
+					MemoryLimit:   "128Mi",
+				},
+				ProxyProbe: ProbeSpec{
+					PeriodSeconds:    5,
+					TimeoutSeconds:   2,
+					FailureThreshold: 30,
+				},
+				LeaderElection: LeaderElection{
+					LeaseDuration: "30s",
+					RenewDeadline: "20s",
+					RetryPeriod:   "4s",
+				},
+				ProxyBootstrapConfigOverride: "stats_flush_interval: 10s",
+				ProxyBindAddress:             "10.0.0.1",
+				StatsPrefix:                  "osm:mesh_1",
+				EnvoyConcurrency:             4,
+				ProxyEnvVars:                 map[string]string{"VENDOR_FEATURE_FLAGS": "tls-inspector", "vendor.log-level": "debug"},
+				ProxyDrainTime:               "30s",
+				ProxyParentShutdownTime:      "45s",
+				InboundExternalAuth: InboundExternalAuth{
+					Enable:  true,
+					Address: "opa.osm-system.svc.cluster.local",
+					Port:    9191,
+					Timeout: "500ms",
+				},
+				MulticlusterEnabled: true,
+				MulticlusterGateway: MulticlusterGateway{
+					Host: "osm-multicluster-gateway.osm-system.svc.cluster.local",
+					Port: 14080,
+				},
+				OutlierDetection: OutlierDetection{
+					Enable:             true,
+					Consecutive5xx:     3,
+					Interval:           "5s",
+					MaxEjectionPercent: 100,
+				},
+				StatsTags: map[string]string{"mesh": "osm", "_region": "westus"},
+				StatsSink: StatsSink{
+					Type:    StatsSinkTypeStatsd,
+					Address: "fd00::10",
+					Port:    8125,
+				},
+				OverloadManager: OverloadManager{
+					MaxHeapBytes:           1 << 30,
+					ShrinkHeapThreshold:    0.9,
+					StopAcceptingThreshold: 0.95,
+				},
+				DefaultIngressBackendProtocol: "HTTP",
+				CertificateProvider:           CertificateProviderVault,
+				Vault: VaultConfig{
+					Protocol:       "https",
+					Host:           "vault.osm-system.svc.cluster.local",
+					Port:           8200,
+					TokenSecretRef: "osm-system/osm-vault-token/token",
+				},
+				WASMExtensions: []WASMExtensionSpec{
+					{Name: "headers", URI: "file:///etc/envoy/wasm/headers.wasm"},
+					{Name: "audit", URI: "/etc/envoy/wasm/audit.wasm", RootID: "audit", InsertionPoint: WASMInsertionPointOutbound},
+				},
+				RetryPolicy: RetryPolicy{
+					NumRetries:    3,
+					PerTryTimeout: "1s",
+					RetryOn:       "5xx, connect-failure",
+				},
+				OutboundPortExclusionList: "6379,3306",
+				InboundPortExclusionList:  "9091",
+				LocalityAwareRouting:      true,
+				LocalityRegion:            "westus2",
+				LocalityZone:              "westus2-1",
+			}
+			Expect(config.validate()).To(BeEmpty())
+		})
+
+		It("reports all the errors of a config with multiple simultaneous errors", func() {
+			samplingRate := math.NaN()
+			config := MeshConfig{
+				EgressMode:                  "everything",
+				MeshCIDRRanges:              "10.0.0.0/16 10.0.0.0/100",
+				EgressAllowedDomains:        "api.stripe.com,https://github.com",
+				SMISpecVersion:              "v1alpha2",
+				EgressDNSResolution:         "ORIGINAL_DST",
+				EgressCABundle:              "egress-ca-bundle",
+				PrometheusScrapePort:        100000,
+				PrometheusScrapePath:        "metrics",
+				EnvoyLogLevel:               "verbose",
+				EnvoyLogFormat:              "logfmt",
+				TracingAddress:              "http://jaeger",
+				TracingPort:                 65536,
+				TracingEndpoint:             "api/v2/spans",
+				TracingSamplingRate:         &samplingRate,
+				TracingBackend:              "datadog",
+				DefaultLBAlgorithm:          "ROUNDROBIN",
+				AccessLogFormat:             "yaml",
+				EnvoyAdminPort:              -1,
+				EnvoyConnectionIdleTimeout:  "1 hour",
+				EnvoyRequestTimeout:         "15",
+				XDSServerResponseTimeout:    "0s",
+				XDSKeepaliveTime:            "-5m",
+				XDSKeepaliveTimeout:         "10",
+				DisabledXDSTypes:            "RDS,ADS",
+				ServiceCertValidityDuration: "1m",
+				TrustDomain:                 "Cluster.Local.",
+				MeshTLSMinVersion:           "TLS1_3",
+				MeshTLSMaxVersion:           "TLS1_1",
+				MeshCipherSuites:            "ECDHE-RSA-AES128-GCM-SHA256,TLS_RSA_WITH_RC4_128_SHA",
+				MTLSExemptSourceCIDRs:       "10.240.0.0/16 10.240.0.1",
+				MaxDataPlaneConnections:     -1,
+				MaxEndpointsPerCluster:      -1,
+				EnvoyImage:                  "Envoy:latest",
+				InitContainerImage:          "openservicemesh/init:v0.3.0 ",
+				ExcludedNamespaces:          "kube-system,Monitoring",
+				RetryPolicy: RetryPolicy{
+					NumRetries:    3,
+					PerTryTimeout: "0s",
+					RetryOn:       "5xx,sometimes",
+				},
+				ProxyProbe: ProbeSpec{
+					PeriodSeconds:    -10,
+					FailureThreshold: -1,
+				},
+				LeaderElection: LeaderElection{
+					LeaseDuration: "10s",
+					RenewDeadline: "15s",
+				},
+				ProxyBootstrapConfigOverride: "stats_flush_interval: [10s",
+				ProxyBindAddress:             "localhost",
+				StatsPrefix:                  "osm.mesh",
+				EnvoyConcurrency:             1000,
+				ProxyEnvVars:                 map[string]string{"1VENDOR_FLAGS": "on", "OSM_PROXY_UID": "0"},
+				ProxyDrainTime:               "20m",
+				ProxyParentShutdownTime:      "10m",
+				InboundExternalAuth: InboundExternalAuth{
+					Enable:  true,
+					Address: "grpc://opa",
+					Timeout: "fast",
+				},
+				MulticlusterEnabled: true,
+				MulticlusterGateway: MulticlusterGateway{
+					Host: "gateway:14080",
+				},
+				OutlierDetection: OutlierDetection{
+					Enable:             true,
+					Interval:           "0s",
+					BaseEjectionTime:   "forever",
+					MaxEjectionPercent: 150,
+				},
+				StatsTags: map[string]string{"mesh": "osm", "cluster-name": "west"},
+				StatsSink: StatsSink{
+					Type:    "graphite",
+					Address: "statsd.osm-system.svc.cluster.local",
+				},
+				OverloadManager: OverloadManager{
+					MaxHeapBytes:           1 << 30,
+					ShrinkHeapThreshold:    1.5,
+					StopAcceptingThreshold: -0.1,
+				},
+				DefaultIngressBackendProtocol: "grpc",
+				CertificateProvider:           CertificateProviderVault,
+				Vault: VaultConfig{
+					Protocol: "ftp",
+					Host:     "vault_server",
+					Port:     70000,
+				},
+				WASMExtensions: []WASMExtensionSpec{
+					{URI: "https://example.com/headers.wasm", InsertionPoint: "sidecar"},
+					{Name: "audit", URI: "/etc/envoy/wasm/audit.wasm"},
+					{Name: "audit", URI: "/etc/envoy/wasm/audit-v2.wasm"},
+				},
+				SidecarResources: SidecarResources{
+					CPURequest:    "2",
+					CPULimit:      "1",
+					MemoryRequest: "lots",
+					MemoryLimit:   "-1Gi",
+				},
+				OutboundPortExclusionList: "6379,abc",
+				InboundPortExclusionList:  "0",
+				LocalityRegion:            "west us",
+				LocalityZone:              "-westus2-1",
+			}
+
+			errs := config.validate()
+			Expect(errorCauses(errs)).To(ConsistOf(
+				errInvalidLogLevel,
+				errInvalidEnumValue, // egress mode
+				errInvalidEnumValue, // SMI spec version
+				errInvalidEnumValue, // egress DNS resolution
+				errInvalidEnumValue, // tracing backend
+				errInvalidEnumValue, // default LB algorithm
+				errInvalidEnumValue, // Envoy log format
+				errInvalidEnumValue, // access log format
+				errInvalidEnumValue, // disabled xDS types
+				errInvalidEnumValue, // mesh cipher suites
+				errInvalidPort,      // Prometheus scrape port
+				errInvalidPath,      // Prometheus scrape path
+				errInvalidHost,      // tracing address
+				errInvalidPath,      // tracing endpoint
+				errInvalidImage,     // Envoy image
+				errInvalidImage,     // init container image
+				errInvalidPort,      // tracing port
+				errInvalidPort,      // admin port
+				errInvalidPort,      // outbound port exclusion list
+				errInvalidPort,      // inbound port exclusion list
+				errInvalidSamplingRate,
+				errNegativeValue,
+				errNegativeValue,    // max endpoints per cluster
+				errNegativeValue,    // probe period
+				errNegativeValue,    // probe failure threshold
+				errInvalidYAML,      // proxy bootstrap config override
+				errInvalidStatsName, // stats prefix
+				errInvalidStatsName, // stats tag name
+				errValueTooLarge,    // Envoy concurrency
+				errInvalidHost,      // external authorization address
+				errInvalidPort,      // external authorization port
+				errInvalidDuration,  // external authorization timeout
+				errInvalidHost,      // multicluster gateway host
+				errInvalidPort,      // multicluster gateway port
+				errInvalidEnumValue, // stats sink type
+				errInvalidIPAddress, // stats sink address
+				errInvalidPort,      // stats sink port
+				errInvalidDuration,  // outlier detection interval
+				errInvalidDuration,  // outlier detection base ejection time
+				errValueTooLarge,    // outlier detection max ejection percent
+				errInvalidCIDR,
+				errInvalidCIDR, // mTLS exempt source CIDR
+				errInvalidBindAddress,
+				errShutdownBeforeDrain,
+				errInvertedTLSVersions,
+				errInvertedLeaseTimings,
+				errInvalidNamespace,
+				errInvalidCABundleRef,
+				errInvalidWASMExtension, // WASM extension name
+				errInvalidWASMExtension, // WASM extension URI
+				errInvalidEnumValue,     // WASM extension insertion point
+				errInvalidEnumValue,     // default ingress backend protocol
+				errInvalidEnumValue,     // Vault protocol
+				errInvalidHost,          // Vault host
+				errInvalidPort,          // Vault port
+				errMissingField,         // Vault token Secret reference
+				errInvalidWASMExtension, // duplicate WASM extension name
+				errInvalidLabelValue,    // locality region
+				errInvalidLabelValue,    // locality zone
+				errInvalidEnvVarName,    // proxy env var name
+				errInvalidThreshold,     // overload manager shrink heap threshold
+				errInvalidThreshold,     // overload manager stop accepting threshold
+				errReservedEnvVarName,   // proxy env var name reserved for OSM
+				errInvalidDomain,
+				errInvalidDomain,    // trust domain
+				errInvalidQuantity,  // CPU request above the limit
+				errInvalidQuantity,  // memory request
+				errInvalidQuantity,  // memory limit
+				errInvalidDuration,  // connection idle timeout
+				errInvalidDuration,  // request timeout
+				errInvalidDuration,  // xDS server response timeout
+				errInvalidDuration,  // xDS keepalive time
+				errInvalidDuration,  // xDS keepalive timeout
+				errInvalidDuration,  // service certificate validity duration
+				errInvalidDuration,  // per-try timeout
+				errInvalidEnumValue, // retry condition
+			))
+		})
+
+		It("reports the missing mesh CIDR ranges when egress is enabled", func() {
+			config := MeshConfig{
+				Egress:         true,
+				MeshCIDRRanges: "10.0.0.0/100",
+			}
+			Expect(errorCauses(config.validate())).To(ConsistOf(errInvalidCIDR, errNoValidMeshCIDRRanges))
+		})
+
+		It("reports the overlong mesh CIDR ranges without quoting them in full", func() {
+			config := MeshConfig{
+				MeshCIDRRanges: "10.0.0.0/" + strings.Repeat("0", 1024) + "8 10.0.0.0/8",
+			}
+			errs := config.validate()
+			Expect(errorCauses(errs)).To(ConsistOf(errInvalidCIDR))
+			Expect(len(errs[0].Error())).To(BeNumerically("<", 4*maxCIDRLength))
+		})
+
+		It("ignores the settings of the outlier detection while it is disabled", func() {
+			config := MeshConfig{OutlierDetection: OutlierDetection{
+				Interval:           "0s",
+				MaxEjectionPercent: 150,
+			}}
+			Expect(config.validate()).To(BeEmpty())
+		})
+
+		It("ignores the multicluster gateway while multicluster is disabled", func() {
+			config := MeshConfig{MulticlusterGateway: MulticlusterGateway{
+				Host: "gateway:14080",
+			}}
+			Expect(config.validate()).To(BeEmpty())
+		})
+
+		It("ignores the stats sink while it is disabled", func() {
+			config := MeshConfig{StatsSink: StatsSink{
+				Address: "statsd.osm-system.svc.cluster.local",
+			}}
+			Expect(config.validate()).To(BeEmpty())
+		})
+
+		It("reports the overload manager shrinking the heap above the threshold to stop accepting requests", func() {
+			config := MeshConfig{OverloadManager: OverloadManager{
+				MaxHeapBytes:        1 << 30,
+				ShrinkHeapThreshold: 0.99,
+			}}
+			Expect(errorCauses(config.validate())).To(ConsistOf(errInvertedThresholds))
+
+			config.OverloadManager.StopAcceptingThreshold = 0.99
+			Expect(config.validate()).To(BeEmpty())
+		})
+
+		It("reports an unknown certificate provider", func() {
+			config := MeshConfig{CertificateProvider: "keyvault"}
+			Expect(errorCauses(config.validate())).To(ConsistOf(errInvalidEnumValue))
+		})
+
+		It("requires the fields of the config of the certificate provider only", func() {
+			config := MeshConfig{CertificateProvider: CertificateProviderVault}
+			Expect(errorCauses(config.validate())).To(ConsistOf(errMissingField, errMissingField))
+
+			config.CertificateProvider = CertificateProviderCertManager
+			Expect(errorCauses(config.validate())).To(ConsistOf(errMissingField))
+
+			config.CertManager.IssuerName = "osm-ca"
+			Expect(config.validate()).To(BeEmpty())
+		})
+
+		It("reports an invalid Vault token Secret reference", func() {
+			config := MeshConfig{Vault: VaultConfig{TokenSecretRef: "osm-vault-token"}}
+			Expect(errorCauses(config.validate())).To(ConsistOf(errInvalidSecretKeyRef))
+		})
+
+		It("ignores the overload manager thresholds while it is disabled", func() {
+			config := MeshConfig{OverloadManager: OverloadManager{
+				ShrinkHeapThreshold:    2,
+				StopAcceptingThreshold: 0.5,
+			}}
+			Expect(config.validate()).To(BeEmpty())
+		})
+
+		It("reports each pair of lease timings which is not in decreasing order", func() {
+			config := MeshConfig{LeaderElection: LeaderElection{
+				LeaseDuration: "10s",
+				RenewDeadline: "10s",
+				RetryPeriod:   "15s",
+			}}
+			Expect(errorCauses(config.validate())).To(ConsistOf(errInvertedLeaseTimings, errInvertedLeaseTimings, errInvertedLeaseTimings))
+
+			config.LeaderElection = LeaderElection{LeaseDuration: "1m", RetryPeriod: "5s"}
+			Expect(config.validate()).To(BeEmpty())
+
+			config.LeaderElection = LeaderElection{LeaseDuration: "soon", RenewDeadline: "0s", RetryPeriod: "1m"}
+			Expect(errorCauses(config.validate())).To(ConsistOf(errInvalidDuration, errInvalidDuration))
+		})
+
+		It("ignores the retry policy when retries are disabled", func() {
+			config := MeshConfig{
+				RetryPolicy: RetryPolicy{
+					PerTryTimeout: "soon",
+					RetryOn:       "sometimes",
+				},
+			}
+			Expect(config.validate()).To(BeEmpty())
+		})
+	})
+
+	Context("validate an update of the OSM ConfigMap", func() {
+		oldConfigMap := &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "osm-system",
+				Name:      "osm-config",
+			},
+			Data: map[string]string{
+				egressKey:     "false",
+				envoyLogLevel: "error",
+			},
+		}
+
+		It("accepts a valid update", func() {
+			newConfigMap := oldConfigMap.DeepCopy()
+			newConfigMap.Data = map[string]string{
+				egressKey:              "true",
+				meshCIDRRangesKey:      "10.0.0.0/16",
+				envoyLogLevel:          "debug",
+				tracingPortKey:         "9411",
+				tracingSamplingRateKey: "0.5",
+				featureFlagsKey:        "feature-a: true",
+				retryPolicyKey:         "num_retries: 3\nretry_on: 5xx",
+			}
+			Expect(ValidateConfigMapUpdate(oldConfigMap, newConfigMap)).To(Succeed())
+		})
+
+		It("accepts a valid ConfigMap being created", func() {
+			Expect(ValidateConfigMapUpdate(nil, oldConfigMap)).To(Succeed())
+		})
+
+		It("rejects an invalid Envoy log level", func() {
+			newConfigMap := oldConfigMap.DeepCopy()
+			newConfigMap.Data[envoyLogLevel] = "verbose"
+
+			err := ValidateConfigMapUpdate(oldConfigMap, newConfigMap)
+			Expect(errors.Cause(err)).To(Equal(errInvalidConfigMap))
+			Expect(err.Error()).To(ContainSubstring(errInvalidLogLevel.Error()))
+		})
+
+		It("rejects an invalid egress mode", func() {
+			newConfigMap := oldConfigMap.DeepCopy()
+			newConfigMap.Data[egressModeKey] = "everywhere"
+
+			err := ValidateConfigMapUpdate(oldConfigMap, newConfigMap)
+			Expect(errors.Cause(err)).To(Equal(errInvalidConfigMap))
+			Expect(err.Error()).To(ContainSubstring(egressModeKey))
+		})
+
+		It("rejects values which cannot be parsed into their field type", func() {
+			newConfigMap := oldConfigMap.DeepCopy()
+			newConfigMap.Data[egressKey] = "yes please"
+			newConfigMap.Data[tracingPortKey] = "http"
+			newConfigMap.Data[tracingSamplingRateKey] = "half"
+			newConfigMap.Data[circuitBreakingKey] = "max_connections: [100]"
+
+			err := ValidateConfigMapUpdate(oldConfigMap, newConfigMap)
+			Expect(errors.Cause(err)).To(Equal(errInvalidConfigMap))
+			Expect(err.Error()).To(ContainSubstring("has 4 problem(s)"))
+			for _, key := range []string{egressKey, tracingPortKey, tracingSamplingRateKey, circuitBreakingKey} {
+				Expect(err.Error()).To(ContainSubstring(key))
+			}
+		})
+
+		It("rejects an update with several problems at once", func() {
+			newConfigMap := oldConfigMap.DeepCopy()
+			newConfigMap.Data[egressKey] = "true"
+			newConfigMap.Data[meshCIDRRangesKey] = "10.0.0.0/100"
+			newConfigMap.Data[envoyRequestTimeoutKey] = "soon"
+
+			err := ValidateConfigMapUpdate(oldConfigMap, newConfigMap)
+			Expect(errors.Cause(err)).To(Equal(errInvalidConfigMap))
+			Expect(err.Error()).To(ContainSubstring("has 3 problem(s)"))
+			Expect(err.Error()).To(ContainSubstring(errInvalidCIDR.Error()))
+			Expect(err.Error()).To(ContainSubstring(errNoValidMeshCIDRRanges.Error()))
+			Expect(err.Error()).To(ContainSubstring(errInvalidDuration.Error()))
+		})
+
+		It("rejects a missing ConfigMap", func() {
+			Expect(errors.Cause(ValidateConfigMapUpdate(oldConfigMap, nil))).To(Equal(errInvalidConfigMap))
+		})
+	})
+
+	Context("validate the OSM config from the ConfigMap", func() {
+		kubeClient := testclient.NewSimpleClientset()
+		stop := make(chan struct{})
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		cfg := newConfigurator(kubeClient, stop, osmNamespace, osmConfigMapName)
+
+		It("reports the errors of the ConfigMap without modifying the config", func() {
+			configMap := v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					envoyLogLevel:          "verbose",
+					tracingPortKey:         "70000",
+					envoyRequestTimeoutKey: "never",
+				},
+			}
+			_, err := kubeClient.CoreV1().ConfigMaps(osmNamespace).Create(context.TODO(), &configMap, metav1.CreateOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			// Wait for the config map change to propagate to the cache.
+			log.Info().Msg("Waiting for announcement")
+			<-cfg.GetAnnouncementsChannel()
+
+			expectedConfig := cfg.getConfigMap().deepCopy()
+			Expect(errorCauses(cfg.ValidateConfig())).To(ConsistOf(errInvalidLogLevel, errInvalidPort, errInvalidDuration))
+			Expect(cfg.getConfigMap().deepCopy()).To(Equal(expectedConfig))
+		})
+	})
+})
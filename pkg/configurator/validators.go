@@ -0,0 +1,57 @@
+package configurator
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ConfigValidator is a custom validation rule of the OSM config, such as an organization policy, returning an error
+// when the config breaks the rule
+type ConfigValidator func(MeshConfig) error
+
+// WithValidators registers the validators before the config is seeded on startup, so the ConfigMap found on startup is
+// validated too: when a validator rejects it, the default config is used until a revision it accepts is applied, and the
+// error of the validator is returned by GetLastConfigError.
+func WithValidators(validators ...ConfigValidator) Option {
+	return func(c *Client) {
+		for _, validator := range validators {
+			c.RegisterValidator(validator)
+		}
+	}
+}
+
+// RegisterValidator registers a validator run, in registration order, on the config of every ConfigMap revision before
+// it is applied, once merged over the default config. A revision rejected by a validator is not applied, keeping the
+// previous config, and the error of the validator is returned by GetLastConfigError. The validators only apply to the
+// revisions handled after their registration, so WithValidators registers those the config found on startup must pass;
+// they must not block, and a panicking validator rejects the revision.
+func (c *Client) RegisterValidator(validator ConfigValidator) {
+	c.validatorsLock.Lock()
+	defer c.validatorsLock.Unlock()
+	c.validators = append(c.validators, validator)
+}
+
+// runValidators returns the error of the first registered validator rejecting the config, or nil when none rejects it.
+// Each validator is given a copy of the config, so it cannot modify the config about to be cached.
+func (c *Client) runValidators(config *MeshConfig) error {
+	c.validatorsLock.Lock()
+	validators := c.validators
+	c.validatorsLock.Unlock()
+
+	for _, validator := range validators {
+		if err := c.runValidator(validator, config.deepCopy()); err != nil {
+			return errors.Wrapf(err, "ConfigMap %s rejected by a config validator", c.getConfigMapCacheKey())
+		}
+	}
+	return nil
+}
+
+// runValidator runs the validator on the config, turning a panic of the validator into an error
+func (c *Client) runValidator(validator ConfigValidator, config MeshConfig) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().Msgf("Recovered from panic in config validator for ConfigMap %s: %v", c.getConfigMapCacheKey(), r)
+			err = errors.Wrapf(errValidatorPanicked, "%v", r)
+		}
+	}()
+	return validator(config)
+}
@@ -0,0 +1,191 @@
+package configurator
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
+)
+
+var _ = Describe("Test the custom validators of the OSM config", func() {
+	errPermissiveModeForbidden := errors.New("permissive traffic policy mode is forbidden in production")
+	forbidPermissiveMode := func(config MeshConfig) error {
+		if config.PermissiveTrafficPolicyMode {
+			return errPermissiveModeForbidden
+		}
+		return nil
+	}
+
+	Context("reject the ConfigMap revisions a validator rejects", func() {
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+		configMap := v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       osmNamespace,
+				Name:            osmConfigMapName,
+				ResourceVersion: "1",
+			},
+			Data: map[string]string{
+				permissiveTrafficPolicyModeKey: "false",
+				egressKey:                      "true",
+			},
+		}
+
+		// The informer never runs: the ConfigMap revisions are stored in the cache and handled by the test
+		cfg := newClient(osmNamespace, osmConfigMapName)
+		cfg.cache = cache.NewStore(cache.MetaNamespaceKeyFunc)
+		close(cfg.cacheSynced)
+		cfg.RegisterValidator(forbidPermissiveMode)
+
+		It("applies the ConfigMap the validator accepts", func() {
+			Expect(cfg.cache.Add(&configMap)).To(Succeed())
+			cfg.handleConfigMapEvent(k8s.Event{Type: k8s.CreateEvent, Value: &configMap})
+
+			Eventually(cfg.GetAnnouncementsChannel()).Should(Receive())
+			Expect(cfg.GetLastConfigError()).ToNot(HaveOccurred())
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+			Expect(cfg.IsPermissiveTrafficPolicyMode()).To(BeFalse())
+		})
+
+		It("rejects the ConfigMap enabling permissive mode, keeping the previous config", func() {
+			permissiveConfigMap := configMap.DeepCopy()
+			permissiveConfigMap.ResourceVersion = "2"
+			permissiveConfigMap.Data[permissiveTrafficPolicyModeKey] = "true"
+			permissiveConfigMap.Data[egressKey] = "false"
+			Expect(cfg.cache.Update(permissiveConfigMap)).To(Succeed())
+			cfg.handleConfigMapEvent(k8s.Event{Type: k8s.UpdateEvent, Value: permissiveConfigMap})
+
+			Expect(cfg.GetAnnouncementsChannel()).ToNot(Receive())
+			Expect(errors.Is(cfg.GetLastConfigError(), errPermissiveModeForbidden)).To(BeTrue())
+			Expect(cfg.IsPermissiveTrafficPolicyMode()).To(BeFalse())
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+			Expect(cfg.GetConfigResourceVersion()).To(Equal("1"))
+			Expect(testutil.ToFloat64(cfg.metrics.rejections)).To(Equal(1.0))
+		})
+
+		It("clears the error once a ConfigMap the validator accepts is applied", func() {
+			fixedConfigMap := configMap.DeepCopy()
+			fixedConfigMap.ResourceVersion = "3"
+			fixedConfigMap.Data[egressKey] = "false"
+			Expect(cfg.cache.Update(fixedConfigMap)).To(Succeed())
+			cfg.handleConfigMapEvent(k8s.Event{Type: k8s.UpdateEvent, Value: fixedConfigMap})
+
+			Eventually(cfg.GetAnnouncementsChannel()).Should(Receive())
+			Expect(cfg.GetLastConfigError()).ToNot(HaveOccurred())
+			Expect(cfg.IsEgressEnabled()).To(BeFalse())
+			Expect(cfg.GetConfigResourceVersion()).To(Equal("3"))
+		})
+	})
+
+	Context("validate the config found on startup", func() {
+		osmNamespace := "-test-osm-namespace-"
+		osmConfigMapName := "-test-osm-config-map-"
+
+		It("uses the default config when a validator rejects the ConfigMap found on startup", func() {
+			configMap := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       osmNamespace,
+					Name:            osmConfigMapName,
+					ResourceVersion: "1",
+				},
+				Data: map[string]string{
+					permissiveTrafficPolicyModeKey: "true",
+					egressKey:                      "true",
+				},
+			}
+			stop := make(chan struct{})
+			defer close(stop)
+			cfg := newConfigurator(testclient.NewSimpleClientset(configMap), stop, osmNamespace, osmConfigMapName, WithValidators(forbidPermissiveMode))
+
+			Expect(errors.Is(cfg.GetLastConfigError(), errPermissiveModeForbidden)).To(BeTrue())
+			Expect(cfg.IsPermissiveTrafficPolicyMode()).To(BeFalse())
+			Expect(cfg.IsEgressEnabled()).To(BeFalse())
+			Expect(cfg.GetConfigResourceVersion()).To(BeEmpty())
+			Expect(testutil.ToFloat64(cfg.metrics.rejections)).To(Equal(1.0))
+		})
+
+		It("uses the default config when a validator rejects the config file found on startup", func() {
+			dir, err := ioutil.TempDir("", "osm-config")
+			Expect(err).ToNot(HaveOccurred())
+			path := filepath.Join(dir, "osm-config.json")
+			Expect(ioutil.WriteFile(path, []byte(`{"permissive_traffic_policy_mode": true, "egress": true}`), 0600)).To(Succeed())
+			stop := make(chan struct{})
+			defer close(stop)
+			cfg := NewFileConfigurator(path, stop, WithValidators(forbidPermissiveMode))
+
+			Expect(errors.Is(cfg.GetLastConfigError(), errPermissiveModeForbidden)).To(BeTrue())
+			Expect(cfg.IsPermissiveTrafficPolicyMode()).To(BeFalse())
+			Expect(cfg.IsEgressEnabled()).To(BeFalse())
+		})
+
+		It("seeds the config found on startup when the validators accept it", func() {
+			configMap := &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: osmNamespace,
+					Name:      osmConfigMapName,
+				},
+				Data: map[string]string{
+					egressKey: "true",
+				},
+			}
+			stop := make(chan struct{})
+			defer close(stop)
+			cfg := NewConfigurator(testclient.NewSimpleClientset(configMap), stop, osmNamespace, osmConfigMapName, WithValidators(forbidPermissiveMode))
+
+			Expect(cfg.GetLastConfigError()).ToNot(HaveOccurred())
+			Expect(cfg.IsEgressEnabled()).To(BeTrue())
+		})
+	})
+
+	Context("run the registered validators", func() {
+		It("applies the config only when every validator accepts it", func() {
+			cfg := newClient("-test-osm-namespace-", "-test-osm-config-map-")
+			var validated []string
+			cfg.RegisterValidator(func(MeshConfig) error {
+				validated = append(validated, "first")
+				return nil
+			})
+			cfg.RegisterValidator(func(config MeshConfig) error {
+				validated = append(validated, "second")
+				return forbidPermissiveMode(config)
+			})
+
+			Expect(cfg.runValidators(&MeshConfig{})).To(Succeed())
+			Expect(validated).To(Equal([]string{"first", "second"}))
+
+			err := cfg.runValidators(&MeshConfig{PermissiveTrafficPolicyMode: true})
+			Expect(errors.Is(err, errPermissiveModeForbidden)).To(BeTrue())
+		})
+
+		It("does not let a validator modify the config", func() {
+			cfg := newClient("-test-osm-namespace-", "-test-osm-config-map-")
+			cfg.RegisterValidator(func(config MeshConfig) error {
+				config.StatsTags["mesh"] = "modified"
+				return nil
+			})
+
+			config := &MeshConfig{StatsTags: map[string]string{"mesh": "osm"}}
+			Expect(cfg.runValidators(config)).To(Succeed())
+			Expect(config.StatsTags).To(Equal(map[string]string{"mesh": "osm"}))
+		})
+
+		It("rejects the config when a validator panics", func() {
+			cfg := newClient("-test-osm-namespace-", "-test-osm-config-map-")
+			cfg.RegisterValidator(func(MeshConfig) error {
+				panic("validator bug")
+			})
+
+			Expect(errors.Is(cfg.runValidators(&MeshConfig{}), errValidatorPanicked)).To(BeTrue())
+		})
+	})
+})
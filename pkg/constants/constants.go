@@ -48,6 +48,9 @@ const (
 	// EnvoyTracingCluster is the default name to refer to the tracing cluster.
 	EnvoyTracingCluster = "envoy-tracing-cluster"
 
+	// EnvoyInboundExternalAuthCluster is the cluster name of the external authorization service of the inbound requests
+	EnvoyInboundExternalAuthCluster = "envoy-inbound-ext-authz-cluster"
+
 	// DefaultTracingEndpoint is the default endpoint route.
 	DefaultTracingEndpoint = "/api/v2/spans"
 
@@ -57,9 +60,49 @@ const (
 	// DefaultTracingPort is the tracing listener port.
 	DefaultTracingPort = uint32(9411)
 
+	// DefaultTracingBackend is the default type of the tracing collector.
+	DefaultTracingBackend = "zipkin"
+
 	// DefaultEnvoyLogLevel is the default envoy log level if not defined in the osm configmap
 	DefaultEnvoyLogLevel = "debug"
 
+	// DefaultEnvoyConnectionIdleTimeout is the default duration after which Envoy closes idle connections
+	DefaultEnvoyConnectionIdleTimeout = 1 * time.Hour
+
+	// DefaultCircuitBreakingMaxConnections is Envoy's default maximum number of connections to an upstream cluster
+	DefaultCircuitBreakingMaxConnections = uint32(1024)
+
+	// DefaultCircuitBreakingMaxPendingRequests is Envoy's default maximum number of pending requests to an upstream cluster
+	DefaultCircuitBreakingMaxPendingRequests = uint32(1024)
+
+	// DefaultCircuitBreakingMaxRequests is Envoy's default maximum number of parallel requests to an upstream cluster
+	DefaultCircuitBreakingMaxRequests = uint32(1024)
+
+	// DefaultCircuitBreakingMaxRetries is Envoy's default maximum number of parallel retries to an upstream cluster
+	DefaultCircuitBreakingMaxRetries = uint32(3)
+
+	// DefaultRetryOn is the default comma separated list of conditions on which Envoy retries requests,
+	// when a default retry policy is configured without any valid condition
+	DefaultRetryOn = "connect-failure,refused-stream,reset"
+
+	// DefaultServiceCertValidityDuration is the default validity duration of the service certificates
+	DefaultServiceCertValidityDuration = 24 * time.Hour
+
+	// DefaultTrustDomain is the default trust domain of the mesh, which the common names of the service certificates end with
+	DefaultTrustDomain = "cluster.local"
+
+	// DefaultEnvoyImage is the default image of the Envoy sidecars
+	DefaultEnvoyImage = "envoyproxy/envoy-alpine:v1.15.0"
+
+	// DefaultInitContainerImage is the default image of the init containers programming the iptables redirection
+	DefaultInitContainerImage = "openservicemesh/init:v0.3.0"
+
+	// MinServiceCertValidityDuration is the shortest validity duration of the service certificates which can be configured
+	MinServiceCertValidityDuration = 5 * time.Minute
+
+	// DefaultEnvoyRequestTimeout is the default duration after which Envoy times out requests; it matches Envoy's own default
+	DefaultEnvoyRequestTimeout = 15 * time.Second
+
 	// EnvoyPrometheusInboundListenerPort is Envoy's inbound listener port number for prometheus
 	EnvoyPrometheusInboundListenerPort = 15010
 
@@ -136,4 +179,10 @@ const (
 
 	// SidecarInjectionAnnotation is the annotation used for sidecar injection
 	SidecarInjectionAnnotation = "openservicemesh.io/sidecar-injection"
+
+	// EgressAnnotation is the namespace annotation overriding whether egress is enabled for the pods of the namespace
+	EgressAnnotation = "openservicemesh.io/egress"
+
+	// EnvoyLogLevelAnnotation is the namespace annotation overriding the log level of the Envoy proxies of the namespace
+	EnvoyLogLevelAnnotation = "openservicemesh.io/envoy-log-level"
 )
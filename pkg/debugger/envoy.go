@@ -19,7 +19,7 @@ func (ds debugServer) getEnvoyConfig(pod *v1.Pod, cn certificate.CommonName, url
 	portFwdRequest := portForward{
 		Pod:       pod,
 		LocalPort: rand.Intn(maxPort-minPort) + minPort,
-		PodPort:   15000,
+		PodPort:   int(ds.configurator.GetEnvoyAdminPort()),
 		Stop:      make(chan struct{}),
 		Ready:     make(chan struct{}),
 	}
@@ -24,7 +24,7 @@ type policies struct {
 
 func (ds debugServer) getOSMConfigHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		confJSON, err := ds.configurator.GetConfigMap()
+		confJSON, err := ds.configurator.GetRedactedConfigMap()
 		if err != nil {
 			log.Error().Err(err)
 			return
@@ -33,6 +33,18 @@ func (ds debugServer) getOSMConfigHandler() http.Handler {
 	})
 }
 
+func (ds debugServer) getOSMConfigProvenanceHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provenance := ds.configurator.GetConfigProvenance()
+		provenanceJSON, err := json.MarshalIndent(provenance, "", "    ")
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling config provenance %+v", provenance)
+			return
+		}
+		_, _ = fmt.Fprint(w, string(provenanceJSON))
+	})
+}
+
 func (ds debugServer) getSMIPoliciesHandler() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var p policies
@@ -12,12 +12,13 @@ import (
 // GetHandlers implements DebugServer interface and returns the rest of URLs and the handling functions.
 func (ds debugServer) GetHandlers() map[string]http.Handler {
 	handlers := map[string]http.Handler{
-		"/debug/certs":      ds.getCertHandler(),
-		"/debug/xds":        ds.getXDSHandler(),
-		"/debug/proxy":      ds.getProxies(),
-		"/debug/policies":   ds.getSMIPoliciesHandler(),
-		"/debug/config":     ds.getOSMConfigHandler(),
-		"/debug/namespaces": ds.getMonitoredNamespacesHandler(),
+		"/debug/certs":             ds.getCertHandler(),
+		"/debug/xds":               ds.getXDSHandler(),
+		"/debug/proxy":             ds.getProxies(),
+		"/debug/policies":          ds.getSMIPoliciesHandler(),
+		"/debug/config":            ds.getOSMConfigHandler(),
+		"/debug/config/provenance": ds.getOSMConfigProvenanceHandler(),
+		"/debug/namespaces":        ds.getMonitoredNamespacesHandler(),
 	}
 
 	// provides an index of the available /debug endpoints
@@ -7,3 +7,5 @@ import (
 var errUnknownTypeURL = errors.New("unknown TypeUrl")
 var errCreatingResponse = errors.New("creating response")
 var errGrpcClosed = errors.New("grpc closed")
+var errTooManyConnections = errors.New("too many connections")
+var errSendTimeout = errors.New("timed out sending response")
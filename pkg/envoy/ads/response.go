@@ -6,18 +6,25 @@ import (
 	"time"
 
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/pkg/errors"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
 )
 
-func (s *Server) sendAllResponses(proxy *envoy.Proxy, server *xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer, cfg configurator.Configurator) {
+// sendAllResponses sends all the xDS responses to the proxy, and returns errSendTimeout when a response could not be
+// sent within the xDS server response timeout, in which case the remaining responses are not sent
+func (s *Server) sendAllResponses(proxy *envoy.Proxy, server *xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer, cfg configurator.Configurator) error {
 	log.Trace().Msgf("A change announcement triggered *DS update for proxy with CN=%s", proxy.GetCommonName())
 	// Order is important: CDS, EDS, LDS, RDS
 	// See: https://github.com/envoyproxy/go-control-plane/issues/59
 	for idx, typeURI := range envoy.XDSResponseOrder {
 		prefix := fmt.Sprintf("[*DS %d/%d]", idx+1, len(envoy.XDSResponseOrder))
+		if isXDSTypeDisabled(typeURI, cfg) {
+			log.Debug().Msgf("%s Skipping disabled %s response for proxy with CN=%s", prefix, typeURI, proxy.GetCommonName())
+			continue
+		}
 		log.Trace().Msgf("%s Creating %s response for proxy with CN=%s", prefix, typeURI, proxy.GetCommonName())
 
 		// For SDS we need to add ResourceNames
@@ -36,10 +43,37 @@ func (s *Server) sendAllResponses(proxy *envoy.Proxy, server *xds_discovery.Aggr
 			log.Error().Err(err).Msgf("%s Failed to create %s discovery response for proxy with CN=%s", prefix, typeURI, proxy.GetCommonName())
 			continue
 		}
-		if err := (*server).Send(discoveryResponse); err != nil {
+		if err := s.sendResponse(*server, discoveryResponse); err != nil {
 			log.Error().Err(err).Msgf("%s Error sending %s to proxy with CN=%s", prefix, typeURI, proxy.GetCommonName())
+			if errors.Is(err, errSendTimeout) {
+				return err
+			}
 		}
 	}
+	return nil
+}
+
+// isXDSTypeDisabled returns whether the xDS type is disabled in the OSM config, in which case it is not sent to the proxies
+func isXDSTypeDisabled(typeURI envoy.TypeURI, cfg configurator.Configurator) bool {
+	shortName, ok := xdsShortNames[typeURI]
+	return ok && cfg.GetDisabledXDSTypes()[shortName]
+}
+
+// sendResponse sends the response on the stream, and returns errSendTimeout when it is not sent within the
+// xDS server response timeout. The send then keeps blocking, so the caller must close the stream.
+func (s *Server) sendResponse(server xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer, response *xds_discovery.DiscoveryResponse) error {
+	responseTimeout := s.cfg.GetXDSServerResponseTimeout()
+	sent := make(chan error, 1)
+	go func() {
+		sent <- server.Send(response)
+	}()
+
+	select {
+	case err := <-sent:
+		return err
+	case <-time.After(responseTimeout):
+		return errors.Wrapf(errSendTimeout, "%s response not sent within %s", response.TypeUrl, responseTimeout)
+	}
 }
 
 // makeRequestForAllSecrets constructs an SDS request AS IF an Envoy proxy sent it.
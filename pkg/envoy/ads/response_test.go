@@ -9,6 +9,7 @@ import (
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testclient "k8s.io/client-go/kubernetes/fake"
 
@@ -105,16 +106,35 @@ var _ = Describe("Test ADS response functions", func() {
 		cert, _ := certificate.DecodePEMCertificate(certPEM.GetCertificateChain())
 		server, actualResponses := tests.NewFakeXDSServer(cert, nil, nil)
 
-		mockConfigurator.EXPECT().IsEgressEnabled().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().IsEgressEnabledForChannel(configurator.PrimaryConfigChannel).Return(false).AnyTimes()
 		mockConfigurator.EXPECT().IsPrometheusScrapingEnabled().Return(false).AnyTimes()
 		mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).AnyTimes()
+		mockConfigurator.EXPECT().StripForwardedHeaders().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().GetDefaultRetryPolicy().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetStatsPrefix().Return("").AnyTimes()
+		mockConfigurator.EXPECT().IsAccessLoggingEnabled().Return(true).AnyTimes()
+		mockConfigurator.EXPECT().GetAccessLogFormat().Return(configurator.AccessLogFormatJSON).AnyTimes()
+		mockConfigurator.EXPECT().GetDefaultCircuitBreaking().Return(configurator.CircuitBreaking{}).AnyTimes()
+		mockConfigurator.EXPECT().GetOutlierDetection().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetDefaultLBAlgorithm().Return(configurator.LBAlgorithmRoundRobin).AnyTimes()
+		mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetWASMExtensions().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetXDSServerResponseTimeout().Return(time.Minute).AnyTimes()
+		mockConfigurator.EXPECT().GetDisabledXDSTypes().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetTrustDomain().Return(constants.DefaultTrustDomain).AnyTimes()
+		mockConfigurator.EXPECT().GetMeshTLSVersions().Return("TLS1_2", "TLS1_3").AnyTimes()
+		mockConfigurator.EXPECT().GetMeshCipherSuites().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().IsPermissiveAuditLoggingEnabled().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().GetMTLSExemptSourceCIDRs().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetMaxEndpointsPerCluster().Return(uint32(0)).AnyTimes()
 
 		It("returns Aggregated Discovery Service response", func() {
 			s := NewADSServer(mc, true, tests.Namespace, mockConfigurator)
 
 			Expect(s).ToNot(BeNil())
 
-			s.sendAllResponses(proxy, &server, mockConfigurator)
+			Expect(s.sendAllResponses(proxy, &server, mockConfigurator)).To(Succeed())
 
 			Expect(actualResponses).ToNot(BeNil())
 			Expect(len(*actualResponses)).To(Equal(5))
@@ -169,4 +189,104 @@ var _ = Describe("Test ADS response functions", func() {
 			}.String()))
 		})
 	})
+
+	Context("Test sendAllResponses() with disabled xDS types", func() {
+		cache := make(map[certificate.CommonName]certificate.Certificater)
+		certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
+		cn := certificate.CommonName(fmt.Sprintf("%s.%s.%s", uuid.New(), serviceAccountName, tests.Namespace))
+		certPEM, _ := certManager.IssueCertificate(cn, nil)
+		cert, _ := certificate.DecodePEMCertificate(certPEM.GetCertificateChain())
+		server, actualResponses := tests.NewFakeXDSServer(cert, nil, nil)
+
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+		mockConfigurator.EXPECT().IsEgressEnabledForChannel(configurator.PrimaryConfigChannel).Return(false).AnyTimes()
+		mockConfigurator.EXPECT().IsPrometheusScrapingEnabled().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).AnyTimes()
+		mockConfigurator.EXPECT().StripForwardedHeaders().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().GetDefaultRetryPolicy().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetStatsPrefix().Return("").AnyTimes()
+		mockConfigurator.EXPECT().IsAccessLoggingEnabled().Return(true).AnyTimes()
+		mockConfigurator.EXPECT().GetAccessLogFormat().Return(configurator.AccessLogFormatJSON).AnyTimes()
+		mockConfigurator.EXPECT().GetDefaultCircuitBreaking().Return(configurator.CircuitBreaking{}).AnyTimes()
+		mockConfigurator.EXPECT().GetOutlierDetection().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetDefaultLBAlgorithm().Return(configurator.LBAlgorithmRoundRobin).AnyTimes()
+		mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetWASMExtensions().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetXDSServerResponseTimeout().Return(time.Minute).AnyTimes()
+		mockConfigurator.EXPECT().GetDisabledXDSTypes().Return(map[string]bool{"RDS": true}).AnyTimes()
+		mockConfigurator.EXPECT().GetTrustDomain().Return(constants.DefaultTrustDomain).AnyTimes()
+		mockConfigurator.EXPECT().GetMeshTLSVersions().Return("TLS1_2", "TLS1_3").AnyTimes()
+		mockConfigurator.EXPECT().GetMeshCipherSuites().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().IsPermissiveAuditLoggingEnabled().Return(false).AnyTimes()
+		mockConfigurator.EXPECT().GetMTLSExemptSourceCIDRs().Return(nil).AnyTimes()
+		mockConfigurator.EXPECT().GetMaxEndpointsPerCluster().Return(uint32(0)).AnyTimes()
+
+		It("does not send the responses of the disabled xDS types", func() {
+			s := NewADSServer(mc, true, tests.Namespace, mockConfigurator)
+			proxy := envoy.NewProxy(cn, nil)
+
+			Expect(s.sendAllResponses(proxy, &server, mockConfigurator)).To(Succeed())
+
+			var typeURLs []string
+			for _, response := range *actualResponses {
+				typeURLs = append(typeURLs, response.TypeUrl)
+			}
+			Expect(typeURLs).To(Equal([]string{string(envoy.TypeCDS), string(envoy.TypeEDS), string(envoy.TypeLDS), string(envoy.TypeSDS)}))
+		})
+	})
+
+	Context("Test isXDSTypeDisabled()", func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+		mockConfigurator.EXPECT().GetDisabledXDSTypes().Return(map[string]bool{"RDS": true, "SDS": true}).AnyTimes()
+
+		It("returns whether the xDS type is disabled", func() {
+			Expect(isXDSTypeDisabled(envoy.TypeRDS, mockConfigurator)).To(BeTrue())
+			Expect(isXDSTypeDisabled(envoy.TypeSDS, mockConfigurator)).To(BeTrue())
+			Expect(isXDSTypeDisabled(envoy.TypeCDS, mockConfigurator)).To(BeFalse())
+			Expect(isXDSTypeDisabled(envoy.TypeZipkinConfig, mockConfigurator)).To(BeFalse())
+		})
+	})
+
+	Context("Test sendResponse()", func() {
+		response := &xds_discovery.DiscoveryResponse{TypeUrl: string(envoy.TypeCDS)}
+
+		It("sends the response within the response timeout", func() {
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetXDSServerResponseTimeout().Return(time.Minute).Times(1)
+			s := NewADSServer(mc, true, tests.Namespace, mockConfigurator)
+			server, actualResponses := tests.NewFakeXDSServer(nil, nil, nil)
+
+			Expect(s.sendResponse(server, response)).To(Succeed())
+			Expect(*actualResponses).To(Equal([]*xds_discovery.DiscoveryResponse{response}))
+		})
+
+		It("times out when the response cannot be sent within the response timeout", func() {
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetXDSServerResponseTimeout().Return(10 * time.Millisecond).Times(1)
+			s := NewADSServer(mc, true, tests.Namespace, mockConfigurator)
+			server := blockedXDSServer{unblock: make(chan struct{})}
+			defer close(server.unblock)
+
+			err := s.sendResponse(server, response)
+			Expect(errors.Is(err, errSendTimeout)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(string(envoy.TypeCDS)))
+		})
+	})
 })
+
+// blockedXDSServer is a stream whose Send blocks until unblock is closed, as when the proxy does not read its responses
+type blockedXDSServer struct {
+	xds_discovery.AggregatedDiscoveryService_StreamAggregatedResourcesServer
+	unblock chan struct{}
+}
+
+// Send implements AggregatedDiscoveryService_StreamAggregatedResourcesServer
+func (s blockedXDSServer) Send(*xds_discovery.DiscoveryResponse) error {
+	<-s.unblock
+	return nil
+}
@@ -46,7 +46,8 @@ func NewADSServer(meshCatalog catalog.MeshCataloger, enableDebug bool, osmNamesp
 
 // Start starts the ADS server
 func (s *Server) Start(ctx context.Context, cancel context.CancelFunc, port int, adsCert certificate.Certificater) {
-	grpcServer, lis := utils.NewGrpc(ServerType, port, adsCert.GetCertificateChain(), adsCert.GetPrivateKey(), adsCert.GetIssuingCA())
+	grpcServer, lis := utils.NewGrpc(ServerType, port, adsCert.GetCertificateChain(), adsCert.GetPrivateKey(), adsCert.GetIssuingCA(),
+		s.cfg.GetXDSKeepaliveTime(), s.cfg.GetXDSKeepaliveTimeout())
 	xds_discovery.RegisterAggregatedDiscoveryServiceServer(grpcServer, s)
 
 	go utils.GrpcServe(ctx, grpcServer, lis, cancel, ServerType)
@@ -3,6 +3,7 @@ package ads
 import (
 	"context"
 	"strconv"
+	"sync/atomic"
 
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/pkg/errors"
@@ -20,10 +21,14 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 		return errors.Wrap(err, "[%s] Could not start stream")
 	}
 
-	// TODO(draychev): check for envoy.ErrTooManyConnections
-
 	ip := utils.GetIPFromContext(server.Context())
 
+	if !s.acquireConnection() {
+		log.Error().Err(errTooManyConnections).Msgf("Rejecting Envoy %s with CN %s; Reached the maximum of %d data plane connections", ip, cn, s.cfg.GetMaxDataPlaneConnections())
+		return errTooManyConnections
+	}
+	defer s.releaseConnection()
+
 	svcList, err := s.catalog.GetServicesFromEnvoyCertificate(cn)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error fetching service for Envoy %s with CN %s", ip, cn)
@@ -134,19 +139,46 @@ func (s *Server) StreamAggregatedResources(server xds_discovery.AggregatedDiscov
 			}
 			log.Info().Msgf("Received discovery request <%s> from Envoy <%s> with Nonce=%s", discoveryRequest.TypeUrl, proxy, discoveryRequest.ResponseNonce)
 
+			if isXDSTypeDisabled(typeURL, s.cfg) {
+				log.Debug().Msgf("Ignoring discovery request <%s> from Envoy <%s>; the xDS type is disabled", discoveryRequest.TypeUrl, proxy)
+				continue
+			}
+
 			resp, err := s.newAggregatedDiscoveryResponse(proxy, &discoveryRequest, s.cfg)
 			if err != nil {
 				log.Error().Err(err).Msgf("Error composing a DiscoveryResponse")
 				continue
 			}
 
-			if err := server.Send(resp); err != nil {
+			if err := s.sendResponse(server, resp); err != nil {
 				log.Error().Err(err).Msgf("Error sending DiscoveryResponse")
+				if errors.Is(err, errSendTimeout) {
+					return err
+				}
 			}
 
 		case <-proxy.GetAnnouncementsChannel():
 			log.Info().Msgf("Change detected - update all Envoys.")
-			s.sendAllResponses(proxy, &server, s.cfg)
+			if err := s.sendAllResponses(proxy, &server, s.cfg); err != nil {
+				log.Error().Err(err).Msgf("Closing the stream of Envoy %s", proxy.GetCommonName())
+				return err
+			}
 		}
 	}
 }
+
+// acquireConnection counts a newly connected proxy, and returns false without counting it when the connection
+// would exceed the maximum number of data plane connections
+func (s *Server) acquireConnection() bool {
+	connections := atomic.AddInt64(&s.connections, 1)
+	if maxConnections := s.cfg.GetMaxDataPlaneConnections(); maxConnections > 0 && connections > int64(maxConnections) {
+		atomic.AddInt64(&s.connections, -1)
+		return false
+	}
+	return true
+}
+
+// releaseConnection stops counting a disconnected proxy
+func (s *Server) releaseConnection() {
+	atomic.AddInt64(&s.connections, -1)
+}
@@ -0,0 +1,42 @@
+package ads
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+var _ = Describe("Test ADS data plane connection limit", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockConfigurator *configurator.MockConfigurator
+	)
+
+	mockCtrl = gomock.NewController(GinkgoT())
+	mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+
+	Context("Test acquireConnection and releaseConnection", func() {
+		It("accepts any number of connections when the maximum is unlimited", func() {
+			mockConfigurator.EXPECT().GetMaxDataPlaneConnections().Return(0).Times(3)
+			s := NewADSServer(nil, false, "osm-system", mockConfigurator)
+
+			Expect(s.acquireConnection()).To(BeTrue())
+			Expect(s.acquireConnection()).To(BeTrue())
+			Expect(s.acquireConnection()).To(BeTrue())
+		})
+
+		It("rejects the connections beyond the maximum until a connection is released", func() {
+			mockConfigurator.EXPECT().GetMaxDataPlaneConnections().Return(2).Times(4)
+			s := NewADSServer(nil, false, "osm-system", mockConfigurator)
+
+			Expect(s.acquireConnection()).To(BeTrue())
+			Expect(s.acquireConnection()).To(BeTrue())
+			Expect(s.acquireConnection()).To(BeFalse())
+
+			s.releaseConnection()
+			Expect(s.acquireConnection()).To(BeTrue())
+		})
+	})
+})
@@ -14,6 +14,15 @@ import (
 
 var (
 	log = logger.New("envoy/ads")
+
+	// xdsShortNames maps the xDS type URIs to the short names the xDS types are disabled by in the OSM config
+	xdsShortNames = map[envoy.TypeURI]string{
+		envoy.TypeCDS: "CDS",
+		envoy.TypeEDS: "EDS",
+		envoy.TypeLDS: "LDS",
+		envoy.TypeRDS: "RDS",
+		envoy.TypeSDS: "SDS",
+	}
 )
 
 // Server implements the Envoy xDS Aggregate Discovery Services
@@ -25,4 +34,7 @@ type Server struct {
 	osmNamespace string
 	cfg          configurator.Configurator
 	ready        bool
+
+	// connections is the number of proxies currently streaming from the server; it is accessed atomically
+	connections int64
 }
@@ -26,8 +26,10 @@ const (
 // getRemoteServiceCluster returns an Envoy Cluster corresponding to the remote service
 func getRemoteServiceCluster(remoteService, localService service.MeshService, cfg configurator.Configurator) (*xds_cluster.Cluster, error) {
 	clusterName := remoteService.String()
-	marshalledUpstreamTLSContext, err := envoy.MessageToAny(
-		envoy.GetUpstreamTLSContext(localService, remoteService.GetCommonName().String()))
+	upstreamTLSContext := envoy.GetUpstreamTLSContext(localService, remoteService.GetCommonNameForTrustDomain(cfg.GetTrustDomain()).String())
+	upstreamTLSContext.CommonTlsContext.TlsParams = envoy.GetTLSParamsForVersions(cfg.GetMeshTLSVersions())
+	upstreamTLSContext.CommonTlsContext.TlsParams.CipherSuites = cfg.GetMeshCipherSuites()
+	marshalledUpstreamTLSContext, err := envoy.MessageToAny(upstreamTLSContext)
 	if err != nil {
 		return nil, err
 	}
@@ -43,6 +45,11 @@ func getRemoteServiceCluster(remoteService, localService service.MeshService, cf
 		},
 		ProtocolSelection:    xds_cluster.Cluster_USE_DOWNSTREAM_PROTOCOL,
 		Http2ProtocolOptions: &xds_core.Http2ProtocolOptions{},
+		CommonHttpProtocolOptions: &xds_core.HttpProtocolOptions{
+			IdleTimeout: ptypes.DurationProto(cfg.GetEnvoyConnectionIdleTimeout()),
+		},
+		CircuitBreakers:  getCircuitBreakers(cfg.GetDefaultCircuitBreaking()),
+		OutlierDetection: getOutlierDetection(cfg.GetOutlierDetection()),
 	}
 
 	if cfg.IsPermissiveTrafficPolicyMode() {
@@ -53,12 +60,54 @@ func getRemoteServiceCluster(remoteService, localService service.MeshService, cf
 		// Configure service discovery based on traffic policies
 		remoteCluster.ClusterDiscoveryType = &xds_cluster.Cluster_Type{Type: xds_cluster.Cluster_EDS}
 		remoteCluster.EdsClusterConfig = &xds_cluster.Cluster_EdsClusterConfig{EdsConfig: envoy.GetADSConfigSource()}
-		remoteCluster.LbPolicy = xds_cluster.Cluster_ROUND_ROBIN
+		remoteCluster.LbPolicy = getLbPolicy(cfg.GetDefaultLBAlgorithm())
 	}
 
 	return remoteCluster, nil
 }
 
+// getLbPolicy returns the Envoy LB policy of the given load balancing algorithm, which the configurator validates
+// against the names of Envoy's LB policies
+func getLbPolicy(lbAlgorithm string) xds_cluster.Cluster_LbPolicy {
+	lbPolicy, ok := xds_cluster.Cluster_LbPolicy_value[lbAlgorithm]
+	if !ok {
+		return xds_cluster.Cluster_ROUND_ROBIN
+	}
+	return xds_cluster.Cluster_LbPolicy(lbPolicy)
+}
+
+// getCircuitBreakers returns the Envoy circuit breakers with the given thresholds for the default routing priority
+func getCircuitBreakers(circuitBreaking configurator.CircuitBreaking) *xds_cluster.CircuitBreakers {
+	return &xds_cluster.CircuitBreakers{
+		Thresholds: []*xds_cluster.CircuitBreakers_Thresholds{
+			{
+				Priority:           xds_core.RoutingPriority_DEFAULT,
+				MaxConnections:     &wrappers.UInt32Value{Value: circuitBreaking.MaxConnections},
+				MaxPendingRequests: &wrappers.UInt32Value{Value: circuitBreaking.MaxPendingRequests},
+				MaxRequests:        &wrappers.UInt32Value{Value: circuitBreaking.MaxRequests},
+				MaxRetries:         &wrappers.UInt32Value{Value: circuitBreaking.MaxRetries},
+			},
+		},
+	}
+}
+
+// getOutlierDetection returns the Envoy outlier detection of the given config, or nil when outlier detection is disabled
+func getOutlierDetection(outlierDetection *configurator.OutlierDetection) *xds_cluster.OutlierDetection {
+	if outlierDetection == nil {
+		return nil
+	}
+
+	// The durations have been validated by the configurator
+	interval, _ := time.ParseDuration(outlierDetection.Interval)
+	baseEjectionTime, _ := time.ParseDuration(outlierDetection.BaseEjectionTime)
+	return &xds_cluster.OutlierDetection{
+		Consecutive_5Xx:    &wrappers.UInt32Value{Value: outlierDetection.Consecutive5xx},
+		Interval:           ptypes.DurationProto(interval),
+		BaseEjectionTime:   ptypes.DurationProto(baseEjectionTime),
+		MaxEjectionPercent: &wrappers.UInt32Value{Value: outlierDetection.MaxEjectionPercent},
+	}
+}
+
 // getOutboundPassthroughCluster returns an Envoy cluster that is used for outbound passthrough traffic
 func getOutboundPassthroughCluster() *xds_cluster.Cluster {
 	return &xds_cluster.Cluster{
@@ -73,6 +122,42 @@ func getOutboundPassthroughCluster() *xds_cluster.Cluster {
 	}
 }
 
+// egressClusterDiscoveryTypes maps the egress DNS resolutions to the discovery types of the egress clusters
+var egressClusterDiscoveryTypes = map[string]xds_cluster.Cluster_DiscoveryType{
+	configurator.EgressDNSResolutionStrictDNS:  xds_cluster.Cluster_STRICT_DNS,
+	configurator.EgressDNSResolutionLogicalDNS: xds_cluster.Cluster_LOGICAL_DNS,
+	configurator.EgressDNSResolutionStatic:     xds_cluster.Cluster_STATIC,
+}
+
+// getEgressCluster returns the egress cluster of the given external domain, resolved with the given egress DNS resolution
+func getEgressCluster(domain string, dnsResolution string) *xds_cluster.Cluster {
+	clusterName := envoy.GetEgressClusterName(domain)
+	return &xds_cluster.Cluster{
+		Name:           clusterName,
+		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
+			Type: egressClusterDiscoveryTypes[dnsResolution],
+		},
+		LbPolicy:        xds_cluster.Cluster_ROUND_ROBIN,
+		RespectDnsTtl:   true,
+		DnsLookupFamily: xds_cluster.Cluster_V4_ONLY,
+		LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
+			ClusterName: clusterName,
+			Endpoints: []*xds_endpoint.LocalityLbEndpoints{
+				{
+					LbEndpoints: []*xds_endpoint.LbEndpoint{{
+						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+							Endpoint: &xds_endpoint.Endpoint{
+								Address: envoy.GetAddress(domain, envoy.EgressTLSPort),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
 // getLocalServiceCluster returns an Envoy Cluster corresponding to the local service
 func getLocalServiceCluster(catalog catalog.MeshCataloger, proxyServiceName service.MeshService, clusterName string) (*xds_cluster.Cluster, error) {
 	xdsCluster := xds_cluster.Cluster{
@@ -126,7 +211,7 @@ func getLocalServiceCluster(catalog catalog.MeshCataloger, proxyServiceName serv
 }
 
 // getPrometheusCluster returns an Envoy Cluster responsible for scraping metrics by Prometheus
-func getPrometheusCluster() xds_cluster.Cluster {
+func getPrometheusCluster(cfg configurator.Configurator) xds_cluster.Cluster {
 	return xds_cluster.Cluster{
 		// The name must match the domain being cURLed in the demo
 		Name:           constants.EnvoyMetricsCluster,
@@ -144,7 +229,7 @@ func getPrometheusCluster() xds_cluster.Cluster {
 					LbEndpoints: []*xds_endpoint.LbEndpoint{{
 						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
 							Endpoint: &xds_endpoint.Endpoint{
-								Address: envoy.GetAddress(constants.LocalhostIPAddress, constants.EnvoyAdminPort),
+								Address: envoy.GetAddress(constants.LocalhostIPAddress, cfg.GetEnvoyAdminPort()),
 							},
 						},
 						LoadBalancingWeight: &wrappers.UInt32Value{
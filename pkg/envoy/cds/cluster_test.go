@@ -1,13 +1,19 @@
 package cds
 
 import (
+	"time"
+
 	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
@@ -22,19 +28,113 @@ var _ = Describe("Cluster configurations", func() {
 
 	localService := tests.BookbuyerService
 	remoteService := tests.BookstoreService
+	Context("Test getLbPolicy", func() {
+		It("Returns the Envoy LB policy of each load balancing algorithm", func() {
+			Expect(getLbPolicy(configurator.LBAlgorithmRoundRobin)).To(Equal(xds_cluster.Cluster_ROUND_ROBIN))
+			Expect(getLbPolicy(configurator.LBAlgorithmLeastRequest)).To(Equal(xds_cluster.Cluster_LEAST_REQUEST))
+			Expect(getLbPolicy(configurator.LBAlgorithmRingHash)).To(Equal(xds_cluster.Cluster_RING_HASH))
+			Expect(getLbPolicy(configurator.LBAlgorithmRandom)).To(Equal(xds_cluster.Cluster_RANDOM))
+			Expect(getLbPolicy(configurator.LBAlgorithmMaglev)).To(Equal(xds_cluster.Cluster_MAGLEV))
+		})
+
+		It("Returns the ROUND_ROBIN LB policy for an unknown load balancing algorithm", func() {
+			Expect(getLbPolicy("least_request")).To(Equal(xds_cluster.Cluster_ROUND_ROBIN))
+		})
+	})
+
+	Context("Test getEgressCluster", func() {
+		It("Returns a cluster of the external domain resolved with each egress DNS resolution", func() {
+			for dnsResolution, discoveryType := range map[string]xds_cluster.Cluster_DiscoveryType{
+				configurator.EgressDNSResolutionStrictDNS:  xds_cluster.Cluster_STRICT_DNS,
+				configurator.EgressDNSResolutionLogicalDNS: xds_cluster.Cluster_LOGICAL_DNS,
+				configurator.EgressDNSResolutionStatic:     xds_cluster.Cluster_STATIC,
+			} {
+				egressCluster := getEgressCluster("api.stripe.com", dnsResolution)
+				Expect(egressCluster.Name).To(Equal(envoy.GetEgressClusterName("api.stripe.com")))
+				Expect(egressCluster.GetType()).To(Equal(discoveryType), "egress DNS resolution %s", dnsResolution)
+				Expect(egressCluster.LoadAssignment.ClusterName).To(Equal(egressCluster.Name))
+				Expect(egressCluster.LoadAssignment.Endpoints[0].LbEndpoints[0].GetEndpoint().Address).To(Equal(envoy.GetAddress("api.stripe.com", envoy.EgressTLSPort)))
+			}
+		})
+	})
+
 	Context("Test getRemoteServiceCluster", func() {
 		It("Returns an EDS based cluster when permissive mode is disabled", func() {
 			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetDefaultLBAlgorithm().Return(configurator.LBAlgorithmRoundRobin).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+			mockConfigurator.EXPECT().GetDefaultCircuitBreaking().Return(configurator.CircuitBreaking{
+				MaxConnections:     100,
+				MaxPendingRequests: 10,
+				MaxRequests:        200,
+				MaxRetries:         5,
+			}).Times(1)
+			mockConfigurator.EXPECT().GetOutlierDetection().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetTrustDomain().Return(constants.DefaultTrustDomain).Times(1)
+			mockConfigurator.EXPECT().GetMeshTLSVersions().Return("TLS1_2", "TLS1_3").Times(1)
+			mockConfigurator.EXPECT().GetMeshCipherSuites().Return(nil).Times(1)
 
 			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, mockConfigurator)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(remoteCluster.GetType()).To(Equal(xds_cluster.Cluster_EDS))
+			Expect(remoteCluster.CommonHttpProtocolOptions.IdleTimeout).To(Equal(ptypes.DurationProto(constants.DefaultEnvoyConnectionIdleTimeout)))
+			Expect(remoteCluster.CircuitBreakers.Thresholds).To(HaveLen(1))
+			Expect(remoteCluster.CircuitBreakers.Thresholds[0].MaxConnections.Value).To(Equal(uint32(100)))
+			Expect(remoteCluster.CircuitBreakers.Thresholds[0].MaxPendingRequests.Value).To(Equal(uint32(10)))
+			Expect(remoteCluster.CircuitBreakers.Thresholds[0].MaxRequests.Value).To(Equal(uint32(200)))
+			Expect(remoteCluster.CircuitBreakers.Thresholds[0].MaxRetries.Value).To(Equal(uint32(5)))
 			Expect(remoteCluster.LbPolicy).To(Equal(xds_cluster.Cluster_ROUND_ROBIN))
 			Expect(remoteCluster.ProtocolSelection).To(Equal(xds_cluster.Cluster_USE_DOWNSTREAM_PROTOCOL))
+			Expect(remoteCluster.OutlierDetection).To(BeNil())
+		})
+
+		It("Sets the outlier detection of the cluster when outlier detection is enabled", func() {
+			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetDefaultLBAlgorithm().Return(configurator.LBAlgorithmRoundRobin).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+			mockConfigurator.EXPECT().GetDefaultCircuitBreaking().Return(configurator.CircuitBreaking{}).Times(1)
+			mockConfigurator.EXPECT().GetOutlierDetection().Return(&configurator.OutlierDetection{
+				Enable:             true,
+				Consecutive5xx:     3,
+				Interval:           "10s",
+				BaseEjectionTime:   "1m",
+				MaxEjectionPercent: 20,
+			}).Times(1)
+			mockConfigurator.EXPECT().GetTrustDomain().Return(constants.DefaultTrustDomain).Times(1)
+			mockConfigurator.EXPECT().GetMeshTLSVersions().Return("TLS1_2", "TLS1_3").Times(1)
+			mockConfigurator.EXPECT().GetMeshCipherSuites().Return(nil).Times(1)
+
+			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(remoteCluster.OutlierDetection.Consecutive_5Xx.Value).To(Equal(uint32(3)))
+			Expect(remoteCluster.OutlierDetection.Interval).To(Equal(ptypes.DurationProto(10 * time.Second)))
+			Expect(remoteCluster.OutlierDetection.BaseEjectionTime).To(Equal(ptypes.DurationProto(time.Minute)))
+			Expect(remoteCluster.OutlierDetection.MaxEjectionPercent.Value).To(Equal(uint32(20)))
+		})
+
+		It("Sets the LB policy of the EDS based cluster to the default load balancing algorithm", func() {
+			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetDefaultLBAlgorithm().Return(configurator.LBAlgorithmLeastRequest).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+			mockConfigurator.EXPECT().GetDefaultCircuitBreaking().Return(configurator.CircuitBreaking{}).Times(1)
+			mockConfigurator.EXPECT().GetOutlierDetection().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetTrustDomain().Return(constants.DefaultTrustDomain).Times(1)
+			mockConfigurator.EXPECT().GetMeshTLSVersions().Return("TLS1_2", "TLS1_3").Times(1)
+			mockConfigurator.EXPECT().GetMeshCipherSuites().Return(nil).Times(1)
+
+			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(remoteCluster.LbPolicy).To(Equal(xds_cluster.Cluster_LEAST_REQUEST))
 		})
 
 		It("Returns an Original Destination based cluster when permissive mode is enabled", func() {
 			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(true).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+			mockConfigurator.EXPECT().GetDefaultCircuitBreaking().Return(configurator.CircuitBreaking{}).Times(1)
+			mockConfigurator.EXPECT().GetOutlierDetection().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetTrustDomain().Return(constants.DefaultTrustDomain).Times(1)
+			mockConfigurator.EXPECT().GetMeshTLSVersions().Return("TLS1_2", "TLS1_3").Times(1)
+			mockConfigurator.EXPECT().GetMeshCipherSuites().Return(nil).Times(1)
 
 			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, mockConfigurator)
 			Expect(err).ToNot(HaveOccurred())
@@ -42,5 +142,61 @@ var _ = Describe("Cluster configurations", func() {
 			Expect(remoteCluster.LbPolicy).To(Equal(xds_cluster.Cluster_CLUSTER_PROVIDED))
 			Expect(remoteCluster.ProtocolSelection).To(Equal(xds_cluster.Cluster_USE_DOWNSTREAM_PROTOCOL))
 		})
+
+		It("Sets the SNI of the upstream TLS context to the common name of the remote service in the trust domain", func() {
+			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetDefaultLBAlgorithm().Return(configurator.LBAlgorithmRoundRobin).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+			mockConfigurator.EXPECT().GetDefaultCircuitBreaking().Return(configurator.CircuitBreaking{}).Times(1)
+			mockConfigurator.EXPECT().GetOutlierDetection().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetTrustDomain().Return("mesh.example.com").Times(1)
+			mockConfigurator.EXPECT().GetMeshTLSVersions().Return("TLS1_2", "TLS1_3").Times(1)
+			mockConfigurator.EXPECT().GetMeshCipherSuites().Return(nil).Times(1)
+
+			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			upstreamTLSContext := xds_auth.UpstreamTlsContext{}
+			Expect(ptypes.UnmarshalAny(remoteCluster.TransportSocket.GetTypedConfig(), &upstreamTLSContext)).To(Succeed())
+			Expect(upstreamTLSContext.Sni).To(Equal("bookstore.default.svc.mesh.example.com"))
+		})
+
+		It("Restricts the upstream TLS context to the mesh TLS versions", func() {
+			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetDefaultLBAlgorithm().Return(configurator.LBAlgorithmRoundRobin).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+			mockConfigurator.EXPECT().GetDefaultCircuitBreaking().Return(configurator.CircuitBreaking{}).Times(1)
+			mockConfigurator.EXPECT().GetOutlierDetection().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetTrustDomain().Return(constants.DefaultTrustDomain).Times(1)
+			mockConfigurator.EXPECT().GetMeshTLSVersions().Return("TLS1_3", "TLS1_3").Times(1)
+			mockConfigurator.EXPECT().GetMeshCipherSuites().Return(nil).Times(1)
+
+			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			upstreamTLSContext := xds_auth.UpstreamTlsContext{}
+			Expect(ptypes.UnmarshalAny(remoteCluster.TransportSocket.GetTypedConfig(), &upstreamTLSContext)).To(Succeed())
+			Expect(upstreamTLSContext.CommonTlsContext.TlsParams.TlsMinimumProtocolVersion).To(Equal(xds_auth.TlsParameters_TLSv1_3))
+			Expect(upstreamTLSContext.CommonTlsContext.TlsParams.TlsMaximumProtocolVersion).To(Equal(xds_auth.TlsParameters_TLSv1_3))
+		})
+
+		It("Restricts the upstream TLS context to the mesh cipher suites", func() {
+			cipherSuites := []string{"ECDHE-ECDSA-AES256-GCM-SHA384", "ECDHE-RSA-AES256-GCM-SHA384"}
+			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetDefaultLBAlgorithm().Return(configurator.LBAlgorithmRoundRobin).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+			mockConfigurator.EXPECT().GetDefaultCircuitBreaking().Return(configurator.CircuitBreaking{}).Times(1)
+			mockConfigurator.EXPECT().GetOutlierDetection().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetTrustDomain().Return(constants.DefaultTrustDomain).Times(1)
+			mockConfigurator.EXPECT().GetMeshTLSVersions().Return("TLS1_2", "TLS1_3").Times(1)
+			mockConfigurator.EXPECT().GetMeshCipherSuites().Return(cipherSuites).Times(1)
+
+			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			upstreamTLSContext := xds_auth.UpstreamTlsContext{}
+			Expect(ptypes.UnmarshalAny(remoteCluster.TransportSocket.GetTypedConfig(), &upstreamTLSContext)).To(Succeed())
+			Expect(upstreamTLSContext.CommonTlsContext.TlsParams.CipherSuites).To(Equal(cipherSuites))
+		})
 	})
 })
@@ -0,0 +1,40 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_endpoint "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy"
+)
+
+// getInboundExternalAuthCluster returns the cluster of the external authorization service, which is a gRPC service
+func getInboundExternalAuthCluster(externalAuth *configurator.InboundExternalAuth) xds_cluster.Cluster {
+	return xds_cluster.Cluster{
+		Name:           constants.EnvoyInboundExternalAuthCluster,
+		AltStatName:    externalAuth.StatPrefix,
+		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
+		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
+			Type: xds_cluster.Cluster_LOGICAL_DNS,
+		},
+		LbPolicy:             xds_cluster.Cluster_ROUND_ROBIN,
+		Http2ProtocolOptions: &xds_core.Http2ProtocolOptions{},
+		LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
+			ClusterName: constants.EnvoyInboundExternalAuthCluster,
+			Endpoints: []*xds_endpoint.LocalityLbEndpoints{
+				{
+					LbEndpoints: []*xds_endpoint.LbEndpoint{{
+						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+							Endpoint: &xds_endpoint.Endpoint{
+								Address: envoy.GetAddress(externalAuth.Address, externalAuth.Port),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
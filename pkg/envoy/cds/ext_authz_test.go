@@ -0,0 +1,35 @@
+package cds
+
+import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+)
+
+var _ = Describe("Test CDS external authorization configuration", func() {
+	Context("Test getInboundExternalAuthCluster()", func() {
+		It("Returns the external authorization cluster config", func() {
+			actual := getInboundExternalAuthCluster(&configurator.InboundExternalAuth{
+				Enable:     true,
+				Address:    "opa.osm-system.svc.cluster.local",
+				Port:       9191,
+				StatPrefix: "inbound_ext_authz",
+				Timeout:    "1s",
+			})
+			Expect(actual.Name).To(Equal(constants.EnvoyInboundExternalAuthCluster))
+			Expect(actual.AltStatName).To(Equal("inbound_ext_authz"))
+			Expect(actual.GetType()).To(Equal(xds_cluster.Cluster_LOGICAL_DNS))
+			Expect(actual.Http2ProtocolOptions).ToNot(BeNil())
+
+			endpoints := actual.GetLoadAssignment().GetEndpoints()
+			Expect(len(endpoints)).To(Equal(1))
+			socketAddress := endpoints[0].LbEndpoints[0].GetEndpoint().GetAddress().GetSocketAddress()
+			Expect(socketAddress.Address).To(Equal("opa.osm-system.svc.cluster.local"))
+			Expect(socketAddress.GetPortValue()).To(Equal(uint32(9191)))
+		})
+	})
+})
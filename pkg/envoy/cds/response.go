@@ -24,6 +24,12 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 	// Github Issue #1575
 	proxyServiceName := svcList[0]
 
+	configChannel, err := catalog.GetConfigChannelFromEnvoyCertificate(proxy.GetCommonName())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error looking up config channel for Envoy with CN=%q", proxy.GetCommonName())
+		return nil, err
+	}
+
 	resp := &xds_discovery.DiscoveryResponse{
 		TypeUrl: string(envoy.TypeCDS),
 	}
@@ -66,10 +72,17 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 	}
 	clusterFactories[localCluster.Name] = localCluster
 
-	if cfg.IsEgressEnabled() {
+	if cfg.IsEgressEnabledForChannel(configChannel) {
 		// Add a pass-through cluster for egress
 		passthroughCluster := getOutboundPassthroughCluster()
 		clusterFactories[passthroughCluster.Name] = passthroughCluster
+
+		// Add a cluster for each of the external domains resolved by Envoy
+		dnsResolution := cfg.GetEgressDNSResolution()
+		for _, domain := range envoy.GetEgressClusterDomains(cfg) {
+			egressCluster := getEgressCluster(domain, dnsResolution)
+			clusterFactories[egressCluster.Name] = egressCluster
+		}
 	}
 
 	for _, cluster := range clusterFactories {
@@ -83,7 +96,7 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 	}
 
 	if cfg.IsPrometheusScrapingEnabled() {
-		prometheusCluster := getPrometheusCluster()
+		prometheusCluster := getPrometheusCluster(cfg)
 		marshalledCluster, err := ptypes.MarshalAny(&prometheusCluster)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error marshaling Prometheus cluster for proxy with CN=%s", proxy.GetCommonName())
@@ -102,6 +115,16 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 		resp.Resources = append(resp.Resources, marshalledCluster)
 	}
 
+	if externalAuth := cfg.GetInboundExternalAuthConfig(); externalAuth != nil {
+		externalAuthCluster := getInboundExternalAuthCluster(externalAuth)
+		marshalledCluster, err := ptypes.MarshalAny(&externalAuthCluster)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshaling external authorization cluster for proxy with CN=%s", proxy.GetCommonName())
+			return nil, err
+		}
+		resp.Resources = append(resp.Resources, marshalledCluster)
+	}
+
 	return resp, nil
 }
 
@@ -75,11 +75,24 @@ var _ = Describe("CDS Response", func() {
 			}
 
 			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).AnyTimes()
+			mockConfigurator.EXPECT().GetDefaultLBAlgorithm().Return(configurator.LBAlgorithmRoundRobin).AnyTimes()
 			mockConfigurator.EXPECT().IsPrometheusScrapingEnabled().Return(true).AnyTimes()
 			mockConfigurator.EXPECT().IsTracingEnabled().Return(true).AnyTimes()
-			mockConfigurator.EXPECT().IsEgressEnabled().Return(true).AnyTimes()
+			mockConfigurator.EXPECT().IsEgressEnabledForChannel(configurator.PrimaryConfigChannel).Return(true).AnyTimes()
+			mockConfigurator.EXPECT().GetEgressAllowedDomains().Return([]string{"*.example.com", "api.stripe.com"}).AnyTimes()
+			mockConfigurator.EXPECT().GetEgressDNSResolution().Return(configurator.EgressDNSResolutionLogicalDNS).AnyTimes()
 			mockConfigurator.EXPECT().GetTracingHost().Return(constants.DefaultTracingHost).AnyTimes()
+			mockConfigurator.EXPECT().GetTracingHosts().Return([]string{constants.DefaultTracingHost}).AnyTimes()
 			mockConfigurator.EXPECT().GetTracingPort().Return(constants.DefaultTracingPort).AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).AnyTimes()
+			mockConfigurator.EXPECT().GetDefaultCircuitBreaking().Return(configurator.CircuitBreaking{}).AnyTimes()
+			mockConfigurator.EXPECT().GetOutlierDetection().Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().GetWASMExtensions().Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoyAdminPort().Return(uint32(constants.EnvoyAdminPort)).AnyTimes()
+			mockConfigurator.EXPECT().GetTrustDomain().Return(constants.DefaultTrustDomain).AnyTimes()
+			mockConfigurator.EXPECT().GetMeshTLSVersions().Return("TLS1_2", "TLS1_3").AnyTimes()
+			mockConfigurator.EXPECT().GetMeshCipherSuites().Return(nil).AnyTimes()
 
 			resp, err := NewResponse(catalog, proxy, nil, mockConfigurator)
 			Expect(err).ToNot(HaveOccurred())
@@ -91,7 +104,8 @@ var _ = Describe("CDS Response", func() {
 			// 3. Prometheus cluster
 			// 4. Tracing cluster
 			// 5. Passthrough cluster for egress
-			numExpectedClusters := 6 // source and destination clusters
+			// 6. Egress cluster of each external domain resolved by Envoy (api.stripe.com)
+			numExpectedClusters := 7 // source and destination clusters
 			Expect(len((*resp).Resources)).To(Equal(numExpectedClusters))
 		})
 	})
@@ -152,6 +166,13 @@ var _ = Describe("CDS Response", func() {
 			remoteService := tests.BookstoreService
 
 			mockConfigurator.EXPECT().IsPermissiveTrafficPolicyMode().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetDefaultLBAlgorithm().Return(configurator.LBAlgorithmRoundRobin).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+			mockConfigurator.EXPECT().GetDefaultCircuitBreaking().Return(configurator.CircuitBreaking{}).Times(1)
+			mockConfigurator.EXPECT().GetOutlierDetection().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetTrustDomain().Return(constants.DefaultTrustDomain).Times(1)
+			mockConfigurator.EXPECT().GetMeshTLSVersions().Return("TLS1_2", "TLS1_3").Times(1)
+			mockConfigurator.EXPECT().GetMeshCipherSuites().Return(nil).Times(1)
 
 			remoteCluster, err := getRemoteServiceCluster(remoteService, localService, mockConfigurator)
 			Expect(err).ToNot(HaveOccurred())
@@ -214,6 +235,9 @@ var _ = Describe("CDS Response", func() {
 						},
 					},
 				},
+				CommonHttpProtocolOptions: &xds_core.HttpProtocolOptions{
+					IdleTimeout: ptypes.DurationProto(constants.DefaultEnvoyConnectionIdleTimeout),
+				},
 				LoadAssignment: expectedClusterLoadAssignment,
 			}
 
@@ -221,6 +245,7 @@ var _ = Describe("CDS Response", func() {
 			Expect(remoteCluster.EdsClusterConfig).To(Equal(expectedCluster.EdsClusterConfig))
 			Expect(remoteCluster.ConnectTimeout).To(Equal(expectedCluster.ConnectTimeout))
 			Expect(remoteCluster.TransportSocket).To(Equal(expectedCluster.TransportSocket))
+			Expect(remoteCluster.CommonHttpProtocolOptions).To(Equal(expectedCluster.CommonHttpProtocolOptions))
 
 			// TODO(draychev): finish the rest
 			// Expect(cluster).To(Equal(expectedCluster))
@@ -266,7 +291,8 @@ var _ = Describe("CDS Response", func() {
 		})
 
 		It("Returns a Prometheus cluster object", func() {
-			remoteCluster := getPrometheusCluster()
+			mockConfigurator.EXPECT().GetEnvoyAdminPort().Return(uint32(15000)).Times(1)
+			remoteCluster := getPrometheusCluster(mockConfigurator)
 
 			expectedClusterLoadAssignment := &xds_endpoint.ClusterLoadAssignment{
 				ClusterName: constants.EnvoyMetricsCluster,
@@ -10,28 +10,43 @@ import (
 	"github.com/openservicemesh/osm/pkg/envoy"
 )
 
+// getTracingCluster returns the cluster of the tracing collectors. When several collectors are configured, each one
+// is given its own priority, in order of preference, so Envoy fails over to the next collector when one is unhealthy.
 func getTracingCluster(cfg configurator.Configurator) xds_cluster.Cluster {
+	hosts := cfg.GetTracingHosts()
+	port := cfg.GetTracingPort()
+
+	// A LOGICAL_DNS cluster can only have a single endpoint
+	discoveryType := xds_cluster.Cluster_LOGICAL_DNS
+	if len(hosts) > 1 {
+		discoveryType = xds_cluster.Cluster_STRICT_DNS
+	}
+
+	var localityEndpoints []*xds_endpoint.LocalityLbEndpoints
+	for priority, host := range hosts {
+		localityEndpoints = append(localityEndpoints, &xds_endpoint.LocalityLbEndpoints{
+			Priority: uint32(priority),
+			LbEndpoints: []*xds_endpoint.LbEndpoint{{
+				HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
+					Endpoint: &xds_endpoint.Endpoint{
+						Address: envoy.GetAddress(host, port),
+					},
+				},
+			}},
+		})
+	}
+
 	return xds_cluster.Cluster{
 		Name:           constants.EnvoyTracingCluster,
 		AltStatName:    constants.EnvoyTracingCluster,
 		ConnectTimeout: ptypes.DurationProto(clusterConnectTimeout),
 		ClusterDiscoveryType: &xds_cluster.Cluster_Type{
-			Type: xds_cluster.Cluster_LOGICAL_DNS,
+			Type: discoveryType,
 		},
 		LbPolicy: xds_cluster.Cluster_ROUND_ROBIN,
 		LoadAssignment: &xds_endpoint.ClusterLoadAssignment{
 			ClusterName: constants.EnvoyTracingCluster,
-			Endpoints: []*xds_endpoint.LocalityLbEndpoints{
-				{
-					LbEndpoints: []*xds_endpoint.LbEndpoint{{
-						HostIdentifier: &xds_endpoint.LbEndpoint_Endpoint{
-							Endpoint: &xds_endpoint.Endpoint{
-								Address: envoy.GetAddress(cfg.GetTracingHost(), cfg.GetTracingPort()),
-							},
-						},
-					}},
-				},
-			},
+			Endpoints:   localityEndpoints,
 		},
 	}
 }
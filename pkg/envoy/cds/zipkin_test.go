@@ -1,6 +1,8 @@
 package cds
 
 import (
+	xds_cluster "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -20,13 +22,28 @@ var _ = Describe("Test CDS Tracing Configuration", func() {
 
 	Context("Test getTracingCluster()", func() {
 		It("Returns Tracing cluster config", func() {
-			mockConfigurator.EXPECT().GetTracingHost().Return(constants.DefaultTracingHost).Times(1)
+			mockConfigurator.EXPECT().GetTracingHosts().Return([]string{constants.DefaultTracingHost}).Times(1)
 			mockConfigurator.EXPECT().GetTracingPort().Return(constants.DefaultTracingPort).Times(1)
 
 			actual := getTracingCluster(mockConfigurator)
 			Expect(actual.Name).To(Equal(constants.EnvoyTracingCluster))
 			Expect(actual.AltStatName).To(Equal(constants.EnvoyTracingCluster))
+			Expect(actual.GetType()).To(Equal(xds_cluster.Cluster_LOGICAL_DNS))
 			Expect(len(actual.GetLoadAssignment().GetEndpoints())).To(Equal(1))
 		})
+
+		It("Returns a Tracing cluster failing over between the tracing hosts in order", func() {
+			mockConfigurator.EXPECT().GetTracingHosts().Return([]string{"zipkin-0", "zipkin-1"}).Times(1)
+			mockConfigurator.EXPECT().GetTracingPort().Return(constants.DefaultTracingPort).Times(1)
+
+			actual := getTracingCluster(mockConfigurator)
+			Expect(actual.GetType()).To(Equal(xds_cluster.Cluster_STRICT_DNS))
+			Expect(actual.GetLoadAssignment().GetEndpoints()).To(HaveLen(2))
+			for priority, host := range []string{"zipkin-0", "zipkin-1"} {
+				localityEndpoints := actual.GetLoadAssignment().GetEndpoints()[priority]
+				Expect(localityEndpoints.Priority).To(Equal(uint32(priority)))
+				Expect(localityEndpoints.LbEndpoints[0].GetEndpoint().GetAddress().GetSocketAddress().GetAddress()).To(Equal(host))
+			}
+		})
 	})
 })
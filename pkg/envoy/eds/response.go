@@ -1,6 +1,9 @@
 package eds
 
 import (
+	"bytes"
+	"sort"
+
 	xds_discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 
 	"github.com/golang/protobuf/ptypes"
@@ -15,7 +18,7 @@ import (
 )
 
 // NewResponse creates a new Endpoint Discovery Response.
-func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_discovery.DiscoveryRequest, _ configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
+func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_discovery.DiscoveryRequest, cfg configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
 	svcList, err := catalog.GetServicesFromEnvoyCertificate(proxy.GetCommonName())
 	if err != nil {
 		log.Error().Err(err).Msgf("Error looking up MeshService for Envoy with CN=%q", proxy.GetCommonName())
@@ -42,9 +45,11 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 
 	log.Trace().Msgf("Outbound service endpoints for proxy %s: %v", proxyServiceName, outboundServicesEndpoints)
 
+	maxEndpoints := cfg.GetMaxEndpointsPerCluster()
+
 	var protos []*any.Any
 	for svc, endpoints := range outboundServicesEndpoints {
-		loadAssignment := cla.NewClusterLoadAssignment(svc, endpoints)
+		loadAssignment := cla.NewClusterLoadAssignment(svc, limitEndpoints(svc, endpoints, maxEndpoints))
 		proto, err := ptypes.MarshalAny(loadAssignment)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error marshalling EDS payload for proxy %s: %+v", proxyServiceName, loadAssignment)
@@ -59,3 +64,23 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 	}
 	return resp, nil
 }
+
+// limitEndpoints returns at most maxEndpoints of the given endpoints, 0 meaning all of them. The endpoints kept are the
+// first ones by address, so that every proxy receives the same subset of the endpoints until these change.
+func limitEndpoints(svc service.MeshService, endpoints []endpoint.Endpoint, maxEndpoints uint32) []endpoint.Endpoint {
+	if maxEndpoints == 0 || len(endpoints) <= int(maxEndpoints) {
+		return endpoints
+	}
+
+	sorted := make([]endpoint.Endpoint, len(endpoints))
+	copy(sorted, endpoints)
+	sort.Slice(sorted, func(i, j int) bool {
+		if cmp := bytes.Compare(sorted[i].IP.To16(), sorted[j].IP.To16()); cmp != 0 {
+			return cmp < 0
+		}
+		return sorted[i].Port < sorted[j].Port
+	})
+
+	log.Debug().Msgf("Trimming the %d endpoints of service %s to the maximum of %d endpoints per cluster", len(endpoints), svc, maxEndpoints)
+	return sorted[:maxEndpoints]
+}
@@ -3,6 +3,7 @@ package eds
 import (
 	"context"
 	"fmt"
+	"net"
 
 	"github.com/google/uuid"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -16,7 +17,9 @@ import (
 	"github.com/openservicemesh/osm/pkg/certificate"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/endpoint"
 	"github.com/openservicemesh/osm/pkg/envoy"
+	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
@@ -64,6 +67,8 @@ var _ = Describe("Test EDS response", func() {
 				Expect(err).ToNot(HaveOccurred())
 			}
 
+			mockConfigurator.EXPECT().GetMaxEndpointsPerCluster().Return(uint32(0)).Times(1)
+
 			_, err := NewResponse(catalog, proxy, nil, mockConfigurator)
 			Expect(err).ToNot(HaveOccurred())
 		})
@@ -84,4 +89,48 @@ var _ = Describe("Test EDS response", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Context("Test limitEndpoints", func() {
+		svc := service.MeshService{Namespace: "osm", Name: "bookstore"}
+		endpoints := []endpoint.Endpoint{
+			{IP: net.ParseIP("10.0.0.12"), Port: 80},
+			{IP: net.ParseIP("10.0.0.3"), Port: 8080},
+			{IP: net.ParseIP("10.0.0.3"), Port: 80},
+			{IP: net.ParseIP("10.0.1.1"), Port: 80},
+			{IP: net.ParseIP("10.0.0.5"), Port: 80},
+		}
+
+		It("keeps all the endpoints when the maximum is unlimited", func() {
+			Expect(limitEndpoints(svc, endpoints, 0)).To(Equal(endpoints))
+		})
+
+		It("keeps all the endpoints when there are no more than the maximum", func() {
+			Expect(limitEndpoints(svc, endpoints, 5)).To(Equal(endpoints))
+			Expect(limitEndpoints(svc, endpoints, 10)).To(Equal(endpoints))
+		})
+
+		It("keeps the first endpoints by address when there are more than the maximum", func() {
+			expected := []endpoint.Endpoint{
+				{IP: net.ParseIP("10.0.0.3"), Port: 80},
+				{IP: net.ParseIP("10.0.0.3"), Port: 8080},
+				{IP: net.ParseIP("10.0.0.5"), Port: 80},
+			}
+			Expect(limitEndpoints(svc, endpoints, 3)).To(Equal(expected))
+		})
+
+		It("keeps the same endpoints whatever their order", func() {
+			reversed := make([]endpoint.Endpoint, len(endpoints))
+			for i, ep := range endpoints {
+				reversed[len(endpoints)-1-i] = ep
+			}
+			Expect(limitEndpoints(svc, reversed, 3)).To(Equal(limitEndpoints(svc, endpoints, 3)))
+		})
+
+		It("does not reorder the given endpoints", func() {
+			original := make([]endpoint.Endpoint, len(endpoints))
+			copy(original, endpoints)
+			limitEndpoints(svc, endpoints, 2)
+			Expect(endpoints).To(Equal(original))
+		})
+	})
 })
@@ -1,10 +1,13 @@
 package lds
 
 import (
+	xds_accesslog_filter "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -18,7 +21,7 @@ const (
 
 func getHTTPConnectionManager(routeName string, cfg configurator.Configurator) *xds_hcm.HttpConnectionManager {
 	connManager := &xds_hcm.HttpConnectionManager{
-		StatPrefix: statPrefix,
+		StatPrefix: getStatPrefix(cfg),
 		CodecType:  xds_hcm.HttpConnectionManager_AUTO,
 		HttpFilters: []*xds_hcm.HttpFilter{{
 			Name: wellknown.Router,
@@ -30,7 +33,15 @@ func getHTTPConnectionManager(routeName string, cfg configurator.Configurator) *
 				RouteConfigName: routeName,
 			},
 		},
-		AccessLog: envoy.GetAccessLog(),
+		AccessLog: getAccessLog(cfg),
+		CommonHttpProtocolOptions: &xds_core.HttpProtocolOptions{
+			IdleTimeout: ptypes.DurationProto(cfg.GetEnvoyConnectionIdleTimeout()),
+		},
+	}
+
+	// The route configurations remove the X-Forwarded-* headers, so X-Forwarded-For is not appended to either
+	if cfg.StripForwardedHeaders() {
+		connManager.SkipXffAppend = true
 	}
 
 	if cfg.IsTracingEnabled() {
@@ -50,7 +61,7 @@ func getHTTPConnectionManager(routeName string, cfg configurator.Configurator) *
 	return connManager
 }
 
-func getPrometheusConnectionManager(listenerName string, routeName string, clusterName string) *xds_hcm.HttpConnectionManager {
+func getPrometheusConnectionManager(listenerName string, routeName string, clusterName string, cfg configurator.Configurator) *xds_hcm.HttpConnectionManager {
 	return &xds_hcm.HttpConnectionManager{
 		StatPrefix: listenerName,
 		CodecType:  xds_hcm.HttpConnectionManager_AUTO,
@@ -80,6 +91,22 @@ func getPrometheusConnectionManager(listenerName string, routeName string, clust
 				}},
 			},
 		},
-		AccessLog: envoy.GetAccessLog(),
+		AccessLog: getAccessLog(cfg),
+	}
+}
+
+// getStatPrefix returns the stat prefix of the HTTP connection managers, prefixed with the configured stats prefix
+func getStatPrefix(cfg configurator.Configurator) string {
+	if prefix := cfg.GetStatsPrefix(); prefix != "" {
+		return prefix + "." + statPrefix
+	}
+	return statPrefix
+}
+
+// getAccessLog returns the access log config of the HTTP connection managers, or nil when access logging is disabled
+func getAccessLog(cfg configurator.Configurator) []*xds_accesslog_filter.AccessLog {
+	if !cfg.IsAccessLoggingEnabled() {
+		return nil
 	}
+	return envoy.GetAccessLog(cfg.GetAccessLogFormat() == configurator.AccessLogFormatJSON)
 }
@@ -3,5 +3,6 @@ package lds
 import "github.com/pkg/errors"
 
 var (
-	errInvalidCIDRRange = errors.New("invalid CIDR range")
+	errInvalidCIDRRange          = errors.New("invalid CIDR range")
+	errUnsupportedTracingBackend = errors.New("unsupported tracing backend")
 )
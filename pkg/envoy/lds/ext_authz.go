@@ -0,0 +1,75 @@
+package lds
+
+import (
+	"time"
+
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_ext_authz "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+	"github.com/openservicemesh/osm/pkg/constants"
+	"github.com/openservicemesh/osm/pkg/envoy/route"
+)
+
+// getInboundHTTPConnectionManager returns the HTTP connection manager of the inbound requests, which has the requests
+// authorized by the external authorization service when inbound external authorization is enabled, then processed by
+// the inbound WebAssembly extensions, before routing them
+func getInboundHTTPConnectionManager(cfg configurator.Configurator) (*xds_hcm.HttpConnectionManager, error) {
+	connManager := getHTTPConnectionManager(route.InboundRouteConfigName, cfg)
+
+	wasmFilters, err := getWASMHTTPFilters(configurator.WASMInsertionPointInbound, cfg)
+	if err != nil {
+		return nil, err
+	}
+	// The HTTP filters run in order, and the router must be the last one
+	connManager.HttpFilters = append(wasmFilters, connManager.HttpFilters...)
+
+	externalAuth := cfg.GetInboundExternalAuthConfig()
+	if externalAuth == nil {
+		return connManager, nil
+	}
+
+	externalAuthFilter, err := getExternalAuthHTTPFilter(externalAuth)
+	if err != nil {
+		log.Error().Err(err).Msg("Error marshalling ext_authz filter")
+		return nil, err
+	}
+
+	connManager.HttpFilters = append([]*xds_hcm.HttpFilter{externalAuthFilter}, connManager.HttpFilters...)
+	return connManager, nil
+}
+
+// getExternalAuthHTTPFilter returns the ext_authz HTTP filter asking the external authorization service, over gRPC,
+// whether to let each request through
+func getExternalAuthHTTPFilter(externalAuth *configurator.InboundExternalAuth) (*xds_hcm.HttpFilter, error) {
+	// The timeout has been validated by the configurator
+	timeout, _ := time.ParseDuration(externalAuth.Timeout)
+
+	marshalledExternalAuth, err := ptypes.MarshalAny(&xds_ext_authz.ExtAuthz{
+		Services: &xds_ext_authz.ExtAuthz_GrpcService{
+			GrpcService: &xds_core.GrpcService{
+				TargetSpecifier: &xds_core.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &xds_core.GrpcService_EnvoyGrpc{
+						ClusterName: constants.EnvoyInboundExternalAuthCluster,
+					},
+				},
+				Timeout: ptypes.DurationProto(timeout),
+			},
+		},
+		FailureModeAllow: externalAuth.FailureModeAllow,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name: wellknown.HTTPExternalAuthorization,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+			TypedConfig: marshalledExternalAuth,
+		},
+	}, nil
+}
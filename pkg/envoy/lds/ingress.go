@@ -10,7 +10,6 @@ import (
 
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
-	"github.com/openservicemesh/osm/pkg/envoy/route"
 	"github.com/openservicemesh/osm/pkg/service"
 )
 
@@ -28,7 +27,11 @@ func newIngressFilterChain(cfg configurator.Configurator, svc service.MeshServic
 		return nil
 	}
 
-	inboundConnManager := getHTTPConnectionManager(route.InboundRouteConfigName, cfg)
+	inboundConnManager, err := getInboundHTTPConnectionManager(cfg)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error building inbound HttpConnectionManager object for proxy %s", svc)
+		return nil
+	}
 	marshalledInboundConnManager, err := ptypes.MarshalAny(inboundConnManager)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling inbound HttpConnectionManager object for proxy %s", svc)
@@ -58,7 +61,7 @@ func getIngressFilterChains(svc service.MeshService, cfg configurator.Configurat
 	if cfg.UseHTTPSIngress() {
 		// Filter chain with SNI matching enabled for HTTPS clients that set the SNI
 		ingressFilterChainWithSNI := newIngressFilterChain(cfg, svc)
-		ingressFilterChainWithSNI.FilterChainMatch.ServerNames = []string{svc.GetCommonName().String()}
+		ingressFilterChainWithSNI.FilterChainMatch.ServerNames = []string{svc.GetCommonNameForTrustDomain(cfg.GetTrustDomain()).String()}
 		ingressFilterChains = append(ingressFilterChains, ingressFilterChainWithSNI)
 	}
 
@@ -3,24 +3,52 @@ package lds
 import (
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 
 	"github.com/golang/protobuf/ptypes"
 
+	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/envoy"
-	"github.com/openservicemesh/osm/pkg/envoy/route"
 	"github.com/openservicemesh/osm/pkg/service"
 )
 
-func getInboundInMeshFilterChain(proxyServiceName service.MeshService, cfg configurator.Configurator) (*xds_listener.FilterChain, error) {
-	marshalledDownstreamTLSContext, err := envoy.MessageToAny(envoy.GetDownstreamTLSContext(proxyServiceName, true /* mTLS */))
+// inboundMTLSExemptFilterChainName is the name of the inbound filter chain accepting the plaintext connections of the
+// sources exempt from mTLS
+const inboundMTLSExemptFilterChainName = "inbound-mtls-exempt-filter-chain"
+
+func getInboundInMeshFilterChain(proxyServiceName service.MeshService, mc catalog.MeshCataloger, cfg configurator.Configurator) (*xds_listener.FilterChain, error) {
+	downstreamTLSContext := envoy.GetDownstreamTLSContext(proxyServiceName, true /* mTLS */)
+	downstreamTLSContext.CommonTlsContext.TlsParams = envoy.GetTLSParamsForVersions(cfg.GetMeshTLSVersions())
+	downstreamTLSContext.CommonTlsContext.TlsParams.CipherSuites = cfg.GetMeshCipherSuites()
+	marshalledDownstreamTLSContext, err := envoy.MessageToAny(downstreamTLSContext)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling DownstreamTLSContext object for proxy %s", proxyServiceName)
 		return nil, err
 	}
 
-	inboundConnManager := getHTTPConnectionManager(route.InboundRouteConfigName, cfg)
+	inboundConnManager, err := getInboundHTTPConnectionManager(cfg)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error building inbound HttpConnectionManager object for proxy %s", proxyServiceName)
+		return nil, err
+	}
+
+	// In permissive mode, the requests the SMI policies would deny are audited before switching to SMI mode
+	if cfg.IsPermissiveAuditLoggingEnabled() {
+		allowedServices, err := mc.ListSMIAllowedInboundServices(proxyServiceName)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error listing the inbound services the SMI policies allow for proxy %s", proxyServiceName)
+			return nil, err
+		}
+		auditFilter, err := getAuditRBACHTTPFilter(allowedServices, cfg.GetTrustDomain())
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling audit RBAC filter for proxy %s", proxyServiceName)
+			return nil, err
+		}
+		// The audit filter sees the requests before the other HTTP filters, which may reply to them
+		inboundConnManager.HttpFilters = append([]*xds_hcm.HttpFilter{auditFilter}, inboundConnManager.HttpFilters...)
+	}
 	marshalledInboundConnManager, err := ptypes.MarshalAny(inboundConnManager)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling inbound HttpConnectionManager object for proxy %s", proxyServiceName)
@@ -42,7 +70,7 @@ func getInboundInMeshFilterChain(proxyServiceName service.MeshService, cfg confi
 		// This field is configured by the GetDownstreamTLSContext() function.
 		// This is not a field obtained from the mTLS Certificate.
 		FilterChainMatch: &xds_listener.FilterChainMatch{
-			ServerNames:          []string{proxyServiceName.GetCommonName().String()},
+			ServerNames:          []string{proxyServiceName.GetCommonNameForTrustDomain(cfg.GetTrustDomain()).String()},
 			TransportProtocol:    envoy.TransportProtocolTLS,
 			ApplicationProtocols: envoy.ALPNInMesh, // in-mesh proxies will advertise this, set in UpstreamTlsContext
 		},
@@ -57,3 +85,52 @@ func getInboundInMeshFilterChain(proxyServiceName service.MeshService, cfg confi
 
 	return filterChain, nil
 }
+
+// getInboundMTLSExemptFilterChain returns the filter chain accepting the plaintext connections from the source CIDR
+// ranges exempt from mTLS, such as the node IPs of the kubelet health checks, or nil when no source is exempt
+func getInboundMTLSExemptFilterChain(proxyServiceName service.MeshService, cfg configurator.Configurator) (*xds_listener.FilterChain, error) {
+	exemptSourceCIDRs := cfg.GetMTLSExemptSourceCIDRs()
+	if len(exemptSourceCIDRs) == 0 {
+		return nil, nil
+	}
+
+	var sourcePrefixRanges []*xds_core.CidrRange
+	for _, ipNet := range exemptSourceCIDRs {
+		cidrRange, err := getCIDRRange(ipNet.String())
+		if err != nil {
+			log.Error().Err(err).Msgf("Error parsing mTLS exempt source CIDR %s for proxy %s", ipNet, proxyServiceName)
+			return nil, err
+		}
+		sourcePrefixRanges = append(sourcePrefixRanges, cidrRange)
+	}
+
+	inboundConnManager, err := getInboundHTTPConnectionManager(cfg)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error building inbound HttpConnectionManager object for proxy %s", proxyServiceName)
+		return nil, err
+	}
+	marshalledInboundConnManager, err := ptypes.MarshalAny(inboundConnManager)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling inbound HttpConnectionManager object for proxy %s", proxyServiceName)
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name: inboundMTLSExemptFilterChainName,
+		Filters: []*xds_listener.Filter{
+			{
+				Name: wellknown.HTTPConnectionManager,
+				ConfigType: &xds_listener.Filter_TypedConfig{
+					TypedConfig: marshalledInboundConnManager,
+				},
+			},
+		},
+
+		// Only the plaintext connections of the exempt sources match, without a transport socket terminating TLS, so
+		// the connections of the exempt sources presenting a mesh certificate still match the in-mesh filter chain
+		FilterChainMatch: &xds_listener.FilterChainMatch{
+			SourcePrefixRanges: sourcePrefixRanges,
+			TransportProtocol:  envoy.TransportProtocolRawBuffer,
+		},
+	}, nil
+}
@@ -25,9 +25,17 @@ const (
 	outboundEgressFilterChainName = "outbound-egress-filter-chain"
 )
 
-func newOutboundListener(cfg configurator.Configurator) (*xds_listener.Listener, error) {
+// newOutboundListener returns the outbound listener of the proxies of the given config channel
+func newOutboundListener(cfg configurator.Configurator, configChannel string) (*xds_listener.Listener, error) {
 	connManager := getHTTPConnectionManager(route.OutboundRouteConfigName, cfg)
 
+	wasmFilters, err := getWASMHTTPFilters(configurator.WASMInsertionPointOutbound, cfg)
+	if err != nil {
+		return nil, err
+	}
+	// The HTTP filters run in order, and the router must be the last one
+	connManager.HttpFilters = append(wasmFilters, connManager.HttpFilters...)
+
 	marshalledConnManager, err := ptypes.MarshalAny(connManager)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling HttpConnectionManager object")
@@ -60,7 +68,7 @@ func newOutboundListener(cfg configurator.Configurator) (*xds_listener.Listener,
 		},
 	}
 
-	if cfg.IsEgressEnabled() {
+	if cfg.IsEgressEnabledForChannel(configChannel) {
 		err := updateOutboundListenerForEgress(outboundListener, cfg)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error building egress config for outbound listener")
@@ -103,9 +111,59 @@ func updateOutboundListenerForEgress(outboundListener *xds_listener.Listener, cf
 	}
 	outboundListener.FilterChains = append(outboundListener.FilterChains, egressFilterChain)
 
+	// The HTTPS egress traffic to the external domains with an egress cluster is matched by its SNI, which the TLS
+	// inspector reads, and proxied to the egress cluster instead, so Envoy resolves the domains.
+	egressDomains := envoy.GetEgressClusterDomains(cfg)
+	for _, domain := range egressDomains {
+		egressDomainFilterChain, err := buildEgressDomainFilterChain(domain)
+		if err != nil {
+			return err
+		}
+		outboundListener.FilterChains = append(outboundListener.FilterChains, egressDomainFilterChain)
+	}
+	if len(egressDomains) > 0 {
+		outboundListener.ListenerFilters = append(outboundListener.ListenerFilters, &xds_listener.ListenerFilter{
+			Name: wellknown.TlsInspector,
+		})
+	}
+
 	return nil
 }
 
+// buildEgressDomainFilterChain returns the filter chain proxying the HTTPS egress traffic to the given external domain
+// to its egress cluster
+func buildEgressDomainFilterChain(domain string) (*xds_listener.FilterChain, error) {
+	clusterName := envoy.GetEgressClusterName(domain)
+	tcpProxy := &xds_tcp_proxy.TcpProxy{
+		StatPrefix:       clusterName,
+		ClusterSpecifier: &xds_tcp_proxy.TcpProxy_Cluster{Cluster: clusterName},
+	}
+	marshalledTCPProxy, err := envoy.MessageToAny(tcpProxy)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error marshalling TcpProxy object for egress HTTPS filter chain of domain %s", domain)
+		return nil, err
+	}
+
+	return &xds_listener.FilterChain{
+		Name: getEgressDomainFilterChainName(domain),
+		FilterChainMatch: &xds_listener.FilterChainMatch{
+			ServerNames:       []string{domain},
+			TransportProtocol: envoy.TransportProtocolTLS,
+		},
+		Filters: []*xds_listener.Filter{
+			{
+				Name:       wellknown.TCPProxy,
+				ConfigType: &xds_listener.Filter_TypedConfig{TypedConfig: marshalledTCPProxy},
+			},
+		},
+	}, nil
+}
+
+// getEgressDomainFilterChainName returns the name of the egress filter chain of the given external domain
+func getEgressDomainFilterChainName(domain string) string {
+	return outboundEgressFilterChainName + ":" + domain
+}
+
 func newInboundListener() *xds_listener.Listener {
 	return &xds_listener.Listener{
 		Name:             inboundListenerName,
@@ -120,7 +178,7 @@ func newInboundListener() *xds_listener.Listener {
 	}
 }
 
-func buildPrometheusListener(connManager *xds_hcm.HttpConnectionManager) (*xds_listener.Listener, error) {
+func buildPrometheusListener(connManager *xds_hcm.HttpConnectionManager, port uint32) (*xds_listener.Listener, error) {
 	marshalledConnManager, err := ptypes.MarshalAny(connManager)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshalling HttpConnectionManager object")
@@ -130,7 +188,7 @@ func buildPrometheusListener(connManager *xds_hcm.HttpConnectionManager) (*xds_l
 	return &xds_listener.Listener{
 		Name:             prometheusListenerName,
 		TrafficDirection: xds_core.TrafficDirection_INBOUND,
-		Address:          envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyPrometheusInboundListenerPort),
+		Address:          envoy.GetAddress(constants.WildcardIPAddr, port),
 		FilterChains: []*xds_listener.FilterChain{
 			{
 				Filters: []*xds_listener.Filter{
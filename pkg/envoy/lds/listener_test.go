@@ -1,12 +1,21 @@
 package lds
 
 import (
+	"time"
+
 	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	xds_listener "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	xds_ext_authz "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_authz/v3"
+	xds_rbac_filter "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	xds_wasm_filter "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/wasm/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_tcp_proxy "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/tcp_proxy/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -14,6 +23,8 @@ import (
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/envoy/route"
+	"github.com/openservicemesh/osm/pkg/service"
+	"github.com/openservicemesh/osm/pkg/tests"
 )
 
 var _ = Describe("Construct inbound and outbound listeners", func() {
@@ -28,6 +39,12 @@ var _ = Describe("Construct inbound and outbound listeners", func() {
 	mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
 	mockConfigurator.EXPECT().GetTracingHost().Return(constants.DefaultTracingHost).AnyTimes()
 	mockConfigurator.EXPECT().GetTracingPort().Return(constants.DefaultTracingPort).AnyTimes()
+	mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).AnyTimes()
+	mockConfigurator.EXPECT().IsAccessLoggingEnabled().Return(true).AnyTimes()
+	mockConfigurator.EXPECT().GetAccessLogFormat().Return(configurator.AccessLogFormatJSON).AnyTimes()
+	mockConfigurator.EXPECT().GetWASMExtensions().Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().GetEgressAllowedDomains().Return(nil).AnyTimes()
+	mockConfigurator.EXPECT().GetEgressDNSResolution().Return(configurator.EgressDNSResolutionLogicalDNS).AnyTimes()
 
 	Context("Test creation of outbound listener", func() {
 		containsListenerFilter := func(filters []string, filterName string) bool {
@@ -42,10 +59,10 @@ var _ = Describe("Construct inbound and outbound listeners", func() {
 			cidr1 := "10.0.0.0/16"
 			cidr2 := "10.2.0.0/16"
 
-			mockConfigurator.EXPECT().IsEgressEnabled().Return(true).Times(1)
+			mockConfigurator.EXPECT().IsEgressEnabledForChannel(configurator.PrimaryConfigChannel).Return(true).Times(1)
 			mockConfigurator.EXPECT().GetMeshCIDRRanges().Return([]string{cidr1, cidr2}).Times(1)
 
-			listener, err := newOutboundListener(mockConfigurator)
+			listener, err := newOutboundListener(mockConfigurator, configurator.PrimaryConfigChannel)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(listener.Address).To(Equal(envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyOutboundListenerPort)))
@@ -69,9 +86,9 @@ var _ = Describe("Construct inbound and outbound listeners", func() {
 		})
 
 		It("Tests the outbound listener config with egress disabled", func() {
-			mockConfigurator.EXPECT().IsEgressEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().IsEgressEnabledForChannel(configurator.PrimaryConfigChannel).Return(false).Times(1)
 
-			listener, err := newOutboundListener(mockConfigurator)
+			listener, err := newOutboundListener(mockConfigurator, configurator.PrimaryConfigChannel)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(listener.Address).To(Equal(envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyOutboundListenerPort)))
@@ -117,6 +134,35 @@ var _ = Describe("Construct inbound and outbound listeners", func() {
 			err := updateOutboundListenerForEgress(&outboundListener, mockConfigurator)
 			Expect(err).To(HaveOccurred())
 		})
+		It("Tests that the HTTPS egress traffic to the external domains with an egress cluster is proxied to their egress clusters", func() {
+			egressMockCtrl := gomock.NewController(GinkgoT())
+			egressMockConfigurator := configurator.NewMockConfigurator(egressMockCtrl)
+			egressMockConfigurator.EXPECT().GetMeshCIDRRanges().Return([]string{"10.0.0.0/16"}).Times(1)
+			egressMockConfigurator.EXPECT().GetEgressAllowedDomains().Return([]string{"*.example.com", "api.stripe.com"}).Times(1)
+			egressMockConfigurator.EXPECT().GetEgressDNSResolution().Return(configurator.EgressDNSResolutionStrictDNS).Times(1)
+
+			outboundListener := xds_listener.Listener{
+				FilterChains: []*xds_listener.FilterChain{
+					{
+						Name: "test",
+					},
+				},
+			}
+			err := updateOutboundListenerForEgress(&outboundListener, egressMockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(len(outboundListener.FilterChains)).To(Equal(3)) // 1. in-mesh, 2. egress, 3. egress to api.stripe.com
+			Expect(outboundListener.FilterChains[2].Name).To(Equal(getEgressDomainFilterChainName("api.stripe.com")))
+			Expect(outboundListener.FilterChains[2].FilterChainMatch.ServerNames).To(Equal([]string{"api.stripe.com"}))
+			Expect(outboundListener.FilterChains[2].FilterChainMatch.TransportProtocol).To(Equal(envoy.TransportProtocolTLS))
+			tcpProxy := &xds_tcp_proxy.TcpProxy{}
+			Expect(ptypes.UnmarshalAny(outboundListener.FilterChains[2].Filters[0].GetTypedConfig(), tcpProxy)).To(Succeed())
+			Expect(tcpProxy.GetCluster()).To(Equal(envoy.GetEgressClusterName("api.stripe.com")))
+
+			// The TLS inspector reads the SNI the egress filter chains of the external domains match
+			Expect(len(outboundListener.ListenerFilters)).To(Equal(1))
+			Expect(outboundListener.ListenerFilters[0].Name).To(Equal(wellknown.TlsInspector))
+		})
 	})
 
 	Context("Test creation of inbound listener", func() {
@@ -131,8 +177,8 @@ var _ = Describe("Construct inbound and outbound listeners", func() {
 
 	Context("Test creation of Prometheus listener", func() {
 		It("Tests the Prometheus listener config", func() {
-			connManager := getPrometheusConnectionManager("fake-prometheus", constants.PrometheusScrapePath, constants.EnvoyMetricsCluster)
-			listener, _ := buildPrometheusListener(connManager)
+			connManager := getPrometheusConnectionManager("fake-prometheus", constants.PrometheusScrapePath, constants.EnvoyMetricsCluster, mockConfigurator)
+			listener, _ := buildPrometheusListener(connManager, constants.EnvoyPrometheusInboundListenerPort)
 			Expect(listener.Address).To(Equal(envoy.GetAddress(constants.WildcardIPAddr, constants.EnvoyPrometheusInboundListenerPort)))
 			Expect(len(listener.ListenerFilters)).To(Equal(0)) //  no listener filters
 			Expect(listener.TrafficDirection).To(Equal(xds_core.TrafficDirection_INBOUND))
@@ -170,21 +216,267 @@ var _ = Describe("Test getHTTPConnectionManager", func() {
 			mockConfigurator.EXPECT().GetTracingHost().Return(constants.DefaultTracingHost).Times(1)
 			mockConfigurator.EXPECT().GetTracingPort().Return(constants.DefaultTracingPort).Times(1)
 			mockConfigurator.EXPECT().GetTracingEndpoint().Return(constants.DefaultTracingEndpoint).Times(1)
+			mockConfigurator.EXPECT().GetTracingSamplingRate().Return(0.25).Times(1)
+			mockConfigurator.EXPECT().GetTracingBackend().Return(configurator.TracingBackendZipkin).Times(1)
 			mockConfigurator.EXPECT().IsTracingEnabled().Return(true).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+			mockConfigurator.EXPECT().IsAccessLoggingEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().StripForwardedHeaders().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetStatsPrefix().Return("").Times(1)
 
 			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
 
 			Expect(connManager.Tracing.Verbose).To(Equal(true))
 			Expect(connManager.Tracing.Provider.Name).To(Equal("envoy.tracers.zipkin"))
+			Expect(connManager.Tracing.RandomSampling.Value).To(Equal(25.0))
+		})
+
+		It("Returns no tracing config given an unsupported tracing backend", func() {
+			mockConfigurator.EXPECT().GetTracingBackend().Return(configurator.TracingBackendOTLP).Times(1)
+			mockConfigurator.EXPECT().IsTracingEnabled().Return(true).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+			mockConfigurator.EXPECT().IsAccessLoggingEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().StripForwardedHeaders().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetStatsPrefix().Return("").Times(1)
+
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
+			var nilHcmTrace *xds_hcm.HttpConnectionManager_Tracing = nil
+
+			Expect(connManager.Tracing).To(Equal(nilHcmTrace))
 		})
 
 		It("Returns proper Zipkin config given when tracing is disabled", func() {
 			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+			mockConfigurator.EXPECT().IsAccessLoggingEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().StripForwardedHeaders().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetStatsPrefix().Return("").Times(1)
 
 			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
 			var nilHcmTrace *xds_hcm.HttpConnectionManager_Tracing = nil
 
 			Expect(connManager.Tracing).To(Equal(nilHcmTrace))
 		})
+
+		It("Returns the configured connection idle timeout", func() {
+			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(2 * time.Hour).Times(1)
+			mockConfigurator.EXPECT().IsAccessLoggingEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().StripForwardedHeaders().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetStatsPrefix().Return("").Times(1)
+
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
+
+			Expect(connManager.CommonHttpProtocolOptions.IdleTimeout).To(Equal(ptypes.DurationProto(2 * time.Hour)))
+		})
+
+		It("Appends to X-Forwarded-For unless the forwarded headers are stripped", func() {
+			for _, strip := range []bool{true, false} {
+				mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
+				mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+				mockConfigurator.EXPECT().IsAccessLoggingEnabled().Return(false).Times(1)
+				mockConfigurator.EXPECT().StripForwardedHeaders().Return(strip).Times(1)
+				mockConfigurator.EXPECT().GetStatsPrefix().Return("").Times(1)
+
+				connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
+
+				Expect(connManager.SkipXffAppend).To(Equal(strip))
+			}
+		})
+
+		It("Prefixes the stat prefix with the configured stats prefix", func() {
+			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+			mockConfigurator.EXPECT().IsAccessLoggingEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().StripForwardedHeaders().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetStatsPrefix().Return("osm").Times(1)
+
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
+
+			Expect(connManager.StatPrefix).To(Equal("osm.http"))
+		})
+
+		It("Returns no access log config when access logging is disabled", func() {
+			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+			mockConfigurator.EXPECT().IsAccessLoggingEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().StripForwardedHeaders().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetStatsPrefix().Return("").Times(1)
+
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
+
+			Expect(connManager.AccessLog).To(BeNil())
+		})
+
+		It("Returns a JSON access log config when the access log format is json", func() {
+			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+			mockConfigurator.EXPECT().IsAccessLoggingEnabled().Return(true).Times(1)
+			mockConfigurator.EXPECT().StripForwardedHeaders().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetStatsPrefix().Return("").Times(1)
+			mockConfigurator.EXPECT().GetAccessLogFormat().Return(configurator.AccessLogFormatJSON).Times(1)
+
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
+
+			Expect(connManager.AccessLog).To(Equal(envoy.GetAccessLog(true)))
+		})
+
+		It("Returns a text access log config when the access log format is text", func() {
+			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+			mockConfigurator.EXPECT().IsAccessLoggingEnabled().Return(true).Times(1)
+			mockConfigurator.EXPECT().StripForwardedHeaders().Return(false).Times(1)
+			mockConfigurator.EXPECT().GetStatsPrefix().Return("").Times(1)
+			mockConfigurator.EXPECT().GetAccessLogFormat().Return(configurator.AccessLogFormatText).Times(1)
+
+			connManager := getHTTPConnectionManager(route.InboundRouteConfigName, mockConfigurator)
+
+			Expect(connManager.AccessLog).To(Equal(envoy.GetAccessLog(false)))
+		})
+	})
+})
+
+var _ = Describe("Test getInboundHTTPConnectionManager", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockConfigurator *configurator.MockConfigurator
+	)
+
+	mockCtrl = gomock.NewController(GinkgoT())
+	mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+
+	BeforeEach(func() {
+		mockConfigurator.EXPECT().IsTracingEnabled().Return(false).Times(1)
+		mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).Times(1)
+		mockConfigurator.EXPECT().IsAccessLoggingEnabled().Return(false).Times(1)
+		mockConfigurator.EXPECT().StripForwardedHeaders().Return(false).Times(1)
+		mockConfigurator.EXPECT().GetStatsPrefix().Return("").Times(1)
+	})
+
+	Context("Test the external authorization of the inbound requests", func() {
+		It("Returns only the router filter when external authorization is disabled", func() {
+			mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetWASMExtensions().Return(nil).Times(1)
+
+			connManager, err := getInboundHTTPConnectionManager(mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(len(connManager.HttpFilters)).To(Equal(1))
+			Expect(connManager.HttpFilters[0].Name).To(Equal(wellknown.Router))
+		})
+
+		It("Returns the ext_authz filter before the router filter when external authorization is enabled", func() {
+			mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(&configurator.InboundExternalAuth{
+				Enable:           true,
+				Address:          "opa.osm-system.svc.cluster.local",
+				Port:             9191,
+				StatPrefix:       "inbound_ext_authz",
+				Timeout:          "500ms",
+				FailureModeAllow: true,
+			}).Times(1)
+			mockConfigurator.EXPECT().GetWASMExtensions().Return(nil).Times(1)
+
+			connManager, err := getInboundHTTPConnectionManager(mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(len(connManager.HttpFilters)).To(Equal(2))
+			Expect(connManager.HttpFilters[0].Name).To(Equal(wellknown.HTTPExternalAuthorization))
+			Expect(connManager.HttpFilters[1].Name).To(Equal(wellknown.Router))
+
+			externalAuth := &xds_ext_authz.ExtAuthz{}
+			Expect(ptypes.UnmarshalAny(connManager.HttpFilters[0].GetTypedConfig(), externalAuth)).To(Succeed())
+			Expect(externalAuth.FailureModeAllow).To(BeTrue())
+			Expect(externalAuth.GetGrpcService().GetEnvoyGrpc().ClusterName).To(Equal(constants.EnvoyInboundExternalAuthCluster))
+			Expect(externalAuth.GetGrpcService().Timeout).To(Equal(ptypes.DurationProto(500 * time.Millisecond)))
+		})
+	})
+
+	Context("Test the WASM extensions of the inbound requests", func() {
+		It("Returns the inbound wasm filters, in order, after the ext_authz filter and before the router filter", func() {
+			mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(&configurator.InboundExternalAuth{
+				Enable:  true,
+				Address: "opa.osm-system.svc.cluster.local",
+				Port:    9191,
+				Timeout: "500ms",
+			}).Times(1)
+			mockConfigurator.EXPECT().GetWASMExtensions().Return([]configurator.WASMExtensionSpec{
+				{
+					Name:           "headers",
+					URI:            "file:///etc/envoy/wasm/headers.wasm",
+					RootID:         "add_header",
+					Config:         `{"header": "x-mesh"}`,
+					InsertionPoint: configurator.WASMInsertionPointInbound,
+				},
+				{
+					Name:           "audit",
+					URI:            "/etc/envoy/wasm/audit.wasm",
+					InsertionPoint: configurator.WASMInsertionPointOutbound,
+				},
+				{
+					Name:           "metrics",
+					URI:            "/etc/envoy/wasm/metrics.wasm",
+					InsertionPoint: configurator.WASMInsertionPointInbound,
+				},
+			}).Times(1)
+
+			connManager, err := getInboundHTTPConnectionManager(mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(len(connManager.HttpFilters)).To(Equal(4))
+			Expect(connManager.HttpFilters[0].Name).To(Equal(wellknown.HTTPExternalAuthorization))
+			Expect(connManager.HttpFilters[1].Name).To(Equal(wasmHTTPFilterName))
+			Expect(connManager.HttpFilters[2].Name).To(Equal(wasmHTTPFilterName))
+			Expect(connManager.HttpFilters[3].Name).To(Equal(wellknown.Router))
+
+			wasm := &xds_wasm_filter.Wasm{}
+			Expect(ptypes.UnmarshalAny(connManager.HttpFilters[1].GetTypedConfig(), wasm)).To(Succeed())
+			Expect(wasm.Config.Name).To(Equal("headers"))
+			Expect(wasm.Config.RootId).To(Equal("add_header"))
+			Expect(wasm.Config.GetVmConfig().Runtime).To(Equal(wasmRuntime))
+			Expect(wasm.Config.GetVmConfig().GetCode().GetLocal().GetFilename()).To(Equal("/etc/envoy/wasm/headers.wasm"))
+			configuration := &wrappers.StringValue{}
+			Expect(ptypes.UnmarshalAny(wasm.Config.Configuration, configuration)).To(Succeed())
+			Expect(configuration.Value).To(Equal(`{"header": "x-mesh"}`))
+
+			Expect(ptypes.UnmarshalAny(connManager.HttpFilters[2].GetTypedConfig(), wasm)).To(Succeed())
+			Expect(wasm.Config.Name).To(Equal("metrics"))
+		})
+	})
+})
+
+var _ = Describe("Test getAuditRBACHTTPFilter", func() {
+	It("Returns the RBAC filter allowing the given services in shadow mode only", func() {
+		filter, err := getAuditRBACHTTPFilter([]service.MeshService{tests.BookstoreService, tests.BookbuyerService}, constants.DefaultTrustDomain)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(filter.Name).To(Equal(rbacHTTPFilterName))
+
+		rbac := &xds_rbac_filter.RBAC{}
+		Expect(ptypes.UnmarshalAny(filter.GetTypedConfig(), rbac)).To(Succeed())
+		Expect(rbac.Rules).To(BeNil())
+		Expect(rbac.ShadowRules.Action).To(Equal(xds_rbac.RBAC_ALLOW))
+
+		policy := rbac.ShadowRules.Policies[smiAuditPolicyName]
+		Expect(len(policy.Permissions)).To(Equal(1))
+		Expect(policy.Permissions[0].GetAny()).To(BeTrue())
+
+		var principalNames []string
+		for _, principal := range policy.Principals {
+			principalNames = append(principalNames, principal.GetAuthenticated().GetPrincipalName().GetExact())
+		}
+		Expect(principalNames).To(Equal([]string{
+			tests.BookbuyerService.GetCommonName().String(),
+			tests.BookstoreService.GetCommonName().String(),
+		}))
+	})
+
+	It("Returns the RBAC filter shadow denying every request without any allowed service", func() {
+		filter, err := getAuditRBACHTTPFilter(nil, constants.DefaultTrustDomain)
+		Expect(err).ToNot(HaveOccurred())
+
+		rbac := &xds_rbac_filter.RBAC{}
+		Expect(ptypes.UnmarshalAny(filter.GetTypedConfig(), rbac)).To(Succeed())
+		Expect(rbac.Rules).To(BeNil())
+		Expect(rbac.ShadowRules.Action).To(Equal(xds_rbac.RBAC_ALLOW))
+		Expect(rbac.ShadowRules.Policies).To(BeEmpty())
 	})
 })
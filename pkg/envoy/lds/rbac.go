@@ -0,0 +1,83 @@
+package lds
+
+import (
+	"sort"
+
+	xds_rbac "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	xds_rbac_filter "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"github.com/openservicemesh/osm/pkg/service"
+)
+
+const (
+	// rbacHTTPFilterName is the name of Envoy's RBAC HTTP filter
+	rbacHTTPFilterName = "envoy.filters.http.rbac"
+
+	// smiAuditPolicyName is the name of the shadow policy allowing the requests from the services the SMI policies allow
+	smiAuditPolicyName = "smi-allowed-inbound-services"
+)
+
+// getAuditRBACHTTPFilter returns the RBAC HTTP filter evaluating the inbound requests against the SMI policies in shadow
+// mode: the requests from the services other than the given allowed ones are logged as shadow denied, and counted in the
+// rbac.shadow_denied stat, without being denied
+func getAuditRBACHTTPFilter(allowedServices []service.MeshService, trustDomain string) (*xds_hcm.HttpFilter, error) {
+	// The principals are sorted, so the config of the proxies only changes along with the SMI policies
+	var principalNames []string
+	for _, svc := range allowedServices {
+		principalNames = append(principalNames, svc.GetCommonNameForTrustDomain(trustDomain).String())
+	}
+	sort.Strings(principalNames)
+
+	// The client certificates carry the common name of their service as a SAN
+	var principals []*xds_rbac.Principal
+	for _, principalName := range principalNames {
+		principals = append(principals, &xds_rbac.Principal{
+			Identifier: &xds_rbac.Principal_Authenticated_{
+				Authenticated: &xds_rbac.Principal_Authenticated{
+					PrincipalName: &xds_matcher.StringMatcher{
+						MatchPattern: &xds_matcher.StringMatcher_Exact{
+							Exact: principalName,
+						},
+					},
+				},
+			},
+		})
+	}
+
+	// Without any allowed service, every request is shadow denied, as the SMI policies would deny them all
+	policies := make(map[string]*xds_rbac.Policy)
+	if len(principals) > 0 {
+		policies[smiAuditPolicyName] = &xds_rbac.Policy{
+			Permissions: []*xds_rbac.Permission{
+				{
+					Rule: &xds_rbac.Permission_Any{
+						Any: true,
+					},
+				},
+			},
+			Principals: principals,
+		}
+	}
+
+	// The RBAC filter only enforces its rules, which are unset, and only evaluates its shadow rules
+	marshalledRBAC, err := ptypes.MarshalAny(&xds_rbac_filter.RBAC{
+		ShadowRules: &xds_rbac.RBAC{
+			Action:   xds_rbac.RBAC_ALLOW,
+			Policies: policies,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name: rbacHTTPFilterName,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+			TypedConfig: marshalledRBAC,
+		},
+	}, nil
+}
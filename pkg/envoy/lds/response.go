@@ -31,12 +31,18 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 	// Github Issue #1575
 	proxyServiceName := svcList[0]
 
+	configChannel, err := catalog.GetConfigChannelFromEnvoyCertificate(proxy.GetCommonName())
+	if err != nil {
+		log.Error().Err(err).Msgf("Error looking up config channel for Envoy with CN=%q", proxy.GetCommonName())
+		return nil, err
+	}
+
 	resp := &xds_discovery.DiscoveryResponse{
 		TypeUrl: string(envoy.TypeLDS),
 	}
 
 	// --- OUTBOUND -------------------
-	if outboundListener, err := newOutboundListener(cfg); err != nil {
+	if outboundListener, err := newOutboundListener(cfg, configChannel); err != nil {
 		log.Error().Err(err).Msgf("Error making outbound listener config for proxy %s", proxyServiceName)
 	} else {
 		if marshalledOutbound, err := ptypes.MarshalAny(outboundListener); err != nil {
@@ -48,11 +54,16 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 
 	// --- INBOUND -------------------
 	inboundListener := newInboundListener()
-	if meshFilterChain, err := getInboundInMeshFilterChain(proxyServiceName, cfg); err != nil {
+	if meshFilterChain, err := getInboundInMeshFilterChain(proxyServiceName, catalog, cfg); err != nil {
 		log.Error().Err(err).Msgf("Error making in-mesh filter chain for proxy %s", proxy.GetCommonName())
 	} else if meshFilterChain != nil {
 		inboundListener.FilterChains = append(inboundListener.FilterChains, meshFilterChain)
 	}
+	if exemptFilterChain, err := getInboundMTLSExemptFilterChain(proxyServiceName, cfg); err != nil {
+		log.Error().Err(err).Msgf("Error making mTLS exempt filter chain for proxy %s", proxy.GetCommonName())
+	} else if exemptFilterChain != nil {
+		inboundListener.FilterChains = append(inboundListener.FilterChains, exemptFilterChain)
+	}
 
 	// --- INGRESS -------------------
 	// Apply an ingress filter chain if there are any ingress routes
@@ -83,8 +94,8 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, _ *xds_disco
 
 	if cfg.IsPrometheusScrapingEnabled() {
 		// Build Prometheus listener config
-		prometheusConnManager := getPrometheusConnectionManager(prometheusListenerName, constants.PrometheusScrapePath, constants.EnvoyMetricsCluster)
-		if prometheusListener, err := buildPrometheusListener(prometheusConnManager); err != nil {
+		prometheusConnManager := getPrometheusConnectionManager(prometheusListenerName, cfg.GetPrometheusScrapePath(), constants.EnvoyMetricsCluster, cfg)
+		if prometheusListener, err := buildPrometheusListener(prometheusConnManager, cfg.GetPrometheusScrapePort()); err != nil {
 			log.Error().Err(err).Msgf("Error building Prometheus listener config for proxy %s", proxyServiceName)
 		} else {
 			if marshalledPrometheus, err := ptypes.MarshalAny(prometheusListener); err != nil {
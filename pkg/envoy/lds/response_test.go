@@ -1,12 +1,19 @@
 package lds
 
 import (
+	"net"
+
+	xds_rbac_filter "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/rbac/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	testclient "k8s.io/client-go/kubernetes/fake"
 
+	"github.com/openservicemesh/osm/pkg/catalog"
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
@@ -28,6 +35,16 @@ var _ = Describe("Test LDS response", func() {
 			mockConfigurator.EXPECT().IsTracingEnabled().Return(false).AnyTimes()
 			mockConfigurator.EXPECT().GetTracingHost().Return(constants.DefaultTracingHost).AnyTimes()
 			mockConfigurator.EXPECT().GetTracingPort().Return(constants.DefaultTracingPort).AnyTimes()
+			mockConfigurator.EXPECT().GetEnvoyConnectionIdleTimeout().Return(constants.DefaultEnvoyConnectionIdleTimeout).AnyTimes()
+			mockConfigurator.EXPECT().StripForwardedHeaders().Return(false).AnyTimes()
+			mockConfigurator.EXPECT().GetStatsPrefix().Return("").AnyTimes()
+			mockConfigurator.EXPECT().GetInboundExternalAuthConfig().Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().GetWASMExtensions().Return(nil).AnyTimes()
+			mockConfigurator.EXPECT().IsAccessLoggingEnabled().Return(true).AnyTimes()
+			mockConfigurator.EXPECT().GetAccessLogFormat().Return(configurator.AccessLogFormatJSON).AnyTimes()
+			mockConfigurator.EXPECT().GetTrustDomain().Return(constants.DefaultTrustDomain).AnyTimes()
+			mockConfigurator.EXPECT().GetMeshTLSVersions().Return("TLS1_2", "TLS1_3").AnyTimes()
+			mockConfigurator.EXPECT().GetMeshCipherSuites().Return(nil).AnyTimes()
 		})
 
 		It("constructs filter chain used for HTTPS ingress", func() {
@@ -64,7 +81,9 @@ var _ = Describe("Test LDS response", func() {
 		})
 
 		It("constructs in-mesh filter chain", func() {
-			filterChain, err := getInboundInMeshFilterChain(tests.BookstoreService, mockConfigurator)
+			mockConfigurator.EXPECT().IsPermissiveAuditLoggingEnabled().Return(false).Times(1)
+
+			filterChain, err := getInboundInMeshFilterChain(tests.BookstoreService, nil, mockConfigurator)
 			Expect(err).ToNot(HaveOccurred())
 
 			expectedServerNames := []string{tests.BookstoreService.GetCommonName().String()}
@@ -83,5 +102,52 @@ var _ = Describe("Test LDS response", func() {
 			// Show what that actually looks like
 			Expect(tlsContext.Sni).To(Equal("bookstore.default.svc.cluster.local"))
 		})
+
+		It("constructs in-mesh filter chain auditing the requests the SMI policies would deny", func() {
+			mockConfigurator.EXPECT().IsPermissiveAuditLoggingEnabled().Return(true).Times(1)
+			mc := catalog.NewFakeMeshCatalog(testclient.NewSimpleClientset())
+
+			filterChain, err := getInboundInMeshFilterChain(tests.BookstoreService, mc, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			connManager := &xds_hcm.HttpConnectionManager{}
+			Expect(ptypes.UnmarshalAny(filterChain.Filters[0].GetTypedConfig(), connManager)).To(Succeed())
+			Expect(len(connManager.HttpFilters)).To(Equal(2))
+			Expect(connManager.HttpFilters[0].Name).To(Equal(rbacHTTPFilterName))
+			Expect(connManager.HttpFilters[1].Name).To(Equal(wellknown.Router))
+
+			rbac := &xds_rbac_filter.RBAC{}
+			Expect(ptypes.UnmarshalAny(connManager.HttpFilters[0].GetTypedConfig(), rbac)).To(Succeed())
+			Expect(rbac.Rules).To(BeNil())
+			principals := rbac.ShadowRules.Policies[smiAuditPolicyName].Principals
+			Expect(len(principals)).To(Equal(1))
+			Expect(principals[0].GetAuthenticated().GetPrincipalName().GetExact()).To(Equal(tests.BookbuyerService.GetCommonName().String()))
+		})
+
+		It("constructs the plaintext filter chain of the sources exempt from mTLS", func() {
+			_, nodeCIDR, err := net.ParseCIDR("10.240.0.0/16")
+			Expect(err).ToNot(HaveOccurred())
+			mockConfigurator.EXPECT().GetMTLSExemptSourceCIDRs().Return([]*net.IPNet{nodeCIDR}).Times(1)
+
+			filterChain, err := getInboundMTLSExemptFilterChain(tests.BookstoreService, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filterChain.Name).To(Equal(inboundMTLSExemptFilterChainName))
+			Expect(filterChain.FilterChainMatch.TransportProtocol).To(Equal(envoy.TransportProtocolRawBuffer))
+			Expect(filterChain.FilterChainMatch.ServerNames).To(BeNil())
+			Expect(len(filterChain.FilterChainMatch.SourcePrefixRanges)).To(Equal(1))
+			Expect(filterChain.FilterChainMatch.SourcePrefixRanges[0].AddressPrefix).To(Equal("10.240.0.0"))
+			Expect(filterChain.FilterChainMatch.SourcePrefixRanges[0].PrefixLen.GetValue()).To(Equal(uint32(16)))
+			Expect(filterChain.TransportSocket).To(BeNil())
+			Expect(len(filterChain.Filters)).To(Equal(1))
+			Expect(filterChain.Filters[0].Name).To(Equal(wellknown.HTTPConnectionManager))
+		})
+
+		It("constructs no mTLS exempt filter chain when mTLS is enforced for every source", func() {
+			mockConfigurator.EXPECT().GetMTLSExemptSourceCIDRs().Return(nil).Times(1)
+
+			filterChain, err := getInboundMTLSExemptFilterChain(tests.BookstoreService, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filterChain).To(BeNil())
+		})
 	})
 })
@@ -3,6 +3,7 @@ package lds
 import (
 	xds_tracing "github.com/envoyproxy/go-control-plane/envoy/config/trace/v3"
 	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_type "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/golang/protobuf/ptypes"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
@@ -11,6 +12,14 @@ import (
 
 // GetTracingConfig returns a configuration tracing struct for a connection manager to use
 func GetTracingConfig(cfg configurator.Configurator) (*xds_hcm.HttpConnectionManager_Tracing, error) {
+	switch tracingBackend := cfg.GetTracingBackend(); tracingBackend {
+	case configurator.TracingBackendZipkin, configurator.TracingBackendJaeger:
+		// Jaeger collectors accept Zipkin spans, so both backends are configured with Envoy's Zipkin tracer
+	default:
+		log.Error().Err(errUnsupportedTracingBackend).Msgf("Tracing backend %s is not supported by the Envoy tracing config", tracingBackend)
+		return nil, errUnsupportedTracingBackend
+	}
+
 	zipkinTracingConf := &xds_tracing.ZipkinConfig{
 		CollectorCluster:         constants.EnvoyTracingCluster,
 		CollectorEndpoint:        cfg.GetTracingEndpoint(),
@@ -25,6 +34,9 @@ func GetTracingConfig(cfg configurator.Configurator) (*xds_hcm.HttpConnectionMan
 
 	tracing := &xds_hcm.HttpConnectionManager_Tracing{
 		Verbose: true,
+		RandomSampling: &xds_type.Percent{
+			Value: cfg.GetTracingSamplingRate() * 100,
+		},
 		Provider: &xds_tracing.Tracing_Http{
 			// Name must refer to an instantiatable tracing driver
 			Name: "envoy.tracers.zipkin",
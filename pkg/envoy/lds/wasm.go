@@ -0,0 +1,82 @@
+package lds
+
+import (
+	xds_core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	xds_wasm_filter "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/wasm/v3"
+	xds_hcm "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	xds_wasm "github.com/envoyproxy/go-control-plane/envoy/extensions/wasm/v3"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	"github.com/openservicemesh/osm/pkg/configurator"
+)
+
+const (
+	// wasmHTTPFilterName is the name of Envoy's wasm HTTP filter
+	wasmHTTPFilterName = "envoy.filters.http.wasm"
+
+	// wasmRuntime is the WebAssembly runtime the extensions run in
+	wasmRuntime = "envoy.wasm.runtime.v8"
+)
+
+// getWASMHTTPFilters returns the wasm HTTP filters, in order, running the WebAssembly extensions with the given insertion point
+func getWASMHTTPFilters(insertionPoint string, cfg configurator.Configurator) ([]*xds_hcm.HttpFilter, error) {
+	var filters []*xds_hcm.HttpFilter
+	for _, wasmExtension := range cfg.GetWASMExtensions() {
+		if wasmExtension.InsertionPoint != insertionPoint {
+			continue
+		}
+		filter, err := getWASMHTTPFilter(wasmExtension)
+		if err != nil {
+			log.Error().Err(err).Msgf("Error marshalling wasm filter for WASM extension %s", wasmExtension.Name)
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+// getWASMHTTPFilter returns the wasm HTTP filter running the given WebAssembly extension in a VM of its own
+func getWASMHTTPFilter(wasmExtension configurator.WASMExtensionSpec) (*xds_hcm.HttpFilter, error) {
+	// The module path has been validated by the configurator
+	modulePath, _ := wasmExtension.ModulePath()
+
+	configuration, err := ptypes.MarshalAny(&wrappers.StringValue{Value: wasmExtension.Config})
+	if err != nil {
+		return nil, err
+	}
+
+	marshalledWASM, err := ptypes.MarshalAny(&xds_wasm_filter.Wasm{
+		Config: &xds_wasm.PluginConfig{
+			Name:   wasmExtension.Name,
+			RootId: wasmExtension.RootID,
+			Vm: &xds_wasm.PluginConfig_VmConfig{
+				VmConfig: &xds_wasm.VmConfig{
+					VmId:    wasmExtension.Name,
+					Runtime: wasmRuntime,
+					Code: &xds_core.AsyncDataSource{
+						Specifier: &xds_core.AsyncDataSource_Local{
+							Local: &xds_core.DataSource{
+								Specifier: &xds_core.DataSource_Filename{
+									Filename: modulePath,
+								},
+							},
+						},
+					},
+				},
+			},
+			Configuration: configuration,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &xds_hcm.HttpFilter{
+		Name: wasmHTTPFilterName,
+		ConfigType: &xds_hcm.HttpFilter_TypedConfig{
+			TypedConfig: marshalledWASM,
+		},
+	}, nil
+}
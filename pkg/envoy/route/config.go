@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	set "github.com/deckarep/golang-set"
 	xds_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	xds_matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
 
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/kubernetes"
@@ -49,7 +52,10 @@ const (
 )
 
 //UpdateRouteConfiguration consrtucts the Envoy construct necessary for TrafficTarget implementation
-func UpdateRouteConfiguration(domainRoutesMap map[string]map[string]trafficpolicy.RouteWeightedClusters, routeConfig *xds_route.RouteConfiguration, direction Direction) {
+// The request timeout applies to all the routes; a zero request timeout disables the timeout.
+// The retry policy, unless nil, applies to the outbound routes, since the SMI policies do not define retry policies;
+// the requests are retried by the proxy of the client only, so the retries of each hop do not multiply.
+func UpdateRouteConfiguration(domainRoutesMap map[string]map[string]trafficpolicy.RouteWeightedClusters, routeConfig *xds_route.RouteConfiguration, direction Direction, requestTimeout time.Duration, retryPolicy *configurator.RetryPolicy) {
 	log.Trace().Msgf("[RDS] Updating Route Configuration")
 	var virtualHostPrefix string
 
@@ -67,7 +73,7 @@ func UpdateRouteConfiguration(domainRoutesMap map[string]map[string]trafficpolic
 
 	for domain, routePolicyWeightedClustersMap := range domainRoutesMap {
 		virtualHost := createVirtualHostStub(virtualHostPrefix, domain)
-		virtualHost.Routes = createRoutes(routePolicyWeightedClustersMap, direction)
+		virtualHost.Routes = createRoutes(routePolicyWeightedClustersMap, direction, requestTimeout, retryPolicy)
 		routeConfig.VirtualHosts = append(routeConfig.VirtualHosts, virtualHost)
 	}
 }
@@ -88,14 +94,14 @@ func createVirtualHostStub(namePrefix string, domain string) *xds_route.VirtualH
 	return &virtualHost
 }
 
-func createRoutes(routePolicyWeightedClustersMap map[string]trafficpolicy.RouteWeightedClusters, direction Direction) []*xds_route.Route {
+func createRoutes(routePolicyWeightedClustersMap map[string]trafficpolicy.RouteWeightedClusters, direction Direction, requestTimeout time.Duration, retryPolicy *configurator.RetryPolicy) []*xds_route.Route {
 	var routes []*xds_route.Route
 	if direction == OutboundRoute {
 		// For a source service, configure a wildcard route match (without any headers) with weighted routes to upstream clusters based on traffic split policies
 		weightedClusters := getDistinctWeightedClusters(routePolicyWeightedClustersMap)
 		totalClustersWeight := getTotalWeightForClusters(weightedClusters)
 		emptyHeaders := make(map[string]string)
-		route := getRoute(constants.RegexMatchAll, constants.WildcardHTTPMethod, emptyHeaders, weightedClusters, totalClustersWeight, OutboundRoute)
+		route := getRoute(constants.RegexMatchAll, constants.WildcardHTTPMethod, emptyHeaders, weightedClusters, totalClustersWeight, OutboundRoute, requestTimeout, retryPolicy)
 		routes = append(routes, route)
 		return routes
 	}
@@ -104,14 +110,14 @@ func createRoutes(routePolicyWeightedClustersMap map[string]trafficpolicy.RouteW
 		// is wildcard or if there are duplicates
 		allowedMethods := sanitizeHTTPMethods(routePolicyWeightedClusters.HTTPRoute.Methods)
 		for _, method := range allowedMethods {
-			route := getRoute(routePolicyWeightedClusters.HTTPRoute.PathRegex, method, routePolicyWeightedClusters.HTTPRoute.Headers, routePolicyWeightedClusters.WeightedClusters, 100, direction)
+			route := getRoute(routePolicyWeightedClusters.HTTPRoute.PathRegex, method, routePolicyWeightedClusters.HTTPRoute.Headers, routePolicyWeightedClusters.WeightedClusters, 100, direction, requestTimeout, retryPolicy)
 			routes = append(routes, route)
 		}
 	}
 	return routes
 }
 
-func getRoute(pathRegex string, method string, headersMap map[string]string, weightedClusters set.Set, totalClustersWeight int, direction Direction) *xds_route.Route {
+func getRoute(pathRegex string, method string, headersMap map[string]string, weightedClusters set.Set, totalClustersWeight int, direction Direction, requestTimeout time.Duration, retryPolicy *configurator.RetryPolicy) *xds_route.Route {
 	route := xds_route.Route{
 		Match: &xds_route.RouteMatch{
 			PathSpecifier: &xds_route.RouteMatch_SafeRegex{
@@ -127,12 +133,38 @@ func getRoute(pathRegex string, method string, headersMap map[string]string, wei
 				ClusterSpecifier: &xds_route.RouteAction_WeightedClusters{
 					WeightedClusters: getWeightedCluster(weightedClusters, totalClustersWeight, direction),
 				},
+				// Always set, as Envoy interprets an unset timeout as its own default rather than as no timeout
+				Timeout: ptypes.DurationProto(requestTimeout),
 			},
 		},
 	}
+	if direction == OutboundRoute {
+		route.GetRoute().RetryPolicy = getRetryPolicy(retryPolicy)
+	}
 	return &route
 }
 
+// getRetryPolicy returns the Envoy retry policy of the given retry policy, or nil when it is nil
+func getRetryPolicy(retryPolicy *configurator.RetryPolicy) *xds_route.RetryPolicy {
+	if retryPolicy == nil {
+		return nil
+	}
+
+	envoyRetryPolicy := &xds_route.RetryPolicy{
+		RetryOn:    retryPolicy.RetryOn,
+		NumRetries: &wrappers.UInt32Value{Value: retryPolicy.NumRetries},
+	}
+	if retryPolicy.PerTryTimeout != "" {
+		perTryTimeout, err := time.ParseDuration(retryPolicy.PerTryTimeout)
+		if err != nil {
+			log.Error().Err(err).Msgf("Invalid per-try timeout %q of the retry policy; Ignoring per-try timeout", retryPolicy.PerTryTimeout)
+		} else {
+			envoyRetryPolicy.PerTryTimeout = ptypes.DurationProto(perTryTimeout)
+		}
+	}
+	return envoyRetryPolicy
+}
+
 func getHeadersForRoute(method string, headersMap map[string]string) []*xds_route.HeaderMatcher {
 	var headers []*xds_route.HeaderMatcher
 
@@ -258,6 +290,11 @@ func NewRouteConfigurationStub(routeConfigName string) *xds_route.RouteConfigura
 	return &routeConfiguration
 }
 
+// StripForwardedHeaders configures the route configuration to remove the X-Forwarded-* headers of the requests it routes
+func StripForwardedHeaders(routeConfig *xds_route.RouteConfiguration) {
+	routeConfig.RequestHeadersToRemove = append(routeConfig.RequestHeadersToRemove, forwardedHeaders...)
+}
+
 func getRegexForMethod(httpMethod string) string {
 	methodRegex := httpMethod
 	if httpMethod == constants.WildcardHTTPMethod {
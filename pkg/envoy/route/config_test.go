@@ -3,15 +3,18 @@ package route
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	envoy_route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 
 	set "github.com/deckarep/golang-set"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/envoy"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -155,7 +158,7 @@ var _ = Describe("Routes with weighted clusters", func() {
 			}
 
 			routeWeightedClustersMap[routePolicy.PathRegex] = trafficpolicy.RouteWeightedClusters{HTTPRoute: routePolicy, WeightedClusters: weightedClusters}
-			rt := createRoutes(routeWeightedClustersMap, InboundRoute)
+			rt := createRoutes(routeWeightedClustersMap, InboundRoute, constants.DefaultEnvoyRequestTimeout, nil)
 			Expect(len(rt)).To(Equal(len(routePolicy.Methods)))
 
 			for i, route := range rt {
@@ -182,7 +185,7 @@ var _ = Describe("Routes with weighted clusters", func() {
 
 			httpMethodCount := 3 // 2 from previously added routes + 1 append
 
-			rt := createRoutes(routeWeightedClustersMap, InboundRoute)
+			rt := createRoutes(routeWeightedClustersMap, InboundRoute, constants.DefaultEnvoyRequestTimeout, nil)
 
 			Expect(len(rt)).To(Equal(httpMethodCount))
 			var newRoute *envoy_route.Route
@@ -235,7 +238,7 @@ var _ = Describe("Route Configuration", func() {
 
 			//Validating the outbound clusters and routes
 			outboundRouteConfig := NewRouteConfigurationStub(OutboundRouteConfigName)
-			UpdateRouteConfiguration(sourceDomainAggregatedData, outboundRouteConfig, OutboundRoute)
+			UpdateRouteConfiguration(sourceDomainAggregatedData, outboundRouteConfig, OutboundRoute, constants.DefaultEnvoyRequestTimeout, nil)
 			Expect(outboundRouteConfig).NotTo(Equal(nil))
 			Expect(outboundRouteConfig.Name).To(Equal(OutboundRouteConfigName))
 			Expect(len(outboundRouteConfig.VirtualHosts)).To(Equal(len(sourceDomainAggregatedData)))
@@ -245,6 +248,84 @@ var _ = Describe("Route Configuration", func() {
 			Expect(outboundRouteConfig.VirtualHosts[0].Routes[0].Match.GetHeaders()[0].GetSafeRegexMatch().Regex).To(Equal(constants.RegexMatchAll))
 			Expect(len(outboundRouteConfig.VirtualHosts[0].Routes[0].GetRoute().GetWeightedClusters().GetClusters())).To(Equal(weightedClusters.Cardinality()))
 			Expect(outboundRouteConfig.VirtualHosts[0].Routes[0].GetRoute().GetWeightedClusters().TotalWeight).To(Equal(&wrappers.UInt32Value{Value: uint32(totalClusterWeight)}))
+			Expect(outboundRouteConfig.VirtualHosts[0].Routes[0].GetRoute().Timeout).To(Equal(ptypes.DurationProto(constants.DefaultEnvoyRequestTimeout)))
+		})
+
+		It("Returns route configuration without a request timeout for a zero request timeout", func() {
+			weightedClusters := set.NewSet()
+			weightedClusters.Add(service.WeightedCluster{ClusterName: service.ClusterName("osm/bookstore-1"), Weight: 100})
+
+			routePolicy := trafficpolicy.HTTPRoute{
+				PathRegex: "/books-bought",
+				Methods:   []string{"GET"},
+			}
+			domainAggregatedData := map[string]map[string]trafficpolicy.RouteWeightedClusters{
+				"bookstore.mesh": {
+					routePolicy.PathRegex: {HTTPRoute: routePolicy, WeightedClusters: weightedClusters},
+				},
+			}
+
+			routeConfig := NewRouteConfigurationStub(InboundRouteConfigName)
+			UpdateRouteConfiguration(domainAggregatedData, routeConfig, InboundRoute, 0, nil)
+			Expect(len(routeConfig.VirtualHosts)).To(Equal(1))
+			Expect(len(routeConfig.VirtualHosts[0].Routes)).To(Equal(1))
+			// An explicit zero timeout disables the timeout, whereas Envoy applies its own default to an unset one
+			Expect(routeConfig.VirtualHosts[0].Routes[0].GetRoute().Timeout).To(Equal(ptypes.DurationProto(0)))
+		})
+
+		It("Returns route configuration retrying the outbound requests with the default retry policy", func() {
+			weightedClusters := set.NewSet()
+			weightedClusters.Add(service.WeightedCluster{ClusterName: service.ClusterName("osm/bookstore-1"), Weight: 100})
+
+			routePolicy := trafficpolicy.HTTPRoute{
+				PathRegex: "/books-bought",
+				Methods:   []string{"GET"},
+			}
+			domainAggregatedData := map[string]map[string]trafficpolicy.RouteWeightedClusters{
+				"bookstore.mesh": {
+					routePolicy.PathRegex: {HTTPRoute: routePolicy, WeightedClusters: weightedClusters},
+				},
+			}
+			retryPolicy := &configurator.RetryPolicy{
+				NumRetries:    3,
+				PerTryTimeout: "2s",
+				RetryOn:       "5xx,reset",
+			}
+
+			outboundRouteConfig := NewRouteConfigurationStub(OutboundRouteConfigName)
+			UpdateRouteConfiguration(domainAggregatedData, outboundRouteConfig, OutboundRoute, constants.DefaultEnvoyRequestTimeout, retryPolicy)
+			Expect(len(outboundRouteConfig.VirtualHosts)).To(Equal(1))
+			Expect(len(outboundRouteConfig.VirtualHosts[0].Routes)).To(Equal(1))
+			Expect(outboundRouteConfig.VirtualHosts[0].Routes[0].GetRoute().RetryPolicy).To(Equal(&envoy_route.RetryPolicy{
+				RetryOn:       "5xx,reset",
+				NumRetries:    &wrappers.UInt32Value{Value: 3},
+				PerTryTimeout: ptypes.DurationProto(2 * time.Second),
+			}))
+
+			// The requests are only retried by the proxy of the client
+			inboundRouteConfig := NewRouteConfigurationStub(InboundRouteConfigName)
+			UpdateRouteConfiguration(domainAggregatedData, inboundRouteConfig, InboundRoute, constants.DefaultEnvoyRequestTimeout, retryPolicy)
+			Expect(len(inboundRouteConfig.VirtualHosts[0].Routes)).To(Equal(1))
+			Expect(inboundRouteConfig.VirtualHosts[0].Routes[0].GetRoute().RetryPolicy).To(BeNil())
+		})
+
+		It("Returns route configuration without a retry policy when retries are disabled", func() {
+			weightedClusters := set.NewSet()
+			weightedClusters.Add(service.WeightedCluster{ClusterName: service.ClusterName("osm/bookstore-1"), Weight: 100})
+
+			routePolicy := trafficpolicy.HTTPRoute{
+				PathRegex: "/books-bought",
+				Methods:   []string{"GET"},
+			}
+			domainAggregatedData := map[string]map[string]trafficpolicy.RouteWeightedClusters{
+				"bookstore.mesh": {
+					routePolicy.PathRegex: {HTTPRoute: routePolicy, WeightedClusters: weightedClusters},
+				},
+			}
+
+			outboundRouteConfig := NewRouteConfigurationStub(OutboundRouteConfigName)
+			UpdateRouteConfiguration(domainAggregatedData, outboundRouteConfig, OutboundRoute, constants.DefaultEnvoyRequestTimeout, nil)
+			Expect(outboundRouteConfig.VirtualHosts[0].Routes[0].GetRoute().RetryPolicy).To(BeNil())
 		})
 
 		It("Returns inbound route configuration", func() {
@@ -275,7 +356,7 @@ var _ = Describe("Route Configuration", func() {
 
 			//Validating the inbound clusters and routes
 			destRouteConfig := NewRouteConfigurationStub(InboundRouteConfigName)
-			UpdateRouteConfiguration(destDomainAggregatedData, destRouteConfig, InboundRoute)
+			UpdateRouteConfiguration(destDomainAggregatedData, destRouteConfig, InboundRoute, constants.DefaultEnvoyRequestTimeout, nil)
 			Expect(destRouteConfig).NotTo(Equal(nil))
 			Expect(destRouteConfig.Name).To(Equal(InboundRouteConfigName))
 			Expect(len(destRouteConfig.VirtualHosts)).To(Equal(len(destDomainAggregatedData)))
@@ -350,3 +431,21 @@ var _ = Describe("Routes with headers", func() {
 		})
 	})
 })
+
+var _ = Describe("Forwarded headers stripping", func() {
+	Context("Testing StripForwardedHeaders", func() {
+		It("Removes the X-Forwarded-* headers from the requests", func() {
+			routeConfig := NewRouteConfigurationStub(InboundRouteConfigName)
+			StripForwardedHeaders(routeConfig)
+			Expect(routeConfig.RequestHeadersToRemove).To(ConsistOf("x-forwarded-for", "x-forwarded-host", "x-forwarded-port", "x-forwarded-proto"))
+		})
+
+		It("Preserves the headers already removed from the requests", func() {
+			routeConfig := NewRouteConfigurationStub(OutboundRouteConfigName)
+			routeConfig.RequestHeadersToRemove = []string{"x-custom"}
+			StripForwardedHeaders(routeConfig)
+			Expect(routeConfig.RequestHeadersToRemove).To(HaveLen(5))
+			Expect(routeConfig.RequestHeadersToRemove).To(ContainElement("x-custom"))
+		})
+	})
+})
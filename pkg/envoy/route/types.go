@@ -6,4 +6,7 @@ import (
 
 var (
 	log = logger.New("envoy/route")
+
+	// forwardedHeaders are the X-Forwarded-* headers removed from the requests when the forwarded headers are stripped
+	forwardedHeaders = []string{"x-forwarded-for", "x-forwarded-host", "x-forwarded-port", "x-forwarded-proto"}
 )
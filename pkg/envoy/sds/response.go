@@ -23,7 +23,7 @@ var directionMap = map[envoy.SDSCertType]string{
 }
 
 // NewResponse creates a new Secrets Discovery Response.
-func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, request *xds_discovery.DiscoveryRequest, _ configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
+func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, request *xds_discovery.DiscoveryRequest, cfg configurator.Configurator) (*xds_discovery.DiscoveryResponse, error) {
 	log.Info().Msgf("Composing SDS Discovery Response for proxy: %s", proxy.GetCommonName())
 
 	svcList, err := catalog.GetServicesFromEnvoyCertificate(proxy.GetCommonName())
@@ -48,7 +48,7 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, request *xds
 	log.Trace().Msgf("Received SDS request for ResourceNames (certificates) %+v", requestedCerts)
 
 	// request.ResourceNames is expected to be a list of either "service-cert:namespace/service" or "root-cert:namespace/service"
-	for _, envoyProto := range getEnvoySDSSecrets(cert, proxy, requestedCerts, catalog) {
+	for _, envoyProto := range getEnvoySDSSecrets(cert, proxy, requestedCerts, catalog, cfg.GetTrustDomain()) {
 		marshalledSecret, err := ptypes.MarshalAny(envoyProto)
 		if err != nil {
 			log.Error().Err(err).Msgf("Error marshaling Envoy secret %s for proxy %s for service %s", envoyProto.Name, proxy.GetCommonName(), serviceForProxy.String())
@@ -63,7 +63,7 @@ func NewResponse(catalog catalog.MeshCataloger, proxy *envoy.Proxy, request *xds
 	}, nil
 }
 
-func getEnvoySDSSecrets(cert certificate.Certificater, proxy *envoy.Proxy, requestedCerts []string, catalog catalog.MeshCataloger) []*xds_auth.Secret {
+func getEnvoySDSSecrets(cert certificate.Certificater, proxy *envoy.Proxy, requestedCerts []string, catalog catalog.MeshCataloger, trustDomain string) []*xds_auth.Secret {
 	// requestedCerts is expected to be a list of either "service-cert:namespace/service" or "root-cert:namespace/service"
 
 	var envoySecrets []*xds_auth.Secret
@@ -106,7 +106,7 @@ func getEnvoySDSSecrets(cert certificate.Certificater, proxy *envoy.Proxy, reque
 			fallthrough
 		case envoy.RootCertTypeForHTTPS:
 			log.Info().Msgf("proxy %s (member of service %s) requested %s", proxy.GetCommonName(), serviceForProxy.String(), requestedCertificate)
-			envoySecret, err := getRootCert(cert, *sdsCert, serviceForProxy, catalog)
+			envoySecret, err := getRootCert(cert, *sdsCert, serviceForProxy, catalog, trustDomain)
 			if err != nil {
 				log.Error().Err(err).Msgf("Error creating cert %s for proxy %s for service %s", requestedCertificate, proxy.GetCommonName(), serviceForProxy.String())
 				continue
@@ -141,7 +141,9 @@ func getServiceCertSecret(cert certificate.Certificater, name string) (*xds_auth
 	return secret, nil
 }
 
-func getRootCert(cert certificate.Certificater, sdscert envoy.SDSCert, proxyServiceName service.MeshService, mc catalog.MeshCataloger) (*xds_auth.Secret, error) {
+// getRootCert returns the root certificate secret of the given type, which only trusts the certificates of the services,
+// in the given trust domain, allowed to connect to or from the proxy's service
+func getRootCert(cert certificate.Certificater, sdscert envoy.SDSCert, proxyServiceName service.MeshService, mc catalog.MeshCataloger, trustDomain string) (*xds_auth.Secret, error) {
 	secret := &xds_auth.Secret{
 		// The Name field must match the tls_context.common_tls_context.tls_certificate_sds_secret_configs.name
 		Name: sdscert.String(),
@@ -181,10 +183,10 @@ func getRootCert(cert certificate.Certificater, sdscert envoy.SDSCert, proxyServ
 
 		var matchingCerts []string
 		for _, serverName := range serverNames {
-			matchingCerts = append(matchingCerts, serverName.GetCommonName().String())
+			matchingCerts = append(matchingCerts, serverName.GetCommonNameForTrustDomain(trustDomain).String())
 			match := xds_matcher.StringMatcher{
 				MatchPattern: &xds_matcher.StringMatcher_Exact{
-					Exact: serverName.GetCommonName().String(),
+					Exact: serverName.GetCommonNameForTrustDomain(trustDomain).String(),
 				},
 			}
 			matchSANs = append(matchSANs, &match)
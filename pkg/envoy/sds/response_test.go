@@ -80,7 +80,7 @@ var _ = Describe("Test SDS response functions", func() {
 
 			resourceName := sdsc.String()
 			mc := catalog.NewFakeMeshCatalog(testclient.NewSimpleClientset())
-			actual, err := getRootCert(cert, sdsc, tests.BookstoreService, mc)
+			actual, err := getRootCert(cert, sdsc, tests.BookstoreService, mc, constants.DefaultTrustDomain)
 			Expect(err).ToNot(HaveOccurred())
 
 			expected := &xds_auth.Secret{
@@ -112,6 +112,24 @@ var _ = Describe("Test SDS response functions", func() {
 			Expect(actual.GetValidationContext()).To(Equal(expected.GetValidationContext()))
 			Expect(actual).To(Equal(expected))
 		})
+
+		It("matches the SANs in the given trust domain", func() {
+			cache := make(map[certificate.CommonName]certificate.Certificater)
+			certManager := tresor.NewFakeCertManager(&cache, 1*time.Hour)
+
+			cert, err := certManager.IssueCertificate("blah", nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			sdsc := envoy.SDSCert{
+				MeshService: tests.BookstoreService,
+				CertType:    envoy.RootCertTypeForMTLSInbound,
+			}
+
+			mc := catalog.NewFakeMeshCatalog(testclient.NewSimpleClientset())
+			actual, err := getRootCert(cert, sdsc, tests.BookstoreService, mc, "mesh.example.com")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(actual.GetValidationContext().MatchSubjectAltNames[0].GetExact()).To(Equal("bookbuyer.default.svc.mesh.example.com"))
+		})
 	})
 
 	Context("Test getEnvoySDSSecrets()", func() {
@@ -131,7 +149,7 @@ var _ = Describe("Test SDS response functions", func() {
 			resourceNames := []string{sdsc.String()}
 			cert, proxy, mc := prep(resourceNames, namespace, serviceName)
 
-			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc)
+			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc, constants.DefaultTrustDomain)
 
 			Expect(len(actual)).To(Equal(1))
 			Expect(actual[0].Name).To(Equal(sdsc.String()))
@@ -146,7 +164,7 @@ var _ = Describe("Test SDS response functions", func() {
 			resourceNames := []string{fmt.Sprintf("root-cert-https:%s/%s", namespace, serviceName)}
 			cert, proxy, mc := prep(resourceNames, namespace, serviceName)
 
-			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc)
+			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc, constants.DefaultTrustDomain)
 
 			Expect(len(actual)).To(Equal(1))
 			Expect(actual[0].Name).To(Equal(fmt.Sprintf("root-cert-https:%s/%s", namespace, serviceName)))
@@ -161,7 +179,7 @@ var _ = Describe("Test SDS response functions", func() {
 			resourceNames := []string{fmt.Sprintf("service-cert:%s/%s", namespace, serviceName)}
 			cert, proxy, mc := prep(resourceNames, namespace, serviceName)
 
-			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc)
+			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc, constants.DefaultTrustDomain)
 
 			Expect(len(actual)).To(Equal(1))
 			Expect(actual[0].Name).To(Equal(fmt.Sprintf("service-cert:%s/%s", namespace, serviceName)))
@@ -176,7 +194,7 @@ var _ = Describe("Test SDS response functions", func() {
 			resourceNames := []string{"service-cert:SomeOtherNamespace/SomeOtherService"}
 			cert, proxy, mc := prep(resourceNames, namespace, serviceName)
 
-			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc)
+			actual := getEnvoySDSSecrets(cert, proxy, resourceNames, mc, constants.DefaultTrustDomain)
 
 			Expect(len(actual)).To(Equal(0))
 		})
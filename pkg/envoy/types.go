@@ -54,6 +54,12 @@ const (
 
 	accessLogPath = "/dev/stdout"
 
+	// accessLogTextFormat is the format of the text access log entries, which carry the same fields as the JSON ones
+	accessLogTextFormat = `[%START_TIME%] "%REQ(:METHOD)% %REQ(X-ENVOY-ORIGINAL-PATH?:PATH)% %PROTOCOL%" ` +
+		`%RESPONSE_CODE% %RESPONSE_CODE_DETAILS% %RESPONSE_FLAGS% %BYTES_RECEIVED% %BYTES_SENT% %DURATION% %RESPONSE_DURATION% ` +
+		`%RESP(X-ENVOY-UPSTREAM-SERVICE-TIME)% "%REQ(X-FORWARDED-FOR)%" "%REQ(USER-AGENT)%" "%REQ(X-REQUEST-ID)%" ` +
+		`"%REQUESTED_SERVER_NAME%" "%REQ(:AUTHORITY)%" "%UPSTREAM_CLUSTER%" "%UPSTREAM_HOST%"` + "\n"
+
 	//LocalClusterSuffix is the tag to append to local clusters
 	LocalClusterSuffix = "-local"
 )
@@ -2,6 +2,7 @@ package envoy
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
 	xds_accesslog_filter "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
@@ -17,6 +18,7 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/jinzhu/copier"
 
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/service"
 )
 
@@ -65,8 +67,17 @@ const (
 	// TransportProtocolTLS is the TLS transport protocol used in Envoy configurations
 	TransportProtocolTLS = "tls"
 
+	// TransportProtocolRawBuffer is the transport protocol the TLS inspector detects for the plaintext connections
+	TransportProtocolRawBuffer = "raw_buffer"
+
 	// OutboundPassthroughCluster is the outbound passthrough cluster name
 	OutboundPassthroughCluster = "passthrough-outbound"
+
+	// EgressClusterPrefix is the prefix of the names of the egress clusters of the external domains egress is allowed to
+	EgressClusterPrefix = "egress|"
+
+	// EgressTLSPort is the port of the external domains of the egress clusters, which are reached over HTTPS
+	EgressTLSPort = 443
 )
 
 // Defines valid cert types
@@ -151,9 +162,30 @@ func GetTLSParams() *xds_auth.TlsParameters {
 	}
 }
 
-// GetAccessLog creates an Envoy AccessLog struct.
-func GetAccessLog() []*xds_accesslog_filter.AccessLog {
-	accessLog, err := ptypes.MarshalAny(getFileAccessLog())
+// tlsProtocolVersions maps the TLS protocol versions named in the OSM config to the Envoy TLS protocol versions
+var tlsProtocolVersions = map[string]xds_auth.TlsParameters_TlsProtocol{
+	"TLS1_0": xds_auth.TlsParameters_TLSv1_0,
+	"TLS1_1": xds_auth.TlsParameters_TLSv1_1,
+	"TLS1_2": xds_auth.TlsParameters_TLSv1_2,
+	"TLS1_3": xds_auth.TlsParameters_TLSv1_3,
+}
+
+// GetTLSParamsForVersions creates Envoy TlsParameters struct restricted to the given range of TLS protocol versions,
+// named TLS1_0 to TLS1_3; a version with another name leaves the bound of GetTLSParams() in place.
+func GetTLSParamsForVersions(minVersion, maxVersion string) *xds_auth.TlsParameters {
+	tlsParams := GetTLSParams()
+	if version, ok := tlsProtocolVersions[minVersion]; ok {
+		tlsParams.TlsMinimumProtocolVersion = version
+	}
+	if version, ok := tlsProtocolVersions[maxVersion]; ok {
+		tlsParams.TlsMaximumProtocolVersion = version
+	}
+	return tlsParams
+}
+
+// GetAccessLog creates an Envoy AccessLog struct, writing the entries as JSON objects when jsonFormat is set and as lines of text otherwise.
+func GetAccessLog(jsonFormat bool) []*xds_accesslog_filter.AccessLog {
+	accessLog, err := ptypes.MarshalAny(getFileAccessLog(jsonFormat))
 	if err != nil {
 		log.Error().Err(err).Msg("Error marshalling AccessLog object")
 		return nil
@@ -166,7 +198,20 @@ func GetAccessLog() []*xds_accesslog_filter.AccessLog {
 	}
 }
 
-func getFileAccessLog() *xds_accesslog.FileAccessLog {
+func getFileAccessLog(jsonFormat bool) *xds_accesslog.FileAccessLog {
+	if !jsonFormat {
+		return &xds_accesslog.FileAccessLog{
+			Path: accessLogPath,
+			AccessLogFormat: &xds_accesslog.FileAccessLog_LogFormat{
+				LogFormat: &xds_core.SubstitutionFormatString{
+					Format: &xds_core.SubstitutionFormatString_TextFormat{
+						TextFormat: accessLogTextFormat,
+					},
+				},
+			},
+		}
+	}
+
 	accessLogger := &xds_accesslog.FileAccessLog{
 		Path: accessLogPath,
 		AccessLogFormat: &xds_accesslog.FileAccessLog_LogFormat{
@@ -292,3 +337,24 @@ func GetADSConfigSource() *xds_core.ConfigSource {
 		ResourceApiVersion: xds_core.ApiVersion_V3,
 	}
 }
+
+// GetEgressClusterName returns the name of the egress cluster of the given external domain
+func GetEgressClusterName(domain string) string {
+	return EgressClusterPrefix + domain
+}
+
+// GetEgressClusterDomains returns the external domains egress is allowed to which have an egress cluster, resolved with
+// the egress DNS resolution. The wildcard domains cannot be resolved, and with the STATIC resolution only the IP
+// addresses are, so the traffic to the other domains goes through the passthrough cluster, to the address the
+// application resolved.
+func GetEgressClusterDomains(cfg configurator.Configurator) []string {
+	static := cfg.GetEgressDNSResolution() == configurator.EgressDNSResolutionStatic
+	var domains []string
+	for _, domain := range cfg.GetEgressAllowedDomains() {
+		if strings.HasPrefix(domain, "*.") || static != (net.ParseIP(domain) != nil) {
+			continue
+		}
+		domains = append(domains, domain)
+	}
+	return domains
+}
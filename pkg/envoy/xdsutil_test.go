@@ -3,16 +3,40 @@ package envoy
 import (
 	core "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	auth "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/ptypes/wrappers"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
 var _ = Describe("Test Envoy tools", func() {
+	Context("Test GetEgressClusterDomains()", func() {
+		allowedDomains := []string{"*.example.com", "10.0.0.1", "api.stripe.com"}
+
+		It("returns the domains Envoy resolves, without the wildcard domains", func() {
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetEgressAllowedDomains().Return(allowedDomains).Times(1)
+			mockConfigurator.EXPECT().GetEgressDNSResolution().Return(configurator.EgressDNSResolutionLogicalDNS).Times(1)
+
+			Expect(GetEgressClusterDomains(mockConfigurator)).To(Equal([]string{"api.stripe.com"}))
+		})
+
+		It("returns only the IP addresses with the STATIC egress DNS resolution", func() {
+			mockCtrl := gomock.NewController(GinkgoT())
+			mockConfigurator := configurator.NewMockConfigurator(mockCtrl)
+			mockConfigurator.EXPECT().GetEgressAllowedDomains().Return(allowedDomains).Times(1)
+			mockConfigurator.EXPECT().GetEgressDNSResolution().Return(configurator.EgressDNSResolutionStatic).Times(1)
+
+			Expect(GetEgressClusterDomains(mockConfigurator)).To(Equal([]string{"10.0.0.1"}))
+		})
+	})
+
 	Context("Test GetAddress()", func() {
 		It("should return address", func() {
 			addr := "blah"
@@ -34,6 +58,35 @@ var _ = Describe("Test Envoy tools", func() {
 		})
 	})
 
+	Context("Test GetTLSParamsForVersions()", func() {
+		It("restricts the TLS protocol versions to the given range", func() {
+			actual := GetTLSParamsForVersions("TLS1_0", "TLS1_1")
+			Expect(actual.TlsMinimumProtocolVersion).To(Equal(auth.TlsParameters_TLSv1_0))
+			Expect(actual.TlsMaximumProtocolVersion).To(Equal(auth.TlsParameters_TLSv1_1))
+		})
+
+		It("keeps the bounds of GetTLSParams() for unknown versions", func() {
+			Expect(GetTLSParamsForVersions("SSLv3", "")).To(Equal(GetTLSParams()))
+		})
+	})
+
+	Context("Test getFileAccessLog()", func() {
+		It("returns a JSON access log", func() {
+			actual := getFileAccessLog(true)
+			Expect(actual.Path).To(Equal(accessLogPath))
+
+			jsonFormat, ok := actual.GetLogFormat().Format.(*core.SubstitutionFormatString_JsonFormat)
+			Expect(ok).To(BeTrue())
+			Expect(jsonFormat.JsonFormat.Fields).To(HaveKey("response_code"))
+		})
+
+		It("returns a text access log", func() {
+			actual := getFileAccessLog(false)
+			Expect(actual.Path).To(Equal(accessLogPath))
+			Expect(actual.GetLogFormat().GetTextFormat()).To(Equal(accessLogTextFormat))
+		})
+	})
+
 	Context("Test CertName interface", func() {
 		It("Interface marshals and unmarshals preserving the exact same data", func() {
 			InitialObj := SDSCert{
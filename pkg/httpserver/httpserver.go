@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 	"time"
 
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/debugger"
 	"github.com/openservicemesh/osm/pkg/health"
 	"github.com/openservicemesh/osm/pkg/logger"
@@ -25,6 +27,9 @@ var (
 // HTTPServer is the object wrapper for OSM's HTTP server class
 type HTTPServer struct {
 	server *http.Server
+
+	// debugServerEnabled holds, as a bool, whether the routes of the debug server are served
+	debugServerEnabled atomic.Value
 }
 
 // NewHealthMux makes a new *http.ServeMux
@@ -38,7 +43,11 @@ func NewHealthMux(handlers map[string]http.Handler) *http.ServeMux {
 }
 
 // NewHTTPServer creates a new API server
+// The routes of the debug server, when given, are served until SetDebugServerEnabled disables them.
 func NewHTTPServer(probes []health.Probes, httpProbes []health.HTTPProbe, metricStore metricsstore.MetricStore, apiPort int32, debugServer debugger.DebugServer) *HTTPServer {
+	s := &HTTPServer{}
+	s.debugServerEnabled.Store(true)
+
 	handlers := map[string]http.Handler{
 		"/health/ready": health.ReadinessHandler(probes, httpProbes),
 		"/health/alive": health.LivenessHandler(probes, httpProbes),
@@ -48,16 +57,62 @@ func NewHTTPServer(probes []health.Probes, httpProbes []health.HTTPProbe, metric
 
 	if debugServer != nil {
 		for url, handler := range debugServer.GetHandlers() {
-			handlers[url] = handler
+			handlers[url] = s.getDebugHandler(handler)
 		}
 	}
 
-	return &HTTPServer{
-		server: &http.Server{
-			Addr:    fmt.Sprintf(":%d", apiPort),
-			Handler: NewHealthMux(handlers),
-		},
+	s.server = &http.Server{
+		Addr:    fmt.Sprintf(":%d", apiPort),
+		Handler: NewHealthMux(handlers),
+	}
+	return s
+}
+
+// SetDebugServerEnabled registers or unregisters the routes of the debug server; the unregistered routes respond
+// 404 Not Found, as if they did not exist
+func (s *HTTPServer) SetDebugServerEnabled(enabled bool) {
+	if s.debugServerEnabled.Load().(bool) == enabled {
+		return
 	}
+	if enabled {
+		log.Info().Msg("Registering the debug server routes")
+	} else {
+		log.Info().Msg("Unregistering the debug server routes")
+	}
+	s.debugServerEnabled.Store(enabled)
+}
+
+// WatchDebugServerConfig registers or unregisters the routes of the debug server as per the OSM config, and again
+// whenever a config change toggles the debug server, until stop is closed
+func (s *HTTPServer) WatchDebugServerConfig(cfg configurator.Configurator, stop <-chan struct{}) {
+	events := cfg.Subscribe("EnableDebugServer")
+	s.SetDebugServerEnabled(cfg.IsDebugServerEnabled())
+
+	go func() {
+		defer cfg.Unsubscribe(events)
+		for {
+			select {
+			case <-stop:
+				return
+			case _, ok := <-events:
+				if !ok {
+					return
+				}
+				s.SetDebugServerEnabled(cfg.IsDebugServerEnabled())
+			}
+		}
+	}()
+}
+
+// getDebugHandler returns an HTTP handler serving the given debug server handler while the debug server is enabled
+func (s *HTTPServer) getDebugHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !s.debugServerEnabled.Load().(bool) {
+			http.NotFound(w, req)
+			return
+		}
+		handler.ServeHTTP(w, req)
+	})
 }
 
 // Start runs the Serve operations for the http.server on a separate go routine context
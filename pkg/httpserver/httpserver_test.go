@@ -5,11 +5,13 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/debugger"
 	"github.com/openservicemesh/osm/pkg/health"
 	"github.com/openservicemesh/osm/pkg/metricsstore"
@@ -145,3 +147,59 @@ var _ = Describe("Test httpserver", func() {
 		Expect(resp.StatusCode).To(Equal(http.StatusOK))
 	})
 })
+
+var _ = Describe("Test toggling the debug server", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockConfigurator *configurator.MockConfigurator
+		httpServ         *HTTPServer
+		testServer       *httptest.Server
+	)
+	mockCtrl = gomock.NewController(GinkgoT())
+
+	BeforeEach(func() {
+		mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+		metricsStore := metricsstore.NewMetricStore("TBD_NameSpace", "TBD_PodName")
+		fakeDebugServer := debugger.FakeDebugServer{
+			Mappings: map[string]http.Handler{
+				validRoutePath: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_, _ = fmt.Fprintf(w, responseBody)
+				}),
+			},
+		}
+
+		httpServ = NewHTTPServer(nil, nil, metricsStore, testPort, fakeDebugServer)
+		testServer = &httptest.Server{
+			Config: httpServ.server,
+		}
+	})
+
+	It("should return 404 for the debug urls while the debug server is disabled", func() {
+		httpServ.SetDebugServerEnabled(false)
+		Expect(recordCall(testServer, fmt.Sprintf("%s%s", url, validRoutePath)).StatusCode).To(Equal(http.StatusNotFound))
+
+		httpServ.SetDebugServerEnabled(true)
+		Expect(recordCall(testServer, fmt.Sprintf("%s%s", url, validRoutePath)).StatusCode).To(Equal(http.StatusOK))
+	})
+
+	It("should register and unregister the debug urls as the OSM config toggles the debug server", func() {
+		events := make(chan configurator.ConfigChangeEvent)
+		stop := make(chan struct{})
+		defer close(stop)
+
+		mockConfigurator.EXPECT().Subscribe("EnableDebugServer").Return((<-chan configurator.ConfigChangeEvent)(events)).Times(1)
+		mockConfigurator.EXPECT().Unsubscribe(gomock.Any()).AnyTimes()
+		gomock.InOrder(
+			mockConfigurator.EXPECT().IsDebugServerEnabled().Return(false).Times(1),
+			mockConfigurator.EXPECT().IsDebugServerEnabled().Return(true).Times(1),
+		)
+
+		httpServ.WatchDebugServerConfig(mockConfigurator, stop)
+		Expect(recordCall(testServer, fmt.Sprintf("%s%s", url, validRoutePath)).StatusCode).To(Equal(http.StatusNotFound))
+
+		events <- configurator.ConfigChangeEvent{ChangedFields: []string{"EnableDebugServer"}}
+		Eventually(func() int {
+			return recordCall(testServer, fmt.Sprintf("%s%s", url, validRoutePath)).StatusCode
+		}, time.Second).Should(Equal(http.StatusOK))
+	})
+})
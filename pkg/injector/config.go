@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"sort"
 	"strconv"
+	"time"
 
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
@@ -15,13 +17,28 @@ import (
 	"github.com/openservicemesh/osm/pkg/constants"
 )
 
+const (
+	// fixedHeapResourceMonitorName is the resource monitor of the overload manager tracking the heap size of the proxy
+	fixedHeapResourceMonitorName = "envoy.resource_monitors.fixed_heap"
+
+	// overloadManagerRefreshInterval is the interval at which the overload manager samples the heap size of the proxy
+	overloadManagerRefreshInterval = 250 * time.Millisecond
+)
+
 func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Configurator) ([]byte, error) {
+	// The pod IP is only known once the pod is scheduled, so the sidecar overrides the wildcard address with the pod IP
+	// on its command line
+	bindAddress := cfg.GetProxyBindAddress()
+	if bindAddress == configurator.ProxyBindAddressPodIP {
+		bindAddress = constants.WildcardIPAddr
+	}
+
 	m := map[interface{}]interface{}{
 		"admin": map[string]interface{}{
 			"access_log_path": "/dev/stdout",
 			"address": map[string]interface{}{
 				"socket_address": map[string]string{
-					"address":    "0.0.0.0",
+					"address":    bindAddress,
 					"port_value": strconv.Itoa(config.EnvoyAdminPort),
 				},
 			},
@@ -53,10 +70,17 @@ func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Config
 		"static_resources": map[string]interface{}{
 			"clusters": []map[string]interface{}{
 				{
-					"name":                   config.XDSClusterName,
-					"connect_timeout":        "0.25s",
-					"type":                   "LOGICAL_DNS",
-					"http2_protocol_options": map[string]string{},
+					"name":            config.XDSClusterName,
+					"connect_timeout": "0.25s",
+					"type":            "LOGICAL_DNS",
+					"http2_protocol_options": map[string]interface{}{
+						// The proxies ping the controller like the controller pings them, keeping the ADS stream alive
+						// through the load balancers closing the idle connections
+						"connection_keepalive": map[string]string{
+							"interval": getProtoDuration(cfg.GetXDSKeepaliveTime()),
+							"timeout":  getProtoDuration(cfg.GetXDSKeepaliveTimeout()),
+						},
+					},
 					"transport_socket": map[string]interface{}{
 						"name": "envoy.transport_sockets.tls",
 						"typed_config": map[string]interface{}{
@@ -111,17 +135,152 @@ func getEnvoyConfigYAML(config envoyBootstrapConfigMeta, cfg configurator.Config
 		},
 	}
 
+	if statsTags := getStatsTags(cfg); len(statsTags) > 0 {
+		m["stats_config"] = map[string]interface{}{
+			"stats_tags": statsTags,
+		}
+	}
+
+	if statsSink := getStatsSink(cfg); statsSink != nil {
+		m["stats_sinks"] = []map[string]interface{}{statsSink}
+	}
+
+	if overloadManager := getOverloadManager(cfg); overloadManager != nil {
+		m["overload_manager"] = overloadManager
+	}
+
 	configYAML, err := yaml.Marshal(&m)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error marshaling Envoy config struct into YAML")
 		return nil, err
 	}
-	return configYAML, err
+
+	override, err := cfg.GetProxyBootstrapOverride()
+	if err != nil {
+		log.Error().Err(err).Msg("Error parsing the proxy bootstrap config override; Ignoring the override")
+		return configYAML, nil
+	}
+	if override == nil {
+		return configYAML, nil
+	}
+
+	// The generated config is decoded again, so its mappings have the same types whatever the types used to build it
+	var bootstrap map[interface{}]interface{}
+	if err := yaml.Unmarshal(configYAML, &bootstrap); err != nil {
+		log.Error().Err(err).Msgf("Error unmarshaling Envoy config YAML")
+		return nil, err
+	}
+	mergeBootstrapOverride(bootstrap, override)
+	return yaml.Marshal(&bootstrap)
+}
+
+// getProtoDuration returns the duration in the JSON format of the protobuf durations, such as 1.5s
+func getProtoDuration(duration time.Duration) string {
+	return strconv.FormatFloat(duration.Seconds(), 'f', -1, 64) + "s"
+}
+
+// getStatsTags returns the configured stats tags, sorted by tag name, as fixed value tags added to all the stats of the proxy
+func getStatsTags(cfg configurator.Configurator) []map[string]string {
+	configuredTags := cfg.GetStatsTags()
+	var names []string
+	for name := range configuredTags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var statsTags []map[string]string
+	for _, name := range names {
+		statsTags = append(statsTags, map[string]string{
+			"tag_name":    name,
+			"fixed_value": configuredTags[name],
+		})
+	}
+	return statsTags
+}
+
+// getStatsSink returns the configured statsd or DogStatsD sink the proxy flushes its stats to over UDP, or nil without a sink
+func getStatsSink(cfg configurator.Configurator) map[string]interface{} {
+	sink := cfg.GetStatsSink()
+	if sink == nil {
+		return nil
+	}
+
+	name, typeURL := "envoy.stat_sinks.statsd", "type.googleapis.com/envoy.config.metrics.v3.StatsdSink"
+	if sink.Type == configurator.StatsSinkTypeDogStatsd {
+		name, typeURL = "envoy.stat_sinks.dog_statsd", "type.googleapis.com/envoy.config.metrics.v3.DogStatsdSink"
+	}
+
+	typedConfig := map[string]interface{}{
+		"@type": typeURL,
+		"address": map[string]interface{}{
+			"socket_address": map[string]interface{}{
+				"protocol":   "UDP",
+				"address":    sink.Address,
+				"port_value": sink.Port,
+			},
+		},
+	}
+	if sink.Prefix != "" {
+		typedConfig["prefix"] = sink.Prefix
+	}
+	return map[string]interface{}{
+		"name":         name,
+		"typed_config": typedConfig,
+	}
+}
+
+// getOverloadManager returns the overload manager monitoring the heap of the proxy against the configured maximum heap
+// size, releasing the free memory of the heap and then rejecting the new requests as it grows, or nil when it is disabled
+func getOverloadManager(cfg configurator.Configurator) map[string]interface{} {
+	overloadManager := cfg.GetOverloadManager()
+	if overloadManager == nil {
+		return nil
+	}
+
+	heapTrigger := func(threshold float64) []map[string]interface{} {
+		return []map[string]interface{}{{
+			"name": fixedHeapResourceMonitorName,
+			"threshold": map[string]interface{}{
+				"value": threshold,
+			},
+		}}
+	}
+	return map[string]interface{}{
+		"refresh_interval": getProtoDuration(overloadManagerRefreshInterval),
+		"resource_monitors": []map[string]interface{}{{
+			"name": fixedHeapResourceMonitorName,
+			"typed_config": map[string]interface{}{
+				"@type":               "type.googleapis.com/envoy.extensions.resource_monitors.fixed_heap.v3.FixedHeapConfig",
+				"max_heap_size_bytes": overloadManager.MaxHeapBytes,
+			},
+		}},
+		"actions": []map[string]interface{}{{
+			"name":     "envoy.overload_actions.shrink_heap",
+			"triggers": heapTrigger(overloadManager.ShrinkHeapThreshold),
+		}, {
+			"name":     "envoy.overload_actions.stop_accepting_requests",
+			"triggers": heapTrigger(overloadManager.StopAcceptingThreshold),
+		}},
+	}
+}
+
+// mergeBootstrapOverride deep-merges the override over the bootstrap config: the mappings present in both are merged,
+// and any other value of the override, lists included, replaces the value of the bootstrap config
+func mergeBootstrapOverride(bootstrap map[interface{}]interface{}, override map[string]interface{}) {
+	for key, overrideValue := range override {
+		bootstrapMapping, isBootstrapMapping := bootstrap[key].(map[interface{}]interface{})
+		overrideMapping, isOverrideMapping := overrideValue.(map[string]interface{})
+		if isBootstrapMapping && isOverrideMapping {
+			mergeBootstrapOverride(bootstrapMapping, overrideMapping)
+			continue
+		}
+		bootstrap[key] = overrideValue
+	}
 }
 
 func (wh *webhook) createEnvoyBootstrapConfig(name, namespace, osmNamespace string, cert certificate.Certificater) (*corev1.Secret, error) {
 	configMeta := envoyBootstrapConfigMeta{
-		EnvoyAdminPort: constants.EnvoyAdminPort,
+		EnvoyAdminPort: int(wh.configurator.GetEnvoyAdminPort()),
 		XDSClusterName: constants.OSMControllerName,
 
 		RootCert: base64.StdEncoding.EncodeToString(cert.GetIssuingCA()),
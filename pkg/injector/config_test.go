@@ -1,9 +1,14 @@
 package injector
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
+	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
@@ -37,7 +42,10 @@ dynamic_resources:
 static_resources:
   clusters:
   - connect_timeout: 0.25s
-    http2_protocol_options: {}
+    http2_protocol_options:
+      connection_keepalive:
+        interval: 7200s
+        timeout: 20s
     load_assignment:
       cluster_name: XDSClusterName
       endpoints:
@@ -90,12 +98,242 @@ var _ = Describe("Test Envoy configuration creation", func() {
 				XDSPort:        2345,
 			}
 
+			mockConfigurator.EXPECT().GetStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetStatsSink().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetOverloadManager().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTime().Return(2 * time.Hour).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTimeout().Return(20 * time.Second).Times(1)
+			mockConfigurator.EXPECT().GetProxyBindAddress().Return(constants.WildcardIPAddr).Times(1)
+			mockConfigurator.EXPECT().GetProxyBootstrapOverride().Return(nil, nil).Times(1)
+
 			actual, err := getEnvoyConfigYAML(config, mockConfigurator)
 			Expect(err).ToNot(HaveOccurred())
 
 			Expect(string(actual)).To(Equal(expectedEnvoyConfig[1:]),
 				fmt.Sprintf("Expected:\n%s\nActual:\n%s\n", expectedEnvoyConfig, string(actual)))
 		})
+
+		It("deep-merges the bootstrap config override over the envoy config", func() {
+			config := envoyBootstrapConfigMeta{
+				EnvoyAdminPort: 3465,
+				XDSClusterName: "XDSClusterName",
+				XDSHost:        "XDSHost",
+				XDSPort:        2345,
+			}
+			mockConfigurator.EXPECT().GetStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetStatsSink().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetOverloadManager().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTime().Return(2 * time.Hour).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTimeout().Return(20 * time.Second).Times(1)
+			mockConfigurator.EXPECT().GetProxyBindAddress().Return(constants.WildcardIPAddr).Times(1)
+			mockConfigurator.EXPECT().GetProxyBootstrapOverride().Return(map[string]interface{}{
+				"admin": map[string]interface{}{
+					"access_log_path": "/dev/null",
+				},
+				"stats_flush_interval": "10s",
+			}, nil).Times(1)
+
+			actual, err := getEnvoyConfigYAML(config, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			var bootstrap map[string]interface{}
+			Expect(yaml.Unmarshal(actual, &bootstrap)).To(Succeed())
+			Expect(bootstrap["stats_flush_interval"]).To(Equal("10s"))
+			Expect(bootstrap["admin"]).To(Equal(map[interface{}]interface{}{
+				"access_log_path": "/dev/null",
+				"address": map[interface{}]interface{}{
+					"socket_address": map[interface{}]interface{}{
+						"address":    "0.0.0.0",
+						"port_value": "3465",
+					},
+				},
+			}))
+			Expect(bootstrap).To(HaveKey("dynamic_resources"))
+			Expect(bootstrap).To(HaveKey("static_resources"))
+		})
+
+		It("adds the configured stats tags to the envoy config", func() {
+			config := envoyBootstrapConfigMeta{
+				EnvoyAdminPort: 3465,
+				XDSClusterName: "XDSClusterName",
+				XDSHost:        "XDSHost",
+				XDSPort:        2345,
+			}
+			mockConfigurator.EXPECT().GetStatsTags().Return(map[string]string{"region": "westus", "mesh": "osm"}).Times(1)
+			mockConfigurator.EXPECT().GetStatsSink().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetOverloadManager().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTime().Return(2 * time.Hour).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTimeout().Return(20 * time.Second).Times(1)
+			mockConfigurator.EXPECT().GetProxyBindAddress().Return(constants.WildcardIPAddr).Times(1)
+			mockConfigurator.EXPECT().GetProxyBootstrapOverride().Return(nil, nil).Times(1)
+
+			actual, err := getEnvoyConfigYAML(config, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(actual)).To(ContainSubstring(`
+stats_config:
+  stats_tags:
+  - fixed_value: osm
+    tag_name: mesh
+  - fixed_value: westus
+    tag_name: region
+`))
+		})
+
+		It("adds the configured stats sink to the envoy config", func() {
+			config := envoyBootstrapConfigMeta{
+				EnvoyAdminPort: 3465,
+				XDSClusterName: "XDSClusterName",
+				XDSHost:        "XDSHost",
+				XDSPort:        2345,
+			}
+			mockConfigurator.EXPECT().GetStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetStatsSink().Return(&configurator.StatsSink{
+				Type:    configurator.StatsSinkTypeDogStatsd,
+				Address: "10.0.0.10",
+				Port:    8125,
+				Prefix:  "osm",
+			}).Times(1)
+			mockConfigurator.EXPECT().GetOverloadManager().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTime().Return(2 * time.Hour).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTimeout().Return(20 * time.Second).Times(1)
+			mockConfigurator.EXPECT().GetProxyBindAddress().Return(constants.WildcardIPAddr).Times(1)
+			mockConfigurator.EXPECT().GetProxyBootstrapOverride().Return(nil, nil).Times(1)
+
+			actual, err := getEnvoyConfigYAML(config, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(actual)).To(ContainSubstring(`
+stats_sinks:
+- name: envoy.stat_sinks.dog_statsd
+  typed_config:
+    '@type': type.googleapis.com/envoy.config.metrics.v3.DogStatsdSink
+    address:
+      socket_address:
+        address: 10.0.0.10
+        port_value: 8125
+        protocol: UDP
+    prefix: osm
+`))
+		})
+
+		It("adds the configured overload manager to the envoy config", func() {
+			config := envoyBootstrapConfigMeta{
+				EnvoyAdminPort: 3465,
+				XDSClusterName: "XDSClusterName",
+				XDSHost:        "XDSHost",
+				XDSPort:        2345,
+			}
+			mockConfigurator.EXPECT().GetStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetStatsSink().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetOverloadManager().Return(&configurator.OverloadManager{
+				MaxHeapBytes:           1 << 30,
+				ShrinkHeapThreshold:    0.95,
+				StopAcceptingThreshold: 0.98,
+			}).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTime().Return(2 * time.Hour).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTimeout().Return(20 * time.Second).Times(1)
+			mockConfigurator.EXPECT().GetProxyBindAddress().Return(constants.WildcardIPAddr).Times(1)
+			mockConfigurator.EXPECT().GetProxyBootstrapOverride().Return(nil, nil).Times(1)
+
+			actual, err := getEnvoyConfigYAML(config, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(string(actual)).To(ContainSubstring(`
+overload_manager:
+  actions:
+  - name: envoy.overload_actions.shrink_heap
+    triggers:
+    - name: envoy.resource_monitors.fixed_heap
+      threshold:
+        value: 0.95
+  - name: envoy.overload_actions.stop_accepting_requests
+    triggers:
+    - name: envoy.resource_monitors.fixed_heap
+      threshold:
+        value: 0.98
+  refresh_interval: 0.25s
+  resource_monitors:
+  - name: envoy.resource_monitors.fixed_heap
+    typed_config:
+      '@type': type.googleapis.com/envoy.extensions.resource_monitors.fixed_heap.v3.FixedHeapConfig
+      max_heap_size_bytes: 1073741824
+`))
+		})
+
+		It("binds the admin listener to the configured IP address", func() {
+			config := envoyBootstrapConfigMeta{
+				EnvoyAdminPort: 3465,
+				XDSClusterName: "XDSClusterName",
+				XDSHost:        "XDSHost",
+				XDSPort:        2345,
+			}
+			mockConfigurator.EXPECT().GetStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetStatsSink().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetOverloadManager().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTime().Return(2 * time.Hour).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTimeout().Return(20 * time.Second).Times(1)
+			mockConfigurator.EXPECT().GetProxyBindAddress().Return("10.0.0.1").Times(1)
+			mockConfigurator.EXPECT().GetProxyBootstrapOverride().Return(nil, nil).Times(1)
+
+			actual, err := getEnvoyConfigYAML(config, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(actual)).To(ContainSubstring(`
+admin:
+  access_log_path: /dev/stdout
+  address:
+    socket_address:
+      address: 10.0.0.1
+      port_value: "3465"
+`))
+		})
+
+		It("leaves the admin listener bound to the wildcard address until the sidecar binds it to the pod IP", func() {
+			config := envoyBootstrapConfigMeta{
+				EnvoyAdminPort: 3465,
+				XDSClusterName: "XDSClusterName",
+				XDSHost:        "XDSHost",
+				XDSPort:        2345,
+			}
+			mockConfigurator.EXPECT().GetStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetStatsSink().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetOverloadManager().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTime().Return(2 * time.Hour).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTimeout().Return(20 * time.Second).Times(1)
+			mockConfigurator.EXPECT().GetProxyBindAddress().Return(configurator.ProxyBindAddressPodIP).Times(1)
+			mockConfigurator.EXPECT().GetProxyBootstrapOverride().Return(nil, nil).Times(1)
+
+			actual, err := getEnvoyConfigYAML(config, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(actual)).To(ContainSubstring(`
+    socket_address:
+      address: 0.0.0.0
+      port_value: "3465"
+`))
+		})
+
+		It("ignores a malformed bootstrap config override", func() {
+			config := envoyBootstrapConfigMeta{
+				EnvoyAdminPort: 3465,
+				XDSClusterName: "XDSClusterName",
+				RootCert:       "RootCert",
+				Cert:           "Cert",
+				Key:            "Key",
+				XDSHost:        "XDSHost",
+				XDSPort:        2345,
+			}
+			mockConfigurator.EXPECT().GetStatsTags().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetStatsSink().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetOverloadManager().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTime().Return(2 * time.Hour).Times(1)
+			mockConfigurator.EXPECT().GetXDSKeepaliveTimeout().Return(20 * time.Second).Times(1)
+			mockConfigurator.EXPECT().GetProxyBindAddress().Return(constants.WildcardIPAddr).Times(1)
+			mockConfigurator.EXPECT().GetProxyBootstrapOverride().Return(nil, errors.New("invalid YAML fragment")).Times(1)
+
+			actual, err := getEnvoyConfigYAML(config, mockConfigurator)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(actual)).To(Equal(expectedEnvoyConfig[1:]))
+		})
 	})
 })
 
@@ -110,9 +348,33 @@ var _ = Describe("Test Envoy sidecar", func() {
 
 	Context("create Envoy sidecar", func() {
 		It("creates correct Envoy sidecar spec", func() {
-			mockConfigurator.EXPECT().GetEnvoyLogLevel().Return("debug").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyLogLevelForChannel(configurator.PrimaryConfigChannel).Return("debug").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyLogFormat().Return(configurator.EnvoyLogFormatText).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyAdminPort().Return(uint32(constants.EnvoyAdminPort)).Times(1)
+			mockConfigurator.EXPECT().GetPrometheusScrapePort().Return(uint32(constants.EnvoyPrometheusInboundListenerPort)).Times(1)
+			resources := corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("128Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("512Mi"),
+				},
+			}
+			mockConfigurator.EXPECT().GetSidecarResources().Return(resources).Times(1)
+			mockConfigurator.EXPECT().GetProxyProbeSpec().Return(corev1.Probe{
+				InitialDelaySeconds: 30,
+				PeriodSeconds:       10,
+				TimeoutSeconds:      1,
+				FailureThreshold:    3,
+			}).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(uint32(0)).Times(1)
+			mockConfigurator.EXPECT().GetProxyEnvVars().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetProxyBindAddress().Return(constants.WildcardIPAddr).Times(1)
+			mockConfigurator.EXPECT().GetProxyDrainTime().Return(10 * time.Minute).Times(1)
+			mockConfigurator.EXPECT().GetProxyParentShutdownTime().Return(15 * time.Minute).Times(1)
 
-			actual := getEnvoySidecarContainerSpec("a", "b", "c", "d", mockConfigurator)
+			actual := getEnvoySidecarContainerSpec("a", "b", "c", "d", configurator.PrimaryConfigChannel, mockConfigurator)
 			Expect(len(actual)).To(Equal(1))
 
 			expected := corev1.Container{
@@ -146,6 +408,30 @@ var _ = Describe("Test Envoy sidecar", func() {
 						MountPath: envoyProxyConfigPath,
 					},
 				},
+				Resources: resources,
+				ReadinessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Path: "/ready",
+							Port: intstr.FromString(constants.EnvoyAdminPortName),
+						},
+					},
+					InitialDelaySeconds: 30,
+					PeriodSeconds:       10,
+					TimeoutSeconds:      1,
+					FailureThreshold:    3,
+				},
+				LivenessProbe: &corev1.Probe{
+					Handler: corev1.Handler{
+						TCPSocket: &corev1.TCPSocketAction{
+							Port: intstr.FromString(constants.EnvoyAdminPortName),
+						},
+					},
+					InitialDelaySeconds: 30,
+					PeriodSeconds:       10,
+					TimeoutSeconds:      1,
+					FailureThreshold:    3,
+				},
 				Command: []string{
 					"envoy",
 				},
@@ -155,9 +441,150 @@ var _ = Describe("Test Envoy sidecar", func() {
 					"--service-node", "c",
 					"--service-cluster", "d",
 					"--bootstrap-version 3",
+					"--drain-time-s", "600",
+					"--parent-shutdown-time-s", "900",
 				},
 			}
 			Expect(actual[0]).To(Equal(expected))
 		})
+
+		It("sets the Envoy concurrency when it is configured", func() {
+			mockConfigurator.EXPECT().GetEnvoyLogLevelForChannel(configurator.PrimaryConfigChannel).Return("debug").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyLogFormat().Return(configurator.EnvoyLogFormatText).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyAdminPort().Return(uint32(constants.EnvoyAdminPort)).Times(1)
+			mockConfigurator.EXPECT().GetPrometheusScrapePort().Return(uint32(constants.EnvoyPrometheusInboundListenerPort)).Times(1)
+			mockConfigurator.EXPECT().GetSidecarResources().Return(corev1.ResourceRequirements{}).Times(1)
+			mockConfigurator.EXPECT().GetProxyProbeSpec().Return(corev1.Probe{}).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(uint32(2)).Times(1)
+			mockConfigurator.EXPECT().GetProxyEnvVars().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetProxyBindAddress().Return(constants.WildcardIPAddr).Times(1)
+			mockConfigurator.EXPECT().GetProxyDrainTime().Return(30 * time.Second).Times(1)
+			mockConfigurator.EXPECT().GetProxyParentShutdownTime().Return(45 * time.Second).Times(1)
+
+			actual := getEnvoySidecarContainerSpec("a", "b", "c", "d", configurator.PrimaryConfigChannel, mockConfigurator)
+			Expect(len(actual)).To(Equal(1))
+			Expect(actual[0].Args).To(Equal([]string{
+				"--log-level", "debug",
+				"--config-path", "/etc/envoy/bootstrap.yaml",
+				"--service-node", "c",
+				"--service-cluster", "d",
+				"--bootstrap-version 3",
+				"--drain-time-s", "30",
+				"--parent-shutdown-time-s", "45",
+				"--concurrency", "2",
+			}))
+		})
+
+		It("sets the Envoy log level of the config channel of the pod", func() {
+			mockConfigurator.EXPECT().GetEnvoyLogLevelForChannel(configurator.StagingConfigChannel).Return("trace").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyLogFormat().Return(configurator.EnvoyLogFormatText).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyAdminPort().Return(uint32(constants.EnvoyAdminPort)).Times(1)
+			mockConfigurator.EXPECT().GetPrometheusScrapePort().Return(uint32(constants.EnvoyPrometheusInboundListenerPort)).Times(1)
+			mockConfigurator.EXPECT().GetSidecarResources().Return(corev1.ResourceRequirements{}).Times(1)
+			mockConfigurator.EXPECT().GetProxyProbeSpec().Return(corev1.Probe{}).Times(1)
+			mockConfigurator.EXPECT().GetProxyBindAddress().Return(constants.WildcardIPAddr).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(uint32(0)).Times(1)
+			mockConfigurator.EXPECT().GetProxyEnvVars().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetProxyDrainTime().Return(30 * time.Second).Times(1)
+			mockConfigurator.EXPECT().GetProxyParentShutdownTime().Return(45 * time.Second).Times(1)
+
+			actual := getEnvoySidecarContainerSpec("a", "b", "c", "d", configurator.StagingConfigChannel, mockConfigurator)
+			Expect(len(actual)).To(Equal(1))
+			Expect(actual[0].Args[:2]).To(Equal([]string{"--log-level", "trace"}))
+		})
+
+		It("switches the Envoy logs to JSON when the Envoy log format is json", func() {
+			mockConfigurator.EXPECT().GetEnvoyLogLevelForChannel(configurator.PrimaryConfigChannel).Return("debug").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyLogFormat().Return(configurator.EnvoyLogFormatJSON).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyAdminPort().Return(uint32(constants.EnvoyAdminPort)).Times(1)
+			mockConfigurator.EXPECT().GetPrometheusScrapePort().Return(uint32(constants.EnvoyPrometheusInboundListenerPort)).Times(1)
+			mockConfigurator.EXPECT().GetSidecarResources().Return(corev1.ResourceRequirements{}).Times(1)
+			mockConfigurator.EXPECT().GetProxyProbeSpec().Return(corev1.Probe{}).Times(1)
+			mockConfigurator.EXPECT().GetProxyBindAddress().Return(constants.WildcardIPAddr).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(uint32(0)).Times(1)
+			mockConfigurator.EXPECT().GetProxyEnvVars().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetProxyDrainTime().Return(30 * time.Second).Times(1)
+			mockConfigurator.EXPECT().GetProxyParentShutdownTime().Return(45 * time.Second).Times(1)
+
+			actual := getEnvoySidecarContainerSpec("a", "b", "c", "d", configurator.PrimaryConfigChannel, mockConfigurator)
+			Expect(len(actual)).To(Equal(1))
+			Expect(actual[0].Args).To(Equal([]string{
+				"--log-level", "debug",
+				"--config-path", "/etc/envoy/bootstrap.yaml",
+				"--service-node", "c",
+				"--service-cluster", "d",
+				"--bootstrap-version 3",
+				"--drain-time-s", "30",
+				"--parent-shutdown-time-s", "45",
+				"--log-format", `{"timestamp":"%Y-%m-%dT%T.%e","thread":"%t","level":"%l","logger":"%n","message":"%j"}`,
+			}))
+		})
+
+		It("binds the admin listener to the pod IP when the proxy bind address is the pod IP", func() {
+			mockConfigurator.EXPECT().GetEnvoyLogLevelForChannel(configurator.PrimaryConfigChannel).Return("debug").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyLogFormat().Return(configurator.EnvoyLogFormatText).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyAdminPort().Return(uint32(constants.EnvoyAdminPort)).Times(1)
+			mockConfigurator.EXPECT().GetPrometheusScrapePort().Return(uint32(constants.EnvoyPrometheusInboundListenerPort)).Times(1)
+			mockConfigurator.EXPECT().GetSidecarResources().Return(corev1.ResourceRequirements{}).Times(1)
+			mockConfigurator.EXPECT().GetProxyProbeSpec().Return(corev1.Probe{}).Times(1)
+			mockConfigurator.EXPECT().GetProxyBindAddress().Return(configurator.ProxyBindAddressPodIP).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(uint32(0)).Times(1)
+			mockConfigurator.EXPECT().GetProxyEnvVars().Return(nil).Times(1)
+			mockConfigurator.EXPECT().GetProxyDrainTime().Return(30 * time.Second).Times(1)
+			mockConfigurator.EXPECT().GetProxyParentShutdownTime().Return(45 * time.Second).Times(1)
+
+			actual := getEnvoySidecarContainerSpec("a", "b", "c", "d", configurator.PrimaryConfigChannel, mockConfigurator)
+			Expect(len(actual)).To(Equal(1))
+			Expect(actual[0].Env).To(Equal([]corev1.EnvVar{{
+				Name: "POD_IP",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: "status.podIP",
+					},
+				},
+			}}))
+			Expect(actual[0].Args).To(Equal([]string{
+				"--log-level", "debug",
+				"--config-path", "/etc/envoy/bootstrap.yaml",
+				"--service-node", "c",
+				"--service-cluster", "d",
+				"--bootstrap-version 3",
+				"--drain-time-s", "30",
+				"--parent-shutdown-time-s", "45",
+				"--config-yaml", `{"admin": {"address": {"socket_address": {"address": "$(POD_IP)"}}}}`,
+			}))
+		})
+
+		It("appends the configured env vars after the ones set by OSM", func() {
+			mockConfigurator.EXPECT().GetEnvoyLogLevelForChannel(configurator.PrimaryConfigChannel).Return("debug").Times(1)
+			mockConfigurator.EXPECT().GetEnvoyLogFormat().Return(configurator.EnvoyLogFormatText).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyAdminPort().Return(uint32(constants.EnvoyAdminPort)).Times(1)
+			mockConfigurator.EXPECT().GetPrometheusScrapePort().Return(uint32(constants.EnvoyPrometheusInboundListenerPort)).Times(1)
+			mockConfigurator.EXPECT().GetSidecarResources().Return(corev1.ResourceRequirements{}).Times(1)
+			mockConfigurator.EXPECT().GetProxyProbeSpec().Return(corev1.Probe{}).Times(1)
+			mockConfigurator.EXPECT().GetProxyBindAddress().Return(configurator.ProxyBindAddressPodIP).Times(1)
+			mockConfigurator.EXPECT().GetEnvoyConcurrency().Return(uint32(0)).Times(1)
+			mockConfigurator.EXPECT().GetProxyEnvVars().Return([]corev1.EnvVar{
+				{Name: "VENDOR_FEATURE_FLAGS", Value: "tls-inspector"},
+				{Name: "VENDOR_POD_ADDRESS", Value: "$(POD_IP)"},
+			}).Times(1)
+			mockConfigurator.EXPECT().GetProxyDrainTime().Return(30 * time.Second).Times(1)
+			mockConfigurator.EXPECT().GetProxyParentShutdownTime().Return(45 * time.Second).Times(1)
+
+			actual := getEnvoySidecarContainerSpec("a", "b", "c", "d", configurator.PrimaryConfigChannel, mockConfigurator)
+			Expect(len(actual)).To(Equal(1))
+			Expect(actual[0].Env).To(Equal([]corev1.EnvVar{
+				{
+					Name: "POD_IP",
+					ValueFrom: &corev1.EnvVarSource{
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: "status.podIP",
+						},
+					},
+				},
+				{Name: "VENDOR_FEATURE_FLAGS", Value: "tls-inspector"},
+				{Name: "VENDOR_POD_ADDRESS", Value: "$(POD_IP)"},
+			}))
+		})
 	})
 })
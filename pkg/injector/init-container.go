@@ -2,6 +2,8 @@ package injector
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -32,6 +34,23 @@ func getInitContainerSpec(pod *corev1.Pod, data *InitContainerData) (corev1.Cont
 				Name:  "OSM_ENVOY_OUTBOUND_PORT",
 				Value: fmt.Sprintf("%d", constants.EnvoyOutboundListenerPort),
 			},
+			{
+				Name:  "OSM_OUTBOUND_PORT_EXCLUSION_LIST",
+				Value: joinPorts(data.OutboundPortExclusionList),
+			},
+			{
+				Name:  "OSM_INBOUND_PORT_EXCLUSION_LIST",
+				Value: joinPorts(data.InboundPortExclusionList),
+			},
 		},
 	}, nil
 }
+
+// joinPorts returns the given ports as a comma separated list
+func joinPorts(ports []int) string {
+	portStrs := make([]string, 0, len(ports))
+	for _, port := range ports {
+		portStrs = append(portStrs, strconv.Itoa(port))
+	}
+	return strings.Join(portStrs, ",")
+}
@@ -11,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/openservicemesh/osm/pkg/catalog"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 )
 
@@ -60,8 +61,10 @@ func (wh *webhook) createPatch(pod *corev1.Pod, namespace string) ([]byte, error
 
 	// Add the Init Container
 	initContainerData := InitContainerData{
-		Name:  constants.InitContainerName,
-		Image: wh.config.InitContainerImage,
+		Name:                      constants.InitContainerName,
+		Image:                     wh.configurator.GetInitContainerImage(),
+		OutboundPortExclusionList: wh.configurator.GetOutboundPortExclusionList(),
+		InboundPortExclusionList:  wh.configurator.GetInboundPortExclusionList(),
 	}
 	initContainerSpec, err := getInitContainerSpec(pod, &initContainerData)
 	if err != nil {
@@ -81,15 +84,15 @@ func (wh *webhook) createPatch(pod *corev1.Pod, namespace string) ([]byte, error
 
 	patches = append(patches, addContainer(
 		pod.Spec.Containers,
-		getEnvoySidecarContainerSpec(constants.EnvoyContainerName, wh.config.SidecarImage, envoyNodeID, envoyClusterID, wh.configurator),
+		getEnvoySidecarContainerSpec(constants.EnvoyContainerName, wh.configurator.GetEnvoyImage(), envoyNodeID, envoyClusterID, configurator.GetConfigChannel(pod.Labels), wh.configurator),
 		"/spec/containers")...,
 	)
 
 	// Patch annotations
 	prometheusAnnotations := map[string]string{
 		prometheusScrapeAnnotation: strconv.FormatBool(true),
-		prometheusPortAnnotation:   strconv.Itoa(constants.EnvoyPrometheusInboundListenerPort),
-		prometheusPathAnnotation:   constants.PrometheusScrapePath,
+		prometheusPortAnnotation:   strconv.Itoa(int(wh.configurator.GetPrometheusScrapePort())),
+		prometheusPathAnnotation:   wh.configurator.GetPrometheusScrapePath(),
 	}
 	patches = append(patches, updateAnnotation(
 		pod.Annotations,
@@ -1,9 +1,11 @@
 package injector
 
 import (
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
@@ -12,9 +14,36 @@ import (
 const (
 	envoyBootstrapConfigFile = "bootstrap.yaml"
 	envoyProxyConfigPath     = "/etc/envoy"
+
+	// envoyReadinessPath is the path of the Envoy admin endpoint reporting whether Envoy is ready to serve traffic
+	envoyReadinessPath = "/ready"
+
+	// envoyJSONLogFormat is the Envoy log format writing each log entry as a JSON object; %j escapes the message for JSON
+	envoyJSONLogFormat = `{"timestamp":"%Y-%m-%dT%T.%e","thread":"%t","level":"%l","logger":"%n","message":"%j"}`
+
+	// envoyPodIPEnvVar is the environment variable the pod IP is exposed to the Envoy sidecar in
+	envoyPodIPEnvVar = "POD_IP"
+
+	// envoyPodIPBindConfig is the bootstrap config fragment, merged by Envoy over its bootstrap config, binding the admin
+	// listener to the pod IP, which Kubernetes expands in the container args
+	envoyPodIPBindConfig = `{"admin": {"address": {"socket_address": {"address": "$(` + envoyPodIPEnvVar + `)"}}}}`
 )
 
-func getEnvoySidecarContainerSpec(containerName, envoyImage, nodeID, clusterID string, cfg configurator.Configurator) []corev1.Container {
+// getEnvoySidecarContainerSpec returns the Envoy sidecar container of the pods of the given config channel
+func getEnvoySidecarContainerSpec(containerName, envoyImage, nodeID, clusterID, configChannel string, cfg configurator.Configurator) []corev1.Container {
+	// The readiness probe checks that Envoy has received its initial config, while the liveness probe only checks that
+	// the admin endpoint accepts connections, so that an unreachable control plane does not restart the sidecars
+	probeSpec := cfg.GetProxyProbeSpec()
+	readinessProbe := probeSpec.DeepCopy()
+	readinessProbe.HTTPGet = &corev1.HTTPGetAction{
+		Path: envoyReadinessPath,
+		Port: intstr.FromString(constants.EnvoyAdminPortName),
+	}
+	livenessProbe := probeSpec.DeepCopy()
+	livenessProbe.TCPSocket = &corev1.TCPSocketAction{
+		Port: intstr.FromString(constants.EnvoyAdminPortName),
+	}
+
 	container := corev1.Container{
 		Name:            containerName,
 		Image:           envoyImage,
@@ -27,28 +56,57 @@ func getEnvoySidecarContainerSpec(containerName, envoyImage, nodeID, clusterID s
 		},
 		Ports: []corev1.ContainerPort{{
 			Name:          constants.EnvoyAdminPortName,
-			ContainerPort: constants.EnvoyAdminPort,
+			ContainerPort: int32(cfg.GetEnvoyAdminPort()),
 		}, {
 			Name:          constants.EnvoyInboundListenerPortName,
 			ContainerPort: constants.EnvoyInboundListenerPort,
 		}, {
 			Name:          constants.EnvoyInboundPrometheusListenerPortName,
-			ContainerPort: constants.EnvoyPrometheusInboundListenerPort,
+			ContainerPort: int32(cfg.GetPrometheusScrapePort()),
 		}},
 		VolumeMounts: []corev1.VolumeMount{{
 			Name:      envoyBootstrapConfigVolume,
 			ReadOnly:  true,
 			MountPath: envoyProxyConfigPath,
 		}},
-		Command: []string{"envoy"},
+		Resources:      cfg.GetSidecarResources(),
+		ReadinessProbe: readinessProbe,
+		LivenessProbe:  livenessProbe,
+		Command:        []string{"envoy"},
 		Args: []string{
-			"--log-level", cfg.GetEnvoyLogLevel(),
+			"--log-level", cfg.GetEnvoyLogLevelForChannel(configChannel),
 			"--config-path", strings.Join([]string{envoyProxyConfigPath, envoyBootstrapConfigFile}, "/"),
 			"--service-node", nodeID,
 			"--service-cluster", clusterID,
 			"--bootstrap-version 3",
+			"--drain-time-s", strconv.Itoa(int(cfg.GetProxyDrainTime().Seconds())),
+			"--parent-shutdown-time-s", strconv.Itoa(int(cfg.GetProxyParentShutdownTime().Seconds())),
 		},
 	}
 
+	if cfg.GetEnvoyLogFormat() == configurator.EnvoyLogFormatJSON {
+		container.Args = append(container.Args, "--log-format", envoyJSONLogFormat)
+	}
+
+	if cfg.GetProxyBindAddress() == configurator.ProxyBindAddressPodIP {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: envoyPodIPEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "status.podIP",
+				},
+			},
+		})
+		container.Args = append(container.Args, "--config-yaml", envoyPodIPBindConfig)
+	}
+
+	// The configured env vars come after the ones set by OSM, so that their values can reference them, such as $(POD_IP)
+	container.Env = append(container.Env, cfg.GetProxyEnvVars()...)
+
+	// Envoy starts one worker thread per hardware thread of the node unless the concurrency is set
+	if concurrency := cfg.GetEnvoyConcurrency(); concurrency > 0 {
+		container.Args = append(container.Args, "--concurrency", strconv.Itoa(int(concurrency)))
+	}
+
 	return []corev1.Container{container}
 }
@@ -36,8 +36,10 @@ type Config struct {
 	// ListenPort defines the port on which the sidecar injector listens
 	ListenPort int
 
+	// InitContainerImage is the default image of the init containers, which the OSM ConfigMap can override
 	InitContainerImage string
 
+	// SidecarImage is the default image of the Envoy sidecars, which the OSM ConfigMap can override
 	SidecarImage string
 }
 
@@ -50,8 +52,10 @@ type JSONPatchOperation struct {
 
 // InitContainerData is the type used to represent information about the init container
 type InitContainerData struct {
-	Name  string
-	Image string
+	Name                      string
+	Image                     string
+	OutboundPortExclusionList []int
+	InboundPortExclusionList  []int
 }
 
 // EnvoySidecarData is the type used to represent information about the Envoy sidecar
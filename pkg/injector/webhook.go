@@ -193,6 +193,12 @@ func (wh *webhook) mutate(req *v1beta1.AdmissionRequest) *v1beta1.AdmissionRespo
 		UID:     req.UID,
 	}
 
+	// Pass the pods through unmodified while the sidecar injection is disabled mesh-wide
+	if !wh.configurator.IsSidecarInjectionEnabled() {
+		log.Info().Msgf("Skipping sidecar injection in namespace %s: sidecar injection is disabled in the OSM config", req.Namespace)
+		return resp
+	}
+
 	// Check if we must inject the sidecar
 	if inject, err := wh.mustInject(&pod, req.Namespace); err != nil {
 		log.Error().Err(err).Msg("Error checking if sidecar must be injected")
@@ -222,6 +228,10 @@ func (wh *webhook) isNamespaceAllowed(namespace string) bool {
 			return false
 		}
 	}
+	// Skip namespaces excluded from the mesh in the OSM config
+	if wh.configurator.GetExcludedNamespaces()[namespace] {
+		return false
+	}
 	// Skip namespaces not being observed
 	return wh.namespaceController.IsMonitoredNamespace(namespace)
 }
@@ -2,17 +2,21 @@ package injector
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"k8s.io/api/admission/v1beta1"
 	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 )
@@ -174,12 +178,14 @@ var _ = Describe("Testing mustInject", func() {
 	var (
 		mockCtrl         *gomock.Controller
 		mockNsController *k8s.MockNamespaceController
+		mockConfigurator *configurator.MockConfigurator
 		fakeClientSet    *fake.Clientset
 		wh               *webhook
 	)
 
 	mockCtrl = gomock.NewController(GinkgoT())
 	mockNsController = k8s.NewMockNamespaceController(mockCtrl)
+	mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
 	fakeClientSet = fake.NewSimpleClientset()
 	namespace := "test"
 
@@ -188,6 +194,7 @@ var _ = Describe("Testing mustInject", func() {
 		wh = &webhook{
 			kubeClient:          fakeClientSet,
 			namespaceController: mockNsController,
+			configurator:        mockConfigurator,
 		}
 	})
 	AfterEach(func() {
@@ -218,6 +225,7 @@ var _ = Describe("Testing mustInject", func() {
 		_, err = fakeClientSet.CoreV1().Pods(namespace).Create(context.TODO(), podWithInjectAnnotationEnabled, metav1.CreateOptions{})
 		Expect(err).ToNot(HaveOccurred())
 
+		mockConfigurator.EXPECT().GetExcludedNamespaces().Return(nil).Times(1)
 		mockNsController.EXPECT().IsMonitoredNamespace(namespace).Return(true).Times(1)
 
 		inject, err := wh.mustInject(podWithInjectAnnotationEnabled, namespace)
@@ -249,6 +257,7 @@ var _ = Describe("Testing mustInject", func() {
 		_, err = fakeClientSet.CoreV1().Pods(namespace).Create(context.TODO(), podWithInjectAnnotationEnabled, metav1.CreateOptions{})
 		Expect(err).ToNot(HaveOccurred())
 
+		mockConfigurator.EXPECT().GetExcludedNamespaces().Return(nil).Times(1)
 		mockNsController.EXPECT().IsMonitoredNamespace(namespace).Return(true).Times(1)
 
 		inject, err := wh.mustInject(podWithInjectAnnotationEnabled, namespace)
@@ -280,6 +289,7 @@ var _ = Describe("Testing mustInject", func() {
 		_, err = fakeClientSet.CoreV1().Pods(namespace).Create(context.TODO(), podWithInjectAnnotationEnabled, metav1.CreateOptions{})
 		Expect(err).ToNot(HaveOccurred())
 
+		mockConfigurator.EXPECT().GetExcludedNamespaces().Return(nil).Times(1)
 		mockNsController.EXPECT().IsMonitoredNamespace(namespace).Return(true).Times(1)
 
 		inject, err := wh.mustInject(podWithInjectAnnotationEnabled, namespace)
@@ -314,6 +324,7 @@ var _ = Describe("Testing mustInject", func() {
 		_, err = fakeClientSet.CoreV1().Pods(namespace).Create(context.TODO(), podWithInjectAnnotationEnabled, metav1.CreateOptions{})
 		Expect(err).ToNot(HaveOccurred())
 
+		mockConfigurator.EXPECT().GetExcludedNamespaces().Return(nil).Times(1)
 		mockNsController.EXPECT().IsMonitoredNamespace(namespace).Return(true).Times(1)
 
 		inject, err := wh.mustInject(podWithInjectAnnotationEnabled, namespace)
@@ -345,6 +356,7 @@ var _ = Describe("Testing mustInject", func() {
 		_, err = fakeClientSet.CoreV1().Pods(namespace).Create(context.TODO(), podWithInjectAnnotationEnabled, metav1.CreateOptions{})
 		Expect(err).ToNot(HaveOccurred())
 
+		mockConfigurator.EXPECT().GetExcludedNamespaces().Return(nil).Times(1)
 		mockNsController.EXPECT().IsMonitoredNamespace(namespace).Return(false).Times(1)
 
 		inject, err := wh.mustInject(podWithInjectAnnotationEnabled, namespace)
@@ -353,6 +365,36 @@ var _ = Describe("Testing mustInject", func() {
 		Expect(inject).To(BeFalse())
 	})
 
+	It("should return false when the namespace is excluded from the mesh in the OSM config", func() {
+		testNamespace := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+			},
+		}
+		_, err := fakeClientSet.CoreV1().Namespaces().Create(context.TODO(), testNamespace, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		podWithInjectAnnotationEnabled := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod-with-injection-enabled",
+				Annotations: map[string]string{
+					constants.SidecarInjectionAnnotation: "enabled",
+				},
+			},
+			Spec: corev1.PodSpec{
+				ServiceAccountName: "test-SA",
+			},
+		}
+
+		// The namespace controller is not expected to be called: the namespace is excluded before checking whether it is monitored
+		mockConfigurator.EXPECT().GetExcludedNamespaces().Return(map[string]bool{namespace: true}).Times(1)
+
+		inject, err := wh.mustInject(podWithInjectAnnotationEnabled, namespace)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(inject).To(BeFalse())
+	})
+
 	It("should return an error when an invalid annotation is specified", func() {
 		testNamespace := &corev1.Namespace{
 			ObjectMeta: metav1.ObjectMeta{
@@ -376,6 +418,7 @@ var _ = Describe("Testing mustInject", func() {
 		_, err = fakeClientSet.CoreV1().Pods(namespace).Create(context.TODO(), podWithInjectAnnotationEnabled, metav1.CreateOptions{})
 		Expect(err).ToNot(HaveOccurred())
 
+		mockConfigurator.EXPECT().GetExcludedNamespaces().Return(nil).Times(1)
 		mockNsController.EXPECT().IsMonitoredNamespace(namespace).Return(true).Times(1)
 
 		inject, err := wh.mustInject(podWithInjectAnnotationEnabled, namespace)
@@ -384,3 +427,47 @@ var _ = Describe("Testing mustInject", func() {
 		Expect(inject).To(BeFalse())
 	})
 })
+
+var _ = Describe("Testing mutate", func() {
+	var (
+		mockCtrl         *gomock.Controller
+		mockNsController *k8s.MockNamespaceController
+		mockConfigurator *configurator.MockConfigurator
+	)
+
+	mockCtrl = gomock.NewController(GinkgoT())
+	mockNsController = k8s.NewMockNamespaceController(mockCtrl)
+	mockConfigurator = configurator.NewMockConfigurator(mockCtrl)
+
+	It("should not mutate the pod when the sidecar injection is disabled in the OSM config", func() {
+		wh := &webhook{
+			kubeClient:          fake.NewSimpleClientset(),
+			namespaceController: mockNsController,
+			configurator:        mockConfigurator,
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod-with-injection-enabled",
+				Annotations: map[string]string{
+					constants.SidecarInjectionAnnotation: "enabled",
+				},
+			},
+		}
+		podBytes, err := json.Marshal(pod)
+		Expect(err).ToNot(HaveOccurred())
+
+		// The namespace controller is not expected to be called: the pod is passed through before checking its namespace
+		mockConfigurator.EXPECT().IsSidecarInjectionEnabled().Return(false).Times(1)
+
+		resp := wh.mutate(&v1beta1.AdmissionRequest{
+			UID:       "test-uid",
+			Namespace: "test",
+			Object:    runtime.RawExtension{Raw: podBytes},
+		})
+
+		Expect(resp.Allowed).To(BeTrue())
+		Expect(resp.UID).To(BeEquivalentTo("test-uid"))
+		Expect(resp.Patch).To(BeNil())
+		Expect(resp.PatchType).To(BeNil())
+	})
+})
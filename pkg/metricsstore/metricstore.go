@@ -29,11 +29,14 @@ type OSMMetricsStore struct {
 	updateLatency      prometheus.Gauge
 	k8sAPIEventCounter prometheus.Counter
 
+	// collectors are the additional collectors, owned by other components, exposed by the store
+	collectors []prometheus.Collector
+
 	registry *prometheus.Registry
 }
 
-// NewMetricStore returns a new metric store
-func NewMetricStore(nameSpace string, podName string) MetricStore {
+// NewMetricStore returns a new metric store, which also exposes the given collectors
+func NewMetricStore(nameSpace string, podName string, collectors ...prometheus.Collector) MetricStore {
 	constLabels := prometheus.Labels{
 		"osm_namespace": nameSpace,
 		"osm_pod":       podName,
@@ -53,7 +56,8 @@ func NewMetricStore(nameSpace string, podName string) MetricStore {
 			Name:        "k8s_api_event_counter",
 			Help:        "This counter represents the number of events received from Kubernetes API Server",
 		}),
-		registry: prometheus.NewRegistry(),
+		collectors: collectors,
+		registry:   prometheus.NewRegistry(),
 	}
 }
 
@@ -61,12 +65,16 @@ func NewMetricStore(nameSpace string, podName string) MetricStore {
 func (ms *OSMMetricsStore) Start() {
 	ms.registry.MustRegister(ms.updateLatency)
 	ms.registry.MustRegister(ms.k8sAPIEventCounter)
+	ms.registry.MustRegister(ms.collectors...)
 }
 
 // Stop store
 func (ms *OSMMetricsStore) Stop() {
 	ms.registry.Unregister(ms.updateLatency)
 	ms.registry.Unregister(ms.k8sAPIEventCounter)
+	for _, collector := range ms.collectors {
+		ms.registry.Unregister(collector)
+	}
 }
 
 // SetUpdateLatencySec updates latency
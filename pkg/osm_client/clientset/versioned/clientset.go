@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	osmv1 "github.com/openservicemesh/osm/pkg/osm_client/clientset/versioned/typed/azureresource/v1"
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/osm_client/clientset/versioned/typed/config/v1alpha1"
 	discovery "k8s.io/client-go/discovery"
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
@@ -30,13 +31,15 @@ import (
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	OsmV1() osmv1.OsmV1Interface
+	ConfigV1alpha1() configv1alpha1.ConfigV1alpha1Interface
 }
 
 // Clientset contains the clients for groups. Each group has exactly one
 // version included in a Clientset.
 type Clientset struct {
 	*discovery.DiscoveryClient
-	osmV1 *osmv1.OsmV1Client
+	osmV1          *osmv1.OsmV1Client
+	configV1alpha1 *configv1alpha1.ConfigV1alpha1Client
 }
 
 // OsmV1 retrieves the OsmV1Client
@@ -44,6 +47,11 @@ func (c *Clientset) OsmV1() osmv1.OsmV1Interface {
 	return c.osmV1
 }
 
+// ConfigV1alpha1 retrieves the ConfigV1alpha1Client
+func (c *Clientset) ConfigV1alpha1() configv1alpha1.ConfigV1alpha1Interface {
+	return c.configV1alpha1
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -69,6 +77,10 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 	if err != nil {
 		return nil, err
 	}
+	cs.configV1alpha1, err = configv1alpha1.NewForConfig(&configShallowCopy)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfig(&configShallowCopy)
 	if err != nil {
@@ -82,6 +94,7 @@ func NewForConfig(c *rest.Config) (*Clientset, error) {
 func NewForConfigOrDie(c *rest.Config) *Clientset {
 	var cs Clientset
 	cs.osmV1 = osmv1.NewForConfigOrDie(c)
+	cs.configV1alpha1 = configv1alpha1.NewForConfigOrDie(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClientForConfigOrDie(c)
 	return &cs
@@ -91,6 +104,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.osmV1 = osmv1.New(c)
+	cs.configV1alpha1 = configv1alpha1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
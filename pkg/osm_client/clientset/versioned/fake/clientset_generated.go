@@ -22,6 +22,8 @@ import (
 	clientset "github.com/openservicemesh/osm/pkg/osm_client/clientset/versioned"
 	osmv1 "github.com/openservicemesh/osm/pkg/osm_client/clientset/versioned/typed/azureresource/v1"
 	fakeosmv1 "github.com/openservicemesh/osm/pkg/osm_client/clientset/versioned/typed/azureresource/v1/fake"
+	configv1alpha1 "github.com/openservicemesh/osm/pkg/osm_client/clientset/versioned/typed/config/v1alpha1"
+	fakeconfigv1alpha1 "github.com/openservicemesh/osm/pkg/osm_client/clientset/versioned/typed/config/v1alpha1/fake"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/discovery"
@@ -80,3 +82,8 @@ var _ clientset.Interface = &Clientset{}
 func (c *Clientset) OsmV1() osmv1.OsmV1Interface {
 	return &fakeosmv1.FakeOsmV1{Fake: &c.Fake}
 }
+
+// ConfigV1alpha1 retrieves the ConfigV1alpha1Client
+func (c *Clientset) ConfigV1alpha1() configv1alpha1.ConfigV1alpha1Interface {
+	return &fakeconfigv1alpha1.FakeConfigV1alpha1{Fake: &c.Fake}
+}
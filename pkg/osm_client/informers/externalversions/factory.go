@@ -25,6 +25,7 @@ import (
 
 	versioned "github.com/openservicemesh/osm/pkg/osm_client/clientset/versioned"
 	azureresource "github.com/openservicemesh/osm/pkg/osm_client/informers/externalversions/azureresource"
+	config "github.com/openservicemesh/osm/pkg/osm_client/informers/externalversions/config"
 	internalinterfaces "github.com/openservicemesh/osm/pkg/osm_client/informers/externalversions/internalinterfaces"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -173,8 +174,13 @@ type SharedInformerFactory interface {
 	WaitForCacheSync(stopCh <-chan struct{}) map[reflect.Type]bool
 
 	Osm() azureresource.Interface
+	Config() config.Interface
 }
 
 func (f *sharedInformerFactory) Osm() azureresource.Interface {
 	return azureresource.New(f, f.namespace, f.tweakListOptions)
 }
+
+func (f *sharedInformerFactory) Config() config.Interface {
+	return config.New(f, f.namespace, f.tweakListOptions)
+}
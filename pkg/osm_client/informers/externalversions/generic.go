@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	v1 "github.com/openservicemesh/osm/pkg/apis/azureresource/v1"
+	v1alpha1 "github.com/openservicemesh/osm/pkg/apis/config/v1alpha1"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 )
@@ -56,6 +57,10 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	case v1.SchemeGroupVersion.WithResource("azureresources"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Osm().V1().AzureResources().Informer()}, nil
 
+		// Group=config.openservicemesh.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("meshconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Config().V1alpha1().MeshConfigs().Informer()}, nil
+
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
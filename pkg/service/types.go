@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/openservicemesh/osm/pkg/certificate"
+	"github.com/openservicemesh/osm/pkg/constants"
 )
 
 const (
@@ -51,9 +52,14 @@ func UnmarshalMeshService(str string) (*MeshService, error) {
 	}, nil
 }
 
-// GetCommonName returns the Subject CN for the MeshService to be used for its certificate.
+// GetCommonName returns the Subject CN for the MeshService to be used for its certificate in the default trust domain.
 func (ms MeshService) GetCommonName() certificate.CommonName {
-	return certificate.CommonName(strings.Join([]string{ms.Name, ms.Namespace, "svc", "cluster", "local"}, "."))
+	return ms.GetCommonNameForTrustDomain(constants.DefaultTrustDomain)
+}
+
+// GetCommonNameForTrustDomain returns the Subject CN for the MeshService to be used for its certificate in the given trust domain.
+func (ms MeshService) GetCommonNameForTrustDomain(trustDomain string) certificate.CommonName {
+	return certificate.CommonName(strings.Join([]string{ms.Name, ms.Namespace, "svc", trustDomain}, "."))
 }
 
 // K8sServiceAccount is a type for a namespaced service account
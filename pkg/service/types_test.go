@@ -6,6 +6,21 @@ import (
 )
 
 var _ = Describe("Test types helpers", func() {
+	Context("Test GetCommonName()", func() {
+		meshService := MeshService{
+			Namespace: "bookstore-ns",
+			Name:      "bookstore",
+		}
+
+		It("returns the common name in the default trust domain", func() {
+			Expect(meshService.GetCommonName().String()).To(Equal("bookstore.bookstore-ns.svc.cluster.local"))
+		})
+
+		It("returns the common name in the given trust domain", func() {
+			Expect(meshService.GetCommonNameForTrustDomain("mesh.example.com").String()).To(Equal("bookstore.bookstore-ns.svc.mesh.example.com"))
+		})
+	})
+
 	Context("Tests namespace unmarshalling", func() {
 		namespace := "randomNamespace"
 		serviceName := "randomServiceName"
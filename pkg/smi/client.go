@@ -1,7 +1,6 @@
 package smi
 
 import (
-	"reflect"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -15,6 +14,13 @@ import (
 	smiTrafficSplitClient "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned"
 	smiTrafficSplitInformers "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/informers/externalversions"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -23,6 +29,7 @@ import (
 	osmPolicy "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
 	osmPolicyClient "github.com/openservicemesh/osm/experimental/pkg/client/clientset/versioned"
 	backpressureInformers "github.com/openservicemesh/osm/experimental/pkg/client/informers/externalversions"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/featureflags"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
 	"github.com/openservicemesh/osm/pkg/service"
@@ -31,11 +38,28 @@ import (
 // We have a few different k8s clients. This identifies these in logs.
 const kubernetesClientName = "MeshSpec"
 
+// The generated SMI clients do not implement the TrafficSplit and TrafficTarget APIs of the v1alpha4 SMI spec version,
+// so they are watched with the dynamic client and converted into the types of the v1alpha3 SMI spec version, whose
+// fields they share.
+var (
+	trafficSplitV1alpha4Resource = schema.GroupVersionResource{
+		Group:    "split.smi-spec.io",
+		Version:  "v1alpha4",
+		Resource: "trafficsplits",
+	}
+	trafficTargetV1alpha4Resource = schema.GroupVersionResource{
+		Group:    "access.smi-spec.io",
+		Version:  "v1alpha3",
+		Resource: "traffictargets",
+	}
+)
+
 // NewMeshSpecClient implements mesh.MeshSpec and creates the Kubernetes client, which retrieves SMI specific CRDs.
 func NewMeshSpecClient(smiKubeConfig *rest.Config, kubeClient kubernetes.Interface, osmNamespace string, namespaceController k8s.NamespaceController, stop chan struct{}) (MeshSpec, error) {
 	smiTrafficSplitClientSet := smiTrafficSplitClient.NewForConfigOrDie(smiKubeConfig)
 	smiTrafficSpecClientSet := smiTrafficSpecClient.NewForConfigOrDie(smiKubeConfig)
 	smiTrafficTargetClientSet := smiAccessClient.NewForConfigOrDie(smiKubeConfig)
+	dynamicClient := dynamic.NewForConfigOrDie(smiKubeConfig)
 
 	var backpressureClientSet *osmPolicyClient.Clientset
 	if featureflags.IsBackpressureEnabled() {
@@ -47,6 +71,7 @@ func NewMeshSpecClient(smiKubeConfig *rest.Config, kubeClient kubernetes.Interfa
 		smiTrafficSplitClientSet,
 		smiTrafficSpecClientSet,
 		smiTrafficTargetClientSet,
+		dynamicClient,
 		backpressureClientSet,
 		osmNamespace,
 		namespaceController,
@@ -71,6 +96,9 @@ func (c *Client) run(stop <-chan struct{}) error {
 		"HTTPRouteGroup": c.informers.HTTPRouteGroup,
 		"TCPRoute":       c.informers.TCPRoute,
 		"TrafficTarget":  c.informers.TrafficTarget,
+
+		"TrafficSplitV1alpha4":  c.informers.TrafficSplitV1alpha4,
+		"TrafficTargetV1alpha4": c.informers.TrafficTargetV1alpha4,
 	}
 
 	if featureflags.IsBackpressureEnabled() {
@@ -107,11 +135,12 @@ func (c *Client) GetAnnouncementsChannel() <-chan interface{} {
 }
 
 // newClient creates a provider based on a Kubernetes client instance.
-func newSMIClient(kubeClient kubernetes.Interface, smiTrafficSplitClient smiTrafficSplitClient.Interface, smiTrafficSpecClient smiTrafficSpecClient.Interface, smiAccessClient smiAccessClient.Interface, backpressureClient osmPolicyClient.Interface, osmNamespace string, namespaceController k8s.NamespaceController, providerIdent string, stop chan struct{}) (*Client, error) {
+func newSMIClient(kubeClient kubernetes.Interface, smiTrafficSplitClient smiTrafficSplitClient.Interface, smiTrafficSpecClient smiTrafficSpecClient.Interface, smiAccessClient smiAccessClient.Interface, dynamicClient dynamic.Interface, backpressureClient osmPolicyClient.Interface, osmNamespace string, namespaceController k8s.NamespaceController, providerIdent string, stop chan struct{}) (*Client, error) {
 	informerFactory := informers.NewSharedInformerFactory(kubeClient, k8s.DefaultKubeEventResyncInterval)
 	smiTrafficSplitInformerFactory := smiTrafficSplitInformers.NewSharedInformerFactory(smiTrafficSplitClient, k8s.DefaultKubeEventResyncInterval)
 	smiTrafficSpecInformerFactory := smiTrafficSpecInformers.NewSharedInformerFactory(smiTrafficSpecClient, k8s.DefaultKubeEventResyncInterval)
 	smiTrafficTargetInformerFactory := smiAccessInformers.NewSharedInformerFactory(smiAccessClient, k8s.DefaultKubeEventResyncInterval)
+	dynamicInformerFactory := dynamicinformer.NewDynamicSharedInformerFactory(dynamicClient, k8s.DefaultKubeEventResyncInterval)
 
 	informerCollection := InformerCollection{
 		Services:       informerFactory.Core().V1().Services().Informer(),
@@ -129,6 +158,21 @@ func newSMIClient(kubeClient kubernetes.Interface, smiTrafficSplitClient smiTraf
 		TrafficTarget:  informerCollection.TrafficTarget.GetStore(),
 	}
 
+	// The APIs of the v1alpha4 SMI spec version are only watched when served, since their informers would otherwise
+	// never sync. They are not watched when they are served only after the SMI client started.
+	if isResourceServed(kubeClient.Discovery(), trafficSplitV1alpha4Resource) {
+		informerCollection.TrafficSplitV1alpha4 = dynamicInformerFactory.ForResource(trafficSplitV1alpha4Resource).Informer()
+		cacheCollection.TrafficSplitV1alpha4 = informerCollection.TrafficSplitV1alpha4.GetStore()
+	} else {
+		log.Info().Msgf("[SMI Client] %s is not served; Not watching the TrafficSplits of the %s SMI spec version", trafficSplitV1alpha4Resource, configurator.SMISpecVersionV1alpha4)
+	}
+	if isResourceServed(kubeClient.Discovery(), trafficTargetV1alpha4Resource) {
+		informerCollection.TrafficTargetV1alpha4 = dynamicInformerFactory.ForResource(trafficTargetV1alpha4Resource).Informer()
+		cacheCollection.TrafficTargetV1alpha4 = informerCollection.TrafficTargetV1alpha4.GetStore()
+	} else {
+		log.Info().Msgf("[SMI Client] %s is not served; Not watching the TrafficTargets of the %s SMI spec version", trafficTargetV1alpha4Resource, configurator.SMISpecVersionV1alpha4)
+	}
+
 	if featureflags.IsBackpressureEnabled() {
 		backPressureInformerFactory := backpressureInformers.NewSharedInformerFactoryWithOptions(backpressureClient, k8s.DefaultKubeEventResyncInterval)
 		informerCollection.Backpressure = backPressureInformerFactory.Policy().V1alpha1().Backpressures().Informer()
@@ -146,8 +190,11 @@ func newSMIClient(kubeClient kubernetes.Interface, smiTrafficSplitClient smiTraf
 	}
 
 	shouldObserve := func(obj interface{}) bool {
-		ns := reflect.ValueOf(obj).Elem().FieldByName("ObjectMeta").FieldByName("Namespace").String()
-		return namespaceController.IsMonitoredNamespace(ns)
+		object, err := meta.Accessor(obj)
+		if err != nil {
+			return false
+		}
+		return namespaceController.IsMonitoredNamespace(object.GetNamespace())
 	}
 	informerCollection.Services.AddEventHandler(k8s.GetKubernetesEventHandlers("Services", "SMI", client.announcements, shouldObserve))
 	informerCollection.TrafficSplit.AddEventHandler(k8s.GetKubernetesEventHandlers("TrafficSplit", "SMI", client.announcements, shouldObserve))
@@ -155,6 +202,13 @@ func newSMIClient(kubeClient kubernetes.Interface, smiTrafficSplitClient smiTraf
 	informerCollection.TCPRoute.AddEventHandler(k8s.GetKubernetesEventHandlers("TCPRoute", "SMI", client.announcements, shouldObserve))
 	informerCollection.TrafficTarget.AddEventHandler(k8s.GetKubernetesEventHandlers("TrafficTarget", "SMI", client.announcements, shouldObserve))
 
+	if informerCollection.TrafficSplitV1alpha4 != nil {
+		informerCollection.TrafficSplitV1alpha4.AddEventHandler(k8s.GetKubernetesEventHandlers("TrafficSplitV1alpha4", "SMI", client.announcements, shouldObserve))
+	}
+	if informerCollection.TrafficTargetV1alpha4 != nil {
+		informerCollection.TrafficTargetV1alpha4.AddEventHandler(k8s.GetKubernetesEventHandlers("TrafficTargetV1alpha4", "SMI", client.announcements, shouldObserve))
+	}
+
 	if featureflags.IsBackpressureEnabled() {
 		informerCollection.Backpressure.AddEventHandler(k8s.GetKubernetesEventHandlers("Backpressure", "SMI", client.announcements, shouldObserve))
 	}
@@ -167,12 +221,10 @@ func newSMIClient(kubeClient kubernetes.Interface, smiTrafficSplitClient smiTraf
 	return &client, err
 }
 
-// ListTrafficSplits implements mesh.MeshSpec by returning the list of traffic splits.
-func (c *Client) ListTrafficSplits() []*smiSplit.TrafficSplit {
+// ListTrafficSplits implements mesh.MeshSpec by returning the list of traffic splits of the given SMI spec version.
+func (c *Client) ListTrafficSplits(smiSpecVersion string) []*smiSplit.TrafficSplit {
 	var trafficSplits []*smiSplit.TrafficSplit
-	for _, splitIface := range c.caches.TrafficSplit.List() {
-		trafficSplit := splitIface.(*smiSplit.TrafficSplit)
-
+	for _, trafficSplit := range c.listTrafficSplits(smiSpecVersion) {
 		if !c.namespaceController.IsMonitoredNamespace(trafficSplit.Namespace) {
 			continue
 		}
@@ -209,12 +261,10 @@ func (c *Client) ListTCPTrafficSpecs() []*smiSpecs.TCPRoute {
 	return tcpRouteSpec
 }
 
-// ListTrafficTargets implements mesh.Topology by returning the list of traffic targets.
-func (c *Client) ListTrafficTargets() []*smiAccess.TrafficTarget {
+// ListTrafficTargets implements mesh.Topology by returning the list of traffic targets of the given SMI spec version.
+func (c *Client) ListTrafficTargets(smiSpecVersion string) []*smiAccess.TrafficTarget {
 	var trafficTargets []*smiAccess.TrafficTarget
-	for _, targetIface := range c.caches.TrafficTarget.List() {
-		trafficTarget := targetIface.(*smiAccess.TrafficTarget)
-
+	for _, trafficTarget := range c.listTrafficTargets(smiSpecVersion) {
 		if !c.namespaceController.IsMonitoredNamespace(trafficTarget.Namespace) {
 			continue
 		}
@@ -251,10 +301,10 @@ func (c *Client) GetBackpressurePolicy(svc service.MeshService) *osmPolicy.Backp
 }
 
 // ListTrafficSplitServices implements mesh.MeshSpec by returning the services observed from the given compute provider
-func (c *Client) ListTrafficSplitServices() []service.WeightedService {
+// in the traffic splits of the given SMI spec version
+func (c *Client) ListTrafficSplitServices(smiSpecVersion string) []service.WeightedService {
 	var services []service.WeightedService
-	for _, splitIface := range c.caches.TrafficSplit.List() {
-		trafficSplit := splitIface.(*smiSplit.TrafficSplit)
+	for _, trafficSplit := range c.listTrafficSplits(smiSpecVersion) {
 		rootService := trafficSplit.Spec.Service
 
 		for _, backend := range trafficSplit.Spec.Backends {
@@ -271,12 +321,10 @@ func (c *Client) ListTrafficSplitServices() []service.WeightedService {
 	return services
 }
 
-// ListServiceAccounts lists ServiceAccounts specified in SMI TrafficTarget resources
-func (c *Client) ListServiceAccounts() []service.K8sServiceAccount {
+// ListServiceAccounts lists ServiceAccounts specified in SMI TrafficTarget resources of the given SMI spec version
+func (c *Client) ListServiceAccounts(smiSpecVersion string) []service.K8sServiceAccount {
 	var serviceAccounts []service.K8sServiceAccount
-	for _, targetIface := range c.caches.TrafficTarget.List() {
-		trafficTarget := targetIface.(*smiAccess.TrafficTarget)
-
+	for _, trafficTarget := range c.listTrafficTargets(smiSpecVersion) {
 		for _, sources := range trafficTarget.Spec.Sources {
 			// Only monitor sources in namespaces OSM is observing
 			if !c.namespaceController.IsMonitoredNamespace(sources.Namespace) {
@@ -329,3 +377,86 @@ func (c Client) ListServices() []*corev1.Service {
 	}
 	return services
 }
+
+// listTrafficSplits returns the TrafficSplits cached by the informer of the given SMI spec version
+func (c *Client) listTrafficSplits(smiSpecVersion string) []*smiSplit.TrafficSplit {
+	var store cache.Store
+	switch smiSpecVersion {
+	case configurator.SMISpecVersionV1alpha3:
+		store = c.caches.TrafficSplit
+	case configurator.SMISpecVersionV1alpha4:
+		store = c.caches.TrafficSplitV1alpha4
+	default:
+		log.Error().Msgf("Unsupported SMI spec version %q; Not listing any TrafficSplit", smiSpecVersion)
+	}
+	if store == nil {
+		return nil
+	}
+
+	var trafficSplits []*smiSplit.TrafficSplit
+	for _, splitIface := range store.List() {
+		trafficSplit, ok := splitIface.(*smiSplit.TrafficSplit)
+		if !ok {
+			trafficSplit = &smiSplit.TrafficSplit{}
+			if err := fromUnstructured(splitIface, trafficSplit); err != nil {
+				log.Error().Err(err).Msgf("Error converting the TrafficSplit of the %s SMI spec version", smiSpecVersion)
+				continue
+			}
+		}
+		trafficSplits = append(trafficSplits, trafficSplit)
+	}
+	return trafficSplits
+}
+
+// listTrafficTargets returns the TrafficTargets cached by the informer of the given SMI spec version
+func (c *Client) listTrafficTargets(smiSpecVersion string) []*smiAccess.TrafficTarget {
+	var store cache.Store
+	switch smiSpecVersion {
+	case configurator.SMISpecVersionV1alpha3:
+		store = c.caches.TrafficTarget
+	case configurator.SMISpecVersionV1alpha4:
+		store = c.caches.TrafficTargetV1alpha4
+	default:
+		log.Error().Msgf("Unsupported SMI spec version %q; Not listing any TrafficTarget", smiSpecVersion)
+	}
+	if store == nil {
+		return nil
+	}
+
+	var trafficTargets []*smiAccess.TrafficTarget
+	for _, targetIface := range store.List() {
+		trafficTarget, ok := targetIface.(*smiAccess.TrafficTarget)
+		if !ok {
+			trafficTarget = &smiAccess.TrafficTarget{}
+			if err := fromUnstructured(targetIface, trafficTarget); err != nil {
+				log.Error().Err(err).Msgf("Error converting the TrafficTarget of the %s SMI spec version", smiSpecVersion)
+				continue
+			}
+		}
+		trafficTargets = append(trafficTargets, trafficTarget)
+	}
+	return trafficTargets
+}
+
+// fromUnstructured converts the given object cached by a dynamic informer into the given typed object
+func fromUnstructured(obj interface{}, into interface{}) error {
+	unstructuredObj, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return errors.Wrapf(errUnexpectedObjectType, "%T", obj)
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredObj.UnstructuredContent(), into)
+}
+
+// isResourceServed returns whether the API server serves the given resource
+func isResourceServed(discoveryClient discovery.DiscoveryInterface, resource schema.GroupVersionResource) bool {
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(resource.GroupVersion().String())
+	if err != nil || resources == nil {
+		return false
+	}
+	for _, apiResource := range resources.APIResources {
+		if apiResource.Name == resource.Resource {
+			return true
+		}
+	}
+	return false
+}
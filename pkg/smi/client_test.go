@@ -13,10 +13,15 @@ import (
 	testTrafficSplitClient "github.com/servicemeshinterface/smi-sdk-go/pkg/gen/client/split/clientset/versioned/fake"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	testDynamicClient "k8s.io/client-go/dynamic/fake"
 	testclient "k8s.io/client-go/kubernetes/fake"
 
 	osmPolicy "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
 	osmPolicyClient "github.com/openservicemesh/osm/experimental/pkg/client/clientset/versioned/fake"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/constants"
 	"github.com/openservicemesh/osm/pkg/featureflags"
 	k8s "github.com/openservicemesh/osm/pkg/kubernetes"
@@ -33,10 +38,17 @@ type fakeKubeClientSet struct {
 	smiTrafficSplitClientSet  *testTrafficSplitClient.Clientset
 	smiTrafficSpecClientSet   *testTrafficSpecClient.Clientset
 	smiTrafficTargetClientSet *testTrafficTargetClient.Clientset
+	dynamicClient             *testDynamicClient.FakeDynamicClient
 	osmPolicyClientSet        *osmPolicyClient.Clientset
 }
 
 func bootstrapClient() (MeshSpec, *fakeKubeClientSet, error) {
+	return bootstrapClientServing(trafficSplitV1alpha4Resource, trafficTargetV1alpha4Resource)
+}
+
+// bootstrapClientServing bootstraps the SMI client against an API server serving the given resources of the SMI spec
+// versions not implemented by the generated SMI clients
+func bootstrapClientServing(servedResources ...schema.GroupVersionResource) (MeshSpec, *fakeKubeClientSet, error) {
 	osmNamespace := "osm-system"
 	meshName := "osm"
 	stop := make(chan struct{})
@@ -44,14 +56,23 @@ func bootstrapClient() (MeshSpec, *fakeKubeClientSet, error) {
 	smiTrafficSplitClientSet := testTrafficSplitClient.NewSimpleClientset()
 	smiTrafficSpecClientSet := testTrafficSpecClient.NewSimpleClientset()
 	smiTrafficTargetClientSet := testTrafficTargetClient.NewSimpleClientset()
+	dynamicClient := testDynamicClient.NewSimpleDynamicClient(runtime.NewScheme())
 	osmPolicyClientSet := osmPolicyClient.NewSimpleClientset()
 	kubernetesClient := k8s.NewKubernetesClient(kubeClient, meshName, stop)
 
+	for _, resource := range servedResources {
+		kubeClient.Resources = append(kubeClient.Resources, &metav1.APIResourceList{
+			GroupVersion: resource.GroupVersion().String(),
+			APIResources: []metav1.APIResource{{Name: resource.Resource, Namespaced: true}},
+		})
+	}
+
 	fakeClientSet := &fakeKubeClientSet{
 		kubeClient:                kubeClient,
 		smiTrafficSplitClientSet:  smiTrafficSplitClientSet,
 		smiTrafficSpecClientSet:   smiTrafficSpecClientSet,
 		smiTrafficTargetClientSet: smiTrafficTargetClientSet,
+		dynamicClient:             dynamicClient,
 		osmPolicyClientSet:        osmPolicyClientSet,
 	}
 
@@ -72,6 +93,7 @@ func bootstrapClient() (MeshSpec, *fakeKubeClientSet, error) {
 		smiTrafficSplitClientSet,
 		smiTrafficSpecClientSet,
 		smiTrafficTargetClientSet,
+		dynamicClient,
 		osmPolicyClientSet,
 		osmNamespace,
 		kubernetesClient,
@@ -114,9 +136,10 @@ var _ = Describe("When listing TrafficSplit", func() {
 		Expect(err).ToNot(HaveOccurred())
 		<-meshSpec.GetAnnouncementsChannel()
 
-		splits := meshSpec.ListTrafficSplits()
+		splits := meshSpec.ListTrafficSplits(configurator.SMISpecVersionV1alpha3)
 		Expect(len(splits)).To(Equal(1))
 		Expect(split).To(Equal(splits[0]))
+		Expect(meshSpec.ListTrafficSplits(configurator.SMISpecVersionV1alpha4)).To(BeEmpty())
 
 		err = fakeClientSet.smiTrafficSplitClientSet.SplitV1alpha2().TrafficSplits(testNamespaceName).Delete(context.TODO(), split.Name, metav1.DeleteOptions{})
 		Expect(err).ToNot(HaveOccurred())
@@ -160,7 +183,7 @@ var _ = Describe("When listing TrafficSplit services", func() {
 		Expect(err).ToNot(HaveOccurred())
 		<-meshSpec.GetAnnouncementsChannel()
 
-		weightedServices := meshSpec.ListTrafficSplitServices()
+		weightedServices := meshSpec.ListTrafficSplitServices(configurator.SMISpecVersionV1alpha3)
 		Expect(len(weightedServices)).To(Equal(len(split.Spec.Backends)))
 		for i, backend := range split.Spec.Backends {
 			Expect(weightedServices[i].Service).To(Equal(service.MeshService{Namespace: split.Namespace, Name: backend.Service}))
@@ -218,7 +241,7 @@ var _ = Describe("When listing ServiceAccounts", func() {
 		Expect(err).ToNot(HaveOccurred())
 		<-meshSpec.GetAnnouncementsChannel()
 
-		svcAccounts := meshSpec.ListServiceAccounts()
+		svcAccounts := meshSpec.ListServiceAccounts(configurator.SMISpecVersionV1alpha3)
 
 		numExpectedSvcAccounts := len(trafficTarget.Spec.Sources) + 1 // 1 for the destination ServiceAccount
 		Expect(len(svcAccounts)).To(Equal(numExpectedSvcAccounts))
@@ -273,8 +296,9 @@ var _ = Describe("When listing TrafficTargets", func() {
 		Expect(err).ToNot(HaveOccurred())
 		<-meshSpec.GetAnnouncementsChannel()
 
-		targets := meshSpec.ListTrafficTargets()
+		targets := meshSpec.ListTrafficTargets(configurator.SMISpecVersionV1alpha3)
 		Expect(len(targets)).To(Equal(1))
+		Expect(meshSpec.ListTrafficTargets(configurator.SMISpecVersionV1alpha4)).To(BeEmpty())
 
 		err = fakeClientSet.smiTrafficTargetClientSet.AccessV1alpha2().TrafficTargets(testNamespaceName).Delete(context.TODO(), trafficTarget.Name, metav1.DeleteOptions{})
 		Expect(err).ToNot(HaveOccurred())
@@ -282,6 +306,137 @@ var _ = Describe("When listing TrafficTargets", func() {
 	})
 })
 
+var _ = Describe("When listing the TrafficSplits and TrafficTargets of the v1alpha4 SMI spec version", func() {
+	var (
+		meshSpec      MeshSpec
+		fakeClientSet *fakeKubeClientSet
+		err           error
+	)
+	BeforeEach(func() {
+		meshSpec, fakeClientSet, err = bootstrapClient()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("returns the TrafficSplits served by split.smi-spec.io/v1alpha4", func() {
+		split := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "split.smi-spec.io/v1alpha4",
+			"kind":       "TrafficSplit",
+			"metadata": map[string]interface{}{
+				"name":      "test-ListTrafficSplits-v1alpha4",
+				"namespace": testNamespaceName,
+			},
+			"spec": map[string]interface{}{
+				"service": tests.BookstoreApexServiceName,
+				"backends": []interface{}{
+					map[string]interface{}{
+						"service": tests.BookstoreServiceName,
+						"weight":  int64(tests.Weight),
+					},
+				},
+			},
+		}}
+
+		_, err := fakeClientSet.dynamicClient.Resource(trafficSplitV1alpha4Resource).Namespace(testNamespaceName).Create(context.TODO(), split, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		<-meshSpec.GetAnnouncementsChannel()
+
+		splits := meshSpec.ListTrafficSplits(configurator.SMISpecVersionV1alpha4)
+		Expect(len(splits)).To(Equal(1))
+		Expect(splits[0].Name).To(Equal(split.GetName()))
+		Expect(splits[0].Spec).To(Equal(smiSplit.TrafficSplitSpec{
+			Service: tests.BookstoreApexServiceName,
+			Backends: []smiSplit.TrafficSplitBackend{
+				{
+					Service: tests.BookstoreServiceName,
+					Weight:  tests.Weight,
+				},
+			},
+		}))
+		Expect(meshSpec.ListTrafficSplitServices(configurator.SMISpecVersionV1alpha4)).To(Equal([]service.WeightedService{{
+			Service:     service.MeshService{Namespace: testNamespaceName, Name: tests.BookstoreServiceName},
+			Weight:      tests.Weight,
+			RootService: tests.BookstoreApexServiceName,
+		}}))
+		Expect(meshSpec.ListTrafficSplits(configurator.SMISpecVersionV1alpha3)).To(BeEmpty())
+
+		err = fakeClientSet.dynamicClient.Resource(trafficSplitV1alpha4Resource).Namespace(testNamespaceName).Delete(context.TODO(), split.GetName(), metav1.DeleteOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		<-meshSpec.GetAnnouncementsChannel()
+	})
+
+	It("returns the TrafficTargets served by access.smi-spec.io/v1alpha3", func() {
+		trafficTarget := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "access.smi-spec.io/v1alpha3",
+			"kind":       "TrafficTarget",
+			"metadata": map[string]interface{}{
+				"name":      "test-ListTrafficTargets-v1alpha4",
+				"namespace": testNamespaceName,
+			},
+			"spec": map[string]interface{}{
+				"destination": map[string]interface{}{
+					"kind":      "ServiceAccount",
+					"name":      tests.BookstoreServiceAccountName,
+					"namespace": testNamespaceName,
+				},
+				"sources": []interface{}{
+					map[string]interface{}{
+						"kind":      "ServiceAccount",
+						"name":      tests.BookbuyerServiceAccountName,
+						"namespace": testNamespaceName,
+					},
+				},
+				"rules": []interface{}{
+					map[string]interface{}{
+						"kind":    "HTTPRouteGroup",
+						"name":    tests.RouteGroupName,
+						"matches": []interface{}{tests.BuyBooksMatchName},
+					},
+				},
+			},
+		}}
+
+		_, err := fakeClientSet.dynamicClient.Resource(trafficTargetV1alpha4Resource).Namespace(testNamespaceName).Create(context.TODO(), trafficTarget, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		<-meshSpec.GetAnnouncementsChannel()
+
+		targets := meshSpec.ListTrafficTargets(configurator.SMISpecVersionV1alpha4)
+		Expect(len(targets)).To(Equal(1))
+		Expect(targets[0].Name).To(Equal(trafficTarget.GetName()))
+		Expect(targets[0].Spec.Rules).To(Equal([]smiAccess.TrafficTargetRule{{
+			Kind:    "HTTPRouteGroup",
+			Name:    tests.RouteGroupName,
+			Matches: []string{tests.BuyBooksMatchName},
+		}}))
+		Expect(meshSpec.ListServiceAccounts(configurator.SMISpecVersionV1alpha4)).To(ConsistOf(
+			service.K8sServiceAccount{Namespace: testNamespaceName, Name: tests.BookbuyerServiceAccountName},
+			service.K8sServiceAccount{Namespace: testNamespaceName, Name: tests.BookstoreServiceAccountName},
+		))
+		Expect(meshSpec.ListTrafficTargets(configurator.SMISpecVersionV1alpha3)).To(BeEmpty())
+
+		err = fakeClientSet.dynamicClient.Resource(trafficTargetV1alpha4Resource).Namespace(testNamespaceName).Delete(context.TODO(), trafficTarget.GetName(), metav1.DeleteOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		<-meshSpec.GetAnnouncementsChannel()
+	})
+
+	It("returns nothing for an unsupported SMI spec version", func() {
+		Expect(meshSpec.ListTrafficSplits("v1alpha2")).To(BeEmpty())
+		Expect(meshSpec.ListTrafficSplitServices("v1alpha2")).To(BeEmpty())
+		Expect(meshSpec.ListServiceAccounts("v1alpha2")).To(BeEmpty())
+		Expect(meshSpec.ListTrafficTargets("v1alpha2")).To(BeEmpty())
+	})
+
+	It("does not watch the APIs the API server does not serve", func() {
+		meshSpec, _, err := bootstrapClientServing()
+		Expect(err).ToNot(HaveOccurred())
+
+		client := meshSpec.(*Client)
+		Expect(client.informers.TrafficSplitV1alpha4).To(BeNil())
+		Expect(client.informers.TrafficTargetV1alpha4).To(BeNil())
+		Expect(meshSpec.ListTrafficSplits(configurator.SMISpecVersionV1alpha4)).To(BeEmpty())
+		Expect(meshSpec.ListTrafficTargets(configurator.SMISpecVersionV1alpha4)).To(BeEmpty())
+	})
+})
+
 var _ = Describe("When fetching a Service corresponding to a Meshservice", func() {
 	var (
 		meshSpec      MeshSpec
@@ -5,4 +5,6 @@ import "github.com/pkg/errors"
 var (
 	errSyncingCaches = errors.New("failed initial sync of resources required for ingress")
 	errInitInformers = errors.New("informers are not initialized")
+
+	errUnexpectedObjectType = errors.New("unexpected type of cached object")
 )
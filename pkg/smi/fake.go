@@ -7,11 +7,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	backpressure "github.com/openservicemesh/osm/experimental/pkg/apis/policy/v1alpha1"
+	"github.com/openservicemesh/osm/pkg/configurator"
 	"github.com/openservicemesh/osm/pkg/service"
 	"github.com/openservicemesh/osm/pkg/tests"
 )
 
 type fakeMeshSpec struct {
+	smiSpecVersion   string
 	trafficSplits    []*split.TrafficSplit
 	httpRouteGroups  []*spec.HTTPRouteGroup
 	tcpRoutes        []*spec.TCPRoute
@@ -23,8 +25,10 @@ type fakeMeshSpec struct {
 }
 
 // NewFakeMeshSpecClient creates a fake Mesh Spec used for testing.
+// Its TrafficSplits and TrafficTargets are of the latest SMI spec version, the default one.
 func NewFakeMeshSpecClient() MeshSpec {
 	return fakeMeshSpec{
+		smiSpecVersion:   configurator.SMISpecVersionV1alpha4,
 		trafficSplits:    []*split.TrafficSplit{&tests.TrafficSplit},
 		httpRouteGroups:  []*spec.HTTPRouteGroup{&tests.HTTPRouteGroup},
 		tcpRoutes:        []*spec.TCPRoute{&tests.TCPRoute},
@@ -45,17 +49,26 @@ func NewFakeMeshSpecClient() MeshSpec {
 }
 
 // ListTrafficSplits lists TrafficSplit SMI resources for the fake Mesh Spec.
-func (f fakeMeshSpec) ListTrafficSplits() []*split.TrafficSplit {
+func (f fakeMeshSpec) ListTrafficSplits(smiSpecVersion string) []*split.TrafficSplit {
+	if smiSpecVersion != f.smiSpecVersion {
+		return nil
+	}
 	return f.trafficSplits
 }
 
 // ListTrafficSplitServices fetches all services declared with SMI Spec for the fake Mesh Spec.
-func (f fakeMeshSpec) ListTrafficSplitServices() []service.WeightedService {
+func (f fakeMeshSpec) ListTrafficSplitServices(smiSpecVersion string) []service.WeightedService {
+	if smiSpecVersion != f.smiSpecVersion {
+		return nil
+	}
 	return f.weightedServices
 }
 
 // ListServiceAccounts fetches all service accounts declared with SMI Spec for the fake Mesh Spec.
-func (f fakeMeshSpec) ListServiceAccounts() []service.K8sServiceAccount {
+func (f fakeMeshSpec) ListServiceAccounts(smiSpecVersion string) []service.K8sServiceAccount {
+	if smiSpecVersion != f.smiSpecVersion {
+		return nil
+	}
 	return f.serviceAccounts
 }
 
@@ -80,7 +93,10 @@ func (f fakeMeshSpec) ListTCPTrafficSpecs() []*spec.TCPRoute {
 }
 
 // ListTrafficTargets lists TrafficTarget SMI resources for the fake Mesh Spec.
-func (f fakeMeshSpec) ListTrafficTargets() []*target.TrafficTarget {
+func (f fakeMeshSpec) ListTrafficTargets(smiSpecVersion string) []*target.TrafficTarget {
+	if smiSpecVersion != f.smiSpecVersion {
+		return nil
+	}
 	return f.trafficTargets
 }
 
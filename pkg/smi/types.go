@@ -26,6 +26,12 @@ type InformerCollection struct {
 	TCPRoute       cache.SharedIndexInformer
 	TrafficTarget  cache.SharedIndexInformer
 	Backpressure   cache.SharedIndexInformer
+
+	// TrafficSplitV1alpha4 and TrafficTargetV1alpha4 watch the TrafficSplits and TrafficTargets of the v1alpha4 SMI
+	// spec version, while TrafficSplit and TrafficTarget watch these of the v1alpha3 one. They are nil when the API
+	// server does not serve their API.
+	TrafficSplitV1alpha4  cache.SharedIndexInformer
+	TrafficTargetV1alpha4 cache.SharedIndexInformer
 }
 
 // CacheCollection is a struct of the Kubernetes caches used in OSM
@@ -36,6 +42,9 @@ type CacheCollection struct {
 	TCPRoute       cache.Store
 	TrafficTarget  cache.Store
 	Backpressure   cache.Store
+
+	TrafficSplitV1alpha4  cache.Store
+	TrafficTargetV1alpha4 cache.Store
 }
 
 // Client is a struct for all components necessary to connect to and maintain state of a Kubernetes cluster.
@@ -51,14 +60,16 @@ type Client struct {
 
 // MeshSpec is an interface declaring functions, which provide the specs for a service mesh declared with SMI.
 type MeshSpec interface {
-	// ListTrafficSplits lists SMI TrafficSplit resources
-	ListTrafficSplits() []*split.TrafficSplit
+	// ListTrafficSplits lists SMI TrafficSplit resources of the given SMI spec version
+	ListTrafficSplits(smiSpecVersion string) []*split.TrafficSplit
 
-	// ListTrafficSplitServices lists WeightedServices for the services specified in TrafficSplit SMI resources
-	ListTrafficSplitServices() []service.WeightedService
+	// ListTrafficSplitServices lists WeightedServices for the services specified in TrafficSplit SMI resources of the
+	// given SMI spec version
+	ListTrafficSplitServices(smiSpecVersion string) []service.WeightedService
 
-	// ListServiceAccounts lists ServiceAccount resources specified in SMI TrafficTarget resources
-	ListServiceAccounts() []service.K8sServiceAccount
+	// ListServiceAccounts lists ServiceAccount resources specified in SMI TrafficTarget resources of the given SMI
+	// spec version
+	ListServiceAccounts(smiSpecVersion string) []service.K8sServiceAccount
 
 	// GetService fetches a Kubernetes Service resource for the given MeshService
 	GetService(service.MeshService) *corev1.Service
@@ -72,8 +83,8 @@ type MeshSpec interface {
 	// ListTCPTrafficSpecs lists SMI TCPRoute resources
 	ListTCPTrafficSpecs() []*spec.TCPRoute
 
-	// ListTrafficTargets lists SMI TrafficTarget resources
-	ListTrafficTargets() []*target.TrafficTarget
+	// ListTrafficTargets lists SMI TrafficTarget resources of the given SMI spec version
+	ListTrafficTargets(smiSpecVersion string) []*target.TrafficTarget
 
 	// GetBackpressurePolicy fetches the Backpressure policy for the MeshService
 	GetBackpressurePolicy(service.MeshService) *backpressure.Backpressure
@@ -11,12 +11,12 @@ import (
 )
 
 const (
-	maxStreams              = 100000
-	streamKeepAliveDuration = 60 * time.Second
+	maxStreams = 100000
 )
 
-// NewGrpc creates a new gRPC server
-func NewGrpc(serverType string, port int, certPem, keyPem, rootCertPem []byte) (*grpc.Server, net.Listener) {
+// NewGrpc creates a new gRPC server, pinging its clients after keepaliveTime of inactivity and closing the connections
+// whose ping is not acknowledged within keepaliveTimeout
+func NewGrpc(serverType string, port int, certPem, keyPem, rootCertPem []byte, keepaliveTime, keepaliveTimeout time.Duration) (*grpc.Server, net.Listener) {
 	log.Info().Msgf("Setting up %s gRPC server...", serverType)
 	addr := fmt.Sprintf(":%d", port)
 	lis, err := net.Listen("tcp", addr)
@@ -24,12 +24,19 @@ func NewGrpc(serverType string, port int, certPem, keyPem, rootCertPem []byte) (
 		log.Fatal().Err(err).Msgf("Could not start %s gRPC server on %s", serverType, addr)
 	}
 
-	log.Info().Msgf("Parameters for %s gRPC server: MaxConcurrentStreams=%d;  KeepAlive=%+v", serverType, maxStreams, streamKeepAliveDuration)
+	log.Info().Msgf("Parameters for %s gRPC server: MaxConcurrentStreams=%d;  KeepAlive=%+v;  KeepAliveTimeout=%+v", serverType, maxStreams, keepaliveTime, keepaliveTimeout)
 
 	grpcOptions := []grpc.ServerOption{
 		grpc.MaxConcurrentStreams(maxStreams),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
-			Time: streamKeepAliveDuration,
+			Time:    keepaliveTime,
+			Timeout: keepaliveTimeout,
+		}),
+		// The clients ping as often as the server, so the server must not close their connections for pinging too often;
+		// half the keepalive time leaves a margin for the clients pinging a bit early
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             keepaliveTime / 2,
+			PermitWithoutStream: true,
 		}),
 	}
 